@@ -0,0 +1,226 @@
+//
+// DISCLAIMER
+//
+// Copyright 2020 ArangoDB GmbH, Cologne, Germany
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// Copyright holder is ArangoDB GmbH, Cologne, Germany
+//
+
+package main
+
+import (
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/spf13/cobra"
+	core "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	meta "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/kubernetes"
+
+	backupApi "github.com/arangodb/kube-arangodb/pkg/apis/backup/v1"
+	"github.com/arangodb/kube-arangodb/pkg/client"
+	"github.com/arangodb/kube-arangodb/pkg/deployment/patch"
+	"github.com/arangodb/kube-arangodb/pkg/deployment/pod"
+	"github.com/arangodb/kube-arangodb/pkg/generated/clientset/versioned"
+	"github.com/arangodb/kube-arangodb/pkg/util/constants"
+	"github.com/arangodb/kube-arangodb/pkg/util/k8sutil"
+	"github.com/arangodb/kube-arangodb/pkg/util/retry"
+)
+
+var (
+	cmdBackup = &cobra.Command{
+		Use: "backup",
+		Run: cmdUsage,
+	}
+
+	cmdBackupClone = &cobra.Command{
+		Use:   "clone",
+		Short: "Restore an ArangoBackup into a different or a new ArangoDeployment",
+		Run:   cmdBackupCloneRun,
+	}
+
+	backupCloneOptions struct {
+		namespace         string
+		backupName        string
+		deploymentName    string
+		timeout           time.Duration
+		allowInconsistent bool
+		image             string
+		labels            []string
+		annotations       []string
+	}
+)
+
+func init() {
+	cmdMain.AddCommand(cmdBackup)
+	cmdBackup.AddCommand(cmdBackupClone)
+
+	f := cmdBackupClone.Flags()
+	f.StringVar(&backupCloneOptions.namespace, "namespace", os.Getenv(constants.EnvOperatorPodNamespace), "Namespace of the backup")
+	f.StringVar(&backupCloneOptions.backupName, "backup-name", "", "Name of the ArangoBackup to restore")
+	f.StringVar(&backupCloneOptions.deploymentName, "deployment-name", "", "Name of the target ArangoDeployment, created when it does not exist")
+	f.DurationVar(&backupCloneOptions.timeout, "timeout", time.Hour, "Time to wait for the backup download")
+	f.BoolVar(&backupCloneOptions.allowInconsistent, "allow-inconsistent", false, "Allow restore from a potentially inconsistent backup")
+	f.StringVar(&backupCloneOptions.image, "image", "", "Image of the created ArangoDeployment, by default the image of the source deployment with the ArangoDB version of the backup")
+	f.StringSliceVar(&backupCloneOptions.labels, "label", nil, "Label of the source deployment copied to the created ArangoDeployment")
+	f.StringSliceVar(&backupCloneOptions.annotations, "annotation", nil, "Annotation of the source deployment copied to the created ArangoDeployment")
+}
+
+// cmdBackupCloneRun downloads the backup into the target deployment and restores it once the download is finished.
+// When the target deployment does not exist, it is created with the topology and the encryption key of the backup.
+func cmdBackupCloneRun(cmd *cobra.Command, args []string) {
+	if backupCloneOptions.backupName == "" || backupCloneOptions.deploymentName == "" {
+		cliLog.Fatal().Msg("--backup-name and --deployment-name are required")
+	}
+
+	ns := backupCloneOptions.namespace
+	extCli := client.MustNewClient()
+	kubeCli, err := k8sutil.NewKubeClient()
+	if err != nil {
+		cliLog.Fatal().Err(err).Msg("Failed to create Kubernetes client")
+	}
+
+	backup, err := extCli.BackupV1().ArangoBackups(ns).Get(backupCloneOptions.backupName, meta.GetOptions{})
+	if err != nil {
+		cliLog.Fatal().Err(err).Msg("Unable to get backup")
+	}
+
+	if backup.Status.State != backupApi.ArangoBackupStateReady {
+		cliLog.Fatal().Msgf("Backup is in %s state, expected %s", backup.Status.State, backupApi.ArangoBackupStateReady)
+	}
+
+	if backup.Status.Backup.IsPotentiallyInconsistent() && !backupCloneOptions.allowInconsistent {
+		cliLog.Fatal().Msg("Backup is potentially inconsistent, use --allow-inconsistent to restore it anyway")
+	}
+
+	if backup.Spec.Deployment.Name == backupCloneOptions.deploymentName {
+		cliLog.Fatal().Msg("Backup belongs to the target deployment, use spec.restoreFrom instead")
+	}
+
+	download, err := backup.NewDownload(backupCloneOptions.deploymentName)
+	if err != nil {
+		cliLog.Fatal().Err(err).Msg("Unable to prepare backup download")
+	}
+
+	// Key is taken from the keys kept by the source deployment, its current key can differ after a rotation
+	encryptionSecret, err := backup.NewEncryptionSecret(fmt.Sprintf("%s-restore-encryption", backupCloneOptions.deploymentName),
+		getSecretIfExists(kubeCli, ns, pod.GetEncryptionFolderSecretName(backup.Spec.Deployment.Name)),
+		getSecretIfExists(kubeCli, ns, pod.GetEncryptionRotationSecretName(backup.Spec.Deployment.Name)))
+	if err != nil {
+		cliLog.Fatal().Err(err).Msg("Unable to get backup encryption key")
+	}
+
+	if encryptionSecret != nil {
+		if _, err := kubeCli.CoreV1().Secrets(ns).Create(encryptionSecret); err != nil && !errors.IsAlreadyExists(err) {
+			cliLog.Fatal().Err(err).Msg("Unable to create encryption key secret")
+		}
+
+		cliLog.Info().Msgf("Created Secret %s/%s with the backup encryption key", ns, encryptionSecret.Name)
+	}
+
+	restorePatch := patch.NewPatch()
+	restorePatch.ItemAdd(patch.NewPath("spec", "restoreFrom"), download.Name)
+
+	target, err := extCli.DatabaseV1().ArangoDeployments(ns).Get(backupCloneOptions.deploymentName, meta.GetOptions{})
+	if err != nil {
+		if !errors.IsNotFound(err) {
+			cliLog.Fatal().Err(err).Msg("Unable to get target deployment")
+		}
+
+		source, err := extCli.DatabaseV1().ArangoDeployments(ns).Get(backup.Spec.Deployment.Name, meta.GetOptions{})
+		if err != nil {
+			cliLog.Fatal().Err(err).Msg("Unable to get source deployment")
+		}
+
+		var encryptionSecretName string
+		if encryptionSecret != nil {
+			encryptionSecretName = encryptionSecret.Name
+		}
+
+		depl, err := backup.NewDeployment(backupCloneOptions.deploymentName, backupCloneOptions.image, encryptionSecretName, source,
+			backupCloneOptions.labels, backupCloneOptions.annotations)
+		if err != nil {
+			cliLog.Fatal().Err(err).Msg("Unable to prepare deployment")
+		}
+
+		if _, err := extCli.DatabaseV1().ArangoDeployments(ns).Create(depl); err != nil {
+			cliLog.Fatal().Err(err).Msg("Unable to create deployment")
+		}
+
+		cliLog.Info().Msgf("Created ArangoDeployment %s/%s", ns, depl.Name)
+	} else if encryptionSecret != nil {
+		if !target.Spec.RocksDB.IsEncrypted() {
+			cliLog.Fatal().Msg("Backup is encrypted but target deployment has no encryption key")
+		}
+
+		restorePatch.ItemAdd(patch.NewPath("spec", "restoreEncryptionSecret"), encryptionSecret.Name)
+	}
+
+	if _, err := extCli.BackupV1().ArangoBackups(ns).Create(download); err != nil {
+		cliLog.Fatal().Err(err).Msg("Unable to create backup download")
+	}
+
+	cliLog.Info().Msgf("Created ArangoBackup %s/%s, waiting for the download", ns, download.Name)
+
+	if err := waitForBackupReady(extCli, ns, download.Name, backupCloneOptions.timeout); err != nil {
+		cliLog.Fatal().Err(err).Msg("Backup download failed")
+	}
+
+	data, err := restorePatch.Marshal()
+	if err != nil {
+		cliLog.Fatal().Err(err).Msg("Unable to prepare restore")
+	}
+
+	if _, err := extCli.DatabaseV1().ArangoDeployments(ns).Patch(backupCloneOptions.deploymentName, types.JSONPatchType, data); err != nil {
+		cliLog.Fatal().Err(err).Msg("Unable to restore deployment")
+	}
+
+	cliLog.Info().Msgf("Restoring ArangoBackup %s/%s into ArangoDeployment %s/%s", ns, download.Name, ns, backupCloneOptions.deploymentName)
+}
+
+// getSecretIfExists returns the secret or nil when it does not exist
+func getSecretIfExists(kubeCli kubernetes.Interface, namespace, name string) *core.Secret {
+	secret, err := kubeCli.CoreV1().Secrets(namespace).Get(name, meta.GetOptions{})
+	if err != nil {
+		if !errors.IsNotFound(err) {
+			cliLog.Fatal().Err(err).Msgf("Unable to get secret %s", name)
+		}
+		return nil
+	}
+
+	return secret
+}
+
+// waitForBackupReady waits until the backup is ready and fails when it ends up in the failed state
+func waitForBackupReady(extCli versioned.Interface, namespace, name string, timeout time.Duration) error {
+	return retry.Retry(func() error {
+		backup, err := extCli.BackupV1().ArangoBackups(namespace).Get(name, meta.GetOptions{})
+		if err != nil {
+			return err
+		}
+
+		switch backup.Status.State {
+		case backupApi.ArangoBackupStateReady:
+			return nil
+		case backupApi.ArangoBackupStateFailed:
+			return retry.Permanent(fmt.Errorf("backup %s/%s failed: %s", namespace, name, backup.Status.Message))
+		}
+
+		return fmt.Errorf("backup %s/%s is in %s state", namespace, name, backup.Status.State)
+	}, timeout)
+}
@@ -21,6 +21,13 @@ Expand the name of the operator.
 {{- printf "arango-%s-operator" .Release.Name | trunc 63 | trimSuffix "-" -}}
 {{- end -}}
 
+{{/*
+Create the name of the admission webhooks and their TLS secret
+*/}}
+{{- define "kube-arangodb.webhookName" -}}
+{{- printf "%s-webhook" (include "kube-arangodb.operatorName" .) | trunc 63 | trimSuffix "-" -}}
+{{- end -}}
+
 {{/*
 Combine name of the deployment.
 */}}
@@ -45,3 +52,113 @@ Create the name of the Operator Cluster resources
 {{- printf "%s-%s-rbac" (include "kube-arangodb.operatorName" .) .Release.Namespace | trunc 63 | trimSuffix "-" -}}
 {{- end -}}
 {{- end -}}
+
+{{/*
+Space separated list of namespaces in which Operator RBAC roles are created
+*/}}
+{{- define "kube-arangodb.namespaces" -}}
+{{- if eq .Values.operator.scope "multi-namespaced" -}}
+{{- prepend .Values.operator.namespaces .Release.Namespace | uniq | join " " -}}
+{{- else -}}
+{{- .Release.Namespace -}}
+{{- end -}}
+{{- end -}}
+
+{{/*
+Rules of the admission webhooks
+*/}}
+{{- define "kube-arangodb.webhookRules" -}}
+rules:
+  - apiGroups: ["database.arangodb.com"]
+    apiVersions: ["v1", "v2alpha1"]
+    operations: ["CREATE", "UPDATE"]
+    resources: ["arangodeployments"]
+  - apiGroups: ["backup.arangodb.com"]
+    apiVersions: ["v1"]
+    operations: ["CREATE", "UPDATE"]
+    resources: ["arangobackups", "arangobackuppolicies"]
+  - apiGroups: ["replication.database.arangodb.com"]
+    apiVersions: ["v1", "v2alpha1"]
+    operations: ["CREATE", "UPDATE"]
+    resources: ["arangodeploymentreplications"]
+  - apiGroups: ["storage.arangodb.com"]
+    apiVersions: ["v1alpha"]
+    operations: ["CREATE", "UPDATE"]
+    resources: ["arangolocalstorages"]
+{{- end -}}
+
+
+{{/*
+Namespaced rules of the deployment operator
+*/}}
+{{- define "kube-arangodb.rules.deployment" -}}
+- apiGroups: ["database.arangodb.com"]
+  resources: ["arangodeployments", "arangodeployments/status", "arangodatabases", "arangodatabases/status", "arangousers", "arangousers/status", "arangocollections", "arangocollections/status"]
+  verbs: ["*"]
+- apiGroups: [""]
+  resources: ["pods", "services", "endpoints", "persistentvolumeclaims", "events", "secrets", "serviceaccounts"]
+  verbs: ["*"]
+- apiGroups: [""]
+  resources: ["pods/log"]
+  verbs: ["get"]
+- apiGroups: ["apps"]
+  resources: ["deployments", "replicasets"]
+  verbs: ["get"]
+- apiGroups: ["policy"]
+  resources: ["poddisruptionbudgets"]
+  verbs: ["*"]
+- apiGroups: ["networking.k8s.io"]
+  resources: ["networkpolicies"]
+  verbs: ["get", "create", "update", "delete"]
+- apiGroups: ["backup.arangodb.com"]
+  resources: ["arangobackuppolicies", "arangobackups"]
+  verbs: ["get", "list", "watch"]
+- apiGroups: ["monitoring.coreos.com"]
+  resources: ["servicemonitors"]
+  verbs: ["get", "create", "delete", "update", "list", "watch", "patch"]
+- apiGroups: ["cert-manager.io"]
+  resources: ["certificates"]
+  verbs: ["get", "create", "update", "delete"]
+{{- end -}}
+
+{{/*
+Namespaced rules of the backup operator
+*/}}
+{{- define "kube-arangodb.rules.backup" -}}
+- apiGroups: [""]
+  resources: ["pods", "services", "endpoints"]
+  verbs: ["get", "update"]
+- apiGroups: [""]
+  resources: ["events"]
+  verbs: ["*"]
+- apiGroups: [""]
+  resources: ["secrets"]
+  verbs: ["get"]
+- apiGroups: ["apps"]
+  resources: ["deployments", "replicasets"]
+  verbs: ["get"]
+- apiGroups: ["backup.arangodb.com"]
+  resources: ["arangobackuppolicies", "arangobackuppolicies/status", "arangobackups", "arangobackups/status"]
+  verbs: ["*"]
+- apiGroups: ["database.arangodb.com"]
+  resources: ["arangodeployments"]
+  verbs: ["get", "list", "watch"]
+{{- end -}}
+
+{{/*
+Namespaced rules of the deployment replication operator
+*/}}
+{{- define "kube-arangodb.rules.deploymentReplication" -}}
+- apiGroups: ["replication.database.arangodb.com"]
+  resources: ["arangodeploymentreplications", "arangodeploymentreplications/status"]
+  verbs: ["*"]
+- apiGroups: ["database.arangodb.com"]
+  resources: ["arangodeployments"]
+  verbs: ["get"]
+- apiGroups: [""]
+  resources: ["pods", "services", "endpoints", "persistentvolumeclaims", "events", "secrets"]
+  verbs: ["*"]
+- apiGroups: ["apps"]
+  resources: ["deployments", "replicasets"]
+  verbs: ["get"]
+{{- end -}}
\ No newline at end of file
@@ -0,0 +1,123 @@
+//
+// DISCLAIMER
+//
+// Copyright 2020 ArangoDB GmbH, Cologne, Germany
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// Copyright holder is ArangoDB GmbH, Cologne, Germany
+//
+
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+
+	"github.com/arangodb/go-driver/agency"
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+
+	api "github.com/arangodb/kube-arangodb/pkg/apis/deployment/v1"
+	agencyDefinitions "github.com/arangodb/kube-arangodb/pkg/deployment/agency"
+	"github.com/arangodb/kube-arangodb/pkg/util/arangod"
+	"github.com/arangodb/kube-arangodb/pkg/util/k8sutil"
+)
+
+var (
+	cmdAgency = &cobra.Command{
+		Use:   "agency",
+		Short: "Inspect the agency of a deployment",
+		Run:   cmdUsage,
+	}
+
+	cmdAgencyDump = &cobra.Command{
+		Use:   "dump [key]...",
+		Short: "Dump the agency state as JSON",
+		Long:  "Dumps the agency state below the given key parts (by default the whole arango tree) through port forwards to the agent pods.",
+		Run:   cmdAgencyDumpRun,
+	}
+)
+
+func init() {
+	cmdMain.AddCommand(cmdAgency)
+	cmdAgency.AddCommand(cmdAgencyDump)
+}
+
+func cmdAgencyDumpRun(cmd *cobra.Command, args []string) {
+	c := mustNewClients()
+	depl := c.mustGetDeployment()
+	ctx := context.Background()
+
+	a, stop, err := c.connectAgency(ctx, depl)
+	if err != nil {
+		cliLog.Fatal().Err(err).Msg("Failed to connect to the agency")
+	}
+	defer stop()
+
+	key := args
+	if len(key) == 0 {
+		key = []string{agencyDefinitions.ArangoKey}
+	}
+
+	var state interface{}
+	if err := a.ReadKey(ctx, key, &state); err != nil {
+		cliLog.Fatal().Err(err).Msg("Failed to read agency")
+	}
+
+	enc := json.NewEncoder(os.Stdout)
+	enc.SetIndent("", "  ")
+	if err := enc.Encode(state); err != nil {
+		cliLog.Fatal().Err(err).Msg("Failed to write agency state")
+	}
+}
+
+// connectAgency creates an agency client using port forwards to all agent pods of the deployment.
+// The returned function stops the port forwards.
+func (c clients) connectAgency(ctx context.Context, depl *api.ArangoDeployment) (agency.Agency, func(), error) {
+	var stops []func()
+	stop := func() {
+		for _, s := range stops {
+			s()
+		}
+	}
+
+	var hosts []string
+	for _, m := range depl.Status.Members.Agents {
+		if m.PodName == "" {
+			continue
+		}
+
+		host, s, err := k8sutil.PortForward(c.Config, c.KubeCli, depl.GetNamespace(), m.PodName, k8sutil.ArangoPort)
+		if err != nil {
+			stop()
+			return nil, nil, errors.Wrapf(err, "failed to forward port of pod %s", m.PodName)
+		}
+		stops = append(stops, s)
+		hosts = append(hosts, host)
+	}
+
+	if len(hosts) == 0 {
+		return nil, nil, fmt.Errorf("deployment %s has no agent pods", depl.GetName())
+	}
+
+	a, err := arangod.CreateArangodAgencyClientForHosts(ctx, c.KubeCli.CoreV1(), depl, hosts)
+	if err != nil {
+		stop()
+		return nil, nil, errors.WithStack(err)
+	}
+
+	return a, stop, nil
+}
@@ -0,0 +1,198 @@
+//
+// DISCLAIMER
+//
+// Copyright 2020 ArangoDB GmbH, Cologne, Germany
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// Copyright holder is ArangoDB GmbH, Cologne, Germany
+//
+
+package main
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"text/tabwriter"
+	"time"
+
+	"github.com/spf13/cobra"
+	meta "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	backupApi "github.com/arangodb/kube-arangodb/pkg/apis/backup/v1"
+)
+
+var (
+	cmdBackup = &cobra.Command{
+		Use:   "backup",
+		Short: "Create and restore backups of a deployment",
+		Run:   cmdUsage,
+	}
+
+	cmdBackupCreate = &cobra.Command{
+		Use:   "create [name]",
+		Short: "Create a backup of a deployment",
+		Args:  cobra.MaximumNArgs(1),
+		Run:   cmdBackupCreateRun,
+	}
+
+	cmdBackupList = &cobra.Command{
+		Use:   "list",
+		Short: "List backups of a deployment which can be used for a restore, newest first",
+		Args:  cobra.NoArgs,
+		Run:   cmdBackupListRun,
+	}
+
+	cmdBackupRestore = &cobra.Command{
+		Use:   "restore <name>",
+		Short: "Restore a deployment from a backup",
+		Args:  cobra.ExactArgs(1),
+		Run:   cmdBackupRestoreRun,
+	}
+
+	backupCreateOptions struct {
+		Wait    bool
+		Timeout time.Duration
+	}
+
+	backupRestoreOptions struct {
+		AllowInconsistent bool
+	}
+)
+
+func init() {
+	cmdMain.AddCommand(cmdBackup)
+	cmdBackup.AddCommand(cmdBackupCreate)
+	cmdBackup.AddCommand(cmdBackupList)
+	cmdBackup.AddCommand(cmdBackupRestore)
+
+	f := cmdBackupCreate.Flags()
+	f.BoolVar(&backupCreateOptions.Wait, "wait", false, "Wait until the backup is ready")
+	f.DurationVar(&backupCreateOptions.Timeout, "timeout", 30*time.Minute, "Maximum time to wait for the backup")
+
+	f = cmdBackupRestore.Flags()
+	f.BoolVar(&backupRestoreOptions.AllowInconsistent, "allow-inconsistent", false, "Allow restore from a potentially inconsistent backup")
+}
+
+func cmdBackupCreateRun(cmd *cobra.Command, args []string) {
+	c := mustNewClients()
+	depl := c.mustGetDeployment()
+
+	name := fmt.Sprintf("%s-%s", depl.GetName(), time.Now().UTC().Format("20060102150405"))
+	if len(args) > 0 {
+		name = args[0]
+	}
+
+	backups := c.ArangoCli.BackupV1().ArangoBackups(depl.GetNamespace())
+	backup, err := backups.Create(&backupApi.ArangoBackup{
+		ObjectMeta: meta.ObjectMeta{
+			Name: name,
+		},
+		Spec: backupApi.ArangoBackupSpec{
+			Deployment: backupApi.ArangoBackupSpecDeployment{
+				Name: depl.GetName(),
+			},
+		},
+	})
+	if err != nil {
+		cliLog.Fatal().Err(err).Msgf("Failed to create backup %s", name)
+	}
+
+	cliLog.Info().Msgf("Backup %s created", backup.GetName())
+
+	if !backupCreateOptions.Wait {
+		return
+	}
+
+	deadline := time.Now().Add(backupCreateOptions.Timeout)
+	for {
+		backup, err = backups.Get(name, meta.GetOptions{})
+		if err != nil {
+			cliLog.Fatal().Err(err).Msgf("Failed to get backup %s", name)
+		}
+
+		switch backup.Status.State {
+		case backupApi.ArangoBackupStateReady:
+			cliLog.Info().Msgf("Backup %s is ready", name)
+			return
+		case backupApi.ArangoBackupStateFailed:
+			cliLog.Fatal().Msgf("Backup %s failed: %s", name, backup.Status.Message)
+		}
+
+		if time.Now().After(deadline) {
+			cliLog.Fatal().Msgf("Backup %s is not ready after %s, current state %s", name, backupCreateOptions.Timeout, backup.Status.State)
+		}
+
+		time.Sleep(time.Second)
+	}
+}
+
+func cmdBackupListRun(cmd *cobra.Command, args []string) {
+	c := mustNewClients()
+	depl := c.mustGetDeployment()
+
+	backups, err := c.ArangoCli.BackupV1().ArangoBackups(depl.GetNamespace()).List(meta.ListOptions{})
+	if err != nil {
+		cliLog.Fatal().Err(err).Msg("Failed to list backups")
+	}
+
+	if err := printBackups(os.Stdout, backups.RestoreCandidates(depl.GetName()), time.Now()); err != nil {
+		cliLog.Fatal().Err(err).Msg("Failed to print backups")
+	}
+}
+
+// printBackups writes a table with the given backups.
+func printBackups(out io.Writer, backups []backupApi.ArangoBackup, now time.Time) error {
+	w := tabwriter.NewWriter(out, 0, 4, 2, ' ', 0)
+
+	fmt.Fprintf(w, "NAME\tVERSION\tPOLICY\tAGE\n")
+	for _, b := range backups {
+		version, policy := "", ""
+		if b.Status.Backup != nil {
+			version = b.Status.Backup.Version
+		}
+		if b.Spec.PolicyName != nil {
+			policy = *b.Spec.PolicyName
+		}
+		fmt.Fprintf(w, "%s\t%s\t%s\t%s\n", b.GetName(), valueOrNone(version), valueOrNone(policy), age(b.CreationTimestamp, now))
+	}
+
+	return w.Flush()
+}
+
+func cmdBackupRestoreRun(cmd *cobra.Command, args []string) {
+	c := mustNewClients()
+	depl := c.mustGetDeployment()
+	name := args[0]
+
+	backup, err := c.ArangoCli.BackupV1().ArangoBackups(depl.GetNamespace()).Get(name, meta.GetOptions{})
+	if err != nil {
+		cliLog.Fatal().Err(err).Msgf("Failed to get backup %s", name)
+	}
+
+	if !backup.Status.Available {
+		cliLog.Fatal().Msgf("Backup %s is not available, current state %s", name, backup.Status.State)
+	}
+
+	if backup.Status.Backup.IsPotentiallyInconsistent() && !backupRestoreOptions.AllowInconsistent {
+		cliLog.Fatal().Msgf("Backup %s is potentially inconsistent, use --allow-inconsistent to restore it anyway", name)
+	}
+
+	depl.Spec.RestoreFrom = &name
+	if _, err := c.ArangoCli.DatabaseV1().ArangoDeployments(depl.GetNamespace()).Update(depl); err != nil {
+		cliLog.Fatal().Err(err).Msgf("Failed to update deployment %s", depl.GetName())
+	}
+
+	cliLog.Info().Msgf("Restore of deployment %s from backup %s requested", depl.GetName(), name)
+}
@@ -0,0 +1,68 @@
+//
+// DISCLAIMER
+//
+// Copyright 2020 ArangoDB GmbH, Cologne, Germany
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// Copyright holder is ArangoDB GmbH, Cologne, Germany
+//
+
+package main
+
+import (
+	"bytes"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	meta "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	backupApi "github.com/arangodb/kube-arangodb/pkg/apis/backup/v1"
+	"github.com/arangodb/kube-arangodb/pkg/util"
+)
+
+func Test_PrintBackups(t *testing.T) {
+	now := time.Date(2020, 10, 1, 12, 0, 0, 0, time.UTC)
+
+	backups := []backupApi.ArangoBackup{
+		{
+			ObjectMeta: meta.ObjectMeta{
+				Name:              "example-daily",
+				CreationTimestamp: meta.NewTime(now.Add(-time.Hour)),
+			},
+			Spec: backupApi.ArangoBackupSpec{
+				PolicyName: util.NewString("daily"),
+			},
+			Status: backupApi.ArangoBackupStatus{
+				Backup: &backupApi.ArangoBackupDetails{
+					Version: "3.7.10",
+				},
+			},
+		},
+		{
+			ObjectMeta: meta.ObjectMeta{
+				Name: "example-manual",
+			},
+		},
+	}
+
+	var out bytes.Buffer
+	require.NoError(t, printBackups(&out, backups, now))
+
+	assert.Equal(t, `NAME            VERSION  POLICY  AGE
+example-daily   3.7.10   daily   60m
+example-manual  <none>   <none>  <unknown>
+`, out.String())
+}
@@ -0,0 +1,152 @@
+//
+// DISCLAIMER
+//
+// Copyright 2020 ArangoDB GmbH, Cologne, Germany
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// Copyright holder is ArangoDB GmbH, Cologne, Germany
+//
+
+package main
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"time"
+
+	driver "github.com/arangodb/go-driver"
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+
+	api "github.com/arangodb/kube-arangodb/pkg/apis/deployment/v1"
+	"github.com/arangodb/kube-arangodb/pkg/debug"
+	agencyDefinitions "github.com/arangodb/kube-arangodb/pkg/deployment/agency"
+	"github.com/arangodb/kube-arangodb/pkg/util/arangod"
+	"github.com/arangodb/kube-arangodb/pkg/util/k8sutil"
+)
+
+var (
+	cmdDebug = &cobra.Command{
+		Use:   "debug",
+		Short: "Collect debug information of a deployment",
+		Run:   cmdUsage,
+	}
+
+	cmdDebugBundle = &cobra.Command{
+		Use:   "bundle",
+		Short: "Write the debug information of a deployment into a tar.gz file",
+		Run:   cmdDebugBundleRun,
+	}
+
+	debugBundleOptions struct {
+		Output       string
+		Logs         bool
+		LogTailLines int64
+		Agency       bool
+		Health       bool
+	}
+)
+
+func init() {
+	cmdMain.AddCommand(cmdDebug)
+	cmdDebug.AddCommand(cmdDebugBundle)
+
+	f := cmdDebugBundle.Flags()
+	f.StringVarP(&debugBundleOptions.Output, "output", "o", "", "Output file, defaults to <deployment>-debug-<timestamp>.tar.gz")
+	f.BoolVar(&debugBundleOptions.Logs, "logs", true, "Collect the container logs of the deployment pods")
+	f.Int64Var(&debugBundleOptions.LogTailLines, "log-tail-lines", 1000, "Number of log lines collected per container, 0 collects all lines")
+	f.BoolVar(&debugBundleOptions.Agency, "agency", true, "Collect the agency Plan and Current")
+	f.BoolVar(&debugBundleOptions.Health, "health", true, "Collect the cluster health")
+}
+
+func cmdDebugBundleRun(cmd *cobra.Command, args []string) {
+	c := mustNewClients()
+	depl := c.mustGetDeployment()
+	ctx := context.Background()
+
+	name := fmt.Sprintf("%s-debug-%s", depl.GetName(), time.Now().UTC().Format("20060102150405"))
+	output := debugBundleOptions.Output
+	if output == "" {
+		output = name + ".tar.gz"
+	}
+
+	collector := debug.Collector{
+		KubeCli:      c.KubeCli,
+		ArangoCli:    c.ArangoCli,
+		Logs:         debugBundleOptions.Logs,
+		LogTailLines: debugBundleOptions.LogTailLines,
+	}
+
+	if debugBundleOptions.Agency {
+		a, stop, err := c.connectAgency(ctx, depl)
+		if err != nil {
+			cliLog.Warn().Err(err).Msg("Failed to connect to the agency, agency state is not collected")
+		} else {
+			defer stop()
+			collector.Agency = agencyDefinitions.NewFetcher(a)
+		}
+	}
+
+	if debugBundleOptions.Health && depl.Spec.GetMode().IsCluster() {
+		db, stop, err := c.connectCoordinator(ctx, depl)
+		if err != nil {
+			cliLog.Warn().Err(err).Msg("Failed to connect to a coordinator, cluster health is not collected")
+		} else {
+			defer stop()
+			collector.Database = db
+		}
+	}
+
+	f, err := os.Create(output)
+	if err != nil {
+		cliLog.Fatal().Err(err).Msgf("Failed to create %s", output)
+	}
+	defer f.Close()
+
+	b := debug.NewBundle(f, name)
+	if err := collector.Collect(ctx, b, depl.GetNamespace(), depl.GetName()); err != nil {
+		cliLog.Fatal().Err(err).Msg("Failed to collect debug information")
+	}
+	if err := b.Close(); err != nil {
+		cliLog.Fatal().Err(err).Msgf("Failed to write %s", output)
+	}
+
+	cliLog.Info().Msgf("Debug bundle written to %s", output)
+}
+
+// connectCoordinator creates a database client using a port forward to a coordinator pod of the deployment.
+// The returned function stops the port forward.
+func (c clients) connectCoordinator(ctx context.Context, depl *api.ArangoDeployment) (driver.Client, func(), error) {
+	for _, m := range depl.Status.Members.Coordinators {
+		if m.PodName == "" {
+			continue
+		}
+
+		host, stop, err := k8sutil.PortForward(c.Config, c.KubeCli, depl.GetNamespace(), m.PodName, k8sutil.ArangoPort)
+		if err != nil {
+			return nil, nil, errors.Wrapf(err, "failed to forward port of pod %s", m.PodName)
+		}
+
+		db, err := arangod.CreateArangodClientForHost(ctx, c.KubeCli.CoreV1(), depl, host)
+		if err != nil {
+			stop()
+			return nil, nil, errors.WithStack(err)
+		}
+
+		return db, stop, nil
+	}
+
+	return nil, nil, fmt.Errorf("deployment %s has no coordinator pods", depl.GetName())
+}
@@ -0,0 +1,152 @@
+//
+// DISCLAIMER
+//
+// Copyright 2020 ArangoDB GmbH, Cologne, Germany
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// Copyright holder is ArangoDB GmbH, Cologne, Germany
+//
+
+package main
+
+import (
+	"os"
+
+	"github.com/rs/zerolog"
+	"github.com/spf13/cobra"
+	meta "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/rest"
+	"k8s.io/client-go/tools/clientcmd"
+
+	api "github.com/arangodb/kube-arangodb/pkg/apis/deployment/v1"
+	"github.com/arangodb/kube-arangodb/pkg/client"
+	"github.com/arangodb/kube-arangodb/pkg/generated/clientset/versioned"
+)
+
+var (
+	projectVersion = "dev"
+	projectBuild   = "dev"
+
+	cmdMain = cobra.Command{
+		Use:   "kubectl-arangodb",
+		Short: "Day-2 operations for ArangoDB deployments managed by kube-arangodb",
+		Run:   cmdUsage,
+	}
+
+	cmdVersion = &cobra.Command{
+		Use:   "version",
+		Short: "Show the plugin version",
+		Run:   cmdVersionRun,
+	}
+
+	cliLog = zerolog.New(zerolog.ConsoleWriter{
+		Out:     os.Stderr,
+		NoColor: true,
+	})
+
+	kubeConfigLoadingRules = clientcmd.NewDefaultClientConfigLoadingRules()
+	kubeConfigOverrides    = &clientcmd.ConfigOverrides{}
+
+	mainOptions struct {
+		DeploymentName string
+	}
+)
+
+func init() {
+	f := cmdMain.PersistentFlags()
+	f.StringVar(&kubeConfigLoadingRules.ExplicitPath, "kubeconfig", "", "Path to the kubeconfig file")
+	clientcmd.BindOverrideFlags(kubeConfigOverrides, f, clientcmd.RecommendedConfigOverrideFlags(""))
+	f.StringVarP(&mainOptions.DeploymentName, "deployment", "d", "", "Name of the ArangoDeployment, can be omitted when the namespace contains only one")
+
+	cmdMain.AddCommand(cmdVersion)
+}
+
+func main() {
+	cmdMain.Execute()
+}
+
+// Show usage
+func cmdUsage(cmd *cobra.Command, args []string) {
+	cmd.Usage()
+}
+
+func cmdVersionRun(cmd *cobra.Command, args []string) {
+	cmd.Printf("kubectl-arangodb version %s build %s\n", projectVersion, projectBuild)
+}
+
+// clients holds the clients for the cluster selected by the kubeconfig flags.
+type clients struct {
+	Config    *rest.Config
+	Namespace string
+	KubeCli   kubernetes.Interface
+	ArangoCli versioned.Interface
+}
+
+// mustNewClients creates the clients for the cluster selected by the kubeconfig flags.
+func mustNewClients() clients {
+	clientConfig := clientcmd.NewNonInteractiveDeferredLoadingClientConfig(kubeConfigLoadingRules, kubeConfigOverrides)
+
+	cfg, err := clientConfig.ClientConfig()
+	if err != nil {
+		cliLog.Fatal().Err(err).Msg("Failed to load kubeconfig")
+	}
+
+	namespace, _, err := clientConfig.Namespace()
+	if err != nil {
+		cliLog.Fatal().Err(err).Msg("Failed to get namespace")
+	}
+
+	kubeCli, err := kubernetes.NewForConfig(cfg)
+	if err != nil {
+		cliLog.Fatal().Err(err).Msg("Failed to create Kubernetes client")
+	}
+
+	arangoCli, err := client.New(cfg)
+	if err != nil {
+		cliLog.Fatal().Err(err).Msg("Failed to create ArangoDB client")
+	}
+
+	return clients{
+		Config:    cfg,
+		Namespace: namespace,
+		KubeCli:   kubeCli,
+		ArangoCli: arangoCli,
+	}
+}
+
+// mustGetDeployment returns the deployment selected by the --deployment flag.
+// Without the flag the only deployment in the namespace is used.
+func (c clients) mustGetDeployment() *api.ArangoDeployment {
+	deployments := c.ArangoCli.DatabaseV1().ArangoDeployments(c.Namespace)
+
+	if name := mainOptions.DeploymentName; name != "" {
+		depl, err := deployments.Get(name, meta.GetOptions{})
+		if err != nil {
+			cliLog.Fatal().Err(err).Msgf("Failed to get deployment %s", name)
+		}
+		return depl
+	}
+
+	list, err := deployments.List(meta.ListOptions{})
+	if err != nil {
+		cliLog.Fatal().Err(err).Msg("Failed to list deployments")
+	}
+
+	if len(list.Items) != 1 {
+		cliLog.Fatal().Msgf("Found %d deployments in namespace %s, select one with --deployment", len(list.Items), c.Namespace)
+	}
+
+	return &list.Items[0]
+}
@@ -0,0 +1,74 @@
+//
+// DISCLAIMER
+//
+// Copyright 2020 ArangoDB GmbH, Cologne, Germany
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// Copyright holder is ArangoDB GmbH, Cologne, Germany
+//
+
+package main
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"text/tabwriter"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	api "github.com/arangodb/kube-arangodb/pkg/apis/deployment/v1"
+)
+
+var (
+	cmdMembers = &cobra.Command{
+		Use:   "members",
+		Short: "List the members of a deployment with their phases",
+		Run:   cmdMembersRun,
+	}
+)
+
+func init() {
+	cmdMain.AddCommand(cmdMembers)
+}
+
+func cmdMembersRun(cmd *cobra.Command, args []string) {
+	c := mustNewClients()
+	depl := c.mustGetDeployment()
+
+	if err := printMembers(os.Stdout, depl.Status.Members, time.Now()); err != nil {
+		cliLog.Fatal().Err(err).Msg("Failed to print members")
+	}
+}
+
+// printMembers writes a table with all members of the deployment.
+func printMembers(out io.Writer, members api.DeploymentStatusMembers, now time.Time) error {
+	w := tabwriter.NewWriter(out, 0, 4, 2, ' ', 0)
+
+	fmt.Fprintf(w, "GROUP\tID\tPHASE\tREADY\tPOD\tVERSION\tAGE\n")
+	members.ForeachServerGroup(func(group api.ServerGroup, list api.MemberStatusList) error {
+		for _, m := range list {
+			version := ""
+			if m.Image != nil {
+				version = string(m.Image.ArangoDBVersion)
+			}
+			fmt.Fprintf(w, "%s\t%s\t%s\t%t\t%s\t%s\t%s\n", group.AsRole(), m.ID, m.Phase,
+				m.Conditions.IsTrue(api.ConditionTypeReady), valueOrNone(m.PodName), valueOrNone(version), age(m.CreatedAt, now))
+		}
+		return nil
+	})
+
+	return w.Flush()
+}
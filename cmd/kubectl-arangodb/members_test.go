@@ -0,0 +1,65 @@
+//
+// DISCLAIMER
+//
+// Copyright 2020 ArangoDB GmbH, Cologne, Germany
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// Copyright holder is ArangoDB GmbH, Cologne, Germany
+//
+
+package main
+
+import (
+	"bytes"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	meta "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	api "github.com/arangodb/kube-arangodb/pkg/apis/deployment/v1"
+)
+
+func Test_PrintMembers(t *testing.T) {
+	now := time.Date(2020, 10, 1, 12, 0, 0, 0, time.UTC)
+
+	members := api.DeploymentStatusMembers{
+		Agents: api.MemberStatusList{
+			{
+				ID:        "AGNT-1",
+				Phase:     api.MemberPhaseCreated,
+				PodName:   "example-agnt-1",
+				CreatedAt: meta.NewTime(now.Add(-2 * time.Hour)),
+				Conditions: api.ConditionList{
+					{Type: api.ConditionTypeReady, Status: "True"},
+				},
+			},
+		},
+		DBServers: api.MemberStatusList{
+			{
+				ID:    "PRMR-1",
+				Phase: api.MemberPhaseNone,
+			},
+		},
+	}
+
+	var out bytes.Buffer
+	require.NoError(t, printMembers(&out, members, now))
+
+	assert.Equal(t, `GROUP     ID      PHASE    READY  POD             VERSION  AGE
+agent     AGNT-1  Created  true   example-agnt-1  <none>   120m
+dbserver  PRMR-1           false  <none>          <none>   <unknown>
+`, out.String())
+}
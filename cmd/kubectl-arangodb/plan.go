@@ -0,0 +1,166 @@
+//
+// DISCLAIMER
+//
+// Copyright 2020 ArangoDB GmbH, Cologne, Germany
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// Copyright holder is ArangoDB GmbH, Cologne, Germany
+//
+
+package main
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"text/tabwriter"
+	"time"
+
+	"github.com/spf13/cobra"
+	meta "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/fields"
+	"k8s.io/apimachinery/pkg/watch"
+
+	api "github.com/arangodb/kube-arangodb/pkg/apis/deployment/v1"
+)
+
+var (
+	cmdPlan = &cobra.Command{
+		Use:   "plan",
+		Short: "Show the plan of a deployment",
+		Run:   cmdPlanRun,
+	}
+
+	planOptions struct {
+		Follow bool
+	}
+)
+
+const (
+	planEventAdded    = "ADDED"
+	planEventStarted  = "STARTED"
+	planEventFinished = "FINISHED"
+)
+
+func init() {
+	cmdMain.AddCommand(cmdPlan)
+	cmdPlan.Flags().BoolVarP(&planOptions.Follow, "follow", "f", false, "Follow the plan and print actions as they are added, started and finished")
+}
+
+func cmdPlanRun(cmd *cobra.Command, args []string) {
+	c := mustNewClients()
+	depl := c.mustGetDeployment()
+
+	if !planOptions.Follow {
+		if err := printPlan(os.Stdout, depl.Status.Plan, time.Now()); err != nil {
+			cliLog.Fatal().Err(err).Msg("Failed to print plan")
+		}
+		return
+	}
+
+	deployments := c.ArangoCli.DatabaseV1().ArangoDeployments(depl.GetNamespace())
+	printPlanChanges(os.Stdout, diffPlan(nil, depl.Status.Plan), time.Now())
+	plan := depl.Status.Plan
+	resourceVersion := depl.GetResourceVersion()
+
+	for {
+		w, err := deployments.Watch(meta.ListOptions{
+			FieldSelector:   fields.OneTermEqualSelector("metadata.name", depl.GetName()).String(),
+			ResourceVersion: resourceVersion,
+		})
+		if err != nil {
+			cliLog.Fatal().Err(err).Msg("Failed to watch deployment")
+		}
+
+		for event := range w.ResultChan() {
+			switch event.Type {
+			case watch.Added, watch.Modified:
+				if d, ok := event.Object.(*api.ArangoDeployment); ok {
+					printPlanChanges(os.Stdout, diffPlan(plan, d.Status.Plan), time.Now())
+					plan = d.Status.Plan
+					resourceVersion = d.GetResourceVersion()
+				}
+			case watch.Deleted:
+				cliLog.Info().Msgf("Deployment %s deleted", depl.GetName())
+				return
+			case watch.Error:
+				// Resource version is too old, continue from the most recent one
+				resourceVersion = ""
+			}
+		}
+	}
+}
+
+// printPlan writes a table with all actions of the plan.
+func printPlan(out io.Writer, plan api.Plan, now time.Time) error {
+	w := tabwriter.NewWriter(out, 0, 4, 2, ' ', 0)
+
+	fmt.Fprintf(w, "ID\tTYPE\tGROUP\tMEMBER\tAGE\tSTARTED\tREASON\n")
+	for _, action := range plan {
+		started := "<none>"
+		if action.StartTime != nil {
+			started = age(*action.StartTime, now)
+		}
+		fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\t%s\t%s\n", action.ID, action.Type, action.Group.AsRole(),
+			valueOrNone(action.MemberID), age(action.CreationTime, now), started, action.Reason)
+	}
+
+	return w.Flush()
+}
+
+// planChange describes a change of a single plan action.
+type planChange struct {
+	Event  string
+	Action api.Action
+}
+
+// diffPlan returns the changes of the actions between two versions of a plan.
+func diffPlan(prev, next api.Plan) []planChange {
+	var changes []planChange
+
+	prevActions := map[string]api.Action{}
+	for _, action := range prev {
+		prevActions[action.ID] = action
+	}
+
+	nextActions := map[string]bool{}
+	for _, action := range next {
+		nextActions[action.ID] = true
+
+		prevAction, ok := prevActions[action.ID]
+		if !ok {
+			changes = append(changes, planChange{Event: planEventAdded, Action: action})
+		}
+		if action.StartTime != nil && (!ok || prevAction.StartTime == nil) {
+			changes = append(changes, planChange{Event: planEventStarted, Action: action})
+		}
+	}
+
+	for _, action := range prev {
+		if !nextActions[action.ID] {
+			changes = append(changes, planChange{Event: planEventFinished, Action: action})
+		}
+	}
+
+	return changes
+}
+
+// printPlanChanges writes one line per plan change.
+func printPlanChanges(out io.Writer, changes []planChange, now time.Time) {
+	for _, change := range changes {
+		action := change.Action
+		fmt.Fprintf(out, "%s %-8s %s %s %s %s\n", now.Format(time.RFC3339), change.Event, action.Type,
+			action.Group.AsRole(), valueOrNone(action.MemberID), action.Reason)
+	}
+}
@@ -0,0 +1,69 @@
+//
+// DISCLAIMER
+//
+// Copyright 2020 ArangoDB GmbH, Cologne, Germany
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// Copyright holder is ArangoDB GmbH, Cologne, Germany
+//
+
+package main
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	meta "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	api "github.com/arangodb/kube-arangodb/pkg/apis/deployment/v1"
+)
+
+func Test_DiffPlan(t *testing.T) {
+	now := meta.Now()
+
+	pending := api.Action{ID: "a", Type: api.ActionTypeRotateMember}
+	started := pending
+	started.StartTime = &now
+	next := api.Action{ID: "b", Type: api.ActionTypeWaitForMemberUp}
+
+	t.Run("Empty", func(t *testing.T) {
+		assert.Empty(t, diffPlan(nil, nil))
+	})
+
+	t.Run("Added", func(t *testing.T) {
+		assert.Equal(t, []planChange{
+			{Event: planEventAdded, Action: pending},
+			{Event: planEventAdded, Action: next},
+		}, diffPlan(nil, api.Plan{pending, next}))
+	})
+
+	t.Run("Added and started", func(t *testing.T) {
+		assert.Equal(t, []planChange{
+			{Event: planEventAdded, Action: started},
+			{Event: planEventStarted, Action: started},
+		}, diffPlan(nil, api.Plan{started}))
+	})
+
+	t.Run("Started", func(t *testing.T) {
+		assert.Equal(t, []planChange{
+			{Event: planEventStarted, Action: started},
+		}, diffPlan(api.Plan{pending, next}, api.Plan{started, next}))
+	})
+
+	t.Run("Finished", func(t *testing.T) {
+		assert.Equal(t, []planChange{
+			{Event: planEventFinished, Action: started},
+		}, diffPlan(api.Plan{started, next}, api.Plan{next}))
+	})
+}
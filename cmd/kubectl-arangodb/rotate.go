@@ -0,0 +1,85 @@
+//
+// DISCLAIMER
+//
+// Copyright 2020 ArangoDB GmbH, Cologne, Germany
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// Copyright holder is ArangoDB GmbH, Cologne, Germany
+//
+
+package main
+
+import (
+	"github.com/spf13/cobra"
+	meta "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+
+	"github.com/arangodb/kube-arangodb/pkg/apis/deployment"
+	"github.com/arangodb/kube-arangodb/pkg/deployment/patch"
+)
+
+var (
+	cmdRotate = &cobra.Command{
+		Use:   "rotate <member-id>...",
+		Short: "Request the rotation of deployment members",
+		Long:  "Marks the pods of the given members with the rotation annotation. The operator rotates marked members one at a time.",
+		Args:  cobra.MinimumNArgs(1),
+		Run:   cmdRotateRun,
+	}
+)
+
+func init() {
+	cmdMain.AddCommand(cmdRotate)
+}
+
+func cmdRotateRun(cmd *cobra.Command, args []string) {
+	c := mustNewClients()
+	depl := c.mustGetDeployment()
+
+	pods := c.KubeCli.CoreV1().Pods(depl.GetNamespace())
+	for _, id := range args {
+		m, group, found := depl.Status.Members.ElementByID(id)
+		if !found {
+			cliLog.Fatal().Msgf("Member %s not found in deployment %s", id, depl.GetName())
+		}
+		if m.PodName == "" {
+			cliLog.Fatal().Msgf("Member %s has no pod", id)
+		}
+
+		pod, err := pods.Get(m.PodName, meta.GetOptions{})
+		if err != nil {
+			cliLog.Fatal().Err(err).Msgf("Failed to get pod %s", m.PodName)
+		}
+
+		p := patch.NewPatch()
+		if pod.GetAnnotations() == nil {
+			p.ItemAdd(patch.NewPath("metadata", "annotations"), map[string]string{
+				deployment.ArangoDeploymentPodRotateAnnotation: "true",
+			})
+		} else {
+			p.ItemAdd(patch.NewPath("metadata", "annotations", deployment.ArangoDeploymentPodRotateAnnotation), "true")
+		}
+
+		data, err := p.Marshal()
+		if err != nil {
+			cliLog.Fatal().Err(err).Msg("Failed to create patch")
+		}
+
+		if _, err := pods.Patch(m.PodName, types.JSONPatchType, data); err != nil {
+			cliLog.Fatal().Err(err).Msgf("Failed to annotate pod %s", m.PodName)
+		}
+
+		cliLog.Info().Msgf("Rotation of %s member %s (pod %s) requested", group.AsRole(), id, m.PodName)
+	}
+}
@@ -0,0 +1,124 @@
+//
+// DISCLAIMER
+//
+// Copyright 2020 ArangoDB GmbH, Cologne, Germany
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// Copyright holder is ArangoDB GmbH, Cologne, Germany
+//
+
+package main
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/spf13/cobra"
+	meta "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/duration"
+
+	api "github.com/arangodb/kube-arangodb/pkg/apis/deployment/v1"
+)
+
+var (
+	cmdStatus = &cobra.Command{
+		Use:   "status",
+		Short: "Show the status of a deployment",
+		Run:   cmdStatusRun,
+	}
+)
+
+func init() {
+	cmdMain.AddCommand(cmdStatus)
+}
+
+func cmdStatusRun(cmd *cobra.Command, args []string) {
+	c := mustNewClients()
+	depl := c.mustGetDeployment()
+
+	if err := printStatus(os.Stdout, depl, time.Now()); err != nil {
+		cliLog.Fatal().Err(err).Msg("Failed to print status")
+	}
+}
+
+// printStatus writes a summary of the deployment status.
+func printStatus(out io.Writer, depl *api.ArangoDeployment, now time.Time) error {
+	w := tabwriter.NewWriter(out, 0, 4, 2, ' ', 0)
+	status := depl.Status
+
+	fmt.Fprintf(w, "Deployment:\t%s\n", depl.GetName())
+	fmt.Fprintf(w, "Namespace:\t%s\n", depl.GetNamespace())
+	fmt.Fprintf(w, "Mode:\t%s\n", depl.Spec.GetMode())
+	fmt.Fprintf(w, "Phase:\t%s\n", valueOrNone(string(status.Phase)))
+	if status.Reason != "" {
+		fmt.Fprintf(w, "Reason:\t%s\n", status.Reason)
+	}
+	if image := status.CurrentImage; image != nil {
+		edition := "community"
+		if image.Enterprise {
+			edition = "enterprise"
+		}
+		fmt.Fprintf(w, "Image:\t%s (%s, %s)\n", image.Image, image.ArangoDBVersion, edition)
+	} else {
+		fmt.Fprintf(w, "Image:\t%s\n", depl.Spec.GetImage())
+	}
+
+	var groups []string
+	status.Members.ForeachServerGroup(func(group api.ServerGroup, list api.MemberStatusList) error {
+		if len(list) == 0 {
+			return nil
+		}
+		ready := 0
+		for _, m := range list {
+			if m.Conditions.IsTrue(api.ConditionTypeReady) {
+				ready++
+			}
+		}
+		groups = append(groups, fmt.Sprintf("%s %d/%d ready", group.AsRole(), ready, len(list)))
+		return nil
+	})
+	fmt.Fprintf(w, "Members:\t%s\n", valueOrNone(strings.Join(groups, ", ")))
+	fmt.Fprintf(w, "Plan:\t%d actions\n", len(status.Plan))
+
+	if len(status.Conditions) > 0 {
+		fmt.Fprintf(w, "Conditions:\n")
+		fmt.Fprintf(w, "  TYPE\tSTATUS\tAGE\tREASON\tMESSAGE\n")
+		for _, condition := range status.Conditions {
+			fmt.Fprintf(w, "  %s\t%s\t%s\t%s\t%s\n", condition.Type, condition.Status, age(condition.LastTransitionTime, now),
+				valueOrNone(condition.Reason), condition.Message)
+		}
+	}
+
+	return w.Flush()
+}
+
+// age returns the human readable time passed since t.
+func age(t meta.Time, now time.Time) string {
+	if t.IsZero() {
+		return "<unknown>"
+	}
+	return duration.HumanDuration(now.Sub(t.Time))
+}
+
+// valueOrNone returns the value or a placeholder when it is empty.
+func valueOrNone(s string) string {
+	if s == "" {
+		return "<none>"
+	}
+	return s
+}
@@ -0,0 +1,160 @@
+//
+// DISCLAIMER
+//
+// Copyright 2020 ArangoDB GmbH, Cologne, Germany
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// Copyright holder is ArangoDB GmbH, Cologne, Germany
+//
+
+package main
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"time"
+
+	"github.com/rs/zerolog"
+	"github.com/spf13/cobra"
+	meta "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	extclient "github.com/arangodb/kube-arangodb/pkg/client"
+	"github.com/arangodb/kube-arangodb/pkg/debug"
+	"github.com/arangodb/kube-arangodb/pkg/deployment/agency"
+	"github.com/arangodb/kube-arangodb/pkg/util/arangod"
+	"github.com/arangodb/kube-arangodb/pkg/util/constants"
+	"github.com/arangodb/kube-arangodb/pkg/util/k8sutil"
+)
+
+var (
+	cmdDebug = &cobra.Command{
+		Use:   "debug",
+		Short: "Collect the debug information of a deployment into a tar.gz file",
+		Run:   cmdDebugRun,
+	}
+
+	debugOptions struct {
+		DeploymentName string
+		Namespace      string
+		Output         string
+		Logs           bool
+		LogTailLines   int64
+	}
+)
+
+func init() {
+	cmdMain.AddCommand(cmdDebug)
+
+	f := cmdDebug.Flags()
+	f.StringVar(&debugOptions.DeploymentName, "deployment-name", "", "Name of the deployment")
+	f.StringVar(&debugOptions.Namespace, "namespace", os.Getenv(constants.EnvOperatorPodNamespace), "Namespace of the deployment")
+	f.StringVar(&debugOptions.Output, "output", "-", "Output file, - writes the bundle to stdout")
+	f.BoolVar(&debugOptions.Logs, "logs", true, "Collect the container logs of the deployment pods")
+	f.Int64Var(&debugOptions.LogTailLines, "log-tail-lines", 1000, "Number of log lines collected per container, 0 collects all lines")
+}
+
+// Collect the debug information of a deployment.
+// Run it inside the operator pod, e.g.
+// kubectl exec <operator-pod> -- /usr/bin/arangodb_operator debug --deployment-name <name> > bundle.tar.gz
+func cmdDebugRun(cmd *cobra.Command, args []string) {
+	// Keep stdout free for the bundle
+	cliLog = zerolog.New(zerolog.ConsoleWriter{
+		Out:        os.Stderr,
+		TimeFormat: time.RFC3339Nano,
+		NoColor:    true,
+	}).With().Timestamp().Logger()
+
+	name, namespace := debugOptions.DeploymentName, debugOptions.Namespace
+	if name == "" {
+		cliLog.Fatal().Msg("--deployment-name is required")
+	}
+	if namespace == "" {
+		cliLog.Fatal().Msgf("--namespace is required when %s is not set", constants.EnvOperatorPodNamespace)
+	}
+
+	kubecli, err := k8sutil.NewKubeClient()
+	if err != nil {
+		cliLog.Fatal().Err(err).Msg("Failed to create Kubernetes client")
+	}
+
+	arangocli, err := extclient.NewClient()
+	if err != nil {
+		cliLog.Fatal().Err(err).Msg("Failed to create ArangoDB client")
+	}
+
+	depl, err := arangocli.DatabaseV1().ArangoDeployments(namespace).Get(name, meta.GetOptions{})
+	if err != nil {
+		cliLog.Fatal().Err(err).Msgf("Failed to get deployment %s", name)
+	}
+
+	ctx := context.Background()
+	collector := debug.Collector{
+		KubeCli:      kubecli,
+		ArangoCli:    arangocli,
+		Logs:         debugOptions.Logs,
+		LogTailLines: debugOptions.LogTailLines,
+	}
+
+	if depl.Spec.GetMode().HasAgents() {
+		if a, err := arangod.CreateArangodAgencyClient(ctx, kubecli.CoreV1(), depl); err != nil {
+			cliLog.Warn().Err(err).Msg("Failed to create agency client, agency state is not collected")
+		} else {
+			collector.Agency = agency.NewFetcher(a)
+		}
+	}
+
+	if db, err := arangod.CreateArangodDatabaseClient(ctx, kubecli.CoreV1(), depl, false); err != nil {
+		cliLog.Warn().Err(err).Msg("Failed to create database client, cluster health is not collected")
+	} else {
+		collector.Database = db
+	}
+
+	// Collect the logs of all operator replicas, the leader is not necessarily the current pod
+	if podName := os.Getenv(constants.EnvOperatorPodName); podName == "" {
+		cliLog.Warn().Msgf("%s environment variable missing, operator logs are not collected", constants.EnvOperatorPodName)
+	} else if pod, err := kubecli.CoreV1().Pods(os.Getenv(constants.EnvOperatorPodNamespace)).Get(podName, meta.GetOptions{}); err != nil {
+		cliLog.Warn().Err(err).Msg("Failed to get operator pod, operator logs are not collected")
+	} else {
+		operatorLabels := map[string]string{}
+		for k, v := range pod.GetLabels() {
+			if k != "pod-template-hash" {
+				operatorLabels[k] = v
+			}
+		}
+		collector.OperatorNamespace = pod.GetNamespace()
+		collector.OperatorLabels = operatorLabels
+	}
+
+	var out io.Writer = os.Stdout
+	if debugOptions.Output != "-" {
+		f, err := os.Create(debugOptions.Output)
+		if err != nil {
+			cliLog.Fatal().Err(err).Msgf("Failed to create %s", debugOptions.Output)
+		}
+		defer f.Close()
+		out = f
+	}
+
+	b := debug.NewBundle(out, fmt.Sprintf("%s-debug-%s", name, time.Now().UTC().Format("20060102150405")))
+	if err := collector.Collect(ctx, b, namespace, name); err != nil {
+		cliLog.Fatal().Err(err).Msg("Failed to collect debug information")
+	}
+	if err := b.Close(); err != nil {
+		cliLog.Fatal().Err(err).Msg("Failed to write debug bundle")
+	}
+
+	cliLog.Info().Msgf("Debug bundle of deployment %s written", name)
+}
@@ -0,0 +1,191 @@
+//
+// DISCLAIMER
+//
+// Copyright 2020 ArangoDB GmbH, Cologne, Germany
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// Copyright holder is ArangoDB GmbH, Cologne, Germany
+//
+
+package main
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+	core "k8s.io/api/core/v1"
+
+	"github.com/arangodb/kube-arangodb/pkg/util/kms"
+)
+
+var (
+	cmdEncryption = &cobra.Command{
+		Use:    "encryption",
+		Run:    cmdUsage,
+		Hidden: true,
+	}
+
+	cmdEncryptionUnwrap = &cobra.Command{
+		Use:    "unwrap",
+		RunE:   cmdEncryptionUnwrapRun,
+		Hidden: true,
+	}
+
+	encryptionUnwrapOptions struct {
+		input, output string
+		report        string
+		provider      struct {
+			providerType, endpoint, keyName, mountPath string
+			credentials, ca                            string
+		}
+		timeout time.Duration
+	}
+)
+
+func init() {
+	cmdMain.AddCommand(cmdEncryption)
+	cmdEncryption.AddCommand(cmdEncryptionUnwrap)
+
+	f := cmdEncryptionUnwrap.Flags()
+	f.StringVar(&encryptionUnwrapOptions.input, "input", "", "Directory with wrapped keys")
+	f.StringVar(&encryptionUnwrapOptions.output, "output", "", "Directory where unwrapped keys are written")
+	f.StringVar(&encryptionUnwrapOptions.report, "report", "", "File where checksums of unwrapped keys are reported by names of wrapped keys")
+	f.StringVar(&encryptionUnwrapOptions.provider.providerType, "provider.type", "", "Type of the key provider (Vault, KMIP, Webhook)")
+	f.StringVar(&encryptionUnwrapOptions.provider.endpoint, "provider.endpoint", "", "Endpoint of the key provider")
+	f.StringVar(&encryptionUnwrapOptions.provider.keyName, "provider.key-name", "", "Name of the key encryption key")
+	f.StringVar(&encryptionUnwrapOptions.provider.mountPath, "provider.mount-path", "", "Mount path of the Vault transit secrets engine")
+	f.StringVar(&encryptionUnwrapOptions.provider.credentials, "provider.credentials", "", "Directory with provider credentials (token, tls.crt, tls.key)")
+	f.StringVar(&encryptionUnwrapOptions.provider.ca, "provider.ca", "", "Path to the CA certificate of the provider")
+	f.DurationVar(&encryptionUnwrapOptions.timeout, "timeout", 2*time.Minute, "Timeout of the unwrap operation")
+}
+
+// cmdEncryptionUnwrapRun unwraps all keys from the input directory with the key provider
+// and writes them, under the same name, into the output directory.
+// Checksums of unwrapped keys are reported, as the operator never unwraps keys itself.
+func cmdEncryptionUnwrapRun(cmd *cobra.Command, args []string) error {
+	opts := encryptionUnwrapOptions
+
+	if opts.input == "" || opts.output == "" {
+		return errors.Errorf("Input and output directories are required")
+	}
+
+	config := kms.Config{
+		Type:      kms.Type(opts.provider.providerType),
+		Endpoint:  opts.provider.endpoint,
+		KeyName:   opts.provider.keyName,
+		MountPath: opts.provider.mountPath,
+	}
+
+	if dir := opts.provider.credentials; dir != "" {
+		token, err := readOptionalFile(filepath.Join(dir, kms.TokenKey))
+		if err != nil {
+			return err
+		}
+		config.Token = strings.TrimSpace(string(token))
+
+		if config.ClientCert, err = readOptionalFile(filepath.Join(dir, core.TLSCertKey)); err != nil {
+			return err
+		}
+		if config.ClientKey, err = readOptionalFile(filepath.Join(dir, core.TLSPrivateKeyKey)); err != nil {
+			return err
+		}
+	}
+
+	if opts.provider.ca != "" {
+		ca, err := ioutil.ReadFile(opts.provider.ca)
+		if err != nil {
+			return errors.Wrapf(err, "Unable to read provider CA")
+		}
+		config.CA = ca
+	}
+
+	provider, err := kms.NewProvider(config)
+	if err != nil {
+		return err
+	}
+
+	files, err := ioutil.ReadDir(opts.input)
+	if err != nil {
+		return errors.Wrapf(err, "Unable to list wrapped keys")
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), opts.timeout)
+	defer cancel()
+
+	checksums := map[string]string{}
+	for _, file := range files {
+		// Skip internal entries of secret volumes
+		if strings.HasPrefix(file.Name(), ".") || file.IsDir() {
+			continue
+		}
+
+		wrapped, err := ioutil.ReadFile(filepath.Join(opts.input, file.Name()))
+		if err != nil {
+			return errors.Wrapf(err, "Unable to read wrapped key %s", file.Name())
+		}
+
+		key, err := provider.Unwrap(ctx, wrapped)
+		if err != nil {
+			return errors.Wrapf(err, "Unable to unwrap key %s", file.Name())
+		}
+
+		if len(key) != 32 {
+			return errors.Errorf("Unwrapped key %s has invalid length %d", file.Name(), len(key))
+		}
+
+		if err := ioutil.WriteFile(filepath.Join(opts.output, file.Name()), key, 0600); err != nil {
+			return errors.Wrapf(err, "Unable to write key %s", file.Name())
+		}
+
+		checksums[file.Name()] = fmt.Sprintf("%0x", sha256.Sum256(key))
+	}
+
+	if len(checksums) == 0 {
+		return errors.Errorf("No wrapped keys found in %s", opts.input)
+	}
+
+	if opts.report != "" {
+		data, err := json.Marshal(checksums)
+		if err != nil {
+			return errors.Wrapf(err, "Unable to prepare report")
+		}
+
+		if err := ioutil.WriteFile(opts.report, data, 0644); err != nil {
+			return errors.Wrapf(err, "Unable to write report")
+		}
+	}
+
+	cliLog.Info().Int("keys", len(checksums)).Msg("Encryption keys unwrapped")
+	return nil
+}
+
+func readOptionalFile(path string) ([]byte, error) {
+	data, err := ioutil.ReadFile(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, errors.Wrapf(err, "Unable to read %s", path)
+	}
+	return data, nil
+}
@@ -36,6 +36,7 @@ require (
 	github.com/gin-contrib/sse v0.0.0-20190301062529-5545eab6dad3 // indirect
 	github.com/gin-gonic/gin v1.3.0
 	github.com/google/addlicense v0.0.0-20200906110928-a0294312aa76 // indirect
+	github.com/google/gofuzz v1.0.0
 	github.com/jessevdk/go-assets v0.0.0-20160921144138-4f4301a06e15
 	github.com/jessevdk/go-assets-builder v0.0.0-20130903091706-b8483521738f
 	github.com/julienschmidt/httprouter v1.3.0
@@ -27,17 +27,22 @@ import (
 	"fmt"
 	"net"
 	"os"
+	"os/signal"
 	"strconv"
 	"strings"
+	"syscall"
 	"time"
 
 	"github.com/arangodb/kube-arangodb/pkg/operator/scope"
+	"github.com/arangodb/kube-arangodb/pkg/operator/sharding"
 
 	"github.com/arangodb/kube-arangodb/pkg/deployment/features"
 
 	"github.com/rs/zerolog/log"
 
+	"github.com/arangodb/kube-arangodb/pkg/apis/deployment"
 	deploymentApi "github.com/arangodb/kube-arangodb/pkg/apis/deployment/v1"
+	"github.com/arangodb/kube-arangodb/pkg/apis/replication"
 
 	"github.com/arangodb/kube-arangodb/pkg/util"
 
@@ -48,6 +53,8 @@ import (
 	"github.com/spf13/cobra"
 	flag "github.com/spf13/pflag"
 	appsv1 "k8s.io/api/apps/v1"
+	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	apiextensionsclient "k8s.io/apiextensions-apiserver/pkg/client/clientset/clientset"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/client-go/kubernetes"
@@ -60,9 +67,11 @@ import (
 	"github.com/arangodb/kube-arangodb/pkg/operator"
 	"github.com/arangodb/kube-arangodb/pkg/server"
 	"github.com/arangodb/kube-arangodb/pkg/util/constants"
+	"github.com/arangodb/kube-arangodb/pkg/util/crd"
 	"github.com/arangodb/kube-arangodb/pkg/util/k8sutil"
 	"github.com/arangodb/kube-arangodb/pkg/util/probe"
 	"github.com/arangodb/kube-arangodb/pkg/util/retry"
+	"github.com/arangodb/kube-arangodb/pkg/webhook"
 	v1 "k8s.io/api/core/v1"
 	"k8s.io/klog"
 )
@@ -101,6 +110,8 @@ var (
 		tlsSecretName   string
 		adminSecretName string // Name of basic authentication secret containing the admin username+password of the dashboard
 		allowAnonymous  bool   // If set, anonymous access to dashboard is allowed
+		webhook         bool   // If set, admission webhooks are served
+		webhookService  string // Name of the service of the operator, if set the conversion webhook is configured on CRDs
 	}
 	operatorOptions struct {
 		enableDeployment            bool // Run deployment operator
@@ -111,7 +122,12 @@ var (
 		alpineImage, metricsExporterImage, arangoImage string
 
 		singleMode bool
+		sharded    bool
+		shards     int
 		scope      string
+
+		scopeNamespaces        []string
+		scopeNamespaceSelector string
 	}
 	chaosOptions struct {
 		allowed bool
@@ -131,6 +147,8 @@ func init() {
 	f.StringVar(&serverOptions.tlsSecretName, "server.tls-secret-name", "", "Name of secret containing tls.crt & tls.key for HTTPS server (if empty, self-signed certificate is used)")
 	f.StringVar(&serverOptions.adminSecretName, "server.admin-secret-name", defaultAdminSecretName, "Name of secret containing username + password for login to the dashboard")
 	f.BoolVar(&serverOptions.allowAnonymous, "server.allow-anonymous-access", false, "Allow anonymous access to the dashboard")
+	f.BoolVar(&serverOptions.webhook, "server.webhook", false, "Serve validating, mutating and conversion webhooks for custom resources")
+	f.StringVar(&serverOptions.webhookService, "server.webhook-service", "", "Name of the Service of the operator. If set, custom resource definitions are configured to use the conversion webhook of the operator")
 	f.StringVar(&logLevel, "log.level", defaultLogLevel, "Set initial log level")
 	f.BoolVar(&operatorOptions.enableDeployment, "operator.deployment", false, "Enable to run the ArangoDeployment operator")
 	f.BoolVar(&operatorOptions.enableDeploymentReplication, "operator.deployment-replication", false, "Enable to run the ArangoDeploymentReplication operator")
@@ -141,7 +159,11 @@ func init() {
 	f.StringVar(&operatorOptions.arangoImage, "operator.arango-image", ArangoImageEnv.GetOrDefault(defaultArangoImage), "Docker image used for arango by default")
 	f.BoolVar(&chaosOptions.allowed, "chaos.allowed", false, "Set to allow chaos in deployments. Only activated when allowed and enabled in deployment")
 	f.BoolVar(&operatorOptions.singleMode, "mode.single", false, "Enable single mode in Operator. WARNING: There should be only one replica of Operator, otherwise Operator can take unexpected actions")
+	f.BoolVar(&operatorOptions.sharded, "mode.sharded", false, "Enable sharded mode of the ArangoDeployment operator. All replicas are active and ArangoDeployments are distributed between them")
+	f.IntVar(&operatorOptions.shards, "mode.sharded.shards", sharding.DefaultShards, "Number of shards into which ArangoDeployments are divided in sharded mode")
 	f.StringVar(&operatorOptions.scope, "scope", scope.DefaultScope.String(), "Define scope on which Operator works. Legacy - pre 1.1.0 scope with limited cluster access")
+	f.StringSliceVar(&operatorOptions.scopeNamespaces, "scope.namespaces", nil, "Namespaces watched by Operator in multi-namespaced scope")
+	f.StringVar(&operatorOptions.scopeNamespaceSelector, "scope.namespace-selector", "", "Label selector of namespaces watched by Operator in multi-namespaced scope. Informers of namespaces are started and stopped when the selected namespaces change")
 
 	features.Init(&cmdMain)
 }
@@ -230,7 +252,28 @@ func cmdMainRun(cmd *cobra.Command, args []string) {
 		cliLog.Fatal().Err(err).Msg("Failed to create operator")
 	}
 
+	// Operator and watches are stopped on SIGINT or SIGTERM
+	stop := make(chan struct{})
+	go func() {
+		signals := make(chan os.Signal, 1)
+		signal.Notify(signals, os.Interrupt, syscall.SIGTERM)
+		<-signals
+		close(stop)
+	}()
+
 	listenAddr := net.JoinHostPort(serverOptions.host, strconv.Itoa(serverOptions.port))
+
+	var admission *webhook.Handler
+	if serverOptions.webhook {
+		admission = webhook.NewHandler(logService.MustGetLogger("webhook"))
+
+		if serverOptions.webhookService != "" {
+			if err := configureConversionWebhook(deps.KubeExtCli, secrets, namespace, cfg, stop); err != nil {
+				cliLog.Fatal().Err(err).Msg("Failed to configure conversion webhook")
+			}
+		}
+	}
+
 	if svr, err := server.NewServer(kubecli.CoreV1(), server.Config{
 		Namespace:          namespace,
 		Address:            listenAddr,
@@ -262,6 +305,7 @@ func cmdMainRun(cmd *cobra.Command, args []string) {
 		Operators: o,
 
 		Secrets: secrets,
+		Webhook: admission,
 	}); err != nil {
 		cliLog.Fatal().Err(err).Msg("Failed to create HTTP server")
 	} else {
@@ -271,7 +315,7 @@ func cmdMainRun(cmd *cobra.Command, args []string) {
 	//	startChaos(context.Background(), cfg.KubeCli, cfg.Namespace, chaosLevel)
 
 	// Start operator
-	o.Run()
+	o.Run(stop)
 }
 
 // newOperatorConfigAndDeps creates operator config & dependencies.
@@ -286,6 +330,11 @@ func newOperatorConfigAndDeps(id, namespace, name string) (operator.Config, oper
 		return operator.Config{}, operator.Dependencies{}, maskAny(err)
 	}
 
+	kubeDynamicCli, err := k8sutil.NewKubeDynamicClient()
+	if err != nil {
+		return operator.Config{}, operator.Dependencies{}, maskAny(err)
+	}
+
 	image, serviceAccount, err := getMyPodInfo(kubecli, namespace, name)
 	if err != nil {
 		return operator.Config{}, operator.Dependencies{}, maskAny(fmt.Errorf("Failed to get my pod's service account: %s", err))
@@ -306,6 +355,23 @@ func newOperatorConfigAndDeps(id, namespace, name string) (operator.Config, oper
 		return operator.Config{}, operator.Dependencies{}, maskAny(fmt.Errorf("Scope %s is not known by Operator", operatorOptions.scope))
 	}
 
+	if operatorOptions.sharded {
+		if operatorOptions.singleMode {
+			return operator.Config{}, operator.Dependencies{}, maskAny(fmt.Errorf("Flags --mode.single and --mode.sharded can not be used together"))
+		}
+		if operatorOptions.shards <= 0 {
+			return operator.Config{}, operator.Dependencies{}, maskAny(fmt.Errorf("Number of shards needs to be greater than 0"))
+		}
+	}
+
+	if scope.IsMultiNamespaced() {
+		if len(operatorOptions.scopeNamespaces) == 0 && operatorOptions.scopeNamespaceSelector == "" {
+			return operator.Config{}, operator.Dependencies{}, maskAny(fmt.Errorf("Scope %s requires --scope.namespaces or --scope.namespace-selector", scope))
+		}
+	} else if len(operatorOptions.scopeNamespaces) > 0 || operatorOptions.scopeNamespaceSelector != "" {
+		return operator.Config{}, operator.Dependencies{}, maskAny(fmt.Errorf("Flags --scope.namespaces and --scope.namespace-selector are not supported in scope %s", scope))
+	}
+
 	cfg := operator.Config{
 		ID:                          id,
 		Namespace:                   namespace,
@@ -321,13 +387,18 @@ func newOperatorConfigAndDeps(id, namespace, name string) (operator.Config, oper
 		MetricsExporterImage:        operatorOptions.metricsExporterImage,
 		ArangoImage:                 operatorOptions.arangoImage,
 		SingleMode:                  operatorOptions.singleMode,
+		Sharded:                     operatorOptions.sharded,
+		Shards:                      operatorOptions.shards,
 		Scope:                       scope,
+		Namespaces:                  operatorOptions.scopeNamespaces,
+		NamespaceSelector:           operatorOptions.scopeNamespaceSelector,
 	}
 	deps := operator.Dependencies{
 		LogService:                 logService,
 		KubeCli:                    kubecli,
 		KubeExtCli:                 kubeExtCli,
 		KubeMonitoringCli:          kubeMonCli,
+		KubeDynamicCli:             kubeDynamicCli,
 		CRCli:                      crCli,
 		EventRecorder:              eventRecorder,
 		LivenessProbe:              &livenessProbe,
@@ -340,6 +411,55 @@ func newOperatorConfigAndDeps(id, namespace, name string) (operator.Config, oper
 	return cfg, deps, nil
 }
 
+// configureConversionWebhook configures the CRDs of the enabled operators to convert versions with the webhook of the operator
+// and watches them to restore the configuration when it drifts. The CA bundle is taken from the TLS secret of the server.
+func configureConversionWebhook(kubeExtCli apiextensionsclient.Interface, secrets v1core.SecretInterface, namespace string, cfg operator.Config, stop <-chan struct{}) error {
+	if serverOptions.tlsSecretName == "" {
+		return maskAny(fmt.Errorf("--server.tls-secret-name is required by the conversion webhook"))
+	}
+
+	secret, err := secrets.Get(serverOptions.tlsSecretName, metav1.GetOptions{})
+	if err != nil {
+		return maskAny(err)
+	}
+
+	caBundle, ok := secret.Data[constants.SecretCACertificate]
+	if !ok {
+		return maskAny(fmt.Errorf("secret %s does not contain %s", serverOptions.tlsSecretName, constants.SecretCACertificate))
+	}
+
+	path := webhook.ConvertPath
+	port := int32(serverOptions.port)
+	service := apiextensionsv1.ServiceReference{
+		Namespace: namespace,
+		Name:      serverOptions.webhookService,
+		Path:      &path,
+		Port:      &port,
+	}
+
+	var crds []string
+	if cfg.EnableDeployment {
+		crds = append(crds, deployment.ArangoDeploymentCRDName)
+	}
+	if cfg.EnableDeploymentReplication {
+		crds = append(crds, replication.ArangoDeploymentReplicationCRDName)
+	}
+
+	for _, name := range crds {
+		if err := crd.EnsureConversionWebhook(kubeExtCli, name, service, caBundle); err != nil {
+			return maskAny(fmt.Errorf("unable to configure conversion webhook of %s: %s", name, err))
+		}
+	}
+
+	// CRDs can be updated independently of the Operator, e.g. by the CRD chart, which resets the conversion
+	log := logService.MustGetLogger("webhook")
+	for _, name := range crds {
+		go crd.WatchConversionWebhook(log, kubeExtCli, name, service, caBundle, stop)
+	}
+
+	return nil
+}
+
 // getMyPodInfo looks up the image & service account of the pod with given name in given namespace
 // Returns image, serviceAccount, error.
 func getMyPodInfo(kubecli kubernetes.Interface, namespace, name string) (string, string, error) {
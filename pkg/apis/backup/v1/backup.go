@@ -23,12 +23,28 @@
 package v1
 
 import (
+	"sort"
+
 	"github.com/arangodb/kube-arangodb/pkg/apis/backup"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
 
 const (
 	FinalizerArangoBackup = backup.ArangoBackupCRDName + "/cleanup"
+
+	// AnnotationRetryOf keeps name of the first backup in the retry chain
+	AnnotationRetryOf = backup.ArangoBackupGroupName + "/retry-of"
+	// AnnotationRetryAttempt keeps number of the retry attempt
+	AnnotationRetryAttempt = backup.ArangoBackupGroupName + "/retry-attempt"
+	// AnnotationRetryDeadline keeps time (RFC3339) after which no more retries are done
+	AnnotationRetryDeadline = backup.ArangoBackupGroupName + "/retry-deadline"
+	// AnnotationRetryStatus keeps the outcome of the retry processing of the potentially inconsistent backup
+	AnnotationRetryStatus = backup.ArangoBackupGroupName + "/retry-status"
+
+	// RetryStatusRetried marks backup which was retried by the policy
+	RetryStatusRetried = "Retried"
+	// RetryStatusDeadlineExceeded marks backup for which the retry deadline was reached
+	RetryStatusDeadlineExceeded = "DeadlineExceeded"
 )
 
 var (
@@ -58,3 +74,25 @@ type ArangoBackup struct {
 	Spec   ArangoBackupSpec   `json:"spec"`
 	Status ArangoBackupStatus `json:"status"`
 }
+
+// IsRestoreCandidate returns true if the backup is available and was created with a consistent snapshot
+func (a *ArangoBackup) IsRestoreCandidate() bool {
+	return a.Status.Available && !a.Status.Backup.IsPotentiallyInconsistent()
+}
+
+// RestoreCandidates returns backups of the deployment which can be used for a restore, newest first
+func (a *ArangoBackupList) RestoreCandidates(deployment string) []ArangoBackup {
+	var candidates []ArangoBackup
+
+	for _, b := range a.Items {
+		if b.Spec.Deployment.Name == deployment && b.IsRestoreCandidate() {
+			candidates = append(candidates, b)
+		}
+	}
+
+	sort.SliceStable(candidates, func(i, j int) bool {
+		return candidates[j].CreationTimestamp.Before(&candidates[i].CreationTimestamp)
+	})
+
+	return candidates
+}
@@ -0,0 +1,218 @@
+//
+// DISCLAIMER
+//
+// Copyright 2020 ArangoDB GmbH, Cologne, Germany
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// Copyright holder is ArangoDB GmbH, Cologne, Germany
+//
+
+package v1
+
+import (
+	"fmt"
+
+	"github.com/arangodb/go-driver"
+	deployment "github.com/arangodb/kube-arangodb/pkg/apis/deployment/v1"
+	"github.com/arangodb/kube-arangodb/pkg/backup/utils"
+	"github.com/arangodb/kube-arangodb/pkg/util"
+	"github.com/arangodb/kube-arangodb/pkg/util/constants"
+	core "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// NewDownload creates ArangoBackup which downloads this backup from the remote repository into given deployment
+func (a *ArangoBackup) NewDownload(deploymentName string) (*ArangoBackup, error) {
+	if a.Status.Backup == nil {
+		return nil, fmt.Errorf("backup %s/%s is not yet created", a.Namespace, a.Name)
+	}
+
+	if a.Spec.Upload == nil || !util.BoolOrDefault(a.Status.Backup.Uploaded) {
+		return nil, fmt.Errorf("backup %s/%s is not uploaded to the remote repository", a.Namespace, a.Name)
+	}
+
+	return &ArangoBackup{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      fmt.Sprintf("%s-%s", deploymentName, utils.RandomString(8)),
+			Namespace: a.Namespace,
+
+			Finalizers: []string{
+				FinalizerArangoBackup,
+			},
+		},
+		Spec: ArangoBackupSpec{
+			Deployment: ArangoBackupSpecDeployment{
+				Name: deploymentName,
+			},
+			Download: &ArangoBackupSpecDownload{
+				ArangoBackupSpecOperation: *a.Spec.Upload.DeepCopy(),
+				ID:                        a.Status.Backup.ID,
+			},
+		},
+	}, nil
+}
+
+// IsEncrypted returns true when the backup was created with an encryption key
+func (a *ArangoBackup) IsEncrypted() bool {
+	return a.Status.Backup != nil && len(a.Status.Backup.Keys) > 0
+}
+
+// NewEncryptionSecret creates the secret with the encryption key of this backup.
+// Key is selected from the given secrets, which keep keys under their checksums, by the checksums recorded in the backup.
+// Returns nil when the backup is not encrypted.
+func (a *ArangoBackup) NewEncryptionSecret(name string, sources ...*core.Secret) (*core.Secret, error) {
+	if !a.IsEncrypted() {
+		return nil, nil
+	}
+
+	for _, source := range sources {
+		if source == nil {
+			continue
+		}
+
+		for sha, key := range source.Data {
+			if !a.Status.Backup.Keys.ContainsSHA256(sha) {
+				continue
+			}
+
+			return &core.Secret{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      name,
+					Namespace: a.Namespace,
+				},
+				Data: map[string][]byte{
+					constants.SecretEncryptionKey: key,
+				},
+			}, nil
+		}
+	}
+
+	return nil, fmt.Errorf("encryption key of backup %s/%s is not found in deployment %s", a.Namespace, a.Name, a.Spec.Deployment.Name)
+}
+
+// NewDeployment creates ArangoDeployment into which the backup is restored.
+// Topology and ArangoDB version are taken from the backup details, all other settings are copied from the source deployment.
+// When image is empty, the image of the source deployment with the ArangoDB version of the backup is used.
+// Encryption key of an encrypted backup is taken from the given secret.
+// Only the given labels and annotations are copied from the source deployment.
+func (a *ArangoBackup) NewDeployment(name, image, encryptionSecret string, source *deployment.ArangoDeployment,
+	labels, annotations []string) (*deployment.ArangoDeployment, error) {
+	if a.Status.Backup == nil {
+		return nil, fmt.Errorf("backup %s/%s is not yet created", a.Namespace, a.Name)
+	}
+
+	image, err := a.deploymentImage(image, source)
+	if err != nil {
+		return nil, err
+	}
+
+	spec := source.Spec.DeepCopy()
+	spec.Image = util.NewString(image)
+
+	// Secrets generated for the source deployment should not be shared
+	resetDefaultName(&spec.Authentication.JWTSecretName, source.Name+"-jwt")
+	resetDefaultName(&spec.TLS.CASecretName, source.Name+"-ca")
+	resetDefaultName(&spec.Sync.Authentication.JWTSecretName, source.Name+"-sync-jwt")
+	resetDefaultName(&spec.Sync.Authentication.ClientCASecretName, source.Name+"-sync-client-auth-ca")
+	resetDefaultName(&spec.Sync.TLS.CASecretName, source.Name+"-sync-ca")
+	resetDefaultName(&spec.Sync.Monitoring.TokenSecretName, source.Name+"-sync-mt")
+	resetDefaultName(&spec.Metrics.Authentication.JWTTokenSecretName, source.Name+"-exporter-jwt-token")
+
+	// Addresses can not be shared with the source deployment
+	spec.ExternalAccess.LoadBalancerIP = nil
+	spec.Sync.ExternalAccess.LoadBalancerIP = nil
+
+	if spec.GetMode() == deployment.DeploymentModeCluster && a.Status.Backup.NumberOfDBServers > 0 {
+		count := int(a.Status.Backup.NumberOfDBServers)
+		spec.DBServers.Count = util.NewInt(count)
+		if min := spec.DBServers.MinCount; min != nil && *min > count {
+			spec.DBServers.MinCount = util.NewInt(count)
+		}
+		if max := spec.DBServers.MaxCount; max != nil && *max < count {
+			spec.DBServers.MaxCount = util.NewInt(count)
+		}
+	}
+
+	if a.IsEncrypted() {
+		if encryptionSecret == "" {
+			return nil, fmt.Errorf("backup %s/%s is encrypted but no encryption key is provided", a.Namespace, a.Name)
+		}
+
+		spec.RocksDB.Encryption.KeySecretName = util.NewString(encryptionSecret)
+	}
+
+	// Backup is restored after the download
+	spec.RestoreFrom = nil
+	spec.RestoreEncryptionSecret = nil
+
+	return &deployment.ArangoDeployment{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        name,
+			Namespace:   a.Namespace,
+			Labels:      copyKeys(source.Labels, labels),
+			Annotations: copyKeys(source.Annotations, annotations),
+		},
+		Spec: *spec,
+	}, nil
+}
+
+// deploymentImage returns the image of the deployment into which the backup is restored.
+// Image needs to run the ArangoDB version of the backup, image versions are known from the source deployment.
+func (a *ArangoBackup) deploymentImage(image string, source *deployment.ArangoDeployment) (string, error) {
+	version := driver.Version(a.Status.Backup.Version)
+	if version == "" {
+		return "", fmt.Errorf("ArangoDB version of backup %s/%s is not known", a.Namespace, a.Name)
+	}
+
+	if image != "" {
+		if info, ok := source.Status.Images.GetByImage(image); ok && info.ArangoDBVersion.CompareTo(version) != 0 {
+			return "", fmt.Errorf("image %s runs ArangoDB %s, backup %s/%s requires %s", image, info.ArangoDBVersion, a.Namespace, a.Name, version)
+		}
+
+		return image, nil
+	}
+
+	for _, info := range source.Status.Images {
+		// Hot backups are available only in the enterprise edition
+		if info.Enterprise && info.ArangoDBVersion.CompareTo(version) == 0 {
+			return info.Image, nil
+		}
+	}
+
+	return "", fmt.Errorf("no image with ArangoDB %s of backup %s/%s is known in deployment %s, image needs to be provided",
+		version, a.Namespace, a.Name, source.Name)
+}
+
+// copyKeys returns the entries of m with the given keys
+func copyKeys(m map[string]string, keys []string) map[string]string {
+	var r map[string]string
+
+	for _, key := range keys {
+		if v, ok := m[key]; ok {
+			if r == nil {
+				r = map[string]string{}
+			}
+
+			r[key] = v
+		}
+	}
+
+	return r
+}
+
+func resetDefaultName(name **string, sourceDefault string) {
+	if util.StringOrDefault(*name) == sourceDefault {
+		*name = nil
+	}
+}
@@ -0,0 +1,200 @@
+//
+// DISCLAIMER
+//
+// Copyright 2020 ArangoDB GmbH, Cologne, Germany
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// Copyright holder is ArangoDB GmbH, Cologne, Germany
+//
+
+package v1
+
+import (
+	"testing"
+
+	deployment "github.com/arangodb/kube-arangodb/pkg/apis/deployment/v1"
+	"github.com/arangodb/kube-arangodb/pkg/util"
+	"github.com/arangodb/kube-arangodb/pkg/util/constants"
+	"github.com/stretchr/testify/require"
+	core "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func newCloneBackup() *ArangoBackup {
+	return &ArangoBackup{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "backup",
+			Namespace: "ns",
+		},
+		Spec: ArangoBackupSpec{
+			Deployment: ArangoBackupSpecDeployment{
+				Name: "source",
+			},
+			Upload: &ArangoBackupSpecOperation{
+				RepositoryURL: "s3://bucket",
+			},
+		},
+		Status: ArangoBackupStatus{
+			Backup: &ArangoBackupDetails{
+				ID:                "id",
+				Version:           "3.7.3",
+				NumberOfDBServers: 5,
+				Uploaded:          util.NewBool(true),
+			},
+		},
+	}
+}
+
+func Test_ArangoBackup_NewDownload(t *testing.T) {
+	backup := newCloneBackup()
+
+	download, err := backup.NewDownload("target")
+	require.NoError(t, err)
+	require.Equal(t, "target", download.Spec.Deployment.Name)
+	require.NotNil(t, download.Spec.Download)
+	require.Equal(t, "id", download.Spec.Download.ID)
+	require.Equal(t, "s3://bucket", download.Spec.Download.RepositoryURL)
+	require.NoError(t, download.Validate())
+
+	backup.Status.Backup.Uploaded = nil
+	_, err = backup.NewDownload("target")
+	require.Error(t, err)
+}
+
+func Test_ArangoBackup_NewDeployment(t *testing.T) {
+	backup := newCloneBackup()
+
+	source := &deployment.ArangoDeployment{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "source",
+			Namespace: "ns",
+			Labels: map[string]string{
+				"team":   "db",
+				"backup": "daily",
+			},
+			Annotations: map[string]string{
+				"kubectl.kubernetes.io/last-applied-configuration": "{}",
+			},
+		},
+		Spec: deployment.DeploymentSpec{
+			Image: util.NewString("arangodb/enterprise:3.7.4"),
+		},
+		Status: deployment.DeploymentStatus{
+			Images: deployment.ImageInfoList{
+				{Image: "arangodb/enterprise:3.7.3", ArangoDBVersion: "3.7.3", Enterprise: true},
+				{Image: "arangodb/enterprise:3.7.4", ArangoDBVersion: "3.7.4", Enterprise: true},
+			},
+		},
+	}
+	source.Spec.SetDefaults(source.Name)
+	source.Spec.ExternalAccess.LoadBalancerIP = util.NewString("10.0.0.1")
+
+	depl, err := backup.NewDeployment("target", "", "", source, []string{"team"}, nil)
+	require.NoError(t, err)
+
+	require.Equal(t, "target", depl.Name)
+	require.Equal(t, map[string]string{"team": "db"}, depl.Labels)
+	require.Nil(t, depl.Annotations)
+	require.Nil(t, depl.Spec.RestoreFrom)
+	require.Equal(t, 5, depl.Spec.DBServers.GetCount())
+	require.Equal(t, "arangodb/enterprise:3.7.3", depl.Spec.GetImage())
+	require.Nil(t, depl.Spec.ExternalAccess.LoadBalancerIP)
+	require.Nil(t, depl.Spec.Authentication.JWTSecretName)
+	require.Nil(t, depl.Spec.TLS.CASecretName)
+
+	depl.Spec.SetDefaults(depl.Name)
+	require.Equal(t, "target-jwt", depl.Spec.Authentication.GetJWTSecretName())
+	require.NoError(t, depl.Spec.Validate())
+}
+
+func Test_ArangoBackup_NewDeployment_Image(t *testing.T) {
+	backup := newCloneBackup()
+
+	source := &deployment.ArangoDeployment{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "source",
+			Namespace: "ns",
+		},
+		Status: deployment.DeploymentStatus{
+			Images: deployment.ImageInfoList{
+				{Image: "arangodb/arangodb:3.7.3", ArangoDBVersion: "3.7.3"},
+				{Image: "arangodb/enterprise:3.7.4", ArangoDBVersion: "3.7.4", Enterprise: true},
+			},
+		},
+	}
+
+	_, err := backup.NewDeployment("target", "", "", source, nil, nil)
+	require.Error(t, err)
+
+	_, err = backup.NewDeployment("target", "arangodb/enterprise:3.7.4", "", source, nil, nil)
+	require.Error(t, err)
+
+	depl, err := backup.NewDeployment("target", "registry/enterprise:3.7.3", "", source, nil, nil)
+	require.NoError(t, err)
+	require.Equal(t, "registry/enterprise:3.7.3", depl.Spec.GetImage())
+
+	backup.Status.Backup.Version = ""
+	_, err = backup.NewDeployment("target", "registry/enterprise:3.7.3", "", source, nil, nil)
+	require.Error(t, err)
+}
+
+func Test_ArangoBackup_NewEncryptionSecret(t *testing.T) {
+	backup := newCloneBackup()
+
+	secret, err := backup.NewEncryptionSecret("target-restore-encryption")
+	require.NoError(t, err)
+	require.Nil(t, secret)
+
+	backup.Status.Backup.Keys = []string{"sha256:old"}
+
+	folder := &core.Secret{
+		Data: map[string][]byte{
+			"current": []byte("current-key"),
+		},
+	}
+	rotation := &core.Secret{
+		Data: map[string][]byte{
+			"old": []byte("old-key"),
+		},
+	}
+
+	_, err = backup.NewEncryptionSecret("target-restore-encryption", folder)
+	require.Error(t, err)
+
+	secret, err = backup.NewEncryptionSecret("target-restore-encryption", folder, nil, rotation)
+	require.NoError(t, err)
+	require.Equal(t, "target-restore-encryption", secret.Name)
+	require.Equal(t, "ns", secret.Namespace)
+	require.Equal(t, []byte("old-key"), secret.Data[constants.SecretEncryptionKey])
+}
+
+func Test_ArangoBackup_NewDeployment_Encrypted(t *testing.T) {
+	backup := newCloneBackup()
+	backup.Status.Backup.Keys = []string{"sha256:old"}
+
+	source := &deployment.ArangoDeployment{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "source",
+			Namespace: "ns",
+		},
+	}
+	source.Spec.RocksDB.Encryption.KeySecretName = util.NewString("source-encryption")
+
+	_, err := backup.NewDeployment("target", "arangodb/enterprise:3.7.3", "", source, nil, nil)
+	require.Error(t, err)
+
+	depl, err := backup.NewDeployment("target", "arangodb/enterprise:3.7.3", "target-restore-encryption", source, nil, nil)
+	require.NoError(t, err)
+	require.Equal(t, "target-restore-encryption", depl.Spec.RocksDB.Encryption.GetKeySecretName())
+}
@@ -23,15 +23,52 @@
 package v1
 
 import (
+	"time"
+
 	meta "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
 
+const (
+	defaultArangoBackupPolicyRetryDeadline = time.Hour
+	defaultArangoBackupPolicyRetryBackoff  = time.Minute
+)
+
 type ArangoBackupPolicySpec struct {
 	Schedule string `json:"schedule"`
 
 	DeploymentSelector *meta.LabelSelector `json:"selector,omitempty"`
 
 	BackupTemplate ArangoBackupTemplate `json:"template"`
+
+	// Retry enables recreation of potentially inconsistent backups
+	Retry *ArangoBackupPolicyRetrySpec `json:"retry,omitempty"`
+}
+
+// ArangoBackupPolicyRetrySpec defines how potentially inconsistent backups are retried
+type ArangoBackupPolicyRetrySpec struct {
+	// Deadline defines how long after the first backup the operator keeps trying to create a consistent one
+	Deadline *meta.Duration `json:"deadline,omitempty"`
+
+	// Backoff defines the delay before the first retry, doubled with every next attempt
+	Backoff *meta.Duration `json:"backoff,omitempty"`
+}
+
+// GetDeadline returns the retry deadline or default value if not set
+func (a *ArangoBackupPolicyRetrySpec) GetDeadline() time.Duration {
+	if a == nil || a.Deadline == nil {
+		return defaultArangoBackupPolicyRetryDeadline
+	}
+
+	return a.Deadline.Duration
+}
+
+// GetBackoff returns the retry backoff or default value if not set
+func (a *ArangoBackupPolicyRetrySpec) GetBackoff() time.Duration {
+	if a == nil || a.Backoff == nil {
+		return defaultArangoBackupPolicyRetryBackoff
+	}
+
+	return a.Backoff.Duration
 }
 
 type ArangoBackupTemplate struct {
@@ -44,5 +44,25 @@ func (a *ArangoBackupPolicySpec) Validate() error {
 		return fmt.Errorf("invalid schedule format")
 	}
 
+	if err := a.Retry.Validate(); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (a *ArangoBackupPolicyRetrySpec) Validate() error {
+	if a == nil {
+		return nil
+	}
+
+	if a.GetDeadline() <= 0 {
+		return fmt.Errorf("retry deadline needs to be greater than 0")
+	}
+
+	if a.GetBackoff() <= 0 {
+		return fmt.Errorf("retry backoff needs to be greater than 0")
+	}
+
 	return nil
 }
@@ -33,6 +33,7 @@ type ArangoBackupStatus struct {
 	ArangoBackupState `json:",inline"`
 	Backup            *ArangoBackupDetails `json:"backup,omitempty"`
 	Available         bool                 `json:"available"`
+	Conditions        ConditionList        `json:"conditions,omitempty"`
 }
 
 func (a *ArangoBackupStatus) Equal(b *ArangoBackupStatus) bool {
@@ -46,7 +47,8 @@ func (a *ArangoBackupStatus) Equal(b *ArangoBackupStatus) bool {
 
 	return a.ArangoBackupState.Equal(&b.ArangoBackupState) &&
 		a.Backup.Equal(b.Backup) &&
-		a.Available == b.Available
+		a.Available == b.Available &&
+		a.Conditions.Equal(b.Conditions)
 }
 
 type ArangoBackupDetails struct {
@@ -83,6 +85,15 @@ func (a *ArangoBackupDetails) Equal(b *ArangoBackupDetails) bool {
 		a.Keys.Equal(b.Keys)
 }
 
+// IsPotentiallyInconsistent returns true if backup was created without a consistent snapshot
+func (a *ArangoBackupDetails) IsPotentiallyInconsistent() bool {
+	if a == nil || a.PotentiallyInconsistent == nil {
+		return false
+	}
+
+	return *a.PotentiallyInconsistent
+}
+
 func compareBoolPointer(a, b *bool) bool {
 	if a == nil && b != nil || a != nil && b == nil {
 		return false
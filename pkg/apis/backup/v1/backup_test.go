@@ -0,0 +1,69 @@
+//
+// DISCLAIMER
+//
+// Copyright 2020 ArangoDB GmbH, Cologne, Germany
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// Copyright holder is ArangoDB GmbH, Cologne, Germany
+//
+
+package v1
+
+import (
+	"testing"
+	"time"
+
+	"github.com/arangodb/kube-arangodb/pkg/util"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func newRestoreBackup(name, deployment string, age time.Duration, available, inconsistent bool) ArangoBackup {
+	return ArangoBackup{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:              name,
+			CreationTimestamp: metav1.NewTime(time.Now().Add(-age)),
+		},
+		Spec: ArangoBackupSpec{
+			Deployment: ArangoBackupSpecDeployment{
+				Name: deployment,
+			},
+		},
+		Status: ArangoBackupStatus{
+			Available: available,
+			Backup: &ArangoBackupDetails{
+				PotentiallyInconsistent: util.NewBool(inconsistent),
+			},
+		},
+	}
+}
+
+func TestArangoBackupList_RestoreCandidates(t *testing.T) {
+	list := ArangoBackupList{
+		Items: []ArangoBackup{
+			newRestoreBackup("old", "example", 3*time.Hour, true, false),
+			newRestoreBackup("inconsistent", "example", 2*time.Hour, true, true),
+			newRestoreBackup("unavailable", "example", 2*time.Hour, false, false),
+			newRestoreBackup("other", "other", 2*time.Hour, true, false),
+			newRestoreBackup("new", "example", time.Hour, true, false),
+		},
+	}
+
+	candidates := list.RestoreCandidates("example")
+	require.Len(t, candidates, 2)
+	require.Equal(t, "new", candidates[0].Name)
+	require.Equal(t, "old", candidates[1].Name)
+
+	require.Empty(t, list.RestoreCandidates("missing"))
+}
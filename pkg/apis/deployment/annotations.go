@@ -23,8 +23,11 @@
 package deployment
 
 const (
-	ArangoDeploymentAnnotationPrefix         = "deployment.arangodb.com"
-	ArangoDeploymentPodMaintenanceAnnotation = ArangoDeploymentAnnotationPrefix + "/maintenance"
-	ArangoDeploymentPodRotateAnnotation      = ArangoDeploymentAnnotationPrefix + "/rotate"
-	ArangoDeploymentPodReplaceAnnotation     = ArangoDeploymentAnnotationPrefix + "/replace"
+	ArangoDeploymentAnnotationPrefix          = "deployment.arangodb.com"
+	ArangoDeploymentPodMaintenanceAnnotation  = ArangoDeploymentAnnotationPrefix + "/maintenance"
+	ArangoDeploymentPodRotateAnnotation       = ArangoDeploymentAnnotationPrefix + "/rotate"
+	ArangoDeploymentPodReplaceAnnotation      = ArangoDeploymentAnnotationPrefix + "/replace"
+	ArangoDeploymentUpgradeContinueAnnotation = ArangoDeploymentAnnotationPrefix + "/upgrade-continue"
+	// ArangoDeploymentLegacyProbesAnnotation keeps the v1 readiness probe flags while an ArangoDeployment is served as v2alpha1
+	ArangoDeploymentLegacyProbesAnnotation = ArangoDeploymentAnnotationPrefix + "/v1-readiness-probe-disabled"
 )
@@ -27,9 +27,27 @@ const (
 	ArangoDeploymentResourceKind   = "ArangoDeployment"
 	ArangoDeploymentResourcePlural = "arangodeployments"
 
+	ArangoDatabaseCRDName        = ArangoDatabaseResourcePlural + "." + ArangoDeploymentGroupName
+	ArangoDatabaseResourceKind   = "ArangoDatabase"
+	ArangoDatabaseResourcePlural = "arangodatabases"
+
+	ArangoUserCRDName        = ArangoUserResourcePlural + "." + ArangoDeploymentGroupName
+	ArangoUserResourceKind   = "ArangoUser"
+	ArangoUserResourcePlural = "arangousers"
+
+	ArangoCollectionCRDName        = ArangoCollectionResourcePlural + "." + ArangoDeploymentGroupName
+	ArangoCollectionResourceKind   = "ArangoCollection"
+	ArangoCollectionResourcePlural = "arangocollections"
+
 	ArangoDeploymentGroupName = "database.arangodb.com"
 )
 
 var (
 	ArangoDeploymentShortNames = []string{"arangodb", "arango"}
+
+	ArangoDatabaseShortNames = []string{"arangodatabase"}
+
+	ArangoUserShortNames = []string{"arangouser"}
+
+	ArangoCollectionShortNames = []string{"arangocollection"}
 )
@@ -0,0 +1,398 @@
+//
+// DISCLAIMER
+//
+// Copyright 2020 ArangoDB GmbH, Cologne, Germany
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// Copyright holder is ArangoDB GmbH, Cologne, Germany
+//
+
+package v1
+
+import (
+	"github.com/arangodb/kube-arangodb/pkg/apis/deployment"
+	"github.com/arangodb/kube-arangodb/pkg/util"
+	"github.com/pkg/errors"
+	meta "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// ArangoCollectionType defines the type of a collection
+// +kubebuilder:validation:Enum=document;edge
+type ArangoCollectionType string
+
+const (
+	// ArangoCollectionTypeDocument defines a document collection
+	ArangoCollectionTypeDocument ArangoCollectionType = "document"
+	// ArangoCollectionTypeEdge defines an edge collection
+	ArangoCollectionTypeEdge ArangoCollectionType = "edge"
+)
+
+// Validate the collection type
+func (t ArangoCollectionType) Validate() error {
+	switch t {
+	case ArangoCollectionTypeDocument, ArangoCollectionTypeEdge:
+		return nil
+	default:
+		return errors.Wrapf(ValidationError, "unknown collection type %s", t)
+	}
+}
+
+// ArangoCollectionIndexType defines the type of an index
+// +kubebuilder:validation:Enum=persistent;hash;skiplist;geo;fulltext;ttl
+type ArangoCollectionIndexType string
+
+const (
+	// ArangoCollectionIndexTypePersistent defines a persistent index
+	ArangoCollectionIndexTypePersistent ArangoCollectionIndexType = "persistent"
+	// ArangoCollectionIndexTypeHash defines a hash index
+	ArangoCollectionIndexTypeHash ArangoCollectionIndexType = "hash"
+	// ArangoCollectionIndexTypeSkiplist defines a skiplist index
+	ArangoCollectionIndexTypeSkiplist ArangoCollectionIndexType = "skiplist"
+	// ArangoCollectionIndexTypeGeo defines a geo index
+	ArangoCollectionIndexTypeGeo ArangoCollectionIndexType = "geo"
+	// ArangoCollectionIndexTypeFulltext defines a fulltext index
+	ArangoCollectionIndexTypeFulltext ArangoCollectionIndexType = "fulltext"
+	// ArangoCollectionIndexTypeTTL defines a ttl index
+	ArangoCollectionIndexTypeTTL ArangoCollectionIndexType = "ttl"
+)
+
+// Validate the index type
+func (t ArangoCollectionIndexType) Validate() error {
+	switch t {
+	case ArangoCollectionIndexTypePersistent, ArangoCollectionIndexTypeHash, ArangoCollectionIndexTypeSkiplist,
+		ArangoCollectionIndexTypeGeo, ArangoCollectionIndexTypeFulltext, ArangoCollectionIndexTypeTTL:
+		return nil
+	default:
+		return errors.Wrapf(ValidationError, "unknown index type %s", t)
+	}
+}
+
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// ArangoCollectionList is a list of ArangoDB collections.
+type ArangoCollectionList struct {
+	meta.TypeMeta `json:",inline"`
+	// Standard list metadata
+	// More info: http://releases.k8s.io/HEAD/docs/devel/api-conventions.md#metadata
+	meta.ListMeta `json:"metadata,omitempty"`
+	Items         []ArangoCollection `json:"items"`
+}
+
+// +genclient
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// ArangoCollection contains the definition of a collection, its indexes, analyzers and views in an ArangoDeployment.
+type ArangoCollection struct {
+	meta.TypeMeta   `json:",inline"`
+	meta.ObjectMeta `json:"metadata,omitempty"`
+	Spec            ArangoCollectionSpec   `json:"spec,omitempty"`
+	Status          ArangoCollectionStatus `json:"status,omitempty"`
+}
+
+// AsOwner creates an OwnerReference for the given collection
+func (c *ArangoCollection) AsOwner() meta.OwnerReference {
+	trueVar := true
+	return meta.OwnerReference{
+		APIVersion: SchemeGroupVersion.String(),
+		Kind:       deployment.ArangoCollectionResourceKind,
+		Name:       c.Name,
+		UID:        c.UID,
+		Controller: &trueVar,
+	}
+}
+
+// GetCollectionName returns the name of the collection in the database.
+// Name from the status is used once the collection is created.
+func (c *ArangoCollection) GetCollectionName() string {
+	if c.Status.Name != "" {
+		return c.Status.Name
+	}
+
+	return c.Spec.GetName(c.GetName())
+}
+
+// GetDatabaseName returns the name of the database which hosts the collection.
+// Database from the status is used once the collection is created.
+func (c *ArangoCollection) GetDatabaseName() string {
+	if c.Status.Database != "" {
+		return c.Status.Database
+	}
+
+	return c.Spec.Database
+}
+
+// ArangoCollectionSpec contains the specification of a collection
+type ArangoCollectionSpec struct {
+	// DeploymentName is the name of the ArangoDeployment (in the same namespace) which hosts the collection
+	DeploymentName string `json:"deploymentName"`
+	// Database is the name of the database in the deployment which hosts the collection
+	Database string `json:"database"`
+	// Name of the collection, defaults to the name of the resource
+	Name *string `json:"name,omitempty"`
+	// Type of the collection: document or edge. Cannot be changed.
+	Type *ArangoCollectionType `json:"type,omitempty"`
+	// NumberOfShards of the collection. Cannot be changed.
+	NumberOfShards *int `json:"numberOfShards,omitempty"`
+	// ShardKeys of the collection. Cannot be changed.
+	ShardKeys []string `json:"shardKeys,omitempty"`
+	// ReplicationFactor of the collection
+	ReplicationFactor *int `json:"replicationFactor,omitempty"`
+	// WriteConcern of the collection
+	WriteConcern *int `json:"writeConcern,omitempty"`
+	// WaitForSync defines if writes wait until data is synchronized to disk
+	WaitForSync *bool `json:"waitForSync,omitempty"`
+	// Indexes of the collection, identified by name
+	Indexes []ArangoCollectionIndex `json:"indexes,omitempty"`
+	// Analyzers which are created in the database if they do not exist. Analyzers are never removed.
+	Analyzers []ArangoCollectionAnalyzer `json:"analyzers,omitempty"`
+	// Views defines ArangoSearch views the collection is linked to. Views are created when they do not exist.
+	Views []ArangoCollectionView `json:"views,omitempty"`
+	// DropOnDelete drops the collection when the resource is deleted
+	DropOnDelete *bool `json:"dropOnDelete,omitempty"`
+}
+
+// GetName returns the name of the collection
+func (s ArangoCollectionSpec) GetName(def string) string {
+	if s.Name == nil || *s.Name == "" {
+		return def
+	}
+
+	return *s.Name
+}
+
+// GetType returns the type of the collection
+func (s ArangoCollectionSpec) GetType() ArangoCollectionType {
+	if s.Type == nil {
+		return ArangoCollectionTypeDocument
+	}
+
+	return *s.Type
+}
+
+// GetDropOnDelete returns true when the collection should be dropped with the resource
+func (s ArangoCollectionSpec) GetDropOnDelete() bool {
+	return util.BoolOrDefault(s.DropOnDelete, false)
+}
+
+// Validate the collection specification
+func (s ArangoCollectionSpec) Validate(def string) error {
+	if s.DeploymentName == "" {
+		return errors.Wrapf(ValidationError, "deploymentName must be set")
+	}
+
+	if s.Database == "" {
+		return errors.Wrapf(ValidationError, "database must be set")
+	}
+
+	if name := s.GetName(def); name == "" || name[0] == '_' {
+		return errors.Wrapf(ValidationError, "invalid collection name %s", name)
+	}
+
+	if err := s.GetType().Validate(); err != nil {
+		return errors.Wrapf(err, "type")
+	}
+
+	if s.NumberOfShards != nil && *s.NumberOfShards < 1 {
+		return errors.Wrapf(ValidationError, "numberOfShards must be greater than 0")
+	}
+
+	if s.ReplicationFactor != nil && *s.ReplicationFactor < 1 {
+		return errors.Wrapf(ValidationError, "replicationFactor must be greater than 0")
+	}
+
+	if s.WriteConcern != nil {
+		if *s.WriteConcern < 1 {
+			return errors.Wrapf(ValidationError, "writeConcern must be greater than 0")
+		}
+		if s.ReplicationFactor != nil && *s.WriteConcern > *s.ReplicationFactor {
+			return errors.Wrapf(ValidationError, "writeConcern cannot be greater than replicationFactor")
+		}
+	}
+
+	for id, index := range s.Indexes {
+		if err := index.Validate(); err != nil {
+			return errors.Wrapf(err, "indexes[%d]", id)
+		}
+
+		for _, o := range s.Indexes[:id] {
+			if o.Name == index.Name {
+				return errors.Wrapf(ValidationError, "indexes[%d]: duplicated index name %s", id, index.Name)
+			}
+		}
+	}
+
+	for id, analyzer := range s.Analyzers {
+		if err := analyzer.Validate(); err != nil {
+			return errors.Wrapf(err, "analyzers[%d]", id)
+		}
+	}
+
+	for id, view := range s.Views {
+		if err := view.Validate(); err != nil {
+			return errors.Wrapf(err, "views[%d]", id)
+		}
+
+		for _, o := range s.Views[:id] {
+			if o.Name == view.Name {
+				return errors.Wrapf(ValidationError, "views[%d]: duplicated view %s", id, view.Name)
+			}
+		}
+	}
+
+	return nil
+}
+
+// ArangoCollectionIndex defines an index of a collection
+type ArangoCollectionIndex struct {
+	// Name of the index, used to identify the index in the collection
+	Name string `json:"name"`
+	// Type of the index: persistent, hash, skiplist, geo, fulltext or ttl
+	Type ArangoCollectionIndexType `json:"type"`
+	// Fields covered by the index
+	Fields []string `json:"fields"`
+	// Unique creates an unique index (persistent, hash and skiplist)
+	Unique *bool `json:"unique,omitempty"`
+	// Sparse creates a sparse index (persistent, hash and skiplist)
+	Sparse *bool `json:"sparse,omitempty"`
+	// GeoJSON defines the order of coordinates in the geo index
+	GeoJSON *bool `json:"geoJson,omitempty"`
+	// MinLength is the minimum length of indexed words in the fulltext index
+	MinLength *int `json:"minLength,omitempty"`
+	// ExpireAfter is the number of seconds after which documents expire in the ttl index
+	ExpireAfter *int `json:"expireAfter,omitempty"`
+	// InBackground creates the index without holding an exclusive collection lock
+	InBackground *bool `json:"inBackground,omitempty"`
+}
+
+// Validate the index
+func (i ArangoCollectionIndex) Validate() error {
+	if i.Name == "" {
+		return errors.Wrapf(ValidationError, "name must be set")
+	}
+
+	if err := i.Type.Validate(); err != nil {
+		return err
+	}
+
+	if len(i.Fields) == 0 {
+		return errors.Wrapf(ValidationError, "fields must be set")
+	}
+
+	if i.Type == ArangoCollectionIndexTypeTTL {
+		if len(i.Fields) != 1 {
+			return errors.Wrapf(ValidationError, "ttl index requires exactly one field")
+		}
+		if i.ExpireAfter == nil || *i.ExpireAfter < 0 {
+			return errors.Wrapf(ValidationError, "ttl index requires expireAfter")
+		}
+	}
+
+	return nil
+}
+
+// ArangoCollectionAnalyzer defines an ArangoSearch analyzer
+type ArangoCollectionAnalyzer struct {
+	// Name of the analyzer
+	Name string `json:"name"`
+	// Type of the analyzer: identity, delimiter, stem, norm, ngram or text
+	Type string `json:"type"`
+	// Features of the analyzer: frequency, norm and position
+	Features []string `json:"features,omitempty"`
+	// Locale used by stem, norm and text analyzers
+	Locale *string `json:"locale,omitempty"`
+	// Delimiter used by delimiter analyzer
+	Delimiter *string `json:"delimiter,omitempty"`
+	// Accent used by norm and text analyzers
+	Accent *bool `json:"accent,omitempty"`
+	// Case used by norm and text analyzers: lower, upper or none
+	Case *string `json:"case,omitempty"`
+	// Min used by ngram analyzer
+	Min *int64 `json:"min,omitempty"`
+	// Max used by ngram analyzer
+	Max *int64 `json:"max,omitempty"`
+	// PreserveOriginal used by ngram analyzer
+	PreserveOriginal *bool `json:"preserveOriginal,omitempty"`
+	// Stemming used by text analyzer
+	Stemming *bool `json:"stemming,omitempty"`
+	// Stopwords used by text analyzer
+	Stopwords []string `json:"stopwords,omitempty"`
+}
+
+// Validate the analyzer
+func (a ArangoCollectionAnalyzer) Validate() error {
+	if a.Name == "" {
+		return errors.Wrapf(ValidationError, "name must be set")
+	}
+
+	switch a.Type {
+	case "identity", "delimiter", "stem", "norm", "ngram", "text":
+	default:
+		return errors.Wrapf(ValidationError, "unknown analyzer type %s", a.Type)
+	}
+
+	for _, f := range a.Features {
+		switch f {
+		case "frequency", "norm", "position":
+		default:
+			return errors.Wrapf(ValidationError, "unknown analyzer feature %s", f)
+		}
+	}
+
+	return nil
+}
+
+// ArangoCollectionView defines the link of the collection to an ArangoSearch view
+type ArangoCollectionView struct {
+	// Name of the view
+	Name string `json:"name"`
+	// Analyzers used to index string values, defaults to identity
+	Analyzers []string `json:"analyzers,omitempty"`
+	// IncludeAllFields indexes all fields of documents
+	IncludeAllFields *bool `json:"includeAllFields,omitempty"`
+	// TrackListPositions indexes values in lists with their position
+	TrackListPositions *bool `json:"trackListPositions,omitempty"`
+	// StoreValues defines how the view tracks values: none or id
+	StoreValues *string `json:"storeValues,omitempty"`
+	// Fields which are indexed
+	Fields []string `json:"fields,omitempty"`
+}
+
+// Validate the view link
+func (v ArangoCollectionView) Validate() error {
+	if v.Name == "" {
+		return errors.Wrapf(ValidationError, "name must be set")
+	}
+
+	if s := util.StringOrDefault(v.StoreValues); s != "" && s != "none" && s != "id" {
+		return errors.Wrapf(ValidationError, "unknown storeValues %s", s)
+	}
+
+	return nil
+}
+
+// ArangoCollectionStatus contains the status of a collection
+type ArangoCollectionStatus struct {
+	// Name of the collection created in the database
+	Name string `json:"name,omitempty"`
+	// Database which hosts the collection
+	Database string `json:"database,omitempty"`
+	// Indexes applied to the collection
+	Indexes []ArangoCollectionIndex `json:"indexes,omitempty"`
+	// Views applied to the collection
+	Views []ArangoCollectionView `json:"views,omitempty"`
+	// Drift lists differences between the specification and the collection which are not reconciled
+	Drift []string `json:"drift,omitempty"`
+	// Conditions specific to the collection
+	Conditions ConditionList `json:"conditions,omitempty"`
+}
@@ -0,0 +1,95 @@
+//
+// DISCLAIMER
+//
+// Copyright 2020 ArangoDB GmbH, Cologne, Germany
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// Copyright holder is ArangoDB GmbH, Cologne, Germany
+//
+
+package v1
+
+import (
+	"testing"
+
+	"github.com/arangodb/kube-arangodb/pkg/util"
+	"github.com/stretchr/testify/require"
+)
+
+func TestArangoCollectionSpec_Validate(t *testing.T) {
+	edge := ArangoCollectionTypeEdge
+	unknown := ArangoCollectionType("unknown")
+
+	valid := ArangoCollectionSpec{
+		DeploymentName:    "example",
+		Database:          "db",
+		Type:              &edge,
+		NumberOfShards:    util.NewInt(3),
+		ReplicationFactor: util.NewInt(2),
+		WriteConcern:      util.NewInt(2),
+		Indexes: []ArangoCollectionIndex{
+			{Name: "by_name", Type: ArangoCollectionIndexTypePersistent, Fields: []string{"name"}},
+			{Name: "expire", Type: ArangoCollectionIndexTypeTTL, Fields: []string{"createdAt"}, ExpireAfter: util.NewInt(3600)},
+		},
+		Analyzers: []ArangoCollectionAnalyzer{{Name: "text_en", Type: "text", Locale: util.NewString("en"), Features: []string{"frequency"}}},
+		Views:     []ArangoCollectionView{{Name: "search", Analyzers: []string{"text_en"}, StoreValues: util.NewString("id")}},
+	}
+	require.NoError(t, valid.Validate("col"))
+	require.Equal(t, ArangoCollectionTypeDocument, ArangoCollectionSpec{}.GetType())
+
+	require.Error(t, ArangoCollectionSpec{Database: "db"}.Validate("col"))
+	require.Error(t, ArangoCollectionSpec{DeploymentName: "example"}.Validate("col"))
+	require.Error(t, ArangoCollectionSpec{DeploymentName: "example", Database: "db"}.Validate("_system"))
+	require.Error(t, ArangoCollectionSpec{DeploymentName: "example", Database: "db", Type: &unknown}.Validate("col"))
+	require.Error(t, ArangoCollectionSpec{DeploymentName: "example", Database: "db", NumberOfShards: util.NewInt(0)}.Validate("col"))
+	require.Error(t, ArangoCollectionSpec{
+		DeploymentName:    "example",
+		Database:          "db",
+		ReplicationFactor: util.NewInt(1),
+		WriteConcern:      util.NewInt(2),
+	}.Validate("col"))
+}
+
+func TestArangoCollectionIndex_Validate(t *testing.T) {
+	require.NoError(t, ArangoCollectionIndex{Name: "idx", Type: ArangoCollectionIndexTypeHash, Fields: []string{"a", "b"}}.Validate())
+
+	require.Error(t, ArangoCollectionIndex{Type: ArangoCollectionIndexTypeHash, Fields: []string{"a"}}.Validate())
+	require.Error(t, ArangoCollectionIndex{Name: "idx", Type: "unknown", Fields: []string{"a"}}.Validate())
+	require.Error(t, ArangoCollectionIndex{Name: "idx", Type: ArangoCollectionIndexTypeHash}.Validate())
+	require.Error(t, ArangoCollectionIndex{Name: "idx", Type: ArangoCollectionIndexTypeTTL, Fields: []string{"a"}}.Validate())
+	require.Error(t, ArangoCollectionIndex{Name: "idx", Type: ArangoCollectionIndexTypeTTL, Fields: []string{"a", "b"}, ExpireAfter: util.NewInt(1)}.Validate())
+
+	require.Error(t, ArangoCollectionSpec{
+		DeploymentName: "example",
+		Database:       "db",
+		Indexes: []ArangoCollectionIndex{
+			{Name: "idx", Type: ArangoCollectionIndexTypeHash, Fields: []string{"a"}},
+			{Name: "idx", Type: ArangoCollectionIndexTypeSkiplist, Fields: []string{"b"}},
+		},
+	}.Validate("col"))
+}
+
+func TestArangoCollectionAnalyzerAndView_Validate(t *testing.T) {
+	require.Error(t, ArangoCollectionAnalyzer{Type: "text"}.Validate())
+	require.Error(t, ArangoCollectionAnalyzer{Name: "a", Type: "unknown"}.Validate())
+	require.Error(t, ArangoCollectionAnalyzer{Name: "a", Type: "text", Features: []string{"unknown"}}.Validate())
+
+	require.Error(t, ArangoCollectionView{}.Validate())
+	require.Error(t, ArangoCollectionView{Name: "v", StoreValues: util.NewString("all")}.Validate())
+	require.Error(t, ArangoCollectionSpec{
+		DeploymentName: "example",
+		Database:       "db",
+		Views:          []ArangoCollectionView{{Name: "v"}, {Name: "v"}},
+	}.Validate("col"))
+}
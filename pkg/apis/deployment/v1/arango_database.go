@@ -0,0 +1,147 @@
+//
+// DISCLAIMER
+//
+// Copyright 2020 ArangoDB GmbH, Cologne, Germany
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// Copyright holder is ArangoDB GmbH, Cologne, Germany
+//
+
+package v1
+
+import (
+	"github.com/arangodb/kube-arangodb/pkg/apis/deployment"
+	"github.com/arangodb/kube-arangodb/pkg/util"
+	"github.com/pkg/errors"
+	meta "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+const (
+	// ArangoDatabaseSystem is the name of the system database, which cannot be managed
+	ArangoDatabaseSystem = "_system"
+	// ArangoDatabaseShardingSingle places all shards of collections in the database on the same DB-servers
+	ArangoDatabaseShardingSingle = "single"
+)
+
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// ArangoDatabaseList is a list of ArangoDB databases.
+type ArangoDatabaseList struct {
+	meta.TypeMeta `json:",inline"`
+	// Standard list metadata
+	// More info: http://releases.k8s.io/HEAD/docs/devel/api-conventions.md#metadata
+	meta.ListMeta `json:"metadata,omitempty"`
+	Items         []ArangoDatabase `json:"items"`
+}
+
+// +genclient
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// ArangoDatabase contains the definition of a database in an ArangoDeployment.
+type ArangoDatabase struct {
+	meta.TypeMeta   `json:",inline"`
+	meta.ObjectMeta `json:"metadata,omitempty"`
+	Spec            ArangoDatabaseSpec   `json:"spec,omitempty"`
+	Status          ArangoDatabaseStatus `json:"status,omitempty"`
+}
+
+// AsOwner creates an OwnerReference for the given database
+func (d *ArangoDatabase) AsOwner() meta.OwnerReference {
+	trueVar := true
+	return meta.OwnerReference{
+		APIVersion: SchemeGroupVersion.String(),
+		Kind:       deployment.ArangoDatabaseResourceKind,
+		Name:       d.Name,
+		UID:        d.UID,
+		Controller: &trueVar,
+	}
+}
+
+// GetDatabaseName returns the name of the database in the deployment.
+// Name from the status is used once the database is created.
+func (d *ArangoDatabase) GetDatabaseName() string {
+	if d.Status.Name != "" {
+		return d.Status.Name
+	}
+
+	return d.Spec.GetName(d.GetName())
+}
+
+// ArangoDatabaseSpec contains the specification of a database
+type ArangoDatabaseSpec struct {
+	// DeploymentName is the name of the ArangoDeployment (in the same namespace) which hosts the database
+	DeploymentName string `json:"deploymentName"`
+	// Name of the database, defaults to the name of the resource
+	Name *string `json:"name,omitempty"`
+	// ReplicationFactor is the default replication factor of collections in the database
+	ReplicationFactor *int `json:"replicationFactor,omitempty"`
+	// WriteConcern is the default write concern of collections in the database
+	WriteConcern *int `json:"writeConcern,omitempty"`
+	// Sharding is the default sharding of collections in the database, empty or "single"
+	Sharding *string `json:"sharding,omitempty"`
+	// DropOnDelete drops the database when the resource is deleted
+	DropOnDelete *bool `json:"dropOnDelete,omitempty"`
+}
+
+// GetName returns the name of the database
+func (s ArangoDatabaseSpec) GetName(def string) string {
+	if s.Name == nil || *s.Name == "" {
+		return def
+	}
+
+	return *s.Name
+}
+
+// GetDropOnDelete returns true when the database should be dropped with the resource
+func (s ArangoDatabaseSpec) GetDropOnDelete() bool {
+	return util.BoolOrDefault(s.DropOnDelete, false)
+}
+
+// Validate the database specification
+func (s ArangoDatabaseSpec) Validate(def string) error {
+	if s.DeploymentName == "" {
+		return errors.Wrapf(ValidationError, "deploymentName must be set")
+	}
+
+	if s.GetName(def) == ArangoDatabaseSystem {
+		return errors.Wrapf(ValidationError, "database %s cannot be managed", ArangoDatabaseSystem)
+	}
+
+	if s.ReplicationFactor != nil && *s.ReplicationFactor < 1 {
+		return errors.Wrapf(ValidationError, "replicationFactor must be greater than 0")
+	}
+
+	if s.WriteConcern != nil {
+		if *s.WriteConcern < 1 {
+			return errors.Wrapf(ValidationError, "writeConcern must be greater than 0")
+		}
+		if s.ReplicationFactor != nil && *s.WriteConcern > *s.ReplicationFactor {
+			return errors.Wrapf(ValidationError, "writeConcern cannot be greater than replicationFactor")
+		}
+	}
+
+	if v := util.StringOrDefault(s.Sharding); v != "" && v != ArangoDatabaseShardingSingle {
+		return errors.Wrapf(ValidationError, "unknown sharding %s", v)
+	}
+
+	return nil
+}
+
+// ArangoDatabaseStatus contains the status of a database
+type ArangoDatabaseStatus struct {
+	// Name of the database created in the deployment
+	Name string `json:"name,omitempty"`
+	// Conditions specific to the database
+	Conditions ConditionList `json:"conditions,omitempty"`
+}
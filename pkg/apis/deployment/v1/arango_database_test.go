@@ -0,0 +1,62 @@
+//
+// DISCLAIMER
+//
+// Copyright 2020 ArangoDB GmbH, Cologne, Germany
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// Copyright holder is ArangoDB GmbH, Cologne, Germany
+//
+
+package v1
+
+import (
+	"testing"
+
+	"github.com/arangodb/kube-arangodb/pkg/util"
+	"github.com/stretchr/testify/require"
+)
+
+func TestArangoDatabaseSpec_Validate(t *testing.T) {
+	require.NoError(t, ArangoDatabaseSpec{DeploymentName: "example"}.Validate("db"))
+	require.NoError(t, ArangoDatabaseSpec{
+		DeploymentName:    "example",
+		ReplicationFactor: util.NewInt(3),
+		WriteConcern:      util.NewInt(2),
+		Sharding:          util.NewString(ArangoDatabaseShardingSingle),
+	}.Validate("db"))
+
+	require.Error(t, ArangoDatabaseSpec{}.Validate("db"))
+	require.Error(t, ArangoDatabaseSpec{DeploymentName: "example"}.Validate(ArangoDatabaseSystem))
+	require.Error(t, ArangoDatabaseSpec{DeploymentName: "example", ReplicationFactor: util.NewInt(0)}.Validate("db"))
+	require.Error(t, ArangoDatabaseSpec{DeploymentName: "example", WriteConcern: util.NewInt(0)}.Validate("db"))
+	require.Error(t, ArangoDatabaseSpec{
+		DeploymentName:    "example",
+		ReplicationFactor: util.NewInt(1),
+		WriteConcern:      util.NewInt(2),
+	}.Validate("db"))
+	require.Error(t, ArangoDatabaseSpec{DeploymentName: "example", Sharding: util.NewString("flexible")}.Validate("db"))
+}
+
+func TestArangoDatabase_GetDatabaseName(t *testing.T) {
+	db := ArangoDatabase{}
+	db.Name = "resource"
+
+	require.Equal(t, "resource", db.GetDatabaseName())
+
+	db.Spec.Name = util.NewString("custom")
+	require.Equal(t, "custom", db.GetDatabaseName())
+
+	db.Status.Name = "created"
+	require.Equal(t, "created", db.GetDatabaseName())
+}
@@ -0,0 +1,216 @@
+//
+// DISCLAIMER
+//
+// Copyright 2020 ArangoDB GmbH, Cologne, Germany
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// Copyright holder is ArangoDB GmbH, Cologne, Germany
+//
+
+package v1
+
+import (
+	"github.com/arangodb/kube-arangodb/pkg/apis/deployment"
+	"github.com/arangodb/kube-arangodb/pkg/util"
+	"github.com/pkg/errors"
+	meta "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+const (
+	// ArangoUserRoot is the name of the root user, which is managed by the deployment bootstrap
+	ArangoUserRoot = "root"
+	// ArangoUserCollectionAll is the collection name used to grant default access to all collections in a database
+	ArangoUserCollectionAll = "*"
+)
+
+// ArangoUserGrant defines access rights of a user
+// +kubebuilder:validation:Enum=rw;ro;none
+type ArangoUserGrant string
+
+const (
+	// ArangoUserGrantReadWrite grants read and write access
+	ArangoUserGrantReadWrite ArangoUserGrant = "rw"
+	// ArangoUserGrantReadOnly grants read only access
+	ArangoUserGrantReadOnly ArangoUserGrant = "ro"
+	// ArangoUserGrantNone denies access
+	ArangoUserGrantNone ArangoUserGrant = "none"
+)
+
+// Validate the grant
+func (g ArangoUserGrant) Validate() error {
+	switch g {
+	case ArangoUserGrantReadWrite, ArangoUserGrantReadOnly, ArangoUserGrantNone:
+		return nil
+	default:
+		return errors.Wrapf(ValidationError, "unknown grant %s", g)
+	}
+}
+
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// ArangoUserList is a list of ArangoDB users.
+type ArangoUserList struct {
+	meta.TypeMeta `json:",inline"`
+	// Standard list metadata
+	// More info: http://releases.k8s.io/HEAD/docs/devel/api-conventions.md#metadata
+	meta.ListMeta `json:"metadata,omitempty"`
+	Items         []ArangoUser `json:"items"`
+}
+
+// +genclient
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// ArangoUser contains the definition of a user and its permissions in an ArangoDeployment.
+type ArangoUser struct {
+	meta.TypeMeta   `json:",inline"`
+	meta.ObjectMeta `json:"metadata,omitempty"`
+	Spec            ArangoUserSpec   `json:"spec,omitempty"`
+	Status          ArangoUserStatus `json:"status,omitempty"`
+}
+
+// AsOwner creates an OwnerReference for the given user
+func (u *ArangoUser) AsOwner() meta.OwnerReference {
+	trueVar := true
+	return meta.OwnerReference{
+		APIVersion: SchemeGroupVersion.String(),
+		Kind:       deployment.ArangoUserResourceKind,
+		Name:       u.Name,
+		UID:        u.UID,
+		Controller: &trueVar,
+	}
+}
+
+// GetUserName returns the name of the user in the deployment.
+// Name from the status is used once the user is created.
+func (u *ArangoUser) GetUserName() string {
+	if u.Status.Name != "" {
+		return u.Status.Name
+	}
+
+	return u.Spec.GetName(u.GetName())
+}
+
+// ArangoUserSpec contains the specification of a user
+type ArangoUserSpec struct {
+	// DeploymentName is the name of the ArangoDeployment (in the same namespace) which hosts the user
+	DeploymentName string `json:"deploymentName"`
+	// Name of the user, defaults to the name of the resource
+	Name *string `json:"name,omitempty"`
+	// PasswordSecretName is the name of a basic auth secret holding the password of the user.
+	// Secret with a random password is created when it does not exist.
+	PasswordSecretName *string `json:"passwordSecretName,omitempty"`
+	// Active defines if the user can log in, true by default
+	Active *bool `json:"active,omitempty"`
+	// Permissions granted to the user
+	Permissions []ArangoUserPermission `json:"permissions,omitempty"`
+	// DropOnDelete removes the user when the resource is deleted.
+	// True by default, false by default for adopted users.
+	DropOnDelete *bool `json:"dropOnDelete,omitempty"`
+	// Adopt allows to manage a user which already exists in the deployment.
+	// Password, active flag and permissions of the adopted user are overwritten. False by default.
+	Adopt *bool `json:"adopt,omitempty"`
+}
+
+// GetName returns the name of the user
+func (s ArangoUserSpec) GetName(def string) string {
+	if s.Name == nil || *s.Name == "" {
+		return def
+	}
+
+	return *s.Name
+}
+
+// GetPasswordSecretName returns the name of the password secret
+func (s ArangoUserSpec) GetPasswordSecretName(def string) string {
+	if s.PasswordSecretName == nil || *s.PasswordSecretName == "" {
+		return def + "-password"
+	}
+
+	return *s.PasswordSecretName
+}
+
+// GetActive returns true if the user can log in
+func (s ArangoUserSpec) GetActive() bool {
+	return util.BoolOrDefault(s.Active, true)
+}
+
+// GetDropOnDelete returns true when the user should be removed with the resource.
+// Adopted users were not created by the operator, so they are kept by default.
+func (s ArangoUserSpec) GetDropOnDelete(adopted bool) bool {
+	return util.BoolOrDefault(s.DropOnDelete, !adopted)
+}
+
+// GetAdopt returns true when an existing user can be adopted
+func (s ArangoUserSpec) GetAdopt() bool {
+	return util.BoolOrDefault(s.Adopt, false)
+}
+
+// Validate the user specification
+func (s ArangoUserSpec) Validate(def string) error {
+	if s.DeploymentName == "" {
+		return errors.Wrapf(ValidationError, "deploymentName must be set")
+	}
+
+	if s.GetName(def) == ArangoUserRoot {
+		return errors.Wrapf(ValidationError, "user %s cannot be managed", ArangoUserRoot)
+	}
+
+	for id, p := range s.Permissions {
+		if err := p.Validate(); err != nil {
+			return errors.Wrapf(err, "permissions[%d]", id)
+		}
+
+		for _, o := range s.Permissions[:id] {
+			if o.Database == p.Database && o.Collection == p.Collection {
+				return errors.Wrapf(ValidationError, "permissions[%d]: duplicated permission", id)
+			}
+		}
+	}
+
+	return nil
+}
+
+// ArangoUserPermission grants access to a database or a collection
+type ArangoUserPermission struct {
+	// Database name
+	Database string `json:"database"`
+	// Collection name, access to the database is granted when empty.
+	// Use "*" to grant default access to all collections of the database.
+	Collection string `json:"collection,omitempty"`
+	// Grant defines access rights: rw, ro or none
+	Grant ArangoUserGrant `json:"grant"`
+}
+
+// Validate the permission
+func (p ArangoUserPermission) Validate() error {
+	if p.Database == "" {
+		return errors.Wrapf(ValidationError, "database must be set")
+	}
+
+	return p.Grant.Validate()
+}
+
+// ArangoUserStatus contains the status of a user
+type ArangoUserStatus struct {
+	// Name of the user created in the deployment
+	Name string `json:"name,omitempty"`
+	// Adopted is true when the user existed before and was adopted
+	Adopted bool `json:"adopted,omitempty"`
+	// PasswordSecretVersion is the resource version of the password secret which was applied to the user
+	PasswordSecretVersion string `json:"passwordSecretVersion,omitempty"`
+	// Permissions applied to the user
+	Permissions []ArangoUserPermission `json:"permissions,omitempty"`
+	// Conditions specific to the user
+	Conditions ConditionList `json:"conditions,omitempty"`
+}
@@ -0,0 +1,82 @@
+//
+// DISCLAIMER
+//
+// Copyright 2020 ArangoDB GmbH, Cologne, Germany
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// Copyright holder is ArangoDB GmbH, Cologne, Germany
+//
+
+package v1
+
+import (
+	"testing"
+
+	"github.com/arangodb/kube-arangodb/pkg/util"
+	"github.com/stretchr/testify/require"
+)
+
+func TestArangoUserSpec_Validate(t *testing.T) {
+	require.NoError(t, ArangoUserSpec{
+		DeploymentName: "example",
+		Permissions: []ArangoUserPermission{
+			{Database: "db", Grant: ArangoUserGrantReadWrite},
+			{Database: "db", Collection: "col", Grant: ArangoUserGrantReadOnly},
+			{Database: "db", Collection: ArangoUserCollectionAll, Grant: ArangoUserGrantNone},
+		},
+	}.Validate("user"))
+
+	require.Error(t, ArangoUserSpec{}.Validate("user"))
+	require.Error(t, ArangoUserSpec{DeploymentName: "example"}.Validate(ArangoUserRoot))
+	require.Error(t, ArangoUserSpec{DeploymentName: "example", Name: util.NewString(ArangoUserRoot)}.Validate("user"))
+	require.Error(t, ArangoUserSpec{
+		DeploymentName: "example",
+		Permissions:    []ArangoUserPermission{{Grant: ArangoUserGrantReadWrite}},
+	}.Validate("user"))
+	require.Error(t, ArangoUserSpec{
+		DeploymentName: "example",
+		Permissions:    []ArangoUserPermission{{Database: "db", Grant: "admin"}},
+	}.Validate("user"))
+	require.Error(t, ArangoUserSpec{
+		DeploymentName: "example",
+		Permissions: []ArangoUserPermission{
+			{Database: "db", Grant: ArangoUserGrantReadWrite},
+			{Database: "db", Grant: ArangoUserGrantReadOnly},
+		},
+	}.Validate("user"))
+}
+
+func TestArangoUserSpec_Defaults(t *testing.T) {
+	s := ArangoUserSpec{}
+
+	require.Equal(t, "user", s.GetName("user"))
+	require.Equal(t, "user-password", s.GetPasswordSecretName("user"))
+	require.True(t, s.GetActive())
+	require.True(t, s.GetDropOnDelete(false))
+	require.False(t, s.GetDropOnDelete(true))
+	require.False(t, s.GetAdopt())
+
+	s.PasswordSecretName = util.NewString("secret")
+	s.Active = util.NewBool(false)
+	s.DropOnDelete = util.NewBool(false)
+	s.Adopt = util.NewBool(true)
+
+	require.Equal(t, "secret", s.GetPasswordSecretName("user"))
+	require.False(t, s.GetActive())
+	require.False(t, s.GetDropOnDelete(false))
+	require.True(t, s.GetAdopt())
+
+	s.DropOnDelete = util.NewBool(true)
+	require.True(t, s.GetDropOnDelete(true))
+}
@@ -32,6 +32,8 @@ import (
 // AuthenticationSpec holds authentication specific configuration settings
 type AuthenticationSpec struct {
 	JWTSecretName *string `json:"jwtSecretName,omitempty"`
+	// Rotation schedules the automatic rotation of the JWT secret
+	Rotation *SecretRotationSpec `json:"rotation,omitempty"`
 }
 
 const (
@@ -60,6 +62,14 @@ func (s AuthenticationSpec) Validate(required bool) error {
 			return maskAny(err)
 		}
 	}
+	if s.Rotation != nil {
+		if !s.IsAuthenticated() {
+			return maskAny(errors.Wrap(ValidationError, "JWT rotation requires authentication"))
+		}
+		if err := s.Rotation.Validate(); err != nil {
+			return maskAny(err)
+		}
+	}
 	return nil
 }
 
@@ -77,6 +87,9 @@ func (s *AuthenticationSpec) SetDefaultsFrom(source AuthenticationSpec) {
 	if s.JWTSecretName == nil {
 		s.JWTSecretName = util.NewStringOrNil(source.JWTSecretName)
 	}
+	if s.Rotation == nil {
+		s.Rotation = source.Rotation.DeepCopy()
+	}
 }
 
 // ResetImmutableFields replaces all immutable fields in the given target with values from the source spec.
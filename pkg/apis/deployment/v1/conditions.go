@@ -63,6 +63,20 @@ const (
 	ConditionTypeUpToDate ConditionType = "UpToDate"
 	// ConditionTypeMarkedToRemove indicates that the member is marked to be removed.
 	ConditionTypeMarkedToRemove ConditionType = "MarkedToRemove"
+	// ConditionTypeCertificateExpiring indicates that the CA certificate, which is not renewed by the operator, expires soon.
+	ConditionTypeCertificateExpiring ConditionType = "CertificateExpiring"
+	// ConditionTypeDriftDetected indicates that the resource in the deployment differs from its specification.
+	ConditionTypeDriftDetected ConditionType = "DriftDetected"
+	// ConditionTypeUpgradePreflightFailed indicates that the preflight checks of an upgrade failed and the upgrade is postponed.
+	ConditionTypeUpgradePreflightFailed ConditionType = "UpgradePreflightFailed"
+	// ConditionTypeUpgradeRolledBack indicates the outcome of the automatic rollback of a failed upgrade.
+	ConditionTypeUpgradeRolledBack ConditionType = "UpgradeRolledBack"
+	// ConditionTypeUpgradePaused indicates that the canary members are upgraded and the upgrade waits to be continued.
+	ConditionTypeUpgradePaused ConditionType = "UpgradePaused"
+	// ConditionTypeImageTagDrift indicates that the image tag points to a different digest in the registry than the one in use.
+	ConditionTypeImageTagDrift ConditionType = "ImageTagDrift"
+	// ConditionTypeImageVerificationFailed indicates that the signature of the image could not be verified.
+	ConditionTypeImageVerificationFailed ConditionType = "ImageVerificationFailed"
 )
 
 // Condition represents one current condition of a deployment or deployment member.
@@ -27,6 +27,7 @@ import (
 )
 
 // DeploymentMode specifies the type of ArangoDB deployment to create.
+// +kubebuilder:validation:Enum=Single;ActiveFailover;Cluster
 type DeploymentMode string
 
 const (
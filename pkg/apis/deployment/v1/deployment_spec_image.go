@@ -24,6 +24,8 @@ package v1
 
 import "fmt"
 
+// DeploymentImageDiscoveryModeSpec defines how the ID and ArangoDB version of images are discovered
+// +kubebuilder:validation:Enum=kubelet;direct;registry
 type DeploymentImageDiscoveryModeSpec string
 
 func NewDeploymentImageDiscoveryModeSpec(mode DeploymentImageDiscoveryModeSpec) *DeploymentImageDiscoveryModeSpec {
@@ -33,6 +35,9 @@ func NewDeploymentImageDiscoveryModeSpec(mode DeploymentImageDiscoveryModeSpec)
 const (
 	DeploymentImageDiscoveryDirectMode  = "direct"
 	DeploymentImageDiscoveryKubeletMode = "kubelet"
+	// DeploymentImageDiscoveryRegistryMode reads the version from the image config in the registry
+	// and uses the pod method when the image does not have version labels or the registry is not reachable
+	DeploymentImageDiscoveryRegistryMode = "registry"
 )
 
 func (d DeploymentImageDiscoveryModeSpec) Validate() error {
@@ -41,6 +46,8 @@ func (d DeploymentImageDiscoveryModeSpec) Validate() error {
 		return nil
 	case DeploymentImageDiscoveryDirectMode:
 		return nil
+	case DeploymentImageDiscoveryRegistryMode:
+		return nil
 	default:
 		return fmt.Errorf("mode %s is not supported", d)
 	}
@@ -64,6 +64,7 @@ type DeploymentStatus struct {
 	Plan Plan `json:"plan,omitempty"`
 
 	// AcceptedSpec contains the last specification that was accepted by the operator.
+	// +kubebuilder:validation:Schemaless
 	AcceptedSpec *DeploymentSpec `json:"accepted-spec,omitempty"`
 
 	// SecretHashes keeps a sha256 hash of secret values, so we can
@@ -73,6 +74,15 @@ type DeploymentStatus struct {
 	// Hashes keep status of hashes in deployment
 	Hashes DeploymentStatusHashes `json:"hashes,omitempty"`
 
+	// Certificates keeps the expiry status of the deployment certificates
+	Certificates *DeploymentStatusCertificates `json:"certificates,omitempty"`
+
+	// Rollback keeps the outcome of the last automatic rollback of a failed upgrade
+	Rollback *DeploymentStatusRollback `json:"rollback,omitempty"`
+
+	// Canary keeps the state of the last canary upgrade
+	Canary *DeploymentStatusCanary `json:"canary,omitempty"`
+
 	// ForceStatusReload if set to true forces a reload of the status from the custom resource.
 	ForceStatusReload *bool `json:"force-status-reload,omitempty"`
 }
@@ -92,7 +102,10 @@ func (ds *DeploymentStatus) Equal(other DeploymentStatus) bool {
 		ds.Conditions.Equal(other.Conditions) &&
 		ds.Plan.Equal(other.Plan) &&
 		ds.AcceptedSpec.Equal(other.AcceptedSpec) &&
-		ds.SecretHashes.Equal(other.SecretHashes)
+		ds.SecretHashes.Equal(other.SecretHashes) &&
+		ds.Certificates.Equal(other.Certificates) &&
+		ds.Rollback.Equal(other.Rollback) &&
+		ds.Canary.Equal(other.Canary)
 }
 
 // IsForceReload returns true if ForceStatusReload is set to true
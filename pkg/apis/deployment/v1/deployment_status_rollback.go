@@ -0,0 +1,58 @@
+//
+// DISCLAIMER
+//
+// Copyright 2020 ArangoDB GmbH, Cologne, Germany
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// Copyright holder is ArangoDB GmbH, Cologne, Germany
+//
+
+package v1
+
+import (
+	"github.com/arangodb/kube-arangodb/pkg/util"
+	meta "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// DeploymentStatusRollback keeps the outcome of an automatic rollback of a failed upgrade.
+type DeploymentStatusRollback struct {
+	// FromImage is the image the deployment is rolled back to
+	FromImage string `json:"fromImage"`
+	// ToImage is the image which members failed to come up on
+	ToImage string `json:"toImage"`
+	// Time is the moment the rollback has been started
+	Time *meta.Time `json:"time,omitempty"`
+}
+
+// IsActive returns true when the rollback applies to the given target image
+func (d *DeploymentStatusRollback) IsActive(image string) bool {
+	if d == nil {
+		return false
+	}
+
+	return d.ToImage == image
+}
+
+// Equal checks for equality
+func (d *DeploymentStatusRollback) Equal(other *DeploymentStatusRollback) bool {
+	if d == nil && other == nil {
+		return true
+	} else if d == nil || other == nil {
+		return false
+	}
+
+	return d.FromImage == other.FromImage &&
+		d.ToImage == other.ToImage &&
+		util.TimeCompareEqualOptional(d.Time, other.Time)
+}
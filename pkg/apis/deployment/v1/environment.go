@@ -27,6 +27,7 @@ import (
 )
 
 // Environment in which to run the cluster
+// +kubebuilder:validation:Enum=Development;Production
 type Environment string
 
 const (
@@ -33,6 +33,8 @@ type DeploymentStatusHashes struct {
 type DeploymentStatusHashesEncryption struct {
 	Keys shared.HashList `json:"keys,omitempty"`
 
+	Rotation *DeploymentStatusSecretRotation `json:"rotation,omitempty"`
+
 	Propagated bool `json:"propagated,omitempty"`
 }
 
@@ -47,5 +49,7 @@ type DeploymentStatusHashesJWT struct {
 	Active  string          `json:"active,omitempty"`
 	Passive shared.HashList `json:"passive,omitempty"`
 
+	Rotation *DeploymentStatusSecretRotation `json:"rotation,omitempty"`
+
 	Propagated bool `json:"propagated,omitempty"`
 }
@@ -0,0 +1,97 @@
+//
+// DISCLAIMER
+//
+// Copyright 2020 ArangoDB GmbH, Cologne, Germany
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// Copyright holder is ArangoDB GmbH, Cologne, Germany
+//
+
+package v1
+
+import (
+	"time"
+
+	"github.com/arangodb/kube-arangodb/pkg/util"
+	"github.com/arangodb/kube-arangodb/pkg/util/k8sutil"
+	"github.com/pkg/errors"
+)
+
+// ImagePolicySpec defines how images of the deployment are pinned and verified
+type ImagePolicySpec struct {
+	// PinDigest renders pods with the image digest resolved during image discovery instead of the tag,
+	// so all members run exactly the same image even when the tag is moved in the registry.
+	// Digest is always pinned when PublicKeySecretName is set.
+	PinDigest *bool `json:"pinDigest,omitempty"`
+	// DriftCheckInterval is the interval in which the tag is resolved in the registry
+	// and compared with the pinned digest. Zero disables drift checks.
+	DriftCheckInterval *Timeout `json:"driftCheckInterval,omitempty"`
+	// PublicKeySecretName is the name of a secret holding a cosign public key.
+	// When set, images are verified against their cosign signature before they are used.
+	PublicKeySecretName *string `json:"publicKeySecretName,omitempty"`
+}
+
+// IsPinDigest returns true when pods are rendered with the image digest.
+// Verified images are always pinned, the tag could point to an image which was not verified.
+func (s *ImagePolicySpec) IsPinDigest() bool {
+	if s == nil {
+		return false
+	}
+
+	if s.IsVerificationEnabled() {
+		return true
+	}
+
+	return util.BoolOrDefault(s.PinDigest, false)
+}
+
+// GetDriftCheckInterval returns the interval of tag drift checks
+func (s *ImagePolicySpec) GetDriftCheckInterval() time.Duration {
+	if s == nil {
+		return 0
+	}
+
+	return s.DriftCheckInterval.Get(0)
+}
+
+// IsVerificationEnabled returns true when image signatures are verified
+func (s *ImagePolicySpec) IsVerificationEnabled() bool {
+	return s.GetPublicKeySecretName() != ""
+}
+
+// GetPublicKeySecretName returns the name of the secret holding the cosign public key
+func (s *ImagePolicySpec) GetPublicKeySecretName() string {
+	if s == nil {
+		return ""
+	}
+
+	return util.StringOrDefault(s.PublicKeySecretName)
+}
+
+// Validate the image policy spec
+func (s *ImagePolicySpec) Validate() error {
+	if s == nil {
+		return nil
+	}
+
+	if s.GetDriftCheckInterval() < 0 {
+		return errors.Wrapf(ValidationError, "Invalid driftCheckInterval value. Expected non negative duration")
+	}
+
+	if err := k8sutil.ValidateOptionalResourceName(s.GetPublicKeySecretName()); err != nil {
+		return errors.Wrapf(err, "publicKeySecretName")
+	}
+
+	return nil
+}
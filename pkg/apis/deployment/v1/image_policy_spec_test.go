@@ -0,0 +1,59 @@
+//
+// DISCLAIMER
+//
+// Copyright 2020 ArangoDB GmbH, Cologne, Germany
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// Copyright holder is ArangoDB GmbH, Cologne, Germany
+//
+
+package v1
+
+import (
+	"testing"
+	"time"
+
+	"github.com/arangodb/kube-arangodb/pkg/util"
+	"github.com/stretchr/testify/assert"
+	meta "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestImagePolicySpec(t *testing.T) {
+	var s *ImagePolicySpec
+	assert.False(t, s.IsPinDigest())
+	assert.False(t, s.IsVerificationEnabled())
+	assert.Equal(t, time.Duration(0), s.GetDriftCheckInterval())
+	assert.NoError(t, s.Validate())
+
+	interval := Timeout(meta.Duration{Duration: time.Hour})
+	s = &ImagePolicySpec{
+		PinDigest:           util.NewBool(true),
+		DriftCheckInterval:  &interval,
+		PublicKeySecretName: util.NewString("cosign-key"),
+	}
+	assert.True(t, s.IsPinDigest())
+	assert.True(t, s.IsVerificationEnabled())
+	assert.Equal(t, time.Hour, s.GetDriftCheckInterval())
+	assert.NoError(t, s.Validate())
+
+	s = &ImagePolicySpec{
+		PinDigest:           util.NewBool(false),
+		PublicKeySecretName: util.NewString("cosign-key"),
+	}
+	assert.True(t, s.IsPinDigest())
+
+	assert.Error(t, (&ImagePolicySpec{PublicKeySecretName: util.NewString("Invalid_Name")}).Validate())
+	interval = Timeout(meta.Duration{Duration: -time.Minute})
+	assert.Error(t, (&ImagePolicySpec{DriftCheckInterval: &interval}).Validate())
+}
@@ -69,6 +69,10 @@ type MemberStatus struct {
 	ImageID string `json:"image-id,omitempty"`
 	// Image holds image details
 	Image *ImageInfo `json:"image,omitempty"`
+	// CertificateNotAfter holds the expiry time of the member TLS certificate
+	CertificateNotAfter *metav1.Time `json:"certificateNotAfter,omitempty"`
+	// Zone holds the zone of the node the member pod is scheduled on
+	Zone string `json:"zone,omitempty"`
 }
 
 // Equal checks for equality
@@ -84,7 +88,9 @@ func (s MemberStatus) Equal(other MemberStatus) bool {
 		reflect.DeepEqual(s.SideCarSpecs, other.SideCarSpecs) &&
 		s.ArangoVersion == other.ArangoVersion &&
 		s.ImageID == other.ImageID &&
-		s.Image.Equal(other.Image)
+		s.Image.Equal(other.Image) &&
+		util.TimeCompareEqualOptional(s.CertificateNotAfter, other.CertificateNotAfter) &&
+		s.Zone == other.Zone
 }
 
 // Age returns the duration since the creation timestamp of this member.
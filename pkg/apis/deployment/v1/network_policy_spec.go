@@ -0,0 +1,100 @@
+//
+// DISCLAIMER
+//
+// Copyright 2020 ArangoDB GmbH, Cologne, Germany
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// Copyright holder is ArangoDB GmbH, Cologne, Germany
+//
+
+package v1
+
+import (
+	"github.com/arangodb/kube-arangodb/pkg/util"
+	"github.com/pkg/errors"
+	networking "k8s.io/api/networking/v1"
+	meta "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// DefaultNetworkPolicyOperatorLabels are the labels of the operator pods installed by the helm chart
+var DefaultNetworkPolicyOperatorLabels = map[string]string{
+	"app.kubernetes.io/name": "kube-arangodb",
+}
+
+// NetworkPolicySpec holds configuration of NetworkPolicies generated for the deployment
+type NetworkPolicySpec struct {
+	// Enabled generates a NetworkPolicy for each server group of the deployment
+	Enabled *bool `json:"enabled,omitempty"`
+	// Clients are allowed to connect to coordinators and single servers.
+	// When empty, connections are allowed from all sources.
+	Clients []networking.NetworkPolicyPeer `json:"clients,omitempty"`
+	// MonitoringNamespaceSelector selects namespaces allowed to scrape metrics.
+	// When not set, metrics can be scraped from all namespaces.
+	MonitoringNamespaceSelector *meta.LabelSelector `json:"monitoringNamespaceSelector,omitempty"`
+	// OperatorSelector selects operator pods (in any namespace) which are allowed to connect to all members
+	OperatorSelector *meta.LabelSelector `json:"operatorSelector,omitempty"`
+}
+
+// IsEnabled returns true when NetworkPolicies should be generated
+func (s *NetworkPolicySpec) IsEnabled() bool {
+	if s == nil {
+		return false
+	}
+
+	return util.BoolOrDefault(s.Enabled, false)
+}
+
+// GetClients returns peers allowed to connect to coordinators and single servers
+func (s *NetworkPolicySpec) GetClients() []networking.NetworkPolicyPeer {
+	if s == nil {
+		return nil
+	}
+
+	return s.Clients
+}
+
+// GetMonitoringNamespaceSelector returns selector of namespaces allowed to scrape metrics
+func (s *NetworkPolicySpec) GetMonitoringNamespaceSelector() *meta.LabelSelector {
+	if s == nil || s.MonitoringNamespaceSelector == nil {
+		return &meta.LabelSelector{}
+	}
+
+	return s.MonitoringNamespaceSelector
+}
+
+// GetOperatorSelector returns selector of operator pods
+func (s *NetworkPolicySpec) GetOperatorSelector() *meta.LabelSelector {
+	if s == nil || s.OperatorSelector == nil {
+		return &meta.LabelSelector{
+			MatchLabels: DefaultNetworkPolicyOperatorLabels,
+		}
+	}
+
+	return s.OperatorSelector
+}
+
+// Validate the network policy spec
+func (s *NetworkPolicySpec) Validate() error {
+	if s == nil {
+		return nil
+	}
+
+	for id, c := range s.Clients {
+		if c.PodSelector == nil && c.NamespaceSelector == nil && c.IPBlock == nil {
+			return errors.Wrapf(ValidationError, "clients[%d]: podSelector, namespaceSelector or ipBlock must be set", id)
+		}
+	}
+
+	return nil
+}
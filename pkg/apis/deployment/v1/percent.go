@@ -27,6 +27,8 @@ import (
 )
 
 // Percent is a percentage between 0 and 100.
+// +kubebuilder:validation:Minimum=0
+// +kubebuilder:validation:Maximum=100
 type Percent int
 
 // Validate the given percentage.
@@ -109,6 +109,8 @@ const (
 	ActionTypeEncryptionKeyStatusUpdate ActionType = "EncryptionKeyStatusUpdate"
 	// ActionTypeEncryptionKeyPropagated change propagated flag
 	ActionTypeEncryptionKeyPropagated ActionType = "EncryptionKeyPropagated"
+	// ActionTypeEncryptionKeyRotate generates new encryption key
+	ActionTypeEncryptionKeyRotate ActionType = "EncryptionKeyRotate"
 	// ActionTypeJWTStatusUpdate update status of JWT Secret
 	ActionTypeJWTStatusUpdate ActionType = "JWTStatusUpdate"
 	// ActionTypeJWTSetActive change active JWT key
@@ -121,6 +123,8 @@ const (
 	ActionTypeJWTRefresh ActionType = "JWTRefresh"
 	// ActionTypeJWTPropagated change propagated flag
 	ActionTypeJWTPropagated ActionType = "JWTPropagated"
+	// ActionTypeJWTRotate generates new JWT key
+	ActionTypeJWTRotate ActionType = "JWTRotate"
 	// ActionTypeClusterMemberCleanup removes member from cluster
 	ActionTypeClusterMemberCleanup ActionType = "ClusterMemberCleanup"
 	// ActionTypeEnableMaintenance enables maintenance on cluster.
@@ -131,6 +135,10 @@ const (
 	ActionTypeBootstrapUpdate ActionType = "BootstrapUpdate"
 	// ActionTypeBootstrapSetPassword set password to the bootstrapped user
 	ActionTypeBootstrapSetPassword ActionType = "BootstrapSetPassword"
+	// ActionTypeUpgradePreflight checks if the deployment is ready to be upgraded
+	ActionTypeUpgradePreflight ActionType = "UpgradePreflight"
+	// ActionTypeUpgradeCanaryStatusUpdate updates the state of the canary upgrade after canary members are upgraded
+	ActionTypeUpgradeCanaryStatusUpdate ActionType = "UpgradeCanaryStatusUpdate"
 )
 
 const (
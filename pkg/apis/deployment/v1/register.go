@@ -50,6 +50,12 @@ func addKnownTypes(s *runtime.Scheme) error {
 	s.AddKnownTypes(SchemeGroupVersion,
 		&ArangoDeployment{},
 		&ArangoDeploymentList{},
+		&ArangoDatabase{},
+		&ArangoDatabaseList{},
+		&ArangoUser{},
+		&ArangoUserList{},
+		&ArangoCollection{},
+		&ArangoCollectionList{},
 	)
 	metav1.AddToGroupVersion(s, SchemeGroupVersion)
 	return nil
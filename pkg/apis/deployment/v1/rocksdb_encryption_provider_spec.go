@@ -0,0 +1,156 @@
+//
+// DISCLAIMER
+//
+// Copyright 2020 ArangoDB GmbH, Cologne, Germany
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// Copyright holder is ArangoDB GmbH, Cologne, Germany
+//
+
+package v1
+
+import (
+	"net/url"
+
+	"github.com/arangodb/kube-arangodb/pkg/util"
+	"github.com/arangodb/kube-arangodb/pkg/util/k8sutil"
+	"github.com/pkg/errors"
+)
+
+// RocksDBEncryptionProviderType is the type of the external key provider
+type RocksDBEncryptionProviderType string
+
+const (
+	// RocksDBEncryptionProviderVault unwraps keys with the HashiCorp Vault transit secrets engine
+	RocksDBEncryptionProviderVault RocksDBEncryptionProviderType = "Vault"
+	// RocksDBEncryptionProviderKMIP unwraps keys with the Decrypt operation of a KMIP server
+	RocksDBEncryptionProviderKMIP RocksDBEncryptionProviderType = "KMIP"
+	// RocksDBEncryptionProviderWebhook unwraps keys with a generic envelope encryption webhook
+	RocksDBEncryptionProviderWebhook RocksDBEncryptionProviderType = "Webhook"
+
+	// DefaultRocksDBEncryptionProviderMountPath is the default mount path of the Vault transit secrets engine
+	DefaultRocksDBEncryptionProviderMountPath = "transit"
+)
+
+// NewRocksDBEncryptionProviderType returns a reference to a string with given value.
+func NewRocksDBEncryptionProviderType(input RocksDBEncryptionProviderType) *RocksDBEncryptionProviderType {
+	return &input
+}
+
+// RocksDBEncryptionProviderSpec holds the configuration of the external provider which wraps encryption keys.
+// When set, secrets with encryption keys hold the wrapped key, which is unwrapped at pod start.
+type RocksDBEncryptionProviderSpec struct {
+	// Type of the provider, one of Vault, KMIP or Webhook
+	Type *RocksDBEncryptionProviderType `json:"type,omitempty"`
+	// Endpoint of the provider, URL for Vault and Webhook, host:port for KMIP
+	Endpoint *string `json:"endpoint,omitempty"`
+	// KeyName is the name (Vault, Webhook) or unique identifier (KMIP) of the key encryption key
+	KeyName *string `json:"keyName,omitempty"`
+	// MountPath of the Vault transit secrets engine, transit by default
+	MountPath *string `json:"mountPath,omitempty"`
+	// CredentialsSecretName is the name of the secret with `token` (Vault, Webhook)
+	// or `tls.crt` and `tls.key` (KMIP) fields
+	CredentialsSecretName *string `json:"credentialsSecretName,omitempty"`
+	// CASecretName is the name of the secret with `ca.crt` field used to verify the provider
+	CASecretName *string `json:"caSecretName,omitempty"`
+}
+
+// GetType returns the type of the provider
+func (s *RocksDBEncryptionProviderSpec) GetType() RocksDBEncryptionProviderType {
+	if s == nil || s.Type == nil {
+		return ""
+	}
+	return *s.Type
+}
+
+// GetEndpoint returns the endpoint of the provider
+func (s *RocksDBEncryptionProviderSpec) GetEndpoint() string {
+	if s == nil {
+		return ""
+	}
+	return util.StringOrDefault(s.Endpoint)
+}
+
+// GetKeyName returns the name of the key encryption key
+func (s *RocksDBEncryptionProviderSpec) GetKeyName() string {
+	if s == nil {
+		return ""
+	}
+	return util.StringOrDefault(s.KeyName)
+}
+
+// GetMountPath returns the mount path of the Vault transit secrets engine
+func (s *RocksDBEncryptionProviderSpec) GetMountPath() string {
+	if s == nil {
+		return DefaultRocksDBEncryptionProviderMountPath
+	}
+	return util.StringOrDefault(s.MountPath, DefaultRocksDBEncryptionProviderMountPath)
+}
+
+// GetCredentialsSecretName returns the name of the secret with provider credentials
+func (s *RocksDBEncryptionProviderSpec) GetCredentialsSecretName() string {
+	if s == nil {
+		return ""
+	}
+	return util.StringOrDefault(s.CredentialsSecretName)
+}
+
+// GetCASecretName returns the name of the secret with the provider CA
+func (s *RocksDBEncryptionProviderSpec) GetCASecretName() string {
+	if s == nil {
+		return ""
+	}
+	return util.StringOrDefault(s.CASecretName)
+}
+
+// Validate the given spec
+func (s *RocksDBEncryptionProviderSpec) Validate() error {
+	if s == nil {
+		return nil
+	}
+
+	switch s.GetType() {
+	case RocksDBEncryptionProviderVault, RocksDBEncryptionProviderWebhook:
+		u, err := url.Parse(s.GetEndpoint())
+		if err != nil {
+			return maskAny(errors.Wrapf(ValidationError, "Invalid provider endpoint: %s", err))
+		}
+		if u.Scheme != "http" && u.Scheme != "https" {
+			return maskAny(errors.Wrapf(ValidationError, "Provider endpoint %s needs to be a http or https URL", s.GetEndpoint()))
+		}
+	case RocksDBEncryptionProviderKMIP:
+		if s.GetEndpoint() == "" {
+			return maskAny(errors.Wrapf(ValidationError, "Provider endpoint is required"))
+		}
+		if s.GetCredentialsSecretName() == "" {
+			return maskAny(errors.Wrapf(ValidationError, "Credentials secret is required for KMIP provider"))
+		}
+	default:
+		return maskAny(errors.Wrapf(ValidationError, "Provider type %s is not supported", s.GetType()))
+	}
+
+	if s.GetType() != RocksDBEncryptionProviderWebhook && s.GetKeyName() == "" {
+		return maskAny(errors.Wrapf(ValidationError, "Provider key name is required"))
+	}
+
+	if err := k8sutil.ValidateOptionalResourceName(s.GetCredentialsSecretName()); err != nil {
+		return maskAny(err)
+	}
+
+	if err := k8sutil.ValidateOptionalResourceName(s.GetCASecretName()); err != nil {
+		return maskAny(err)
+	}
+
+	return nil
+}
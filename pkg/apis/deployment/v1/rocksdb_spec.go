@@ -23,6 +23,8 @@
 package v1
 
 import (
+	"github.com/pkg/errors"
+
 	"github.com/arangodb/kube-arangodb/pkg/util"
 	"github.com/arangodb/kube-arangodb/pkg/util/k8sutil"
 )
@@ -30,6 +32,10 @@ import (
 // RocksDBEncryptionSpec holds rocksdb encryption at rest specific configuration settings
 type RocksDBEncryptionSpec struct {
 	KeySecretName *string `json:"keySecretName,omitempty"`
+	// Provider unwraps the key stored in KeySecretName, when set
+	Provider *RocksDBEncryptionProviderSpec `json:"provider,omitempty"`
+	// Rotation schedules the automatic rotation of the encryption key
+	Rotation *SecretRotationSpec `json:"rotation,omitempty"`
 }
 
 // GetKeySecretName returns the value of keySecretName.
@@ -43,6 +49,11 @@ func (s RocksDBEncryptionSpec) IsEncrypted() bool {
 	return s.GetKeySecretName() != ""
 }
 
+// HasProvider returns true when encryption keys are wrapped by an external provider
+func (s RocksDBEncryptionSpec) HasProvider() bool {
+	return s.IsEncrypted() && s.Provider != nil
+}
+
 // RocksDBSpec holds rocksdb specific configuration settings
 type RocksDBSpec struct {
 	Encryption RocksDBEncryptionSpec `json:"encryption"`
@@ -59,6 +70,15 @@ func (s RocksDBSpec) Validate() error {
 	if err := k8sutil.ValidateOptionalResourceName(s.Encryption.GetKeySecretName()); err != nil {
 		return maskAny(err)
 	}
+	if err := s.Encryption.Provider.Validate(); err != nil {
+		return maskAny(err)
+	}
+	if err := s.Encryption.Rotation.Validate(); err != nil {
+		return maskAny(err)
+	}
+	if s.Encryption.Rotation != nil && s.Encryption.Provider != nil {
+		return maskAny(errors.Wrap(ValidationError, "Encryption key rotation is not supported for keys wrapped by a provider"))
+	}
 	return nil
 }
 
@@ -72,6 +92,12 @@ func (s *RocksDBSpec) SetDefaultsFrom(source RocksDBSpec) {
 	if s.Encryption.KeySecretName == nil {
 		s.Encryption.KeySecretName = util.NewStringOrNil(source.Encryption.KeySecretName)
 	}
+	if s.Encryption.Provider == nil {
+		s.Encryption.Provider = source.Encryption.Provider.DeepCopy()
+	}
+	if s.Encryption.Rotation == nil {
+		s.Encryption.Rotation = source.Encryption.Rotation.DeepCopy()
+	}
 }
 
 // ResetImmutableFields replaces all immutable fields in the given target with values from the source spec.
@@ -84,5 +110,10 @@ func (s RocksDBSpec) ResetImmutableFields(fieldPrefix string, target *RocksDBSpe
 		target.Encryption.KeySecretName = util.NewStringOrNil(s.Encryption.KeySecretName)
 		resetFields = append(resetFields, fieldPrefix+".encryption.keySecretName")
 	}
+	if s.Encryption.HasProvider() != target.Encryption.HasProvider() {
+		// Note: Keys wrapped by a provider cannot be used without it (and reverse).
+		target.Encryption.Provider = s.Encryption.Provider.DeepCopy()
+		resetFields = append(resetFields, fieldPrefix+".encryption.provider")
+	}
 	return resetFields
 }
@@ -38,6 +38,31 @@ func TestRocksDBSpecValidate(t *testing.T) {
 	assert.Error(t, RocksDBSpec{Encryption: RocksDBEncryptionSpec{KeySecretName: util.NewString("Foo")}}.Validate())
 }
 
+func TestRocksDBSpecProviderValidate(t *testing.T) {
+	provider := func(t RocksDBEncryptionProviderType, endpoint, key string) RocksDBSpec {
+		return RocksDBSpec{Encryption: RocksDBEncryptionSpec{
+			KeySecretName: util.NewString("foo"),
+			Provider: &RocksDBEncryptionProviderSpec{
+				Type:                  &t,
+				Endpoint:              util.NewString(endpoint),
+				KeyName:               util.NewString(key),
+				CredentialsSecretName: util.NewString("creds"),
+			},
+		}}
+	}
+
+	// Valid
+	assert.Nil(t, provider(RocksDBEncryptionProviderVault, "https://vault:8200", "arangodb").Validate())
+	assert.Nil(t, provider(RocksDBEncryptionProviderWebhook, "http://unwrap.kms.svc/unwrap", "").Validate())
+	assert.Nil(t, provider(RocksDBEncryptionProviderKMIP, "kmip:5696", "1234").Validate())
+
+	// Not valid
+	assert.Error(t, provider("Unknown", "https://vault:8200", "arangodb").Validate())
+	assert.Error(t, provider(RocksDBEncryptionProviderVault, "vault:8200", "arangodb").Validate())
+	assert.Error(t, provider(RocksDBEncryptionProviderVault, "https://vault:8200", "").Validate())
+	assert.Error(t, provider(RocksDBEncryptionProviderKMIP, "", "1234").Validate())
+}
+
 func TestRocksDBSpecIsEncrypted(t *testing.T) {
 	assert.False(t, RocksDBSpec{}.IsEncrypted())
 	assert.False(t, RocksDBSpec{Encryption: RocksDBEncryptionSpec{KeySecretName: util.NewString("")}}.IsEncrypted())
@@ -87,6 +112,12 @@ func TestRocksDBSpecResetImmutableFields(t *testing.T) {
 			RocksDBSpec{Encryption: RocksDBEncryptionSpec{KeySecretName: util.NewString("foo")}},
 			[]string{"test.encryption.keySecretName"},
 		},
+		{
+			RocksDBSpec{Encryption: RocksDBEncryptionSpec{KeySecretName: util.NewString("foo")}},
+			RocksDBSpec{Encryption: RocksDBEncryptionSpec{KeySecretName: util.NewString("foo"), Provider: &RocksDBEncryptionProviderSpec{}}},
+			RocksDBSpec{Encryption: RocksDBEncryptionSpec{KeySecretName: util.NewString("foo")}},
+			[]string{"test.encryption.provider"},
+		},
 	}
 
 	for _, test := range tests {
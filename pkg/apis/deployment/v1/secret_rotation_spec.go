@@ -0,0 +1,98 @@
+//
+// DISCLAIMER
+//
+// Copyright 2020 ArangoDB GmbH, Cologne, Germany
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// Copyright holder is ArangoDB GmbH, Cologne, Germany
+//
+
+package v1
+
+import (
+	"time"
+
+	"github.com/pkg/errors"
+	meta "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+const (
+	// DefaultSecretRotationGracePeriod defines how long replaced keys are kept by default
+	DefaultSecretRotationGracePeriod = Duration("24h")
+	// SecretRotationHistoryLimit defines how many keys are kept in the rotation history
+	SecretRotationHistoryLimit = 10
+)
+
+// SecretRotationSpec defines the schedule of the automatic key rotation
+type SecretRotationSpec struct {
+	// Interval between two key rotations
+	Interval *Duration `json:"interval,omitempty"`
+	// GracePeriod defines how long replaced keys are still accepted before they are removed
+	GracePeriod *Duration `json:"gracePeriod,omitempty"`
+}
+
+// IsEnabled returns true when the rotation is scheduled
+func (s *SecretRotationSpec) IsEnabled() bool {
+	return s.GetInterval() > 0
+}
+
+// GetInterval returns the interval between two key rotations
+func (s *SecretRotationSpec) GetInterval() time.Duration {
+	if s == nil {
+		return 0
+	}
+	return DurationOrDefault(s.Interval).AsDuration()
+}
+
+// GetGracePeriod returns how long replaced keys are kept
+func (s *SecretRotationSpec) GetGracePeriod() time.Duration {
+	if s == nil {
+		return 0
+	}
+	return DurationOrDefault(s.GracePeriod, DefaultSecretRotationGracePeriod).AsDuration()
+}
+
+// Validate the given spec
+func (s *SecretRotationSpec) Validate() error {
+	if s == nil {
+		return nil
+	}
+
+	if err := DurationOrDefault(s.Interval).Validate(); err != nil {
+		return maskAny(err)
+	}
+
+	if err := DurationOrDefault(s.GracePeriod).Validate(); err != nil {
+		return maskAny(err)
+	}
+
+	if s.GetInterval() <= 0 {
+		return maskAny(errors.Wrapf(ValidationError, "Rotation interval needs to be greater than 0"))
+	}
+
+	if s.GetGracePeriod() < 0 {
+		return maskAny(errors.Wrapf(ValidationError, "Rotation grace period cannot be negative"))
+	}
+
+	return nil
+}
+
+// IsRotationRequired returns true when the key rotated last at given time needs to be rotated
+func (s *SecretRotationSpec) IsRotationRequired(lastRotation meta.Time, now time.Time) bool {
+	if !s.IsEnabled() {
+		return false
+	}
+
+	return !lastRotation.Add(s.GetInterval()).After(now)
+}
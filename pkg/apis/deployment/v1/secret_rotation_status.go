@@ -0,0 +1,127 @@
+//
+// DISCLAIMER
+//
+// Copyright 2020 ArangoDB GmbH, Cologne, Germany
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// Copyright holder is ArangoDB GmbH, Cologne, Germany
+//
+
+package v1
+
+import (
+	"time"
+
+	meta "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// DeploymentStatusSecretRotation keeps the history of automatically rotated keys
+type DeploymentStatusSecretRotation struct {
+	// Source is the SHA of the user provided key, prefixed with "sha256:".
+	// Generated keys are used only as long as the user provided key does not change.
+	Source string `json:"source,omitempty"`
+	// LastRotation is the time of the last key rotation
+	LastRotation *meta.Time `json:"lastRotation,omitempty"`
+	// History of the keys, ordered from the oldest to the newest one
+	History []DeploymentStatusSecretRotationEntry `json:"history,omitempty"`
+}
+
+// DeploymentStatusSecretRotationEntry describes single key in the rotation history
+type DeploymentStatusSecretRotationEntry struct {
+	// SHA of the key, prefixed with "sha256:"
+	SHA string `json:"sha"`
+	// Created is the time when the key was generated
+	Created meta.Time `json:"created"`
+	// Replaced is the time when the key stopped being the active one
+	Replaced *meta.Time `json:"replaced,omitempty"`
+}
+
+// GetLastRotation returns the time of the last rotation, or given default when rotation did not happen yet
+func (s *DeploymentStatusSecretRotation) GetLastRotation(def meta.Time) meta.Time {
+	if s == nil || s.LastRotation == nil {
+		return def
+	}
+
+	return *s.LastRotation
+}
+
+// Get returns the history entry of the key with given sha
+func (s *DeploymentStatusSecretRotation) Get(sha string) (DeploymentStatusSecretRotationEntry, bool) {
+	if s == nil {
+		return DeploymentStatusSecretRotationEntry{}, false
+	}
+
+	for _, e := range s.History {
+		if e.SHA == sha {
+			return e, true
+		}
+	}
+
+	return DeploymentStatusSecretRotationEntry{}, false
+}
+
+// InGracePeriod returns true when the key with given sha has been replaced less than gracePeriod ago
+func (s *DeploymentStatusSecretRotation) InGracePeriod(sha string, gracePeriod time.Duration, now time.Time) bool {
+	e, ok := s.Get(sha)
+	if !ok || e.Replaced == nil {
+		return false
+	}
+
+	return e.Replaced.Add(gracePeriod).After(now)
+}
+
+// Active returns the SHA of the generated key which is currently active.
+// It returns false when no key was generated or when the user provided key changed since the last rotation.
+func (s *DeploymentStatusSecretRotation) Active(sourceSHA string) (string, bool) {
+	if s == nil || s.Source != sourceSHA {
+		return "", false
+	}
+
+	for id := len(s.History) - 1; id >= 0; id-- {
+		if e := s.History[id]; e.Replaced == nil && e.SHA != s.Source {
+			return e.SHA, true
+		}
+	}
+
+	return "", false
+}
+
+// Rotate records the rotation from the old key to the new key, generated while the user provided key was sourceSHA
+func (s *DeploymentStatusSecretRotation) Rotate(sourceSHA, oldSHA, newSHA string, now meta.Time) {
+	s.Source = sourceSHA
+
+	if _, ok := s.Get(oldSHA); !ok {
+		s.History = append(s.History, DeploymentStatusSecretRotationEntry{
+			SHA:     oldSHA,
+			Created: now,
+		})
+	}
+
+	for id := range s.History {
+		if s.History[id].Replaced == nil {
+			s.History[id].Replaced = now.DeepCopy()
+		}
+	}
+
+	s.History = append(s.History, DeploymentStatusSecretRotationEntry{
+		SHA:     newSHA,
+		Created: now,
+	})
+
+	if l := len(s.History); l > SecretRotationHistoryLimit {
+		s.History = s.History[l-SecretRotationHistoryLimit:]
+	}
+
+	s.LastRotation = now.DeepCopy()
+}
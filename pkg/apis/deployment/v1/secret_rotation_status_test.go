@@ -0,0 +1,87 @@
+//
+// DISCLAIMER
+//
+// Copyright 2020 ArangoDB GmbH, Cologne, Germany
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// Copyright holder is ArangoDB GmbH, Cologne, Germany
+//
+
+package v1
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	meta "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestDeploymentStatusSecretRotation(t *testing.T) {
+	var s *DeploymentStatusSecretRotation
+
+	created := meta.NewTime(time.Now().Add(-time.Hour))
+	assert.Equal(t, created, s.GetLastRotation(created))
+	assert.False(t, s.InGracePeriod("sha256:a", time.Hour, time.Now()))
+
+	_, ok := s.Active("sha256:a")
+	assert.False(t, ok)
+
+	s = &DeploymentStatusSecretRotation{}
+	now := meta.Now()
+	s.Rotate("sha256:a", "sha256:a", "sha256:b", now)
+
+	require.Len(t, s.History, 2)
+	assert.Equal(t, now, s.GetLastRotation(created))
+	assert.Equal(t, "sha256:a", s.History[0].SHA)
+	require.NotNil(t, s.History[0].Replaced)
+	assert.Equal(t, "sha256:b", s.History[1].SHA)
+	assert.Nil(t, s.History[1].Replaced)
+
+	assert.True(t, s.InGracePeriod("sha256:a", time.Hour, time.Now()))
+	assert.False(t, s.InGracePeriod("sha256:a", time.Hour, time.Now().Add(2*time.Hour)))
+	assert.False(t, s.InGracePeriod("sha256:b", time.Hour, time.Now()))
+
+	active, ok := s.Active("sha256:a")
+	assert.True(t, ok)
+	assert.Equal(t, "sha256:b", active)
+
+	// Generated key is not used anymore when the user provided key changes
+	_, ok = s.Active("sha256:c")
+	assert.False(t, ok)
+
+	for i := 0; i < SecretRotationHistoryLimit; i++ {
+		s.Rotate("sha256:a", s.History[len(s.History)-1].SHA, fmt.Sprintf("sha256:%d", i), now)
+	}
+
+	require.Len(t, s.History, SecretRotationHistoryLimit)
+	assert.Equal(t, fmt.Sprintf("sha256:%d", SecretRotationHistoryLimit-1), s.History[SecretRotationHistoryLimit-1].SHA)
+}
+
+func TestSecretRotationSpecValidate(t *testing.T) {
+	var s *SecretRotationSpec
+	assert.NoError(t, s.Validate())
+	assert.False(t, s.IsEnabled())
+
+	assert.NoError(t, (&SecretRotationSpec{Interval: NewDuration("720h")}).Validate())
+	assert.NoError(t, (&SecretRotationSpec{Interval: NewDuration("720h"), GracePeriod: NewDuration("0s")}).Validate())
+	assert.Error(t, (&SecretRotationSpec{}).Validate())
+	assert.Error(t, (&SecretRotationSpec{Interval: NewDuration("1month")}).Validate())
+	assert.Error(t, (&SecretRotationSpec{Interval: NewDuration("-1h")}).Validate())
+	assert.Error(t, (&SecretRotationSpec{Interval: NewDuration("1h"), GracePeriod: NewDuration("-1h")}).Validate())
+
+	assert.Equal(t, DefaultSecretRotationGracePeriod.AsDuration(), (&SecretRotationSpec{}).GetGracePeriod())
+}
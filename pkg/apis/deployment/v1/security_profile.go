@@ -0,0 +1,308 @@
+//
+// DISCLAIMER
+//
+// Copyright 2020 ArangoDB GmbH, Cologne, Germany
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// Copyright holder is ArangoDB GmbH, Cologne, Germany
+//
+
+package v1
+
+import (
+	"encoding/json"
+	"strings"
+
+	jsonpatch "github.com/evanphx/json-patch"
+	"github.com/pkg/errors"
+	core "k8s.io/api/core/v1"
+)
+
+// SecurityProfile defines the security profile which all containers of the deployment are rendered with
+// +kubebuilder:validation:Enum=none;restricted
+type SecurityProfile string
+
+const (
+	// SecurityProfileNone renders containers only with settings provided by the user
+	SecurityProfileNone SecurityProfile = "none"
+	// SecurityProfileRestricted renders containers compliant with the Pod Security Standards "restricted" profile
+	SecurityProfileRestricted SecurityProfile = "restricted"
+
+	// SecurityProfileRestrictedUser is the user and group used by containers when the profile is restricted
+	// and no user is set
+	SecurityProfileRestrictedUser int64 = 1000
+)
+
+// Get returns the security profile, none by default
+func (s *SecurityProfile) Get() SecurityProfile {
+	if s == nil || *s == "" {
+		return SecurityProfileNone // default
+	}
+
+	return *s
+}
+
+// New returns pointer to the security profile
+func (s SecurityProfile) New() *SecurityProfile {
+	return &s
+}
+
+// IsRestricted returns true when the restricted profile is selected
+func (s *SecurityProfile) IsRestricted() bool {
+	return s.Get() == SecurityProfileRestricted
+}
+
+// Validate the security profile
+func (s *SecurityProfile) Validate() error {
+	switch v := s.Get(); v {
+	case SecurityProfileNone, SecurityProfileRestricted:
+		return nil
+	default:
+		return errors.Wrapf(ValidationError, "Unknown security profile: %s", v)
+	}
+}
+
+// ValidateSecurityContext returns an error when the given settings conflict with the security profile
+func (s *SecurityProfile) ValidateSecurityContext(sc *ServerGroupSpecSecurityContext) error {
+	if !s.IsRestricted() || sc == nil {
+		return nil
+	}
+
+	if !sc.GetDropAllCapabilities() {
+		return errors.Wrapf(ValidationError, "dropAllCapabilities cannot be disabled in %s security profile", SecurityProfileRestricted)
+	}
+
+	return s.validate(sc.Privileged, sc.AllowPrivilegeEscalation, sc.RunAsNonRoot, sc.RunAsUser, sc.AddCapabilities)
+}
+
+// ValidateContainers returns an error when security context of any of the given containers conflicts
+// with the security profile
+func (s *SecurityProfile) ValidateContainers(containers []core.Container) error {
+	if !s.IsRestricted() {
+		return nil
+	}
+
+	for _, c := range containers {
+		sc := c.SecurityContext
+		if sc == nil {
+			continue
+		}
+
+		var add []core.Capability
+		if sc.Capabilities != nil {
+			add = sc.Capabilities.Add
+		}
+
+		if err := s.validate(sc.Privileged, sc.AllowPrivilegeEscalation, sc.RunAsNonRoot, sc.RunAsUser, add); err != nil {
+			return errors.Wrapf(err, "container %s", c.Name)
+		}
+	}
+
+	return nil
+}
+
+func (s *SecurityProfile) validate(privileged, allowPrivilegeEscalation, runAsNonRoot *bool, runAsUser *int64, add []core.Capability) error {
+	if privileged != nil && *privileged {
+		return errors.Wrapf(ValidationError, "privileged is not allowed in %s security profile", SecurityProfileRestricted)
+	}
+
+	if allowPrivilegeEscalation != nil && *allowPrivilegeEscalation {
+		return errors.Wrapf(ValidationError, "allowPrivilegeEscalation is not allowed in %s security profile", SecurityProfileRestricted)
+	}
+
+	if runAsNonRoot != nil && !*runAsNonRoot {
+		return errors.Wrapf(ValidationError, "runAsNonRoot cannot be disabled in %s security profile", SecurityProfileRestricted)
+	}
+
+	if runAsUser != nil && *runAsUser == 0 {
+		return errors.Wrapf(ValidationError, "running as root user is not allowed in %s security profile", SecurityProfileRestricted)
+	}
+
+	for _, c := range add {
+		if c != "NET_BIND_SERVICE" {
+			return errors.Wrapf(ValidationError, "capability %s is not allowed in %s security profile", c, SecurityProfileRestricted)
+		}
+	}
+
+	return nil
+}
+
+// ValidatePodTemplatePatch returns an error when the pod template patch can weaken the security profile.
+// Patch can not set privileged mode, root user, host namespaces, hostPath volumes or additional capabilities,
+// and it can not remove security settings rendered by the operator.
+func (s *SecurityProfile) ValidatePodTemplatePatch(p *ServerGroupPodTemplatePatch) error {
+	if !s.IsRestricted() || p.IsEmpty() {
+		return nil
+	}
+
+	data, err := p.AsJSON()
+	if err != nil {
+		return maskAny(err)
+	}
+
+	switch p.GetType() {
+	case ServerGroupPodTemplatePatchJSON:
+		patch, err := jsonpatch.DecodePatch(data)
+		if err != nil {
+			return maskAny(errors.Wrapf(err, "Invalid JSON patch"))
+		}
+
+		for _, op := range patch {
+			path, err := op.Path()
+			if err != nil {
+				return maskAny(err)
+			}
+
+			switch op.Kind() {
+			case "add", "replace":
+				var value interface{}
+				if v := op["value"]; v != nil {
+					if err := json.Unmarshal(*v, &value); err != nil {
+						return maskAny(err)
+					}
+				}
+
+				segments := strings.Split(path, "/")
+				if err := s.validatePatchValue(segments[:len(segments)-1], segments[len(segments)-1], value); err != nil {
+					return maskAny(err)
+				}
+			case "test":
+			default:
+				// Remove, move and copy can drop settings rendered by the operator
+				if strings.Contains(path, "securityContext") {
+					return errors.Wrapf(ValidationError, "%s of %s is not allowed in %s security profile", op.Kind(), path, SecurityProfileRestricted)
+				}
+			}
+		}
+	default:
+		var patch map[string]interface{}
+		if err := json.Unmarshal(data, &patch); err != nil {
+			return maskAny(errors.Wrapf(err, "Strategic merge patch needs to be an object"))
+		}
+
+		for key, value := range patch {
+			if err := s.validatePatchValue(nil, key, value); err != nil {
+				return maskAny(err)
+			}
+		}
+	}
+
+	return nil
+}
+
+// validatePatchValue checks recursively the value set by the patch under the given key
+func (s *SecurityProfile) validatePatchValue(parents []string, key string, value interface{}) error {
+	path := strings.Join(append(append([]string{}, parents...), key), "/")
+	inSecurityContext := key == "securityContext"
+	for _, p := range parents {
+		if p == "securityContext" {
+			inSecurityContext = true
+		}
+	}
+
+	forbidden := func() error {
+		return errors.Wrapf(ValidationError, "%s is not allowed in %s security profile", path, SecurityProfileRestricted)
+	}
+
+	if value == nil {
+		// Null removes the field in a strategic merge patch
+		if inSecurityContext {
+			return forbidden()
+		}
+		return nil
+	}
+
+	switch key {
+	case "privileged", "allowPrivilegeEscalation", "hostNetwork", "hostPID", "hostIPC":
+		if v, ok := value.(bool); !ok || v {
+			return forbidden()
+		}
+	case "runAsNonRoot":
+		if v, ok := value.(bool); !ok || !v {
+			return forbidden()
+		}
+	case "runAsUser":
+		if v, ok := value.(float64); !ok || v == 0 {
+			return forbidden()
+		}
+	case "hostPath":
+		return forbidden()
+	case "$patch", "$retainKeys":
+		if inSecurityContext {
+			return forbidden()
+		}
+	case "add":
+		if len(parents) > 0 && parents[len(parents)-1] == "capabilities" {
+			if !onlyCapabilities(value, "NET_BIND_SERVICE") {
+				return forbidden()
+			}
+			return nil
+		}
+	case "drop":
+		if len(parents) > 0 && parents[len(parents)-1] == "capabilities" {
+			if !containsCapability(value, "ALL") {
+				return forbidden()
+			}
+			return nil
+		}
+	}
+
+	switch v := value.(type) {
+	case map[string]interface{}:
+		next := append(append([]string{}, parents...), key)
+		for k, item := range v {
+			if err := s.validatePatchValue(next, k, item); err != nil {
+				return err
+			}
+		}
+	case []interface{}:
+		for _, item := range v {
+			if err := s.validatePatchValue(parents, key, item); err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+func onlyCapabilities(value interface{}, allowed string) bool {
+	list, ok := value.([]interface{})
+	if !ok {
+		return allowed == value
+	}
+
+	for _, item := range list {
+		if item != allowed {
+			return false
+		}
+	}
+
+	return true
+}
+
+func containsCapability(value interface{}, capability string) bool {
+	list, ok := value.([]interface{})
+	if !ok {
+		return capability == value
+	}
+
+	for _, item := range list {
+		if item == capability {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,142 @@
+//
+// DISCLAIMER
+//
+// Copyright 2020 ArangoDB GmbH, Cologne, Germany
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// Copyright holder is ArangoDB GmbH, Cologne, Germany
+//
+
+package v1
+
+import (
+	"testing"
+
+	"github.com/arangodb/kube-arangodb/pkg/util"
+	"github.com/stretchr/testify/require"
+	core "k8s.io/api/core/v1"
+)
+
+func TestSecurityProfile_Validate(t *testing.T) {
+	require.NoError(t, (*SecurityProfile)(nil).Validate())
+	require.NoError(t, SecurityProfileRestricted.New().Validate())
+	require.Error(t, SecurityProfile("unknown").New().Validate())
+}
+
+func TestSecurityProfile_ValidateSecurityContext(t *testing.T) {
+	restricted := SecurityProfileRestricted.New()
+
+	require.NoError(t, restricted.ValidateSecurityContext(nil))
+	require.NoError(t, restricted.ValidateSecurityContext(&ServerGroupSpecSecurityContext{
+		RunAsUser:       util.NewInt64(1001),
+		AddCapabilities: []core.Capability{"NET_BIND_SERVICE"},
+	}))
+
+	require.Error(t, restricted.ValidateSecurityContext(&ServerGroupSpecSecurityContext{Privileged: util.NewBool(true)}))
+	require.Error(t, restricted.ValidateSecurityContext(&ServerGroupSpecSecurityContext{AllowPrivilegeEscalation: util.NewBool(true)}))
+	require.Error(t, restricted.ValidateSecurityContext(&ServerGroupSpecSecurityContext{RunAsNonRoot: util.NewBool(false)}))
+	require.Error(t, restricted.ValidateSecurityContext(&ServerGroupSpecSecurityContext{RunAsUser: util.NewInt64(0)}))
+	require.Error(t, restricted.ValidateSecurityContext(&ServerGroupSpecSecurityContext{DropAllCapabilities: util.NewBool(false)}))
+	require.Error(t, restricted.ValidateSecurityContext(&ServerGroupSpecSecurityContext{AddCapabilities: []core.Capability{"SYS_ADMIN"}}))
+
+	// Settings are not validated without the restricted profile
+	require.NoError(t, SecurityProfileNone.New().ValidateSecurityContext(&ServerGroupSpecSecurityContext{Privileged: util.NewBool(true)}))
+}
+
+func TestSecurityProfile_ValidateContainers(t *testing.T) {
+	restricted := SecurityProfileRestricted.New()
+
+	require.NoError(t, restricted.ValidateContainers([]core.Container{{Name: "sidecar"}}))
+	require.Error(t, restricted.ValidateContainers([]core.Container{
+		{
+			Name: "sidecar",
+			SecurityContext: &core.SecurityContext{
+				Capabilities: &core.Capabilities{
+					Add: []core.Capability{"NET_ADMIN"},
+				},
+			},
+		},
+	}))
+}
+
+func TestDeploymentSpec_ValidateSecurityProfile(t *testing.T) {
+	s := DeploymentSpec{
+		SecurityProfile: SecurityProfileRestricted.New(),
+		DBServers: ServerGroupSpec{
+			Sidecars: []core.Container{
+				{
+					Name: "sidecar",
+					SecurityContext: &core.SecurityContext{
+						Privileged: util.NewBool(true),
+					},
+				},
+			},
+		},
+	}
+
+	require.Error(t, s.validateSecurityProfile())
+
+	s.DBServers.Sidecars[0].SecurityContext = nil
+	require.NoError(t, s.validateSecurityProfile())
+}
+
+func TestSecurityProfile_ValidatePodTemplatePatch(t *testing.T) {
+	restricted := SecurityProfileRestricted.New()
+
+	strategic := func(patch string) *ServerGroupPodTemplatePatch {
+		return &ServerGroupPodTemplatePatch{Patch: patch}
+	}
+	jsonPatch := func(patch string) *ServerGroupPodTemplatePatch {
+		return &ServerGroupPodTemplatePatch{Type: ServerGroupPodTemplatePatchJSON.New(), Patch: patch}
+	}
+
+	require.NoError(t, restricted.ValidatePodTemplatePatch(nil))
+	require.NoError(t, restricted.ValidatePodTemplatePatch(strategic(`
+metadata:
+  labels:
+    team: db
+spec:
+  priorityClassName: high
+  containers:
+    - name: server
+      securityContext:
+        runAsUser: 1001
+        capabilities:
+          add: ["NET_BIND_SERVICE"]
+          drop: ["ALL"]
+`)))
+	require.NoError(t, restricted.ValidatePodTemplatePatch(jsonPatch(`[{"op": "add", "path": "/spec/priorityClassName", "value": "high"}]`)))
+
+	for name, patch := range map[string]*ServerGroupPodTemplatePatch{
+		"privileged":       strategic(`{"spec": {"containers": [{"name": "server", "securityContext": {"privileged": true}}]}}`),
+		"root user":        strategic(`{"spec": {"securityContext": {"runAsUser": 0}}}`),
+		"root allowed":     strategic(`{"spec": {"containers": [{"name": "server", "securityContext": {"runAsNonRoot": false}}]}}`),
+		"host network":     strategic(`{"spec": {"hostNetwork": true}}`),
+		"hostPath":         strategic(`{"spec": {"volumes": [{"name": "host", "hostPath": {"path": "/"}}]}}`),
+		"capability":       strategic(`{"spec": {"containers": [{"name": "server", "securityContext": {"capabilities": {"add": ["SYS_ADMIN"]}}}]}}`),
+		"drop all removed": strategic(`{"spec": {"containers": [{"name": "server", "securityContext": {"capabilities": {"drop": []}}}]}}`),
+		"null context":     strategic(`{"spec": {"containers": [{"name": "server", "securityContext": null}]}}`),
+		"json privileged":  jsonPatch(`[{"op": "add", "path": "/spec/containers/0/securityContext/privileged", "value": true}]`),
+		"json hostPath":    jsonPatch(`[{"op": "add", "path": "/spec/volumes/-", "value": {"name": "host", "hostPath": {"path": "/"}}}]`),
+		"json root user":   jsonPatch(`[{"op": "replace", "path": "/spec/containers/0/securityContext/runAsUser", "value": 0}]`),
+		"json remove":      jsonPatch(`[{"op": "remove", "path": "/spec/containers/0/securityContext"}]`),
+	} {
+		t.Run(name, func(t *testing.T) {
+			require.Error(t, restricted.ValidatePodTemplatePatch(patch))
+
+			// Patches are not validated without the restricted profile
+			require.NoError(t, SecurityProfileNone.New().ValidatePodTemplatePatch(patch))
+		})
+	}
+}
@@ -51,6 +51,7 @@ func ValidateServerGroupInitContainerName(name string) error {
 	return sharedv1.AsKubernetesResourceName(&name).Validate()
 }
 
+// +kubebuilder:validation:Enum=ignore;update
 type ServerGroupInitContainerMode string
 
 func (s *ServerGroupInitContainerMode) Get() ServerGroupInitContainerMode {
@@ -0,0 +1,135 @@
+//
+// DISCLAIMER
+//
+// Copyright 2020 ArangoDB GmbH, Cologne, Germany
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// Copyright holder is ArangoDB GmbH, Cologne, Germany
+//
+
+package v1
+
+import (
+	"encoding/json"
+
+	jsonpatch "github.com/evanphx/json-patch"
+	"github.com/ghodss/yaml"
+	"github.com/pkg/errors"
+)
+
+// ServerGroupPodTemplatePatchType defines how the pod template patch is applied
+// +kubebuilder:validation:Enum=strategic;json
+type ServerGroupPodTemplatePatchType string
+
+const (
+	// ServerGroupPodTemplatePatchStrategicMerge applies the patch as a strategic merge patch of the Pod
+	ServerGroupPodTemplatePatchStrategicMerge ServerGroupPodTemplatePatchType = "strategic"
+	// ServerGroupPodTemplatePatchJSON applies the patch as a JSON patch (RFC 6902) of the Pod
+	ServerGroupPodTemplatePatchJSON ServerGroupPodTemplatePatchType = "json"
+)
+
+// Get returns the patch type, strategic merge by default
+func (s *ServerGroupPodTemplatePatchType) Get() ServerGroupPodTemplatePatchType {
+	if s == nil {
+		return ServerGroupPodTemplatePatchStrategicMerge // default
+	}
+
+	return *s
+}
+
+// New returns pointer to the patch type
+func (s ServerGroupPodTemplatePatchType) New() *ServerGroupPodTemplatePatchType {
+	return &s
+}
+
+// Validate the patch type
+func (s *ServerGroupPodTemplatePatchType) Validate() error {
+	switch v := s.Get(); v {
+	case ServerGroupPodTemplatePatchStrategicMerge, ServerGroupPodTemplatePatchJSON:
+		return nil
+	default:
+		return errors.Errorf("Unknown pod template patch type %s", v)
+	}
+}
+
+// ServerGroupPodTemplatePatch defines a patch applied to the rendered Pod of each member of the group.
+// The patch is applied as the last step of Pod rendering, so it can override any field set by the operator.
+type ServerGroupPodTemplatePatch struct {
+	// Type of the patch, strategic (default) or json
+	Type *ServerGroupPodTemplatePatchType `json:"type,omitempty"`
+	// Patch content in JSON or YAML format
+	Patch string `json:"patch,omitempty"`
+}
+
+// GetType returns the type of the patch
+func (s *ServerGroupPodTemplatePatch) GetType() ServerGroupPodTemplatePatchType {
+	if s == nil {
+		return ServerGroupPodTemplatePatchStrategicMerge
+	}
+
+	return s.Type.Get()
+}
+
+// IsEmpty returns true when there is nothing to apply
+func (s *ServerGroupPodTemplatePatch) IsEmpty() bool {
+	return s == nil || s.Patch == ""
+}
+
+// AsJSON returns the patch content in JSON format
+func (s *ServerGroupPodTemplatePatch) AsJSON() ([]byte, error) {
+	if s.IsEmpty() {
+		return nil, nil
+	}
+
+	data, err := yaml.YAMLToJSON([]byte(s.Patch))
+	if err != nil {
+		return nil, errors.Wrapf(err, "Unable to parse patch")
+	}
+
+	return data, nil
+}
+
+// Validate the pod template patch
+func (s *ServerGroupPodTemplatePatch) Validate() error {
+	if s == nil {
+		return nil
+	}
+
+	if err := s.Type.Validate(); err != nil {
+		return maskAny(err)
+	}
+
+	data, err := s.AsJSON()
+	if err != nil {
+		return maskAny(err)
+	}
+
+	if data == nil {
+		return nil
+	}
+
+	switch s.GetType() {
+	case ServerGroupPodTemplatePatchJSON:
+		if _, err := jsonpatch.DecodePatch(data); err != nil {
+			return maskAny(errors.Wrapf(err, "Invalid JSON patch"))
+		}
+	default:
+		var patch map[string]interface{}
+		if err := json.Unmarshal(data, &patch); err != nil {
+			return maskAny(errors.Wrapf(err, "Strategic merge patch needs to be an object"))
+		}
+	}
+
+	return nil
+}
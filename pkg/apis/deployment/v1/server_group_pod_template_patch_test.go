@@ -0,0 +1,42 @@
+//
+// DISCLAIMER
+//
+// Copyright 2020 ArangoDB GmbH, Cologne, Germany
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// Copyright holder is ArangoDB GmbH, Cologne, Germany
+//
+
+package v1
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestServerGroupPodTemplatePatchValidate(t *testing.T) {
+	var empty *ServerGroupPodTemplatePatch
+	assert.NoError(t, empty.Validate())
+	assert.True(t, empty.IsEmpty())
+
+	assert.NoError(t, (&ServerGroupPodTemplatePatch{Patch: "spec:\n  priorityClassName: high\n"}).Validate())
+	assert.NoError(t, (&ServerGroupPodTemplatePatch{Patch: `{"spec": {"priorityClassName": "high"}}`}).Validate())
+	assert.NoError(t, (&ServerGroupPodTemplatePatch{Type: ServerGroupPodTemplatePatchJSON.New(), Patch: `[{"op": "add", "path": "/spec/priorityClassName", "value": "high"}]`}).Validate())
+
+	assert.Error(t, (&ServerGroupPodTemplatePatch{Type: ServerGroupPodTemplatePatchType("merge").New()}).Validate())
+	assert.Error(t, (&ServerGroupPodTemplatePatch{Patch: "- a\n- b\n"}).Validate())
+	assert.Error(t, (&ServerGroupPodTemplatePatch{Type: ServerGroupPodTemplatePatchJSON.New(), Patch: `{"spec": {}}`}).Validate())
+	assert.Error(t, (&ServerGroupPodTemplatePatch{Patch: "spec: [\n"}).Validate())
+}
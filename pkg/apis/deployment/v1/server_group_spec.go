@@ -41,10 +41,13 @@ import (
 // ServerGroupSpec contains the specification for all servers in a specific group (e.g. all agents)
 type ServerGroupSpec struct {
 	// Count holds the requested number of servers
+	// +kubebuilder:validation:Minimum=0
 	Count *int `json:"count,omitempty"`
 	// MinCount specifies a lower limit for count
+	// +kubebuilder:validation:Minimum=0
 	MinCount *int `json:"minCount,omitempty"`
 	// MaxCount specifies a upper limit for count
+	// +kubebuilder:validation:Minimum=0
 	MaxCount *int `json:"maxCount,omitempty"`
 	// Args holds additional commandline arguments
 	Args []string `json:"args,omitempty"`
@@ -93,6 +96,9 @@ type ServerGroupSpec struct {
 	Affinity *core.PodAffinity `json:"affinity,omitempty"`
 	// NodeAffinity specified additional nodeAffinity settings in ArangoDB Pod definitions
 	NodeAffinity *core.NodeAffinity `json:"nodeAffinity,omitempty"`
+	// TopologySpreadConstraints specifies how Pods in this group are spread across topology domains.
+	// When LabelSelector is not set, Pods of this group are selected.
+	TopologySpreadConstraints ServerGroupTopologySpreadConstraints `json:"topologySpreadConstraints,omitempty"`
 	// Sidecars specifies a list of additional containers to be started
 	Sidecars []core.Container `json:"sidecars,omitempty"`
 	// SecurityContext specifies security context for group
@@ -105,6 +111,12 @@ type ServerGroupSpec struct {
 	ExtendedRotationCheck *bool `json:"extendedRotationCheck,omitempty"`
 	// InitContainers Init containers specification
 	InitContainers *ServerGroupInitContainers `json:"initContainers,omitempty"`
+	// PodTemplatePatch is applied to the rendered Pod of each member as the last step.
+	// Patches weakening the pod security are rejected in the restricted security profile.
+	PodTemplatePatch *ServerGroupPodTemplatePatch `json:"podTemplatePatch,omitempty"`
+	// MaxUnavailable is the number of members rotated or upgraded at the same time.
+	// Values greater than 1 are allowed only for coordinators, syncmasters and syncworkers.
+	MaxUnavailable *int `json:"maxUnavailable,omitempty"`
 }
 
 // ServerGroupSpecSecurityContext contains specification for pod security context
@@ -319,6 +331,11 @@ func (s ServerGroupSpec) GetCount() int {
 	return util.IntOrDefault(s.Count)
 }
 
+// GetMaxUnavailable returns MaxUnavailable or 1 if not set
+func (s ServerGroupSpec) GetMaxUnavailable() int {
+	return util.IntOrDefault(s.MaxUnavailable, 1)
+}
+
 // GetMinCount returns MinCount or 1 if not set
 func (s ServerGroupSpec) GetMinCount() int {
 	return util.IntOrDefault(s.MinCount, 1)
@@ -435,6 +452,12 @@ func (s ServerGroupSpec) Validate(group ServerGroup, used bool, mode DeploymentM
 		if s.GetCount() > 1 && group == ServerGroupSingle && mode == DeploymentModeSingle {
 			return maskAny(errors.Wrapf(ValidationError, "Invalid count value %d. Expected 1", s.GetCount()))
 		}
+		if s.GetMaxUnavailable() < 1 {
+			return maskAny(errors.Wrapf(ValidationError, "Invalid maxUnavailable value %d. Expected >= 1", s.GetMaxUnavailable()))
+		}
+		if s.GetMaxUnavailable() > 1 && !group.IsStateless() {
+			return maskAny(errors.Wrapf(ValidationError, "Invalid maxUnavailable value %d. Expected 1 for stateful group", s.GetMaxUnavailable()))
+		}
 		if name := s.GetServiceAccountName(); name != "" {
 			if err := k8sutil.ValidateOptionalResourceName(name); err != nil {
 				return maskAny(errors.Wrapf(ValidationError, "Invalid serviceAccountName: %s", err))
@@ -477,10 +500,22 @@ func (s *ServerGroupSpec) validate() error {
 		shared.PrefixResourceError("volumes", s.Volumes.Validate()),
 		shared.PrefixResourceError("volumeMounts", s.VolumeMounts.Validate()),
 		shared.PrefixResourceError("initContainers", s.InitContainers.Validate()),
+		shared.PrefixResourceError("podTemplatePatch", s.PodTemplatePatch.Validate()),
+		shared.PrefixResourceError("topologySpreadConstraints", s.TopologySpreadConstraints.Validate()),
 		s.validateVolumes(),
 	)
 }
 
+// ValidateSecurityProfile returns an error when the group settings conflict with the security profile
+func (s ServerGroupSpec) ValidateSecurityProfile(profile *SecurityProfile) error {
+	return shared.WithErrors(
+		shared.PrefixResourceError("securityContext", profile.ValidateSecurityContext(s.SecurityContext)),
+		shared.PrefixResourceError("sidecars", profile.ValidateContainers(s.GetSidecars())),
+		shared.PrefixResourceError("initContainers", profile.ValidateContainers(s.InitContainers.GetContainers())),
+		shared.PrefixResourceError("podTemplatePatch", profile.ValidatePodTemplatePatch(s.PodTemplatePatch)),
+	)
+}
+
 func (s *ServerGroupSpec) validateVolumes() error {
 	volumes := map[string]bool{}
 
@@ -579,6 +614,9 @@ func (s *ServerGroupSpec) SetDefaultsFrom(source ServerGroupSpec) {
 	if s.MaxCount == nil {
 		s.MaxCount = util.NewIntOrNil(source.MaxCount)
 	}
+	if s.MaxUnavailable == nil {
+		s.MaxUnavailable = util.NewIntOrNil(source.MaxUnavailable)
+	}
 	if s.Args == nil {
 		s.Args = source.Args
 	}
@@ -594,11 +632,17 @@ func (s *ServerGroupSpec) SetDefaultsFrom(source ServerGroupSpec) {
 	if s.NodeSelector == nil {
 		s.NodeSelector = source.NodeSelector
 	}
+	if s.TopologySpreadConstraints == nil {
+		s.TopologySpreadConstraints = source.TopologySpreadConstraints
+	}
 	setDefaultsFromResourceList(&s.Resources.Limits, source.Resources.Limits)
 	setDefaultsFromResourceList(&s.Resources.Requests, source.Resources.Requests)
 	if s.VolumeClaimTemplate == nil {
 		s.VolumeClaimTemplate = source.VolumeClaimTemplate.DeepCopy()
 	}
+	if s.PodTemplatePatch == nil {
+		s.PodTemplatePatch = source.PodTemplatePatch.DeepCopy()
+	}
 }
 
 // ResetImmutableFields replaces all immutable fields in the given target with values from the source spec.
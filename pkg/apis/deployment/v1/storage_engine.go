@@ -29,6 +29,7 @@ import (
 )
 
 // StorageEngine specifies the type of storage engine used by the cluster
+// +kubebuilder:validation:Enum=MMFiles;RocksDB
 type StorageEngine string
 
 const (
@@ -69,6 +69,9 @@ func (s SyncSpec) Validate(mode DeploymentMode) error {
 		if err := s.TLS.Validate(); err != nil {
 			return maskAny(err)
 		}
+		if s.TLS.Issuer != nil {
+			return maskAny(errors.Wrapf(ValidationError, "Issuer is not supported for sync TLS"))
+		}
 	}
 	if err := s.Monitoring.Validate(); err != nil {
 		return maskAny(err)
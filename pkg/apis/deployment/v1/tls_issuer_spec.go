@@ -0,0 +1,93 @@
+//
+// DISCLAIMER
+//
+// Copyright 2020 ArangoDB GmbH, Cologne, Germany
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// Copyright holder is ArangoDB GmbH, Cologne, Germany
+//
+
+package v1
+
+import (
+	"github.com/arangodb/kube-arangodb/pkg/util"
+	"github.com/arangodb/kube-arangodb/pkg/util/k8sutil"
+	"github.com/pkg/errors"
+)
+
+// TLSIssuerKind is the kind of the cert-manager issuer
+type TLSIssuerKind string
+
+const (
+	// TLSIssuerKindIssuer is a namespaced cert-manager issuer
+	TLSIssuerKindIssuer TLSIssuerKind = "Issuer"
+	// TLSIssuerKindClusterIssuer is a cluster wide cert-manager issuer
+	TLSIssuerKindClusterIssuer TLSIssuerKind = "ClusterIssuer"
+
+	// DefaultTLSIssuerGroup is the API group of cert-manager issuers
+	DefaultTLSIssuerGroup = "cert-manager.io"
+)
+
+// TLSIssuerSpec holds the reference to the cert-manager issuer which signs member certificates
+type TLSIssuerSpec struct {
+	// Name of the Issuer or ClusterIssuer
+	Name *string `json:"name,omitempty"`
+	// Kind of the issuer, Issuer (default) or ClusterIssuer
+	Kind *TLSIssuerKind `json:"kind,omitempty"`
+	// Group of the issuer, cert-manager.io by default
+	Group *string `json:"group,omitempty"`
+}
+
+// GetName returns the name of the issuer
+func (s *TLSIssuerSpec) GetName() string {
+	if s == nil {
+		return ""
+	}
+	return util.StringOrDefault(s.Name)
+}
+
+// GetKind returns the kind of the issuer
+func (s *TLSIssuerSpec) GetKind() TLSIssuerKind {
+	if s == nil || s.Kind == nil {
+		return TLSIssuerKindIssuer
+	}
+	return *s.Kind
+}
+
+// GetGroup returns the API group of the issuer
+func (s *TLSIssuerSpec) GetGroup() string {
+	if s == nil {
+		return DefaultTLSIssuerGroup
+	}
+	return util.StringOrDefault(s.Group, DefaultTLSIssuerGroup)
+}
+
+// Validate the given spec
+func (s *TLSIssuerSpec) Validate() error {
+	if s == nil {
+		return nil
+	}
+
+	if err := k8sutil.ValidateResourceName(s.GetName()); err != nil {
+		return maskAny(errors.Wrapf(err, "Invalid issuer name"))
+	}
+
+	switch s.GetKind() {
+	case TLSIssuerKindIssuer, TLSIssuerKindClusterIssuer:
+	default:
+		return maskAny(errors.Errorf("Issuer kind %s is not supported", s.GetKind()))
+	}
+
+	return nil
+}
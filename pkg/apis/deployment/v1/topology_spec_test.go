@@ -0,0 +1,65 @@
+//
+// DISCLAIMER
+//
+// Copyright 2020 ArangoDB GmbH, Cologne, Germany
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// Copyright holder is ArangoDB GmbH, Cologne, Germany
+//
+
+package v1
+
+import (
+	"testing"
+
+	"github.com/arangodb/kube-arangodb/pkg/util"
+	"github.com/stretchr/testify/assert"
+	core "k8s.io/api/core/v1"
+)
+
+func TestTopologySpec(t *testing.T) {
+	var s *TopologySpec
+	assert.False(t, s.IsEnabled())
+	assert.Equal(t, DefaultTopologyZoneLabel, s.GetZoneLabel())
+	assert.NoError(t, s.Validate())
+
+	s = &TopologySpec{Enabled: util.NewBool(true), ZoneLabel: util.NewString("example.com/zone")}
+	assert.True(t, s.IsEnabled())
+	assert.Equal(t, "example.com/zone", s.GetZoneLabel())
+	assert.NoError(t, s.Validate())
+
+	s.ZoneLabel = util.NewString("")
+	assert.Error(t, s.Validate())
+}
+
+func TestServerGroupTopologySpreadConstraintsValidate(t *testing.T) {
+	valid := core.TopologySpreadConstraint{
+		MaxSkew:           1,
+		TopologyKey:       DefaultTopologyZoneLabel,
+		WhenUnsatisfiable: core.DoNotSchedule,
+	}
+	assert.NoError(t, ServerGroupTopologySpreadConstraints{valid}.Validate())
+
+	c := valid
+	c.MaxSkew = 0
+	assert.Error(t, ServerGroupTopologySpreadConstraints{valid, c}.Validate())
+
+	c = valid
+	c.TopologyKey = ""
+	assert.Error(t, ServerGroupTopologySpreadConstraints{c}.Validate())
+
+	c = valid
+	c.WhenUnsatisfiable = "Unknown"
+	assert.Error(t, ServerGroupTopologySpreadConstraints{c}.Validate())
+}
@@ -29,6 +29,7 @@ import (
 
 	sharedv1 "github.com/arangodb/kube-arangodb/pkg/apis/shared/v1"
 	corev1 "k8s.io/api/core/v1"
+	networkingv1 "k8s.io/api/networking/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	runtime "k8s.io/apimachinery/pkg/runtime"
 )
@@ -61,6 +62,469 @@ func (in *Action) DeepCopy() *Action {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ArangoCollection) DeepCopyInto(out *ArangoCollection) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ArangoCollection.
+func (in *ArangoCollection) DeepCopy() *ArangoCollection {
+	if in == nil {
+		return nil
+	}
+	out := new(ArangoCollection)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *ArangoCollection) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ArangoCollectionAnalyzer) DeepCopyInto(out *ArangoCollectionAnalyzer) {
+	*out = *in
+	if in.Features != nil {
+		in, out := &in.Features, &out.Features
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Locale != nil {
+		in, out := &in.Locale, &out.Locale
+		*out = new(string)
+		**out = **in
+	}
+	if in.Delimiter != nil {
+		in, out := &in.Delimiter, &out.Delimiter
+		*out = new(string)
+		**out = **in
+	}
+	if in.Accent != nil {
+		in, out := &in.Accent, &out.Accent
+		*out = new(bool)
+		**out = **in
+	}
+	if in.Case != nil {
+		in, out := &in.Case, &out.Case
+		*out = new(string)
+		**out = **in
+	}
+	if in.Min != nil {
+		in, out := &in.Min, &out.Min
+		*out = new(int64)
+		**out = **in
+	}
+	if in.Max != nil {
+		in, out := &in.Max, &out.Max
+		*out = new(int64)
+		**out = **in
+	}
+	if in.PreserveOriginal != nil {
+		in, out := &in.PreserveOriginal, &out.PreserveOriginal
+		*out = new(bool)
+		**out = **in
+	}
+	if in.Stemming != nil {
+		in, out := &in.Stemming, &out.Stemming
+		*out = new(bool)
+		**out = **in
+	}
+	if in.Stopwords != nil {
+		in, out := &in.Stopwords, &out.Stopwords
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ArangoCollectionAnalyzer.
+func (in *ArangoCollectionAnalyzer) DeepCopy() *ArangoCollectionAnalyzer {
+	if in == nil {
+		return nil
+	}
+	out := new(ArangoCollectionAnalyzer)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ArangoCollectionIndex) DeepCopyInto(out *ArangoCollectionIndex) {
+	*out = *in
+	if in.Fields != nil {
+		in, out := &in.Fields, &out.Fields
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Unique != nil {
+		in, out := &in.Unique, &out.Unique
+		*out = new(bool)
+		**out = **in
+	}
+	if in.Sparse != nil {
+		in, out := &in.Sparse, &out.Sparse
+		*out = new(bool)
+		**out = **in
+	}
+	if in.GeoJSON != nil {
+		in, out := &in.GeoJSON, &out.GeoJSON
+		*out = new(bool)
+		**out = **in
+	}
+	if in.MinLength != nil {
+		in, out := &in.MinLength, &out.MinLength
+		*out = new(int)
+		**out = **in
+	}
+	if in.ExpireAfter != nil {
+		in, out := &in.ExpireAfter, &out.ExpireAfter
+		*out = new(int)
+		**out = **in
+	}
+	if in.InBackground != nil {
+		in, out := &in.InBackground, &out.InBackground
+		*out = new(bool)
+		**out = **in
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ArangoCollectionIndex.
+func (in *ArangoCollectionIndex) DeepCopy() *ArangoCollectionIndex {
+	if in == nil {
+		return nil
+	}
+	out := new(ArangoCollectionIndex)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ArangoCollectionList) DeepCopyInto(out *ArangoCollectionList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]ArangoCollection, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ArangoCollectionList.
+func (in *ArangoCollectionList) DeepCopy() *ArangoCollectionList {
+	if in == nil {
+		return nil
+	}
+	out := new(ArangoCollectionList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *ArangoCollectionList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ArangoCollectionSpec) DeepCopyInto(out *ArangoCollectionSpec) {
+	*out = *in
+	if in.Name != nil {
+		in, out := &in.Name, &out.Name
+		*out = new(string)
+		**out = **in
+	}
+	if in.Type != nil {
+		in, out := &in.Type, &out.Type
+		*out = new(ArangoCollectionType)
+		**out = **in
+	}
+	if in.NumberOfShards != nil {
+		in, out := &in.NumberOfShards, &out.NumberOfShards
+		*out = new(int)
+		**out = **in
+	}
+	if in.ShardKeys != nil {
+		in, out := &in.ShardKeys, &out.ShardKeys
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.ReplicationFactor != nil {
+		in, out := &in.ReplicationFactor, &out.ReplicationFactor
+		*out = new(int)
+		**out = **in
+	}
+	if in.WriteConcern != nil {
+		in, out := &in.WriteConcern, &out.WriteConcern
+		*out = new(int)
+		**out = **in
+	}
+	if in.WaitForSync != nil {
+		in, out := &in.WaitForSync, &out.WaitForSync
+		*out = new(bool)
+		**out = **in
+	}
+	if in.Indexes != nil {
+		in, out := &in.Indexes, &out.Indexes
+		*out = make([]ArangoCollectionIndex, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.Analyzers != nil {
+		in, out := &in.Analyzers, &out.Analyzers
+		*out = make([]ArangoCollectionAnalyzer, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.Views != nil {
+		in, out := &in.Views, &out.Views
+		*out = make([]ArangoCollectionView, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.DropOnDelete != nil {
+		in, out := &in.DropOnDelete, &out.DropOnDelete
+		*out = new(bool)
+		**out = **in
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ArangoCollectionSpec.
+func (in *ArangoCollectionSpec) DeepCopy() *ArangoCollectionSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(ArangoCollectionSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ArangoCollectionStatus) DeepCopyInto(out *ArangoCollectionStatus) {
+	*out = *in
+	if in.Indexes != nil {
+		in, out := &in.Indexes, &out.Indexes
+		*out = make([]ArangoCollectionIndex, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.Views != nil {
+		in, out := &in.Views, &out.Views
+		*out = make([]ArangoCollectionView, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.Drift != nil {
+		in, out := &in.Drift, &out.Drift
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make(ConditionList, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ArangoCollectionStatus.
+func (in *ArangoCollectionStatus) DeepCopy() *ArangoCollectionStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(ArangoCollectionStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ArangoCollectionView) DeepCopyInto(out *ArangoCollectionView) {
+	*out = *in
+	if in.Analyzers != nil {
+		in, out := &in.Analyzers, &out.Analyzers
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.IncludeAllFields != nil {
+		in, out := &in.IncludeAllFields, &out.IncludeAllFields
+		*out = new(bool)
+		**out = **in
+	}
+	if in.TrackListPositions != nil {
+		in, out := &in.TrackListPositions, &out.TrackListPositions
+		*out = new(bool)
+		**out = **in
+	}
+	if in.StoreValues != nil {
+		in, out := &in.StoreValues, &out.StoreValues
+		*out = new(string)
+		**out = **in
+	}
+	if in.Fields != nil {
+		in, out := &in.Fields, &out.Fields
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ArangoCollectionView.
+func (in *ArangoCollectionView) DeepCopy() *ArangoCollectionView {
+	if in == nil {
+		return nil
+	}
+	out := new(ArangoCollectionView)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ArangoDatabase) DeepCopyInto(out *ArangoDatabase) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ArangoDatabase.
+func (in *ArangoDatabase) DeepCopy() *ArangoDatabase {
+	if in == nil {
+		return nil
+	}
+	out := new(ArangoDatabase)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *ArangoDatabase) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ArangoDatabaseList) DeepCopyInto(out *ArangoDatabaseList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]ArangoDatabase, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ArangoDatabaseList.
+func (in *ArangoDatabaseList) DeepCopy() *ArangoDatabaseList {
+	if in == nil {
+		return nil
+	}
+	out := new(ArangoDatabaseList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *ArangoDatabaseList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ArangoDatabaseSpec) DeepCopyInto(out *ArangoDatabaseSpec) {
+	*out = *in
+	if in.Name != nil {
+		in, out := &in.Name, &out.Name
+		*out = new(string)
+		**out = **in
+	}
+	if in.ReplicationFactor != nil {
+		in, out := &in.ReplicationFactor, &out.ReplicationFactor
+		*out = new(int)
+		**out = **in
+	}
+	if in.WriteConcern != nil {
+		in, out := &in.WriteConcern, &out.WriteConcern
+		*out = new(int)
+		**out = **in
+	}
+	if in.Sharding != nil {
+		in, out := &in.Sharding, &out.Sharding
+		*out = new(string)
+		**out = **in
+	}
+	if in.DropOnDelete != nil {
+		in, out := &in.DropOnDelete, &out.DropOnDelete
+		*out = new(bool)
+		**out = **in
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ArangoDatabaseSpec.
+func (in *ArangoDatabaseSpec) DeepCopy() *ArangoDatabaseSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(ArangoDatabaseSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ArangoDatabaseStatus) DeepCopyInto(out *ArangoDatabaseStatus) {
+	*out = *in
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make(ConditionList, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ArangoDatabaseStatus.
+func (in *ArangoDatabaseStatus) DeepCopy() *ArangoDatabaseStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(ArangoDatabaseStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ArangoDeployment) DeepCopyInto(out *ArangoDeployment) {
 	*out = *in
@@ -71,18 +535,100 @@ func (in *ArangoDeployment) DeepCopyInto(out *ArangoDeployment) {
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ArangoDeployment.
-func (in *ArangoDeployment) DeepCopy() *ArangoDeployment {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ArangoDeployment.
+func (in *ArangoDeployment) DeepCopy() *ArangoDeployment {
+	if in == nil {
+		return nil
+	}
+	out := new(ArangoDeployment)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *ArangoDeployment) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ArangoDeploymentList) DeepCopyInto(out *ArangoDeploymentList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]ArangoDeployment, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ArangoDeploymentList.
+func (in *ArangoDeploymentList) DeepCopy() *ArangoDeploymentList {
+	if in == nil {
+		return nil
+	}
+	out := new(ArangoDeploymentList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *ArangoDeploymentList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ArangoDeploymentRecoverySpec) DeepCopyInto(out *ArangoDeploymentRecoverySpec) {
+	*out = *in
+	if in.AutoRecover != nil {
+		in, out := &in.AutoRecover, &out.AutoRecover
+		*out = new(bool)
+		**out = **in
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ArangoDeploymentRecoverySpec.
+func (in *ArangoDeploymentRecoverySpec) DeepCopy() *ArangoDeploymentRecoverySpec {
+	if in == nil {
+		return nil
+	}
+	out := new(ArangoDeploymentRecoverySpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ArangoUser) DeepCopyInto(out *ArangoUser) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ArangoUser.
+func (in *ArangoUser) DeepCopy() *ArangoUser {
 	if in == nil {
 		return nil
 	}
-	out := new(ArangoDeployment)
+	out := new(ArangoUser)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
-func (in *ArangoDeployment) DeepCopyObject() runtime.Object {
+func (in *ArangoUser) DeepCopyObject() runtime.Object {
 	if c := in.DeepCopy(); c != nil {
 		return c
 	}
@@ -90,13 +636,13 @@ func (in *ArangoDeployment) DeepCopyObject() runtime.Object {
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *ArangoDeploymentList) DeepCopyInto(out *ArangoDeploymentList) {
+func (in *ArangoUserList) DeepCopyInto(out *ArangoUserList) {
 	*out = *in
 	out.TypeMeta = in.TypeMeta
 	in.ListMeta.DeepCopyInto(&out.ListMeta)
 	if in.Items != nil {
 		in, out := &in.Items, &out.Items
-		*out = make([]ArangoDeployment, len(*in))
+		*out = make([]ArangoUser, len(*in))
 		for i := range *in {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
@@ -104,18 +650,18 @@ func (in *ArangoDeploymentList) DeepCopyInto(out *ArangoDeploymentList) {
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ArangoDeploymentList.
-func (in *ArangoDeploymentList) DeepCopy() *ArangoDeploymentList {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ArangoUserList.
+func (in *ArangoUserList) DeepCopy() *ArangoUserList {
 	if in == nil {
 		return nil
 	}
-	out := new(ArangoDeploymentList)
+	out := new(ArangoUserList)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
-func (in *ArangoDeploymentList) DeepCopyObject() runtime.Object {
+func (in *ArangoUserList) DeepCopyObject() runtime.Object {
 	if c := in.DeepCopy(); c != nil {
 		return c
 	}
@@ -123,22 +669,91 @@ func (in *ArangoDeploymentList) DeepCopyObject() runtime.Object {
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *ArangoDeploymentRecoverySpec) DeepCopyInto(out *ArangoDeploymentRecoverySpec) {
+func (in *ArangoUserPermission) DeepCopyInto(out *ArangoUserPermission) {
 	*out = *in
-	if in.AutoRecover != nil {
-		in, out := &in.AutoRecover, &out.AutoRecover
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ArangoUserPermission.
+func (in *ArangoUserPermission) DeepCopy() *ArangoUserPermission {
+	if in == nil {
+		return nil
+	}
+	out := new(ArangoUserPermission)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ArangoUserSpec) DeepCopyInto(out *ArangoUserSpec) {
+	*out = *in
+	if in.Name != nil {
+		in, out := &in.Name, &out.Name
+		*out = new(string)
+		**out = **in
+	}
+	if in.PasswordSecretName != nil {
+		in, out := &in.PasswordSecretName, &out.PasswordSecretName
+		*out = new(string)
+		**out = **in
+	}
+	if in.Active != nil {
+		in, out := &in.Active, &out.Active
+		*out = new(bool)
+		**out = **in
+	}
+	if in.Permissions != nil {
+		in, out := &in.Permissions, &out.Permissions
+		*out = make([]ArangoUserPermission, len(*in))
+		copy(*out, *in)
+	}
+	if in.DropOnDelete != nil {
+		in, out := &in.DropOnDelete, &out.DropOnDelete
+		*out = new(bool)
+		**out = **in
+	}
+	if in.Adopt != nil {
+		in, out := &in.Adopt, &out.Adopt
 		*out = new(bool)
 		**out = **in
 	}
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ArangoDeploymentRecoverySpec.
-func (in *ArangoDeploymentRecoverySpec) DeepCopy() *ArangoDeploymentRecoverySpec {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ArangoUserSpec.
+func (in *ArangoUserSpec) DeepCopy() *ArangoUserSpec {
 	if in == nil {
 		return nil
 	}
-	out := new(ArangoDeploymentRecoverySpec)
+	out := new(ArangoUserSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ArangoUserStatus) DeepCopyInto(out *ArangoUserStatus) {
+	*out = *in
+	if in.Permissions != nil {
+		in, out := &in.Permissions, &out.Permissions
+		*out = make([]ArangoUserPermission, len(*in))
+		copy(*out, *in)
+	}
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make(ConditionList, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ArangoUserStatus.
+func (in *ArangoUserStatus) DeepCopy() *ArangoUserStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(ArangoUserStatus)
 	in.DeepCopyInto(out)
 	return out
 }
@@ -151,6 +766,11 @@ func (in *AuthenticationSpec) DeepCopyInto(out *AuthenticationSpec) {
 		*out = new(string)
 		**out = **in
 	}
+	if in.Rotation != nil {
+		in, out := &in.Rotation, &out.Rotation
+		*out = new(SecretRotationSpec)
+		(*in).DeepCopyInto(*out)
+	}
 	return
 }
 
@@ -459,6 +1079,31 @@ func (in *DeploymentSpec) DeepCopyInto(out *DeploymentSpec) {
 		*out = new(Timeouts)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.SecurityProfile != nil {
+		in, out := &in.SecurityProfile, &out.SecurityProfile
+		*out = new(SecurityProfile)
+		**out = **in
+	}
+	if in.NetworkPolicy != nil {
+		in, out := &in.NetworkPolicy, &out.NetworkPolicy
+		*out = new(NetworkPolicySpec)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Topology != nil {
+		in, out := &in.Topology, &out.Topology
+		*out = new(TopologySpec)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Upgrade != nil {
+		in, out := &in.Upgrade, &out.Upgrade
+		*out = new(DeploymentUpgradeSpec)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.ImagePolicy != nil {
+		in, out := &in.ImagePolicy, &out.ImagePolicy
+		*out = new(ImagePolicySpec)
+		(*in).DeepCopyInto(*out)
+	}
 	return
 }
 
@@ -516,6 +1161,21 @@ func (in *DeploymentStatus) DeepCopyInto(out *DeploymentStatus) {
 		(*in).DeepCopyInto(*out)
 	}
 	in.Hashes.DeepCopyInto(&out.Hashes)
+	if in.Certificates != nil {
+		in, out := &in.Certificates, &out.Certificates
+		*out = new(DeploymentStatusCertificates)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Rollback != nil {
+		in, out := &in.Rollback, &out.Rollback
+		*out = new(DeploymentStatusRollback)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Canary != nil {
+		in, out := &in.Canary, &out.Canary
+		*out = new(DeploymentStatusCanary)
+		(*in).DeepCopyInto(*out)
+	}
 	if in.ForceStatusReload != nil {
 		in, out := &in.ForceStatusReload, &out.ForceStatusReload
 		*out = new(bool)
@@ -534,6 +1194,46 @@ func (in *DeploymentStatus) DeepCopy() *DeploymentStatus {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DeploymentStatusCanary) DeepCopyInto(out *DeploymentStatusCanary) {
+	*out = *in
+	if in.SoakStartTime != nil {
+		in, out := &in.SoakStartTime, &out.SoakStartTime
+		*out = (*in).DeepCopy()
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DeploymentStatusCanary.
+func (in *DeploymentStatusCanary) DeepCopy() *DeploymentStatusCanary {
+	if in == nil {
+		return nil
+	}
+	out := new(DeploymentStatusCanary)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DeploymentStatusCertificates) DeepCopyInto(out *DeploymentStatusCertificates) {
+	*out = *in
+	if in.CANotAfter != nil {
+		in, out := &in.CANotAfter, &out.CANotAfter
+		*out = (*in).DeepCopy()
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DeploymentStatusCertificates.
+func (in *DeploymentStatusCertificates) DeepCopy() *DeploymentStatusCertificates {
+	if in == nil {
+		return nil
+	}
+	out := new(DeploymentStatusCertificates)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *DeploymentStatusHashes) DeepCopyInto(out *DeploymentStatusHashes) {
 	*out = *in
@@ -561,6 +1261,11 @@ func (in *DeploymentStatusHashesEncryption) DeepCopyInto(out *DeploymentStatusHa
 		*out = make(sharedv1.HashList, len(*in))
 		copy(*out, *in)
 	}
+	if in.Rotation != nil {
+		in, out := &in.Rotation, &out.Rotation
+		*out = new(DeploymentStatusSecretRotation)
+		(*in).DeepCopyInto(*out)
+	}
 	return
 }
 
@@ -582,6 +1287,11 @@ func (in *DeploymentStatusHashesJWT) DeepCopyInto(out *DeploymentStatusHashesJWT
 		*out = make(sharedv1.HashList, len(*in))
 		copy(*out, *in)
 	}
+	if in.Rotation != nil {
+		in, out := &in.Rotation, &out.Rotation
+		*out = new(DeploymentStatusSecretRotation)
+		(*in).DeepCopyInto(*out)
+	}
 	return
 }
 
@@ -598,83 +1308,202 @@ func (in *DeploymentStatusHashesJWT) DeepCopy() *DeploymentStatusHashesJWT {
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *DeploymentStatusHashesTLS) DeepCopyInto(out *DeploymentStatusHashesTLS) {
 	*out = *in
-	if in.CA != nil {
-		in, out := &in.CA, &out.CA
-		*out = new(string)
-		**out = **in
-	}
-	if in.Truststore != nil {
-		in, out := &in.Truststore, &out.Truststore
-		*out = make(sharedv1.HashList, len(*in))
-		copy(*out, *in)
+	if in.CA != nil {
+		in, out := &in.CA, &out.CA
+		*out = new(string)
+		**out = **in
+	}
+	if in.Truststore != nil {
+		in, out := &in.Truststore, &out.Truststore
+		*out = make(sharedv1.HashList, len(*in))
+		copy(*out, *in)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DeploymentStatusHashesTLS.
+func (in *DeploymentStatusHashesTLS) DeepCopy() *DeploymentStatusHashesTLS {
+	if in == nil {
+		return nil
+	}
+	out := new(DeploymentStatusHashesTLS)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DeploymentStatusMembers) DeepCopyInto(out *DeploymentStatusMembers) {
+	*out = *in
+	if in.Single != nil {
+		in, out := &in.Single, &out.Single
+		*out = make(MemberStatusList, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.Agents != nil {
+		in, out := &in.Agents, &out.Agents
+		*out = make(MemberStatusList, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.DBServers != nil {
+		in, out := &in.DBServers, &out.DBServers
+		*out = make(MemberStatusList, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.Coordinators != nil {
+		in, out := &in.Coordinators, &out.Coordinators
+		*out = make(MemberStatusList, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.SyncMasters != nil {
+		in, out := &in.SyncMasters, &out.SyncMasters
+		*out = make(MemberStatusList, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.SyncWorkers != nil {
+		in, out := &in.SyncWorkers, &out.SyncWorkers
+		*out = make(MemberStatusList, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DeploymentStatusMembers.
+func (in *DeploymentStatusMembers) DeepCopy() *DeploymentStatusMembers {
+	if in == nil {
+		return nil
+	}
+	out := new(DeploymentStatusMembers)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DeploymentStatusRollback) DeepCopyInto(out *DeploymentStatusRollback) {
+	*out = *in
+	if in.Time != nil {
+		in, out := &in.Time, &out.Time
+		*out = (*in).DeepCopy()
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DeploymentStatusRollback.
+func (in *DeploymentStatusRollback) DeepCopy() *DeploymentStatusRollback {
+	if in == nil {
+		return nil
+	}
+	out := new(DeploymentStatusRollback)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DeploymentStatusSecretRotation) DeepCopyInto(out *DeploymentStatusSecretRotation) {
+	*out = *in
+	if in.LastRotation != nil {
+		in, out := &in.LastRotation, &out.LastRotation
+		*out = (*in).DeepCopy()
+	}
+	if in.History != nil {
+		in, out := &in.History, &out.History
+		*out = make([]DeploymentStatusSecretRotationEntry, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DeploymentStatusSecretRotation.
+func (in *DeploymentStatusSecretRotation) DeepCopy() *DeploymentStatusSecretRotation {
+	if in == nil {
+		return nil
+	}
+	out := new(DeploymentStatusSecretRotation)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DeploymentStatusSecretRotationEntry) DeepCopyInto(out *DeploymentStatusSecretRotationEntry) {
+	*out = *in
+	in.Created.DeepCopyInto(&out.Created)
+	if in.Replaced != nil {
+		in, out := &in.Replaced, &out.Replaced
+		*out = (*in).DeepCopy()
 	}
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DeploymentStatusHashesTLS.
-func (in *DeploymentStatusHashesTLS) DeepCopy() *DeploymentStatusHashesTLS {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DeploymentStatusSecretRotationEntry.
+func (in *DeploymentStatusSecretRotationEntry) DeepCopy() *DeploymentStatusSecretRotationEntry {
 	if in == nil {
 		return nil
 	}
-	out := new(DeploymentStatusHashesTLS)
+	out := new(DeploymentStatusSecretRotationEntry)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *DeploymentStatusMembers) DeepCopyInto(out *DeploymentStatusMembers) {
+func (in *DeploymentUpgradeSpec) DeepCopyInto(out *DeploymentUpgradeSpec) {
 	*out = *in
-	if in.Single != nil {
-		in, out := &in.Single, &out.Single
-		*out = make(MemberStatusList, len(*in))
-		for i := range *in {
-			(*in)[i].DeepCopyInto(&(*out)[i])
-		}
+	if in.PreflightChecks != nil {
+		in, out := &in.PreflightChecks, &out.PreflightChecks
+		*out = new(bool)
+		**out = **in
 	}
-	if in.Agents != nil {
-		in, out := &in.Agents, &out.Agents
-		*out = make(MemberStatusList, len(*in))
-		for i := range *in {
-			(*in)[i].DeepCopyInto(&(*out)[i])
-		}
+	if in.MinFreeDiskPercentage != nil {
+		in, out := &in.MinFreeDiskPercentage, &out.MinFreeDiskPercentage
+		*out = new(int)
+		**out = **in
 	}
-	if in.DBServers != nil {
-		in, out := &in.DBServers, &out.DBServers
-		*out = make(MemberStatusList, len(*in))
-		for i := range *in {
-			(*in)[i].DeepCopyInto(&(*out)[i])
-		}
+	if in.Rollback != nil {
+		in, out := &in.Rollback, &out.Rollback
+		*out = new(bool)
+		**out = **in
 	}
-	if in.Coordinators != nil {
-		in, out := &in.Coordinators, &out.Coordinators
-		*out = make(MemberStatusList, len(*in))
-		for i := range *in {
-			(*in)[i].DeepCopyInto(&(*out)[i])
-		}
+	if in.RollbackTimeout != nil {
+		in, out := &in.RollbackTimeout, &out.RollbackTimeout
+		*out = new(Timeout)
+		**out = **in
 	}
-	if in.SyncMasters != nil {
-		in, out := &in.SyncMasters, &out.SyncMasters
-		*out = make(MemberStatusList, len(*in))
-		for i := range *in {
-			(*in)[i].DeepCopyInto(&(*out)[i])
-		}
+	if in.Strategy != nil {
+		in, out := &in.Strategy, &out.Strategy
+		*out = new(UpgradeStrategy)
+		**out = **in
 	}
-	if in.SyncWorkers != nil {
-		in, out := &in.SyncWorkers, &out.SyncWorkers
-		*out = make(MemberStatusList, len(*in))
-		for i := range *in {
-			(*in)[i].DeepCopyInto(&(*out)[i])
-		}
+	if in.CanaryPercentage != nil {
+		in, out := &in.CanaryPercentage, &out.CanaryPercentage
+		*out = new(int)
+		**out = **in
+	}
+	if in.CanarySoakPeriod != nil {
+		in, out := &in.CanarySoakPeriod, &out.CanarySoakPeriod
+		*out = new(Timeout)
+		**out = **in
 	}
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DeploymentStatusMembers.
-func (in *DeploymentStatusMembers) DeepCopy() *DeploymentStatusMembers {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DeploymentUpgradeSpec.
+func (in *DeploymentUpgradeSpec) DeepCopy() *DeploymentUpgradeSpec {
 	if in == nil {
 		return nil
 	}
-	out := new(DeploymentStatusMembers)
+	out := new(DeploymentUpgradeSpec)
 	in.DeepCopyInto(out)
 	return out
 }
@@ -756,6 +1585,37 @@ func (in ImageInfoList) DeepCopy() ImageInfoList {
 	return *out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ImagePolicySpec) DeepCopyInto(out *ImagePolicySpec) {
+	*out = *in
+	if in.PinDigest != nil {
+		in, out := &in.PinDigest, &out.PinDigest
+		*out = new(bool)
+		**out = **in
+	}
+	if in.DriftCheckInterval != nil {
+		in, out := &in.DriftCheckInterval, &out.DriftCheckInterval
+		*out = new(Timeout)
+		**out = **in
+	}
+	if in.PublicKeySecretName != nil {
+		in, out := &in.PublicKeySecretName, &out.PublicKeySecretName
+		*out = new(string)
+		**out = **in
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ImagePolicySpec.
+func (in *ImagePolicySpec) DeepCopy() *ImagePolicySpec {
+	if in == nil {
+		return nil
+	}
+	out := new(ImagePolicySpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *LicenseSpec) DeepCopyInto(out *LicenseSpec) {
 	*out = *in
@@ -824,6 +1684,10 @@ func (in *MemberStatus) DeepCopyInto(out *MemberStatus) {
 		*out = new(ImageInfo)
 		**out = **in
 	}
+	if in.CertificateNotAfter != nil {
+		in, out := &in.CertificateNotAfter, &out.CertificateNotAfter
+		*out = (*in).DeepCopy()
+	}
 	return
 }
 
@@ -944,6 +1808,44 @@ func (in *MonitoringSpec) DeepCopy() *MonitoringSpec {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *NetworkPolicySpec) DeepCopyInto(out *NetworkPolicySpec) {
+	*out = *in
+	if in.Enabled != nil {
+		in, out := &in.Enabled, &out.Enabled
+		*out = new(bool)
+		**out = **in
+	}
+	if in.Clients != nil {
+		in, out := &in.Clients, &out.Clients
+		*out = make([]networkingv1.NetworkPolicyPeer, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.MonitoringNamespaceSelector != nil {
+		in, out := &in.MonitoringNamespaceSelector, &out.MonitoringNamespaceSelector
+		*out = new(metav1.LabelSelector)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.OperatorSelector != nil {
+		in, out := &in.OperatorSelector, &out.OperatorSelector
+		*out = new(metav1.LabelSelector)
+		(*in).DeepCopyInto(*out)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NetworkPolicySpec.
+func (in *NetworkPolicySpec) DeepCopy() *NetworkPolicySpec {
+	if in == nil {
+		return nil
+	}
+	out := new(NetworkPolicySpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in PasswordSecretNameList) DeepCopyInto(out *PasswordSecretNameList) {
 	{
@@ -988,6 +1890,52 @@ func (in Plan) DeepCopy() Plan {
 	return *out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RocksDBEncryptionProviderSpec) DeepCopyInto(out *RocksDBEncryptionProviderSpec) {
+	*out = *in
+	if in.Type != nil {
+		in, out := &in.Type, &out.Type
+		*out = new(RocksDBEncryptionProviderType)
+		**out = **in
+	}
+	if in.Endpoint != nil {
+		in, out := &in.Endpoint, &out.Endpoint
+		*out = new(string)
+		**out = **in
+	}
+	if in.KeyName != nil {
+		in, out := &in.KeyName, &out.KeyName
+		*out = new(string)
+		**out = **in
+	}
+	if in.MountPath != nil {
+		in, out := &in.MountPath, &out.MountPath
+		*out = new(string)
+		**out = **in
+	}
+	if in.CredentialsSecretName != nil {
+		in, out := &in.CredentialsSecretName, &out.CredentialsSecretName
+		*out = new(string)
+		**out = **in
+	}
+	if in.CASecretName != nil {
+		in, out := &in.CASecretName, &out.CASecretName
+		*out = new(string)
+		**out = **in
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RocksDBEncryptionProviderSpec.
+func (in *RocksDBEncryptionProviderSpec) DeepCopy() *RocksDBEncryptionProviderSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(RocksDBEncryptionProviderSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *RocksDBEncryptionSpec) DeepCopyInto(out *RocksDBEncryptionSpec) {
 	*out = *in
@@ -996,6 +1944,16 @@ func (in *RocksDBEncryptionSpec) DeepCopyInto(out *RocksDBEncryptionSpec) {
 		*out = new(string)
 		**out = **in
 	}
+	if in.Provider != nil {
+		in, out := &in.Provider, &out.Provider
+		*out = new(RocksDBEncryptionProviderSpec)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Rotation != nil {
+		in, out := &in.Rotation, &out.Rotation
+		*out = new(SecretRotationSpec)
+		(*in).DeepCopyInto(*out)
+	}
 	return
 }
 
@@ -1049,6 +2007,32 @@ func (in *SecretHashes) DeepCopy() *SecretHashes {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SecretRotationSpec) DeepCopyInto(out *SecretRotationSpec) {
+	*out = *in
+	if in.Interval != nil {
+		in, out := &in.Interval, &out.Interval
+		*out = new(Duration)
+		**out = **in
+	}
+	if in.GracePeriod != nil {
+		in, out := &in.GracePeriod, &out.GracePeriod
+		*out = new(Duration)
+		**out = **in
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SecretRotationSpec.
+func (in *SecretRotationSpec) DeepCopy() *SecretRotationSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(SecretRotationSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ServerGroupEnvVar) DeepCopyInto(out *ServerGroupEnvVar) {
 	*out = *in
@@ -1113,6 +2097,27 @@ func (in *ServerGroupInitContainers) DeepCopy() *ServerGroupInitContainers {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ServerGroupPodTemplatePatch) DeepCopyInto(out *ServerGroupPodTemplatePatch) {
+	*out = *in
+	if in.Type != nil {
+		in, out := &in.Type, &out.Type
+		*out = new(ServerGroupPodTemplatePatchType)
+		**out = **in
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ServerGroupPodTemplatePatch.
+func (in *ServerGroupPodTemplatePatch) DeepCopy() *ServerGroupPodTemplatePatch {
+	if in == nil {
+		return nil
+	}
+	out := new(ServerGroupPodTemplatePatch)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ServerGroupProbeSpec) DeepCopyInto(out *ServerGroupProbeSpec) {
 	*out = *in
@@ -1332,6 +2337,13 @@ func (in *ServerGroupSpec) DeepCopyInto(out *ServerGroupSpec) {
 		*out = new(corev1.NodeAffinity)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.TopologySpreadConstraints != nil {
+		in, out := &in.TopologySpreadConstraints, &out.TopologySpreadConstraints
+		*out = make(ServerGroupTopologySpreadConstraints, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 	if in.Sidecars != nil {
 		in, out := &in.Sidecars, &out.Sidecars
 		*out = make([]corev1.Container, len(*in))
@@ -1368,6 +2380,16 @@ func (in *ServerGroupSpec) DeepCopyInto(out *ServerGroupSpec) {
 		*out = new(ServerGroupInitContainers)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.PodTemplatePatch != nil {
+		in, out := &in.PodTemplatePatch, &out.PodTemplatePatch
+		*out = new(ServerGroupPodTemplatePatch)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.MaxUnavailable != nil {
+		in, out := &in.MaxUnavailable, &out.MaxUnavailable
+		*out = new(int)
+		**out = **in
+	}
 	return
 }
 
@@ -1631,6 +2653,28 @@ func (in ServerGroupSpecVolumes) DeepCopy() ServerGroupSpecVolumes {
 	return *out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in ServerGroupTopologySpreadConstraints) DeepCopyInto(out *ServerGroupTopologySpreadConstraints) {
+	{
+		in := &in
+		*out = make(ServerGroupTopologySpreadConstraints, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+		return
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ServerGroupTopologySpreadConstraints.
+func (in ServerGroupTopologySpreadConstraints) DeepCopy() ServerGroupTopologySpreadConstraints {
+	if in == nil {
+		return nil
+	}
+	out := new(ServerGroupTopologySpreadConstraints)
+	in.DeepCopyInto(out)
+	return *out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ServerIDGroupSpec) DeepCopyInto(out *ServerIDGroupSpec) {
 	*out = *in
@@ -1779,6 +2823,37 @@ func (in *SyncSpec) DeepCopy() *SyncSpec {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TLSIssuerSpec) DeepCopyInto(out *TLSIssuerSpec) {
+	*out = *in
+	if in.Name != nil {
+		in, out := &in.Name, &out.Name
+		*out = new(string)
+		**out = **in
+	}
+	if in.Kind != nil {
+		in, out := &in.Kind, &out.Kind
+		*out = new(TLSIssuerKind)
+		**out = **in
+	}
+	if in.Group != nil {
+		in, out := &in.Group, &out.Group
+		*out = new(string)
+		**out = **in
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TLSIssuerSpec.
+func (in *TLSIssuerSpec) DeepCopy() *TLSIssuerSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(TLSIssuerSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *TLSSNISpec) DeepCopyInto(out *TLSSNISpec) {
 	*out = *in
@@ -1838,6 +2913,11 @@ func (in *TLSSpec) DeepCopyInto(out *TLSSpec) {
 		*out = new(TLSRotateMode)
 		**out = **in
 	}
+	if in.Issuer != nil {
+		in, out := &in.Issuer, &out.Issuer
+		*out = new(TLSIssuerSpec)
+		(*in).DeepCopyInto(*out)
+	}
 	return
 }
 
@@ -1887,3 +2967,29 @@ func (in *Timeouts) DeepCopy() *Timeouts {
 	in.DeepCopyInto(out)
 	return out
 }
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TopologySpec) DeepCopyInto(out *TopologySpec) {
+	*out = *in
+	if in.Enabled != nil {
+		in, out := &in.Enabled, &out.Enabled
+		*out = new(bool)
+		**out = **in
+	}
+	if in.ZoneLabel != nil {
+		in, out := &in.ZoneLabel, &out.ZoneLabel
+		*out = new(string)
+		**out = **in
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TopologySpec.
+func (in *TopologySpec) DeepCopy() *TopologySpec {
+	if in == nil {
+		return nil
+	}
+	out := new(TopologySpec)
+	in.DeepCopyInto(out)
+	return out
+}
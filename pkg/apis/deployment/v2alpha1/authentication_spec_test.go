@@ -35,8 +35,12 @@ func TestAuthenticationSpecValidate(t *testing.T) {
 	assert.Nil(t, AuthenticationSpec{JWTSecretName: util.NewString("foo")}.Validate(false))
 	assert.Nil(t, AuthenticationSpec{JWTSecretName: util.NewString("foo")}.Validate(true))
 
+	assert.Nil(t, AuthenticationSpec{JWTSecretName: util.NewString("foo"), Rotation: &SecretRotationSpec{Interval: NewDuration("720h")}}.Validate(true))
+
 	// Not valid
 	assert.Error(t, AuthenticationSpec{JWTSecretName: util.NewString("Foo")}.Validate(false))
+	assert.Error(t, AuthenticationSpec{JWTSecretName: util.NewString("None"), Rotation: &SecretRotationSpec{Interval: NewDuration("720h")}}.Validate(false))
+	assert.Error(t, AuthenticationSpec{JWTSecretName: util.NewString("foo"), Rotation: &SecretRotationSpec{}}.Validate(false))
 }
 
 func TestAuthenticationSpecIsAuthenticated(t *testing.T) {
@@ -36,7 +36,7 @@ type ChaosSpec struct {
 	// Interval is the time between events
 	Interval *time.Duration `json:"interval,omitempty"`
 	// KillPodProbability is the chance of a pod being killed during an event
-	KillPodProbability *Percent `json:"kill-pod-probability,omitempty"`
+	KillPodProbability *Percent `json:"killPodProbability,omitempty"`
 }
 
 // IsEnabled returns the value of enabled.
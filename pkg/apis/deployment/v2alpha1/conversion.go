@@ -0,0 +1,140 @@
+//
+// DISCLAIMER
+//
+// Copyright 2020 ArangoDB GmbH, Cologne, Germany
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// Copyright holder is ArangoDB GmbH, Cologne, Germany
+//
+
+package v2alpha1
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/arangodb/kube-arangodb/pkg/apis/deployment"
+	v1 "github.com/arangodb/kube-arangodb/pkg/apis/deployment/v1"
+	"github.com/arangodb/kube-arangodb/pkg/util/conversion"
+)
+
+// legacyReadinessProbe keeps both v1 readiness probe flags of a server group
+type legacyReadinessProbe struct {
+	Old *bool `json:"ReadinessProbeDisabled,omitempty"`
+	New *bool `json:"readinessProbeDisabled,omitempty"`
+}
+
+// ConvertTo converts the ArangoDeployment into the hub version (v1)
+func (d *ArangoDeployment) ConvertTo(dst *v1.ArangoDeployment) error {
+	in := d.DeepCopy()
+
+	var legacy map[string]legacyReadinessProbe
+	if data, ok := in.GetAnnotations()[deployment.ArangoDeploymentLegacyProbesAnnotation]; ok {
+		if err := json.Unmarshal([]byte(data), &legacy); err != nil {
+			return maskAny(fmt.Errorf("invalid %s annotation: %s", deployment.ArangoDeploymentLegacyProbesAnnotation, err.Error()))
+		}
+		delete(in.Annotations, deployment.ArangoDeploymentLegacyProbesAnnotation)
+		if len(in.Annotations) == 0 {
+			in.Annotations = nil
+		}
+	}
+
+	*dst = v1.ArangoDeployment{}
+	if err := conversion.Convert(in, dst); err != nil {
+		return maskAny(err)
+	}
+	dst.APIVersion = v1.SchemeGroupVersion.String()
+
+	restoreLegacyReadinessProbes("spec", &dst.Spec, legacy)
+	if dst.Status.AcceptedSpec != nil {
+		restoreLegacyReadinessProbes("status.acceptedSpec", dst.Status.AcceptedSpec, legacy)
+	}
+
+	return nil
+}
+
+// ConvertFrom converts the hub version (v1) into the ArangoDeployment.
+// The deprecated v1 ReadinessProbeDisabled flag is merged into readinessProbeDisabled and kept in an annotation,
+// so the object can be converted back without loss.
+func (d *ArangoDeployment) ConvertFrom(src *v1.ArangoDeployment) error {
+	in := src.DeepCopy()
+
+	legacy := map[string]legacyReadinessProbe{}
+	collectLegacyReadinessProbes("spec", &in.Spec, legacy)
+	if in.Status.AcceptedSpec != nil {
+		collectLegacyReadinessProbes("status.acceptedSpec", in.Status.AcceptedSpec, legacy)
+	}
+
+	*d = ArangoDeployment{}
+	if err := conversion.Convert(in, d); err != nil {
+		return maskAny(err)
+	}
+	d.APIVersion = SchemeGroupVersion.String()
+
+	if len(legacy) > 0 {
+		data, err := json.Marshal(legacy)
+		if err != nil {
+			return maskAny(err)
+		}
+		if d.Annotations == nil {
+			d.Annotations = map[string]string{}
+		}
+		d.Annotations[deployment.ArangoDeploymentLegacyProbesAnnotation] = string(data)
+	}
+
+	return nil
+}
+
+// collectLegacyReadinessProbes moves the deprecated readiness probe flag of all groups into readinessProbeDisabled
+func collectLegacyReadinessProbes(path string, spec *v1.DeploymentSpec, legacy map[string]legacyReadinessProbe) {
+	for _, group := range v1.AllServerGroups {
+		probes := spec.GetServerGroupSpec(group).Probes
+		if probes == nil || probes.OldReadinessProbeDisabled == nil {
+			continue
+		}
+
+		legacy[path+"."+group.AsRole()] = legacyReadinessProbe{
+			Old: probes.OldReadinessProbeDisabled,
+			New: probes.ReadinessProbeDisabled,
+		}
+
+		probes.ReadinessProbeDisabled = probes.OldReadinessProbeDisabled
+		probes.OldReadinessProbeDisabled = nil
+	}
+}
+
+// restoreLegacyReadinessProbes restores the v1 readiness probe flags of groups which were not changed in the meantime
+func restoreLegacyReadinessProbes(path string, spec *v1.DeploymentSpec, legacy map[string]legacyReadinessProbe) {
+	for _, group := range v1.AllServerGroups {
+		l, ok := legacy[path+"."+group.AsRole()]
+		if !ok {
+			continue
+		}
+
+		probes := spec.GetServerGroupSpec(group).Probes
+		if probes == nil || !equalBool(probes.ReadinessProbeDisabled, l.Old) {
+			continue
+		}
+
+		probes.OldReadinessProbeDisabled = l.Old
+		probes.ReadinessProbeDisabled = l.New
+	}
+}
+
+func equalBool(a, b *bool) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+	return *a == *b
+}
@@ -0,0 +1,162 @@
+//
+// DISCLAIMER
+//
+// Copyright 2020 ArangoDB GmbH, Cologne, Germany
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// Copyright holder is ArangoDB GmbH, Cologne, Germany
+//
+
+package v2alpha1
+
+import (
+	"encoding/json"
+	"math/rand"
+	"testing"
+	"time"
+
+	fuzz "github.com/google/gofuzz"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"k8s.io/apimachinery/pkg/api/equality"
+	"k8s.io/apimachinery/pkg/api/resource"
+	"k8s.io/apimachinery/pkg/util/intstr"
+
+	"github.com/arangodb/kube-arangodb/pkg/apis/deployment"
+	v1 "github.com/arangodb/kube-arangodb/pkg/apis/deployment/v1"
+	"github.com/arangodb/kube-arangodb/pkg/util"
+)
+
+const fuzzIterations = 100
+
+func newFuzzer(t *testing.T) *fuzz.Fuzzer {
+	seed := time.Now().UnixNano()
+	t.Logf("Fuzz seed: %d", seed)
+
+	return fuzz.New().
+		RandSource(rand.NewSource(seed)).
+		NilChance(0.3).
+		NumElements(0, 2).
+		MaxDepth(12).
+		Funcs(
+			func(q *resource.Quantity, c fuzz.Continue) {
+				*q = *resource.NewQuantity(c.Int63n(1000), resource.DecimalSI)
+			},
+			func(i *intstr.IntOrString, c fuzz.Continue) {
+				*i = intstr.FromInt(c.Intn(100))
+			},
+		)
+}
+
+func TestConversionRoundTripFromHub(t *testing.T) {
+	f := newFuzzer(t)
+
+	for i := 0; i < fuzzIterations; i++ {
+		var src v1.ArangoDeployment
+		f.Fuzz(&src)
+		delete(src.Annotations, deployment.ArangoDeploymentLegacyProbesAnnotation)
+
+		var d ArangoDeployment
+		require.NoError(t, d.ConvertFrom(&src))
+		assert.Equal(t, SchemeGroupVersion.String(), d.APIVersion)
+
+		var dst v1.ArangoDeployment
+		require.NoError(t, d.ConvertTo(&dst))
+
+		dst.APIVersion = src.APIVersion
+		require.True(t, equality.Semantic.DeepEqual(src, dst), "Round trip v1 -> v2alpha1 -> v1 is lossy")
+	}
+}
+
+func TestConversionRoundTripToHub(t *testing.T) {
+	f := newFuzzer(t)
+
+	for i := 0; i < fuzzIterations; i++ {
+		var src ArangoDeployment
+		f.Fuzz(&src)
+		delete(src.Annotations, deployment.ArangoDeploymentLegacyProbesAnnotation)
+
+		var hub v1.ArangoDeployment
+		require.NoError(t, src.ConvertTo(&hub))
+		assert.Equal(t, v1.SchemeGroupVersion.String(), hub.APIVersion)
+
+		var dst ArangoDeployment
+		require.NoError(t, dst.ConvertFrom(&hub))
+
+		dst.APIVersion = src.APIVersion
+		require.True(t, equality.Semantic.DeepEqual(src, dst), "Round trip v2alpha1 -> v1 -> v2alpha1 is lossy")
+	}
+}
+
+func TestConversionLegacyReadinessProbe(t *testing.T) {
+	src := v1.ArangoDeployment{
+		Spec: v1.DeploymentSpec{
+			Agents: v1.ServerGroupSpec{
+				Probes: &v1.ServerGroupProbesSpec{
+					OldReadinessProbeDisabled: util.NewBool(true),
+					ReadinessProbeDisabled:    util.NewBool(false),
+				},
+			},
+		},
+	}
+
+	var d ArangoDeployment
+	require.NoError(t, d.ConvertFrom(&src))
+	require.NotNil(t, d.Spec.Agents.Probes)
+	assert.Equal(t, util.NewBool(true), d.Spec.Agents.Probes.GetReadinessProbeDisabled())
+	assert.Contains(t, d.Annotations, deployment.ArangoDeploymentLegacyProbesAnnotation)
+
+	data, err := json.Marshal(d.Spec.Agents.Probes)
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"readinessProbeDisabled":true}`, string(data))
+
+	t.Run("Unchanged", func(t *testing.T) {
+		var dst v1.ArangoDeployment
+		require.NoError(t, d.ConvertTo(&dst))
+		assert.Equal(t, src.Spec.Agents.Probes, dst.Spec.Agents.Probes)
+		assert.Empty(t, dst.Annotations)
+	})
+
+	t.Run("Changed", func(t *testing.T) {
+		changed := d.DeepCopy()
+		changed.Spec.Agents.Probes.ReadinessProbeDisabled = util.NewBool(false)
+
+		var dst v1.ArangoDeployment
+		require.NoError(t, changed.ConvertTo(&dst))
+		assert.Nil(t, dst.Spec.Agents.Probes.OldReadinessProbeDisabled)
+		assert.Equal(t, util.NewBool(false), dst.Spec.Agents.Probes.GetReadinessProbeDisabled())
+	})
+}
+
+func TestConversionStatusKeys(t *testing.T) {
+	src := v1.ArangoDeployment{
+		Status: v1.DeploymentStatus{
+			CurrentImage: &v1.ImageInfo{Image: "arangodb/arangodb", ImageID: "sha256:1"},
+			AcceptedSpec: &v1.DeploymentSpec{},
+		},
+	}
+
+	var d ArangoDeployment
+	require.NoError(t, d.ConvertFrom(&src))
+
+	data, err := json.Marshal(d.Status)
+	require.NoError(t, err)
+
+	var status map[string]interface{}
+	require.NoError(t, json.Unmarshal(data, &status))
+	assert.Contains(t, status, "currentImage")
+	assert.Contains(t, status, "acceptedSpec")
+	assert.NotContains(t, status, "current-image")
+	assert.NotContains(t, status, "accepted-spec")
+}
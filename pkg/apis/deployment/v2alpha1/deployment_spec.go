@@ -112,6 +112,21 @@ type DeploymentSpec struct {
 	Bootstrap BootstrapSpec `json:"bootstrap,omitempty"`
 
 	Timeouts *Timeouts `json:"timeouts,omitempty"`
+
+	// SecurityProfile defines the security profile which all containers of the deployment are rendered with
+	SecurityProfile *SecurityProfile `json:"securityProfile,omitempty"`
+
+	// NetworkPolicy defines NetworkPolicies generated for the deployment
+	NetworkPolicy *NetworkPolicySpec `json:"networkPolicy,omitempty"`
+
+	// Topology defines zone awareness of the deployment
+	Topology *TopologySpec `json:"topology,omitempty"`
+
+	// Upgrade defines how version upgrades of the deployment are performed
+	Upgrade *DeploymentUpgradeSpec `json:"upgrade,omitempty"`
+
+	// ImagePolicy defines how images of the deployment are pinned and verified
+	ImagePolicy *ImagePolicySpec `json:"imagePolicy,omitempty"`
 }
 
 // GetRestoreFrom returns the restore from string or empty string if not set
@@ -303,6 +318,21 @@ func (s *DeploymentSpec) SetDefaultsFrom(source DeploymentSpec) {
 	if s.Database == nil {
 		s.Database = source.Database.DeepCopy()
 	}
+	if s.Topology == nil {
+		s.Topology = source.Topology.DeepCopy()
+	}
+	if s.SecurityProfile == nil {
+		s.SecurityProfile = source.SecurityProfile
+	}
+	if s.NetworkPolicy == nil {
+		s.NetworkPolicy = source.NetworkPolicy.DeepCopy()
+	}
+	if s.Upgrade == nil {
+		s.Upgrade = source.Upgrade.DeepCopy()
+	}
+	if s.ImagePolicy == nil {
+		s.ImagePolicy = source.ImagePolicy.DeepCopy()
+	}
 
 	s.License.SetDefaultsFrom(source.License)
 	s.ExternalAccess.SetDefaultsFrom(source.ExternalAccess)
@@ -382,12 +412,46 @@ func (s *DeploymentSpec) Validate() error {
 	if err := s.License.Validate(); err != nil {
 		return maskAny(errors.Wrap(err, "spec.licenseKey"))
 	}
+	if err := s.Topology.Validate(); err != nil {
+		return maskAny(errors.Wrap(err, "spec.topology"))
+	}
+	if err := s.NetworkPolicy.Validate(); err != nil {
+		return maskAny(errors.Wrap(err, "spec.networkPolicy"))
+	}
+	if err := s.Upgrade.Validate(); err != nil {
+		return maskAny(errors.Wrap(err, "spec.upgrade"))
+	}
+	if err := s.ImagePolicy.Validate(); err != nil {
+		return maskAny(errors.Wrap(err, "spec.imagePolicy"))
+	}
+	if err := s.validateSecurityProfile(); err != nil {
+		return maskAny(errors.Wrap(err, "spec"))
+	}
 	if err := s.Bootstrap.Validate(); err != nil {
 		return maskAny(err)
 	}
 	return nil
 }
 
+// validateSecurityProfile checks if settings of all groups are compliant with the security profile
+func (s *DeploymentSpec) validateSecurityProfile() error {
+	if err := s.SecurityProfile.Validate(); err != nil {
+		return maskAny(errors.Wrap(err, "securityProfile"))
+	}
+
+	for _, group := range AllServerGroups {
+		if err := s.GetServerGroupSpec(group).ValidateSecurityProfile(s.SecurityProfile); err != nil {
+			return maskAny(errors.Wrap(err, group.AsRole()))
+		}
+	}
+
+	if err := s.SecurityProfile.ValidateSecurityContext(s.ID.Get().SecurityContext); err != nil {
+		return maskAny(errors.Wrap(err, "id.securityContext"))
+	}
+
+	return nil
+}
+
 // IsDevelopment returns true when the spec contains a Development environment.
 func (s DeploymentSpec) IsDevelopment() bool {
 	return s.GetEnvironment() == EnvironmentDevelopment
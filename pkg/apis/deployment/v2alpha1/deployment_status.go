@@ -50,9 +50,9 @@ type DeploymentStatus struct {
 	Restore *DeploymentRestoreResult `json:"restore,omitempty"`
 
 	// Images holds a list of ArangoDB images with their ID and ArangoDB version.
-	Images ImageInfoList `json:"arangodb-images,omitempty"`
+	Images ImageInfoList `json:"images,omitempty"`
 	// Image that is currently being used when new pods are created
-	CurrentImage *ImageInfo `json:"current-image,omitempty"`
+	CurrentImage *ImageInfo `json:"currentImage,omitempty"`
 
 	// Members holds the status for all members in all server groups
 	Members DeploymentStatusMembers `json:"members"`
@@ -64,17 +64,27 @@ type DeploymentStatus struct {
 	Plan Plan `json:"plan,omitempty"`
 
 	// AcceptedSpec contains the last specification that was accepted by the operator.
-	AcceptedSpec *DeploymentSpec `json:"accepted-spec,omitempty"`
+	// +kubebuilder:validation:Schemaless
+	AcceptedSpec *DeploymentSpec `json:"acceptedSpec,omitempty"`
 
 	// SecretHashes keeps a sha256 hash of secret values, so we can
 	// detect changes in secret values.
-	SecretHashes *SecretHashes `json:"secret-hashes,omitempty"`
+	SecretHashes *SecretHashes `json:"secretHashes,omitempty"`
 
 	// Hashes keep status of hashes in deployment
 	Hashes DeploymentStatusHashes `json:"hashes,omitempty"`
 
+	// Certificates keeps the expiry status of the deployment certificates
+	Certificates *DeploymentStatusCertificates `json:"certificates,omitempty"`
+
+	// Rollback keeps the outcome of the last automatic rollback of a failed upgrade
+	Rollback *DeploymentStatusRollback `json:"rollback,omitempty"`
+
+	// Canary keeps the state of the last canary upgrade
+	Canary *DeploymentStatusCanary `json:"canary,omitempty"`
+
 	// ForceStatusReload if set to true forces a reload of the status from the custom resource.
-	ForceStatusReload *bool `json:"force-status-reload,omitempty"`
+	ForceStatusReload *bool `json:"forceStatusReload,omitempty"`
 }
 
 // Equal checks for equality
@@ -92,7 +102,10 @@ func (ds *DeploymentStatus) Equal(other DeploymentStatus) bool {
 		ds.Conditions.Equal(other.Conditions) &&
 		ds.Plan.Equal(other.Plan) &&
 		ds.AcceptedSpec.Equal(other.AcceptedSpec) &&
-		ds.SecretHashes.Equal(other.SecretHashes)
+		ds.SecretHashes.Equal(other.SecretHashes) &&
+		ds.Certificates.Equal(other.Certificates) &&
+		ds.Rollback.Equal(other.Rollback) &&
+		ds.Canary.Equal(other.Canary)
 }
 
 // IsForceReload returns true if ForceStatusReload is set to true
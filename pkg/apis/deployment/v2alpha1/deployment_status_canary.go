@@ -0,0 +1,58 @@
+//
+// DISCLAIMER
+//
+// Copyright 2020 ArangoDB GmbH, Cologne, Germany
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// Copyright holder is ArangoDB GmbH, Cologne, Germany
+//
+
+package v2alpha1
+
+import (
+	"github.com/arangodb/kube-arangodb/pkg/util"
+	meta "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// DeploymentStatusCanary keeps the state of a canary upgrade.
+type DeploymentStatusCanary struct {
+	// Image is the image the canary members are upgraded to
+	Image string `json:"image"`
+	// SoakStartTime is the time since the deployment is healthy with the canary members
+	SoakStartTime *meta.Time `json:"soakStartTime,omitempty"`
+	// Passed is set when the upgrade is continued after the canary members
+	Passed bool `json:"passed,omitempty"`
+}
+
+// IsPassed returns true when the canary upgrade to the given image is continued
+func (d *DeploymentStatusCanary) IsPassed(image string) bool {
+	if d == nil {
+		return false
+	}
+
+	return d.Image == image && d.Passed
+}
+
+// Equal checks for equality
+func (d *DeploymentStatusCanary) Equal(other *DeploymentStatusCanary) bool {
+	if d == nil && other == nil {
+		return true
+	} else if d == nil || other == nil {
+		return false
+	}
+
+	return d.Image == other.Image &&
+		util.TimeCompareEqualOptional(d.SoakStartTime, other.SoakStartTime) &&
+		d.Passed == other.Passed
+}
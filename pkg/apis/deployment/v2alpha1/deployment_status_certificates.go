@@ -0,0 +1,47 @@
+//
+// DISCLAIMER
+//
+// Copyright 2020 ArangoDB GmbH, Cologne, Germany
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// Copyright holder is ArangoDB GmbH, Cologne, Germany
+//
+
+package v2alpha1
+
+import (
+	"github.com/arangodb/kube-arangodb/pkg/util"
+	meta "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// DeploymentStatusCertificates keeps the expiry status of the deployment certificates.
+// Expiry of member certificates is kept in the member status.
+type DeploymentStatusCertificates struct {
+	// CANotAfter holds the expiry time of the CA certificate which expires first
+	CANotAfter *meta.Time `json:"caNotAfter,omitempty"`
+	// CAOwned is set when the CA is renewed by the operator
+	CAOwned bool `json:"caOwned,omitempty"`
+}
+
+// Equal checks for equality
+func (d *DeploymentStatusCertificates) Equal(other *DeploymentStatusCertificates) bool {
+	if d == nil && other == nil {
+		return true
+	} else if d == nil || other == nil {
+		return false
+	}
+
+	return util.TimeCompareEqualOptional(d.CANotAfter, other.CANotAfter) &&
+		d.CAOwned == other.CAOwned
+}
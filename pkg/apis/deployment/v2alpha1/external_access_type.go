@@ -28,6 +28,7 @@ import (
 )
 
 // ExternalAccessType specifies the type of external access provides for the deployment
+// +kubebuilder:validation:Enum=None;Auto;LoadBalancer;NodePort
 type ExternalAccessType string
 
 const (
@@ -26,10 +26,10 @@ import driver "github.com/arangodb/go-driver"
 
 // ImageInfo contains an ID of an image and the ArangoDB version inside the image.
 type ImageInfo struct {
-	Image           string         `json:"image"`                      // Human provided name of the image
-	ImageID         string         `json:"image-id,omitempty"`         // Unique ID (with SHA256) of the image
-	ArangoDBVersion driver.Version `json:"arangodb-version,omitempty"` // ArangoDB version within the image
-	Enterprise      bool           `json:"enterprise,omitempty"`       // If set, this is an enterprise image
+	Image           string         `json:"image"`                     // Human provided name of the image
+	ImageID         string         `json:"imageID,omitempty"`         // Unique ID (with SHA256) of the image
+	ArangoDBVersion driver.Version `json:"arangodbVersion,omitempty"` // ArangoDB version within the image
+	Enterprise      bool           `json:"enterprise,omitempty"`      // If set, this is an enterprise image
 }
 
 // ImageInfoList is a list of image infos
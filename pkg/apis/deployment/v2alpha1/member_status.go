@@ -42,7 +42,7 @@ type MemberStatus struct {
 	// Phase holds the current lifetime phase of this member
 	Phase MemberPhase `json:"phase"`
 	// CreatedAt holds the creation timestamp of this member.
-	CreatedAt metav1.Time `json:"created-at"`
+	CreatedAt metav1.Time `json:"createdAt"`
 	// PersistentVolumeClaimName holds the name of the persistent volume claim used for this member (if any).
 	PersistentVolumeClaimName string `json:"persistentVolumeClaimName,omitempty"`
 	// PodName holds the name of the Pod that currently runs this member
@@ -55,20 +55,24 @@ type MemberStatus struct {
 	Conditions ConditionList `json:"conditions,omitempty"`
 	// RecentTerminatons holds the times when this member was recently terminated.
 	// First entry is the oldest. (do not add omitempty, since we want to be able to switch from a list to an empty list)
-	RecentTerminations []metav1.Time `json:"recent-terminations"`
+	RecentTerminations []metav1.Time `json:"recentTerminations"`
 	// IsInitialized is set after the very first time a pod was created for this member.
 	// After that, DBServers must have a UUID field or fail.
 	IsInitialized bool `json:"initialized"`
 	// CleanoutJobID holds the ID of the agency job for cleaning out this server
-	CleanoutJobID string `json:"cleanout-job-id,omitempty"`
+	CleanoutJobID string `json:"cleanoutJobID,omitempty"`
 	// SideCarSpecs contains list of specifications specified for side cars
-	SideCarSpecs map[string]v1.Container `json:"sidecars-specs,omitempty"`
+	SideCarSpecs map[string]v1.Container `json:"sidecarsSpecs,omitempty"`
 	// ArangoVersion holds the ArangoDB version in member
-	ArangoVersion driver.Version `json:"arango-version,omitempty"`
+	ArangoVersion driver.Version `json:"arangoVersion,omitempty"`
 	// ImageId holds the members ArangoDB image ID
-	ImageID string `json:"image-id,omitempty"`
+	ImageID string `json:"imageID,omitempty"`
 	// Image holds image details
 	Image *ImageInfo `json:"image,omitempty"`
+	// CertificateNotAfter holds the expiry time of the member TLS certificate
+	CertificateNotAfter *metav1.Time `json:"certificateNotAfter,omitempty"`
+	// Zone holds the zone of the node the member pod is scheduled on
+	Zone string `json:"zone,omitempty"`
 }
 
 // Equal checks for equality
@@ -84,7 +88,9 @@ func (s MemberStatus) Equal(other MemberStatus) bool {
 		reflect.DeepEqual(s.SideCarSpecs, other.SideCarSpecs) &&
 		s.ArangoVersion == other.ArangoVersion &&
 		s.ImageID == other.ImageID &&
-		s.Image.Equal(other.Image)
+		s.Image.Equal(other.Image) &&
+		util.TimeCompareEqualOptional(s.CertificateNotAfter, other.CertificateNotAfter) &&
+		s.Zone == other.Zone
 }
 
 // Age returns the duration since the creation timestamp of this member.
@@ -27,13 +27,13 @@ package v2alpha1
 // For each used secret, a sha256 hash is stored.
 type SecretHashes struct {
 	// AuthJWT contains the hash of the auth.jwtSecretName secret
-	AuthJWT string `json:"auth-jwt,omitempty"`
+	AuthJWT string `json:"authJWT,omitempty"`
 	// RocksDBEncryptionKey contains the hash of the rocksdb.encryption.keySecretName secret
-	RocksDBEncryptionKey string `json:"rocksdb-encryption-key,omitempty"`
+	RocksDBEncryptionKey string `json:"rocksDBEncryptionKey,omitempty"`
 	// TLSCA contains the hash of the tls.caSecretName secret
-	TLSCA string `json:"tls-ca,omitempty"`
+	TLSCA string `json:"tlsCA,omitempty"`
 	// SyncTLSCA contains the hash of the sync.tls.caSecretName secret
-	SyncTLSCA string `json:"sync-tls-ca,omitempty"`
+	SyncTLSCA string `json:"syncTLSCA,omitempty"`
 	// User's map contains hashes for each user
 	Users map[string]string `json:"users,omitempty"`
 }
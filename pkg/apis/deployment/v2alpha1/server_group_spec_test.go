@@ -83,6 +83,18 @@ func TestServerGroupSpecValidateCount(t *testing.T) {
 
 }
 
+func TestServerGroupSpecValidateMaxUnavailable(t *testing.T) {
+	// Valid
+	assert.Nil(t, ServerGroupSpec{Count: util.NewInt(5), MaxUnavailable: util.NewInt(3)}.Validate(ServerGroupCoordinators, true, DeploymentModeCluster, EnvironmentDevelopment))
+	assert.Nil(t, ServerGroupSpec{Count: util.NewInt(5), MaxUnavailable: util.NewInt(2)}.Validate(ServerGroupSyncWorkers, true, DeploymentModeCluster, EnvironmentDevelopment))
+	assert.Nil(t, ServerGroupSpec{Count: util.NewInt(3), MaxUnavailable: util.NewInt(1)}.Validate(ServerGroupDBServers, true, DeploymentModeCluster, EnvironmentDevelopment))
+
+	// Invalid
+	assert.Error(t, ServerGroupSpec{Count: util.NewInt(5), MaxUnavailable: util.NewInt(0)}.Validate(ServerGroupCoordinators, true, DeploymentModeCluster, EnvironmentDevelopment))
+	assert.Error(t, ServerGroupSpec{Count: util.NewInt(3), MaxUnavailable: util.NewInt(2)}.Validate(ServerGroupDBServers, true, DeploymentModeCluster, EnvironmentDevelopment))
+	assert.Error(t, ServerGroupSpec{Count: util.NewInt(3), MaxUnavailable: util.NewInt(2)}.Validate(ServerGroupAgents, true, DeploymentModeCluster, EnvironmentDevelopment))
+}
+
 func TestServerGroupSpecDefault(t *testing.T) {
 	def := func(spec ServerGroupSpec, group ServerGroup, used bool, mode DeploymentMode) ServerGroupSpec {
 		spec.SetDefaults(group, used, mode)
@@ -61,6 +61,7 @@ type TLSSpec struct {
 	TTL          *Duration      `json:"ttl,omitempty"`
 	SNI          *TLSSNISpec    `json:"sni,omitempty"`
 	Mode         *TLSRotateMode `json:"mode,omitempty"`
+	Issuer       *TLSIssuerSpec `json:"issuer,omitempty"`
 }
 
 const (
@@ -91,6 +92,12 @@ func (a TLSSpec) GetSNI() TLSSNISpec {
 	return *a.SNI
 }
 
+// IsIssued returns true when member certificates are issued by a cert-manager issuer
+// instead of the CA stored in the CA secret.
+func (s TLSSpec) IsIssued() bool {
+	return s.IsSecure() && s.Issuer != nil
+}
+
 // IsSecure returns true when a CA secret has been set, false otherwise.
 func (s TLSSpec) IsSecure() bool {
 	return s.GetCASecretName() != CASecretNameDisabled
@@ -125,6 +132,9 @@ func (s TLSSpec) Validate() error {
 		if err := s.GetTTL().Validate(); err != nil {
 			return maskAny(err)
 		}
+		if err := s.Issuer.Validate(); err != nil {
+			return maskAny(err)
+		}
 	}
 	return nil
 }
@@ -157,4 +167,7 @@ func (s *TLSSpec) SetDefaultsFrom(source TLSSpec) {
 	if s.SNI == nil {
 		s.SNI = source.SNI.DeepCopy()
 	}
+	if s.Issuer == nil {
+		s.Issuer = source.Issuer.DeepCopy()
+	}
 }
@@ -45,6 +45,25 @@ func TestTLSSpecValidate(t *testing.T) {
 	assert.Error(t, TLSSpec{CASecretName: util.NewString("foo"), AltNames: []string{"@@"}}.Validate())
 }
 
+func TestTLSSpecIssuerValidate(t *testing.T) {
+	clusterIssuer := TLSIssuerKindClusterIssuer
+	unknown := TLSIssuerKind("Unknown")
+
+	// Valid
+	assert.Nil(t, TLSSpec{CASecretName: util.NewString("foo"), Issuer: &TLSIssuerSpec{Name: util.NewString("issuer")}}.Validate())
+	assert.Nil(t, TLSSpec{CASecretName: util.NewString("foo"), Issuer: &TLSIssuerSpec{Name: util.NewString("issuer"), Kind: &clusterIssuer}}.Validate())
+
+	// Not valid
+	assert.Error(t, TLSSpec{CASecretName: util.NewString("foo"), Issuer: &TLSIssuerSpec{}}.Validate())
+	assert.Error(t, TLSSpec{CASecretName: util.NewString("foo"), Issuer: &TLSIssuerSpec{Name: util.NewString("issuer"), Kind: &unknown}}.Validate())
+}
+
+func TestTLSSpecIsIssued(t *testing.T) {
+	assert.False(t, TLSSpec{CASecretName: util.NewString("foo")}.IsIssued())
+	assert.True(t, TLSSpec{CASecretName: util.NewString("foo"), Issuer: &TLSIssuerSpec{Name: util.NewString("issuer")}}.IsIssued())
+	assert.False(t, TLSSpec{CASecretName: util.NewString("None"), Issuer: &TLSIssuerSpec{Name: util.NewString("issuer")}}.IsIssued())
+}
+
 func TestTLSSpecIsSecure(t *testing.T) {
 	assert.True(t, TLSSpec{CASecretName: util.NewString("")}.IsSecure())
 	assert.True(t, TLSSpec{CASecretName: util.NewString("foo")}.IsSecure())
@@ -0,0 +1,95 @@
+//
+// DISCLAIMER
+//
+// Copyright 2020 ArangoDB GmbH, Cologne, Germany
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// Copyright holder is ArangoDB GmbH, Cologne, Germany
+//
+
+package v2alpha1
+
+import (
+	"github.com/arangodb/kube-arangodb/pkg/util"
+	"github.com/pkg/errors"
+	core "k8s.io/api/core/v1"
+)
+
+const (
+	// DefaultTopologyZoneLabel is the node label used to determine the zone of a member
+	DefaultTopologyZoneLabel = "topology.kubernetes.io/zone"
+)
+
+// TopologySpec defines zone awareness of the deployment
+type TopologySpec struct {
+	// Enabled spreads agents and dbservers across zones and records the zone of each member.
+	// Zones are not passed to arangod, zone aware shard placement is not supported.
+	// Zones are not recorded when the operator is not allowed to get nodes.
+	Enabled *bool `json:"enabled,omitempty"`
+	// ZoneLabel is the node label which holds the zone name
+	ZoneLabel *string `json:"zoneLabel,omitempty"`
+}
+
+// IsEnabled returns true when zone awareness is enabled
+func (s *TopologySpec) IsEnabled() bool {
+	if s == nil {
+		return false
+	}
+
+	return util.BoolOrDefault(s.Enabled, false)
+}
+
+// GetZoneLabel returns the node label which holds the zone name
+func (s *TopologySpec) GetZoneLabel() string {
+	if s == nil || s.ZoneLabel == nil || *s.ZoneLabel == "" {
+		return DefaultTopologyZoneLabel
+	}
+
+	return *s.ZoneLabel
+}
+
+// Validate the topology spec
+func (s *TopologySpec) Validate() error {
+	if s == nil || s.ZoneLabel == nil {
+		return nil
+	}
+
+	if *s.ZoneLabel == "" {
+		return errors.Wrapf(ValidationError, "zoneLabel cannot be empty")
+	}
+
+	return nil
+}
+
+// ServerGroupTopologySpreadConstraints is a list of topology spread constraints of a server group
+type ServerGroupTopologySpreadConstraints []core.TopologySpreadConstraint
+
+// Validate the topology spread constraints
+func (s ServerGroupTopologySpreadConstraints) Validate() error {
+	for id, c := range s {
+		if c.MaxSkew <= 0 {
+			return errors.Wrapf(ValidationError, "[%d]: maxSkew must be greater than 0", id)
+		}
+		if c.TopologyKey == "" {
+			return errors.Wrapf(ValidationError, "[%d]: topologyKey cannot be empty", id)
+		}
+		switch c.WhenUnsatisfiable {
+		case core.DoNotSchedule, core.ScheduleAnyway:
+		default:
+			return errors.Wrapf(ValidationError, "[%d]: unknown whenUnsatisfiable value %s", id, c.WhenUnsatisfiable)
+		}
+	}
+
+	return nil
+}
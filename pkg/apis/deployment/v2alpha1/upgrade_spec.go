@@ -0,0 +1,190 @@
+//
+// DISCLAIMER
+//
+// Copyright 2020 ArangoDB GmbH, Cologne, Germany
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// Copyright holder is ArangoDB GmbH, Cologne, Germany
+//
+
+package v2alpha1
+
+import (
+	"time"
+
+	"github.com/arangodb/kube-arangodb/pkg/util"
+	"github.com/pkg/errors"
+)
+
+const (
+	// DefaultUpgradeMinFreeDiskPercentage is the minimal percentage of free disk space required on each member before an upgrade starts
+	DefaultUpgradeMinFreeDiskPercentage = 10
+	// DefaultUpgradeRollbackTimeout is the time a member has to come up on the new image before the upgrade is rolled back
+	DefaultUpgradeRollbackTimeout = 15 * time.Minute
+)
+
+// UpgradeStrategy defines how members are upgraded
+// +kubebuilder:validation:Enum=Rolling;Canary
+type UpgradeStrategy string
+
+const (
+	// UpgradeStrategyRolling upgrades all members one after another
+	UpgradeStrategyRolling UpgradeStrategy = "Rolling"
+	// UpgradeStrategyCanary upgrades canary members of each group first and pauses the upgrade
+	// until it is continued by annotation or the deployment is healthy for the soak period
+	UpgradeStrategyCanary UpgradeStrategy = "Canary"
+)
+
+// Validate the upgrade strategy
+func (s UpgradeStrategy) Validate() error {
+	switch s {
+	case UpgradeStrategyRolling, UpgradeStrategyCanary:
+		return nil
+	default:
+		return errors.Wrapf(ValidationError, "Unknown upgrade strategy: '%s'", string(s))
+	}
+}
+
+// NewUpgradeStrategy returns a reference to a string with given value.
+func NewUpgradeStrategy(input UpgradeStrategy) *UpgradeStrategy {
+	return &input
+}
+
+// DeploymentUpgradeSpec defines how version upgrades of the deployment are performed
+type DeploymentUpgradeSpec struct {
+	// PreflightChecks verify agency health, shard sync, free disk space and upgrade rules before the first member is upgraded.
+	// Enabled by default.
+	PreflightChecks *bool `json:"preflightChecks,omitempty"`
+	// MinFreeDiskPercentage is the minimal percentage of free disk space required on each member during preflight checks
+	MinFreeDiskPercentage *int `json:"minFreeDiskPercentage,omitempty"`
+	// Rollback reverts members which are already upgraded, when a member does not come up on the new image within RollbackTimeout.
+	// Rollback is done only when the downgrade is allowed for all upgraded members.
+	Rollback *bool `json:"rollback,omitempty"`
+	// RollbackTimeout is the time a member has to come up on the new image
+	RollbackTimeout *Timeout `json:"rollbackTimeout,omitempty"`
+	// Strategy defines how members are upgraded. Defaults to Rolling.
+	Strategy *UpgradeStrategy `json:"strategy,omitempty"`
+	// CanaryPercentage is the percentage of members of each group upgraded before the canary upgrade is paused.
+	// At least one member of each group is upgraded.
+	CanaryPercentage *int `json:"canaryPercentage,omitempty"`
+	// CanarySoakPeriod is the time the deployment has to be healthy with the canary members
+	// before the upgrade is continued automatically. When not set, the upgrade is continued only by annotation.
+	CanarySoakPeriod *Timeout `json:"canarySoakPeriod,omitempty"`
+}
+
+// IsPreflightChecksEnabled returns true when preflight checks are done before upgrade
+func (s *DeploymentUpgradeSpec) IsPreflightChecksEnabled() bool {
+	if s == nil {
+		return true
+	}
+
+	return util.BoolOrDefault(s.PreflightChecks, true)
+}
+
+// GetMinFreeDiskPercentage returns the minimal percentage of free disk space required for upgrade
+func (s *DeploymentUpgradeSpec) GetMinFreeDiskPercentage() int {
+	if s == nil || s.MinFreeDiskPercentage == nil {
+		return DefaultUpgradeMinFreeDiskPercentage
+	}
+
+	return *s.MinFreeDiskPercentage
+}
+
+// IsRollbackEnabled returns true when failed upgrades are rolled back
+func (s *DeploymentUpgradeSpec) IsRollbackEnabled() bool {
+	if s == nil {
+		return false
+	}
+
+	return util.BoolOrDefault(s.Rollback, false)
+}
+
+// GetRollbackTimeout returns the time a member has to come up on the new image
+func (s *DeploymentUpgradeSpec) GetRollbackTimeout() time.Duration {
+	if s == nil {
+		return DefaultUpgradeRollbackTimeout
+	}
+
+	return s.RollbackTimeout.Get(DefaultUpgradeRollbackTimeout)
+}
+
+// GetStrategy returns the upgrade strategy
+func (s *DeploymentUpgradeSpec) GetStrategy() UpgradeStrategy {
+	if s == nil || s.Strategy == nil {
+		return UpgradeStrategyRolling
+	}
+
+	return *s.Strategy
+}
+
+// IsCanary returns true when canary upgrades are enabled
+func (s *DeploymentUpgradeSpec) IsCanary() bool {
+	return s.GetStrategy() == UpgradeStrategyCanary
+}
+
+// GetCanaryMembers returns the number of canary members in a group with given number of members
+func (s *DeploymentUpgradeSpec) GetCanaryMembers(count int) int {
+	canary := 1
+	if s != nil && s.CanaryPercentage != nil {
+		// Round up, so the percentage is not lower than requested
+		if c := (count**s.CanaryPercentage + 99) / 100; c > canary {
+			canary = c
+		}
+	}
+
+	if canary > count {
+		return count
+	}
+
+	return canary
+}
+
+// GetCanarySoakPeriod returns the soak period of canary upgrades.
+// Zero means the upgrade is continued only by annotation.
+func (s *DeploymentUpgradeSpec) GetCanarySoakPeriod() time.Duration {
+	if s == nil {
+		return 0
+	}
+
+	return s.CanarySoakPeriod.Get(0)
+}
+
+// Validate the upgrade spec
+func (s *DeploymentUpgradeSpec) Validate() error {
+	if s == nil {
+		return nil
+	}
+
+	if p := s.GetMinFreeDiskPercentage(); p < 0 || p > 100 {
+		return errors.Wrapf(ValidationError, "Invalid minFreeDiskPercentage value %d. Expected value between 0 and 100", p)
+	}
+
+	if s.GetRollbackTimeout() <= 0 {
+		return errors.Wrapf(ValidationError, "Invalid rollbackTimeout value. Expected positive duration")
+	}
+
+	if err := s.GetStrategy().Validate(); err != nil {
+		return errors.Wrapf(err, "strategy")
+	}
+
+	if s.CanaryPercentage != nil && (*s.CanaryPercentage < 0 || *s.CanaryPercentage > 100) {
+		return errors.Wrapf(ValidationError, "Invalid canaryPercentage value %d. Expected value between 0 and 100", *s.CanaryPercentage)
+	}
+
+	if s.GetCanarySoakPeriod() < 0 {
+		return errors.Wrapf(ValidationError, "Invalid canarySoakPeriod value. Expected non negative duration")
+	}
+
+	return nil
+}
@@ -0,0 +1,116 @@
+//
+// DISCLAIMER
+//
+// Copyright 2020 ArangoDB GmbH, Cologne, Germany
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// Copyright holder is ArangoDB GmbH, Cologne, Germany
+//
+
+package v2alpha1
+
+import (
+	"testing"
+	"time"
+
+	"github.com/arangodb/kube-arangodb/pkg/util"
+	"github.com/stretchr/testify/assert"
+	meta "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestDeploymentUpgradeSpec(t *testing.T) {
+	var s *DeploymentUpgradeSpec
+	assert.True(t, s.IsPreflightChecksEnabled())
+	assert.False(t, s.IsRollbackEnabled())
+	assert.Equal(t, DefaultUpgradeMinFreeDiskPercentage, s.GetMinFreeDiskPercentage())
+	assert.Equal(t, DefaultUpgradeRollbackTimeout, s.GetRollbackTimeout())
+	assert.NoError(t, s.Validate())
+
+	timeout := Timeout(meta.Duration{Duration: time.Minute})
+	s = &DeploymentUpgradeSpec{
+		PreflightChecks:       util.NewBool(false),
+		MinFreeDiskPercentage: util.NewInt(25),
+		Rollback:              util.NewBool(true),
+		RollbackTimeout:       &timeout,
+	}
+	assert.False(t, s.IsPreflightChecksEnabled())
+	assert.True(t, s.IsRollbackEnabled())
+	assert.Equal(t, 25, s.GetMinFreeDiskPercentage())
+	assert.Equal(t, time.Minute, s.GetRollbackTimeout())
+	assert.NoError(t, s.Validate())
+
+	s.MinFreeDiskPercentage = util.NewInt(101)
+	assert.Error(t, s.Validate())
+
+	s.MinFreeDiskPercentage = util.NewInt(-1)
+	assert.Error(t, s.Validate())
+
+	s.MinFreeDiskPercentage = nil
+	timeout = Timeout(meta.Duration{})
+	assert.Error(t, s.Validate())
+}
+
+func TestDeploymentStatusRollback(t *testing.T) {
+	var r *DeploymentStatusRollback
+	assert.False(t, r.IsActive("arangodb:3.7.2"))
+	assert.True(t, r.Equal(nil))
+
+	r = &DeploymentStatusRollback{FromImage: "arangodb:3.7.1", ToImage: "arangodb:3.7.2"}
+	assert.True(t, r.IsActive("arangodb:3.7.2"))
+	assert.False(t, r.IsActive("arangodb:3.7.3"))
+	assert.False(t, r.Equal(nil))
+	assert.True(t, r.Equal(&DeploymentStatusRollback{FromImage: "arangodb:3.7.1", ToImage: "arangodb:3.7.2"}))
+}
+
+func TestDeploymentUpgradeSpecCanary(t *testing.T) {
+	var s *DeploymentUpgradeSpec
+	assert.Equal(t, UpgradeStrategyRolling, s.GetStrategy())
+	assert.False(t, s.IsCanary())
+	assert.Equal(t, 1, s.GetCanaryMembers(3))
+	assert.Equal(t, time.Duration(0), s.GetCanarySoakPeriod())
+
+	soak := Timeout(meta.Duration{Duration: time.Hour})
+	s = &DeploymentUpgradeSpec{
+		Strategy:         NewUpgradeStrategy(UpgradeStrategyCanary),
+		CanaryPercentage: util.NewInt(25),
+		CanarySoakPeriod: &soak,
+	}
+	assert.True(t, s.IsCanary())
+	assert.Equal(t, time.Hour, s.GetCanarySoakPeriod())
+	assert.Equal(t, 1, s.GetCanaryMembers(3))
+	assert.Equal(t, 2, s.GetCanaryMembers(5))
+	assert.Equal(t, 3, s.GetCanaryMembers(10))
+	assert.Equal(t, 0, s.GetCanaryMembers(0))
+	assert.NoError(t, s.Validate())
+
+	s.CanaryPercentage = util.NewInt(101)
+	assert.Error(t, s.Validate())
+
+	s.CanaryPercentage = nil
+	s.Strategy = NewUpgradeStrategy("Unknown")
+	assert.Error(t, s.Validate())
+}
+
+func TestDeploymentStatusCanary(t *testing.T) {
+	var c *DeploymentStatusCanary
+	assert.False(t, c.IsPassed("arangodb:3.7.2"))
+
+	c = &DeploymentStatusCanary{Image: "arangodb:3.7.2"}
+	assert.False(t, c.IsPassed("arangodb:3.7.2"))
+
+	c.Passed = true
+	assert.True(t, c.IsPassed("arangodb:3.7.2"))
+	assert.False(t, c.IsPassed("arangodb:3.7.3"))
+	assert.False(t, c.Equal(nil))
+}
@@ -29,6 +29,7 @@ import (
 
 	sharedv1 "github.com/arangodb/kube-arangodb/pkg/apis/shared/v1"
 	v1 "k8s.io/api/core/v1"
+	networkingv1 "k8s.io/api/networking/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	runtime "k8s.io/apimachinery/pkg/runtime"
 )
@@ -151,6 +152,11 @@ func (in *AuthenticationSpec) DeepCopyInto(out *AuthenticationSpec) {
 		*out = new(string)
 		**out = **in
 	}
+	if in.Rotation != nil {
+		in, out := &in.Rotation, &out.Rotation
+		*out = new(SecretRotationSpec)
+		(*in).DeepCopyInto(*out)
+	}
 	return
 }
 
@@ -459,6 +465,31 @@ func (in *DeploymentSpec) DeepCopyInto(out *DeploymentSpec) {
 		*out = new(Timeouts)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.SecurityProfile != nil {
+		in, out := &in.SecurityProfile, &out.SecurityProfile
+		*out = new(SecurityProfile)
+		**out = **in
+	}
+	if in.NetworkPolicy != nil {
+		in, out := &in.NetworkPolicy, &out.NetworkPolicy
+		*out = new(NetworkPolicySpec)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Topology != nil {
+		in, out := &in.Topology, &out.Topology
+		*out = new(TopologySpec)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Upgrade != nil {
+		in, out := &in.Upgrade, &out.Upgrade
+		*out = new(DeploymentUpgradeSpec)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.ImagePolicy != nil {
+		in, out := &in.ImagePolicy, &out.ImagePolicy
+		*out = new(ImagePolicySpec)
+		(*in).DeepCopyInto(*out)
+	}
 	return
 }
 
@@ -516,6 +547,21 @@ func (in *DeploymentStatus) DeepCopyInto(out *DeploymentStatus) {
 		(*in).DeepCopyInto(*out)
 	}
 	in.Hashes.DeepCopyInto(&out.Hashes)
+	if in.Certificates != nil {
+		in, out := &in.Certificates, &out.Certificates
+		*out = new(DeploymentStatusCertificates)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Rollback != nil {
+		in, out := &in.Rollback, &out.Rollback
+		*out = new(DeploymentStatusRollback)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Canary != nil {
+		in, out := &in.Canary, &out.Canary
+		*out = new(DeploymentStatusCanary)
+		(*in).DeepCopyInto(*out)
+	}
 	if in.ForceStatusReload != nil {
 		in, out := &in.ForceStatusReload, &out.ForceStatusReload
 		*out = new(bool)
@@ -534,6 +580,46 @@ func (in *DeploymentStatus) DeepCopy() *DeploymentStatus {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DeploymentStatusCanary) DeepCopyInto(out *DeploymentStatusCanary) {
+	*out = *in
+	if in.SoakStartTime != nil {
+		in, out := &in.SoakStartTime, &out.SoakStartTime
+		*out = (*in).DeepCopy()
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DeploymentStatusCanary.
+func (in *DeploymentStatusCanary) DeepCopy() *DeploymentStatusCanary {
+	if in == nil {
+		return nil
+	}
+	out := new(DeploymentStatusCanary)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DeploymentStatusCertificates) DeepCopyInto(out *DeploymentStatusCertificates) {
+	*out = *in
+	if in.CANotAfter != nil {
+		in, out := &in.CANotAfter, &out.CANotAfter
+		*out = (*in).DeepCopy()
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DeploymentStatusCertificates.
+func (in *DeploymentStatusCertificates) DeepCopy() *DeploymentStatusCertificates {
+	if in == nil {
+		return nil
+	}
+	out := new(DeploymentStatusCertificates)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *DeploymentStatusHashes) DeepCopyInto(out *DeploymentStatusHashes) {
 	*out = *in
@@ -561,6 +647,11 @@ func (in *DeploymentStatusHashesEncryption) DeepCopyInto(out *DeploymentStatusHa
 		*out = make(sharedv1.HashList, len(*in))
 		copy(*out, *in)
 	}
+	if in.Rotation != nil {
+		in, out := &in.Rotation, &out.Rotation
+		*out = new(DeploymentStatusSecretRotation)
+		(*in).DeepCopyInto(*out)
+	}
 	return
 }
 
@@ -582,6 +673,11 @@ func (in *DeploymentStatusHashesJWT) DeepCopyInto(out *DeploymentStatusHashesJWT
 		*out = make(sharedv1.HashList, len(*in))
 		copy(*out, *in)
 	}
+	if in.Rotation != nil {
+		in, out := &in.Rotation, &out.Rotation
+		*out = new(DeploymentStatusSecretRotation)
+		(*in).DeepCopyInto(*out)
+	}
 	return
 }
 
@@ -679,6 +775,125 @@ func (in *DeploymentStatusMembers) DeepCopy() *DeploymentStatusMembers {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DeploymentStatusRollback) DeepCopyInto(out *DeploymentStatusRollback) {
+	*out = *in
+	if in.Time != nil {
+		in, out := &in.Time, &out.Time
+		*out = (*in).DeepCopy()
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DeploymentStatusRollback.
+func (in *DeploymentStatusRollback) DeepCopy() *DeploymentStatusRollback {
+	if in == nil {
+		return nil
+	}
+	out := new(DeploymentStatusRollback)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DeploymentStatusSecretRotation) DeepCopyInto(out *DeploymentStatusSecretRotation) {
+	*out = *in
+	if in.LastRotation != nil {
+		in, out := &in.LastRotation, &out.LastRotation
+		*out = (*in).DeepCopy()
+	}
+	if in.History != nil {
+		in, out := &in.History, &out.History
+		*out = make([]DeploymentStatusSecretRotationEntry, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DeploymentStatusSecretRotation.
+func (in *DeploymentStatusSecretRotation) DeepCopy() *DeploymentStatusSecretRotation {
+	if in == nil {
+		return nil
+	}
+	out := new(DeploymentStatusSecretRotation)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DeploymentStatusSecretRotationEntry) DeepCopyInto(out *DeploymentStatusSecretRotationEntry) {
+	*out = *in
+	in.Created.DeepCopyInto(&out.Created)
+	if in.Replaced != nil {
+		in, out := &in.Replaced, &out.Replaced
+		*out = (*in).DeepCopy()
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DeploymentStatusSecretRotationEntry.
+func (in *DeploymentStatusSecretRotationEntry) DeepCopy() *DeploymentStatusSecretRotationEntry {
+	if in == nil {
+		return nil
+	}
+	out := new(DeploymentStatusSecretRotationEntry)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DeploymentUpgradeSpec) DeepCopyInto(out *DeploymentUpgradeSpec) {
+	*out = *in
+	if in.PreflightChecks != nil {
+		in, out := &in.PreflightChecks, &out.PreflightChecks
+		*out = new(bool)
+		**out = **in
+	}
+	if in.MinFreeDiskPercentage != nil {
+		in, out := &in.MinFreeDiskPercentage, &out.MinFreeDiskPercentage
+		*out = new(int)
+		**out = **in
+	}
+	if in.Rollback != nil {
+		in, out := &in.Rollback, &out.Rollback
+		*out = new(bool)
+		**out = **in
+	}
+	if in.RollbackTimeout != nil {
+		in, out := &in.RollbackTimeout, &out.RollbackTimeout
+		*out = new(Timeout)
+		**out = **in
+	}
+	if in.Strategy != nil {
+		in, out := &in.Strategy, &out.Strategy
+		*out = new(UpgradeStrategy)
+		**out = **in
+	}
+	if in.CanaryPercentage != nil {
+		in, out := &in.CanaryPercentage, &out.CanaryPercentage
+		*out = new(int)
+		**out = **in
+	}
+	if in.CanarySoakPeriod != nil {
+		in, out := &in.CanarySoakPeriod, &out.CanarySoakPeriod
+		*out = new(Timeout)
+		**out = **in
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DeploymentUpgradeSpec.
+func (in *DeploymentUpgradeSpec) DeepCopy() *DeploymentUpgradeSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(DeploymentUpgradeSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ExternalAccessSpec) DeepCopyInto(out *ExternalAccessSpec) {
 	*out = *in
@@ -756,6 +971,37 @@ func (in ImageInfoList) DeepCopy() ImageInfoList {
 	return *out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ImagePolicySpec) DeepCopyInto(out *ImagePolicySpec) {
+	*out = *in
+	if in.PinDigest != nil {
+		in, out := &in.PinDigest, &out.PinDigest
+		*out = new(bool)
+		**out = **in
+	}
+	if in.DriftCheckInterval != nil {
+		in, out := &in.DriftCheckInterval, &out.DriftCheckInterval
+		*out = new(Timeout)
+		**out = **in
+	}
+	if in.PublicKeySecretName != nil {
+		in, out := &in.PublicKeySecretName, &out.PublicKeySecretName
+		*out = new(string)
+		**out = **in
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ImagePolicySpec.
+func (in *ImagePolicySpec) DeepCopy() *ImagePolicySpec {
+	if in == nil {
+		return nil
+	}
+	out := new(ImagePolicySpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *LicenseSpec) DeepCopyInto(out *LicenseSpec) {
 	*out = *in
@@ -824,6 +1070,10 @@ func (in *MemberStatus) DeepCopyInto(out *MemberStatus) {
 		*out = new(ImageInfo)
 		**out = **in
 	}
+	if in.CertificateNotAfter != nil {
+		in, out := &in.CertificateNotAfter, &out.CertificateNotAfter
+		*out = (*in).DeepCopy()
+	}
 	return
 }
 
@@ -944,6 +1194,44 @@ func (in *MonitoringSpec) DeepCopy() *MonitoringSpec {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *NetworkPolicySpec) DeepCopyInto(out *NetworkPolicySpec) {
+	*out = *in
+	if in.Enabled != nil {
+		in, out := &in.Enabled, &out.Enabled
+		*out = new(bool)
+		**out = **in
+	}
+	if in.Clients != nil {
+		in, out := &in.Clients, &out.Clients
+		*out = make([]networkingv1.NetworkPolicyPeer, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.MonitoringNamespaceSelector != nil {
+		in, out := &in.MonitoringNamespaceSelector, &out.MonitoringNamespaceSelector
+		*out = new(metav1.LabelSelector)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.OperatorSelector != nil {
+		in, out := &in.OperatorSelector, &out.OperatorSelector
+		*out = new(metav1.LabelSelector)
+		(*in).DeepCopyInto(*out)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NetworkPolicySpec.
+func (in *NetworkPolicySpec) DeepCopy() *NetworkPolicySpec {
+	if in == nil {
+		return nil
+	}
+	out := new(NetworkPolicySpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in PasswordSecretNameList) DeepCopyInto(out *PasswordSecretNameList) {
 	{
@@ -988,6 +1276,52 @@ func (in Plan) DeepCopy() Plan {
 	return *out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RocksDBEncryptionProviderSpec) DeepCopyInto(out *RocksDBEncryptionProviderSpec) {
+	*out = *in
+	if in.Type != nil {
+		in, out := &in.Type, &out.Type
+		*out = new(RocksDBEncryptionProviderType)
+		**out = **in
+	}
+	if in.Endpoint != nil {
+		in, out := &in.Endpoint, &out.Endpoint
+		*out = new(string)
+		**out = **in
+	}
+	if in.KeyName != nil {
+		in, out := &in.KeyName, &out.KeyName
+		*out = new(string)
+		**out = **in
+	}
+	if in.MountPath != nil {
+		in, out := &in.MountPath, &out.MountPath
+		*out = new(string)
+		**out = **in
+	}
+	if in.CredentialsSecretName != nil {
+		in, out := &in.CredentialsSecretName, &out.CredentialsSecretName
+		*out = new(string)
+		**out = **in
+	}
+	if in.CASecretName != nil {
+		in, out := &in.CASecretName, &out.CASecretName
+		*out = new(string)
+		**out = **in
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RocksDBEncryptionProviderSpec.
+func (in *RocksDBEncryptionProviderSpec) DeepCopy() *RocksDBEncryptionProviderSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(RocksDBEncryptionProviderSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *RocksDBEncryptionSpec) DeepCopyInto(out *RocksDBEncryptionSpec) {
 	*out = *in
@@ -996,6 +1330,16 @@ func (in *RocksDBEncryptionSpec) DeepCopyInto(out *RocksDBEncryptionSpec) {
 		*out = new(string)
 		**out = **in
 	}
+	if in.Provider != nil {
+		in, out := &in.Provider, &out.Provider
+		*out = new(RocksDBEncryptionProviderSpec)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Rotation != nil {
+		in, out := &in.Rotation, &out.Rotation
+		*out = new(SecretRotationSpec)
+		(*in).DeepCopyInto(*out)
+	}
 	return
 }
 
@@ -1049,6 +1393,32 @@ func (in *SecretHashes) DeepCopy() *SecretHashes {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SecretRotationSpec) DeepCopyInto(out *SecretRotationSpec) {
+	*out = *in
+	if in.Interval != nil {
+		in, out := &in.Interval, &out.Interval
+		*out = new(Duration)
+		**out = **in
+	}
+	if in.GracePeriod != nil {
+		in, out := &in.GracePeriod, &out.GracePeriod
+		*out = new(Duration)
+		**out = **in
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SecretRotationSpec.
+func (in *SecretRotationSpec) DeepCopy() *SecretRotationSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(SecretRotationSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ServerGroupEnvVar) DeepCopyInto(out *ServerGroupEnvVar) {
 	*out = *in
@@ -1113,6 +1483,27 @@ func (in *ServerGroupInitContainers) DeepCopy() *ServerGroupInitContainers {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ServerGroupPodTemplatePatch) DeepCopyInto(out *ServerGroupPodTemplatePatch) {
+	*out = *in
+	if in.Type != nil {
+		in, out := &in.Type, &out.Type
+		*out = new(ServerGroupPodTemplatePatchType)
+		**out = **in
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ServerGroupPodTemplatePatch.
+func (in *ServerGroupPodTemplatePatch) DeepCopy() *ServerGroupPodTemplatePatch {
+	if in == nil {
+		return nil
+	}
+	out := new(ServerGroupPodTemplatePatch)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ServerGroupProbeSpec) DeepCopyInto(out *ServerGroupProbeSpec) {
 	*out = *in
@@ -1167,11 +1558,6 @@ func (in *ServerGroupProbesSpec) DeepCopyInto(out *ServerGroupProbesSpec) {
 		*out = new(ServerGroupProbeSpec)
 		(*in).DeepCopyInto(*out)
 	}
-	if in.OldReadinessProbeDisabled != nil {
-		in, out := &in.OldReadinessProbeDisabled, &out.OldReadinessProbeDisabled
-		*out = new(bool)
-		**out = **in
-	}
 	if in.ReadinessProbeDisabled != nil {
 		in, out := &in.ReadinessProbeDisabled, &out.ReadinessProbeDisabled
 		*out = new(bool)
@@ -1332,6 +1718,13 @@ func (in *ServerGroupSpec) DeepCopyInto(out *ServerGroupSpec) {
 		*out = new(v1.NodeAffinity)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.TopologySpreadConstraints != nil {
+		in, out := &in.TopologySpreadConstraints, &out.TopologySpreadConstraints
+		*out = make(ServerGroupTopologySpreadConstraints, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 	if in.Sidecars != nil {
 		in, out := &in.Sidecars, &out.Sidecars
 		*out = make([]v1.Container, len(*in))
@@ -1368,6 +1761,16 @@ func (in *ServerGroupSpec) DeepCopyInto(out *ServerGroupSpec) {
 		*out = new(ServerGroupInitContainers)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.PodTemplatePatch != nil {
+		in, out := &in.PodTemplatePatch, &out.PodTemplatePatch
+		*out = new(ServerGroupPodTemplatePatch)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.MaxUnavailable != nil {
+		in, out := &in.MaxUnavailable, &out.MaxUnavailable
+		*out = new(int)
+		**out = **in
+	}
 	return
 }
 
@@ -1631,6 +2034,28 @@ func (in ServerGroupSpecVolumes) DeepCopy() ServerGroupSpecVolumes {
 	return *out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in ServerGroupTopologySpreadConstraints) DeepCopyInto(out *ServerGroupTopologySpreadConstraints) {
+	{
+		in := &in
+		*out = make(ServerGroupTopologySpreadConstraints, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+		return
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ServerGroupTopologySpreadConstraints.
+func (in ServerGroupTopologySpreadConstraints) DeepCopy() ServerGroupTopologySpreadConstraints {
+	if in == nil {
+		return nil
+	}
+	out := new(ServerGroupTopologySpreadConstraints)
+	in.DeepCopyInto(out)
+	return *out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ServerIDGroupSpec) DeepCopyInto(out *ServerIDGroupSpec) {
 	*out = *in
@@ -1779,6 +2204,37 @@ func (in *SyncSpec) DeepCopy() *SyncSpec {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TLSIssuerSpec) DeepCopyInto(out *TLSIssuerSpec) {
+	*out = *in
+	if in.Name != nil {
+		in, out := &in.Name, &out.Name
+		*out = new(string)
+		**out = **in
+	}
+	if in.Kind != nil {
+		in, out := &in.Kind, &out.Kind
+		*out = new(TLSIssuerKind)
+		**out = **in
+	}
+	if in.Group != nil {
+		in, out := &in.Group, &out.Group
+		*out = new(string)
+		**out = **in
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TLSIssuerSpec.
+func (in *TLSIssuerSpec) DeepCopy() *TLSIssuerSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(TLSIssuerSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *TLSSNISpec) DeepCopyInto(out *TLSSNISpec) {
 	*out = *in
@@ -1838,6 +2294,11 @@ func (in *TLSSpec) DeepCopyInto(out *TLSSpec) {
 		*out = new(TLSRotateMode)
 		**out = **in
 	}
+	if in.Issuer != nil {
+		in, out := &in.Issuer, &out.Issuer
+		*out = new(TLSIssuerSpec)
+		(*in).DeepCopyInto(*out)
+	}
 	return
 }
 
@@ -1887,3 +2348,29 @@ func (in *Timeouts) DeepCopy() *Timeouts {
 	in.DeepCopyInto(out)
 	return out
 }
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TopologySpec) DeepCopyInto(out *TopologySpec) {
+	*out = *in
+	if in.Enabled != nil {
+		in, out := &in.Enabled, &out.Enabled
+		*out = new(bool)
+		**out = **in
+	}
+	if in.ZoneLabel != nil {
+		in, out := &in.ZoneLabel, &out.ZoneLabel
+		*out = new(string)
+		**out = **in
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TopologySpec.
+func (in *TopologySpec) DeepCopy() *TopologySpec {
+	if in == nil {
+		return nil
+	}
+	out := new(TopologySpec)
+	in.DeepCopyInto(out)
+	return out
+}
@@ -0,0 +1,48 @@
+//
+// DISCLAIMER
+//
+// Copyright 2020 ArangoDB GmbH, Cologne, Germany
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// Copyright holder is ArangoDB GmbH, Cologne, Germany
+//
+
+package v2alpha1
+
+import (
+	v1 "github.com/arangodb/kube-arangodb/pkg/apis/replication/v1"
+	"github.com/arangodb/kube-arangodb/pkg/util/conversion"
+)
+
+// ConvertTo converts the ArangoDeploymentReplication into the hub version (v1)
+func (d *ArangoDeploymentReplication) ConvertTo(dst *v1.ArangoDeploymentReplication) error {
+	*dst = v1.ArangoDeploymentReplication{}
+	if err := conversion.Convert(d.DeepCopy(), dst); err != nil {
+		return maskAny(err)
+	}
+	dst.APIVersion = v1.SchemeGroupVersion.String()
+
+	return nil
+}
+
+// ConvertFrom converts the hub version (v1) into the ArangoDeploymentReplication
+func (d *ArangoDeploymentReplication) ConvertFrom(src *v1.ArangoDeploymentReplication) error {
+	*d = ArangoDeploymentReplication{}
+	if err := conversion.Convert(src.DeepCopy(), d); err != nil {
+		return maskAny(err)
+	}
+	d.APIVersion = SchemeGroupVersion.String()
+
+	return nil
+}
@@ -0,0 +1,54 @@
+//
+// DISCLAIMER
+//
+// Copyright 2020 ArangoDB GmbH, Cologne, Germany
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// Copyright holder is ArangoDB GmbH, Cologne, Germany
+//
+
+package v2alpha1
+
+import (
+	"math/rand"
+	"testing"
+	"time"
+
+	fuzz "github.com/google/gofuzz"
+	"github.com/stretchr/testify/require"
+	"k8s.io/apimachinery/pkg/api/equality"
+
+	v1 "github.com/arangodb/kube-arangodb/pkg/apis/replication/v1"
+)
+
+func TestConversionRoundTrip(t *testing.T) {
+	seed := time.Now().UnixNano()
+	t.Logf("Fuzz seed: %d", seed)
+	f := fuzz.New().RandSource(rand.NewSource(seed)).NilChance(0.3).NumElements(0, 2)
+
+	for i := 0; i < 100; i++ {
+		var src v1.ArangoDeploymentReplication
+		f.Fuzz(&src)
+
+		var d ArangoDeploymentReplication
+		require.NoError(t, d.ConvertFrom(&src))
+		require.Equal(t, SchemeGroupVersion.String(), d.APIVersion)
+
+		var dst v1.ArangoDeploymentReplication
+		require.NoError(t, d.ConvertTo(&dst))
+
+		dst.APIVersion = src.APIVersion
+		require.True(t, equality.Semantic.DeepEqual(src, dst), "Round trip v1 -> v2alpha1 -> v1 is lossy")
+	}
+}
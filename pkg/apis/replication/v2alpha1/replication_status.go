@@ -40,5 +40,5 @@ type DeploymentReplicationStatus struct {
 
 	// CancelFailures records the number of times that the configuration was canceled
 	// which resulted in an error.
-	CancelFailures int `json:"cancel-failures,omitempty"`
+	CancelFailures int `json:"cancelFailures,omitempty"`
 }
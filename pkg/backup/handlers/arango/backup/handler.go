@@ -75,7 +75,8 @@ type handler struct {
 	arangoClientFactory ArangoClientFactory
 	arangoClientTimeout time.Duration
 
-	operator operator.Operator
+	operator   operator.Operator
+	namespaces func() []string
 }
 
 func (h *handler) Start(stopCh <-chan struct{}) {
@@ -101,15 +102,17 @@ func (h *handler) start(stopCh <-chan struct{}) {
 }
 
 func (h *handler) refresh() error {
-	deployments, err := h.client.DatabaseV1().ArangoDeployments(h.operator.Namespace()).List(meta.ListOptions{})
-	if err != nil {
-		return err
-	}
-
-	for _, deployment := range deployments.Items {
-		if err = h.refreshDeployment(&deployment); err != nil {
+	for _, namespace := range h.namespaces() {
+		deployments, err := h.client.DatabaseV1().ArangoDeployments(namespace).List(meta.ListOptions{})
+		if err != nil {
 			return err
 		}
+
+		for _, deployment := range deployments.Items {
+			if err = h.refreshDeployment(&deployment); err != nil {
+				return err
+			}
+		}
 	}
 
 	return nil
@@ -38,22 +38,16 @@ func newEventInstance(recorder event.Recorder) event.RecorderInstance {
 		backup.ArangoBackupResourceKind)
 }
 
-// RegisterInformer into operator
-func RegisterInformer(operator operator.Operator, recorder event.Recorder, client arangoClientSet.Interface, kubeClient kubernetes.Interface, informer arangoInformer.SharedInformerFactory) error {
-	if err := operator.RegisterInformer(informer.Backup().V1().ArangoBackups().Informer(),
-		backupApi.SchemeGroupVersion.Group,
-		backupApi.SchemeGroupVersion.Version,
-		backup.ArangoBackupResourceKind); err != nil {
-		return err
-	}
-
+// RegisterHandler into operator. Namespaces returns the namespaces watched by the operator.
+func RegisterHandler(operator operator.Operator, recorder event.Recorder, client arangoClientSet.Interface, kubeClient kubernetes.Interface, namespaces func() []string) error {
 	h := &handler{
 		client:     client,
 		kubeClient: kubeClient,
 
 		eventRecorder: newEventInstance(recorder),
 
-		operator: operator,
+		operator:   operator,
+		namespaces: namespaces,
 
 		arangoClientTimeout: defaultArangoClientTimeout,
 	}
@@ -69,3 +63,11 @@ func RegisterInformer(operator operator.Operator, recorder event.Recorder, clien
 
 	return nil
 }
+
+// RegisterNamespaceInformer into operator. Called for each namespace watched by the operator.
+func RegisterNamespaceInformer(operator operator.Operator, informer arangoInformer.SharedInformerFactory) error {
+	return operator.RegisterInformer(informer.Backup().V1().ArangoBackups().Informer(),
+		backupApi.SchemeGroupVersion.Group,
+		backupApi.SchemeGroupVersion.Version,
+		backup.ArangoBackupResourceKind)
+}
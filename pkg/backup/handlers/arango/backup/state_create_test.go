@@ -92,6 +92,7 @@ func Test_State_Create_SuccessForced(t *testing.T) {
 	compareBackupMeta(t, backupMeta, newObj)
 	require.NotNil(t, newObj.Status.Backup.PotentiallyInconsistent)
 	require.True(t, *newObj.Status.Backup.PotentiallyInconsistent)
+	require.True(t, newObj.Status.Conditions.IsTrue(backupApi.ConditionTypePotentiallyInconsistent))
 }
 
 func Test_State_Create_Upload(t *testing.T) {
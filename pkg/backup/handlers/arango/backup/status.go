@@ -103,6 +103,13 @@ func updateStatusBackupDownload(downloaded *bool) updateStatusFunc {
 func updateStatusBackup(backupMeta driver.BackupMeta) updateStatusFunc {
 	return func(status *backupApi.ArangoBackupStatus) {
 		status.Backup = createBackupFromMeta(backupMeta, status.Backup)
+
+		if backupMeta.PotentiallyInconsistent {
+			status.Conditions.Update(backupApi.ConditionTypePotentiallyInconsistent, true,
+				"Inconsistent", "Backup was created without a global lock")
+		} else {
+			status.Conditions.Remove(backupApi.ConditionTypePotentiallyInconsistent)
+		}
 	}
 }
 
@@ -73,6 +73,10 @@ func (h *handler) Handle(item operation.Item) error {
 		return err
 	}
 
+	if err := h.processInconsistentBackups(policy.DeepCopy()); err != nil {
+		return err
+	}
+
 	status, err := h.processBackupPolicy(policy.DeepCopy())
 	if err != nil {
 		return err
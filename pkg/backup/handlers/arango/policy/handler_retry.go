@@ -0,0 +1,172 @@
+//
+// DISCLAIMER
+//
+// Copyright 2020 ArangoDB GmbH, Cologne, Germany
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// Copyright holder is ArangoDB GmbH, Cologne, Germany
+//
+
+package policy
+
+import (
+	"fmt"
+	"strconv"
+	"time"
+
+	backupApi "github.com/arangodb/kube-arangodb/pkg/apis/backup/v1"
+	meta "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+const (
+	backupRetried        = "ArangoBackupRetried"
+	backupRetryExhausted = "ArangoBackupRetryExhausted"
+
+	// maxRetryBackoffShift limits exponential growth of the retry delay
+	maxRetryBackoffShift = 10
+)
+
+// processInconsistentBackups recreates potentially inconsistent backups created by the policy
+// until a consistent one is obtained or the retry deadline is reached.
+func (h *handler) processInconsistentBackups(policy *backupApi.ArangoBackupPolicy) error {
+	if policy.Spec.Retry == nil {
+		return nil
+	}
+
+	if err := policy.Validate(); err != nil {
+		// Validation errors are reported by the policy processing
+		return nil
+	}
+
+	backups, err := h.client.BackupV1().ArangoBackups(policy.Namespace).List(meta.ListOptions{})
+	if err != nil {
+		return err
+	}
+
+	// Only the newest backup of each deployment is taken into account
+	latest := map[string]*backupApi.ArangoBackup{}
+
+	for id := range backups.Items {
+		b := &backups.Items[id]
+
+		if b.Spec.PolicyName == nil || *b.Spec.PolicyName != policy.Name {
+			continue
+		}
+
+		if l, ok := latest[b.Spec.Deployment.Name]; !ok || l.CreationTimestamp.Before(&b.CreationTimestamp) {
+			latest[b.Spec.Deployment.Name] = b
+		}
+	}
+
+	now := time.Now()
+
+	for _, b := range latest {
+		if b.Status.State != backupApi.ArangoBackupStateReady || !b.Status.Backup.IsPotentiallyInconsistent() {
+			continue
+		}
+
+		if _, ok := b.Annotations[backupApi.AnnotationRetryStatus]; ok {
+			// Backup already processed
+			continue
+		}
+
+		origin, attempt, deadline := getRetryInfo(policy, b)
+
+		if now.After(deadline) {
+			h.eventRecorder.Warning(policy, backupRetryExhausted, "Unable to create consistent backup of %s/%s before %s",
+				b.Namespace, b.Spec.Deployment.Name, deadline.Format(time.RFC3339))
+
+			if err := h.markRetryStatus(b, backupApi.RetryStatusDeadlineExceeded); err != nil {
+				return err
+			}
+
+			continue
+		}
+
+		shift := attempt
+		if shift > maxRetryBackoffShift {
+			shift = maxRetryBackoffShift
+		}
+
+		if now.Before(b.CreationTimestamp.Add(policy.Spec.Retry.GetBackoff() << uint(shift))) {
+			continue
+		}
+
+		deployment, err := h.client.DatabaseV1().ArangoDeployments(b.Namespace).Get(b.Spec.Deployment.Name, meta.GetOptions{})
+		if err != nil {
+			h.eventRecorder.Warning(policy, policyError, "Policy Error: %s", err.Error())
+			continue
+		}
+
+		retry := policy.NewBackup(deployment)
+
+		annotations := map[string]string{}
+		for k, v := range retry.Annotations {
+			annotations[k] = v
+		}
+		annotations[backupApi.AnnotationRetryOf] = origin
+		annotations[backupApi.AnnotationRetryAttempt] = strconv.Itoa(attempt + 1)
+		annotations[backupApi.AnnotationRetryDeadline] = deadline.Format(time.RFC3339)
+		retry.Annotations = annotations
+
+		if _, err := h.client.BackupV1().ArangoBackups(retry.Namespace).Create(retry); err != nil {
+			return err
+		}
+
+		h.eventRecorder.Normal(policy, backupRetried, "Backup %s/%s is potentially inconsistent, created ArangoBackup: %s/%s",
+			b.Namespace, b.Name, retry.Namespace, retry.Name)
+
+		if err := h.markRetryStatus(b, backupApi.RetryStatusRetried); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (h *handler) markRetryStatus(b *backupApi.ArangoBackup, status string) error {
+	annotations := map[string]string{}
+	for k, v := range b.Annotations {
+		annotations[k] = v
+	}
+	annotations[backupApi.AnnotationRetryStatus] = status
+	b.Annotations = annotations
+
+	if _, err := h.client.BackupV1().ArangoBackups(b.Namespace).Update(b); err != nil {
+		return fmt.Errorf("unable to update backup %s/%s: %s", b.Namespace, b.Name, err.Error())
+	}
+
+	return nil
+}
+
+// getRetryInfo returns name of the first backup in the retry chain, current attempt and retry deadline
+func getRetryInfo(policy *backupApi.ArangoBackupPolicy, b *backupApi.ArangoBackup) (string, int, time.Time) {
+	deadline := b.CreationTimestamp.Add(policy.Spec.Retry.GetDeadline())
+
+	origin, ok := b.Annotations[backupApi.AnnotationRetryOf]
+	if !ok {
+		return b.Name, 0, deadline
+	}
+
+	attempt, err := strconv.Atoi(b.Annotations[backupApi.AnnotationRetryAttempt])
+	if err != nil {
+		attempt = 1
+	}
+
+	if t, err := time.Parse(time.RFC3339, b.Annotations[backupApi.AnnotationRetryDeadline]); err == nil {
+		deadline = t
+	}
+
+	return origin, attempt, deadline
+}
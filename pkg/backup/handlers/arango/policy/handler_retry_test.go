@@ -0,0 +1,167 @@
+//
+// DISCLAIMER
+//
+// Copyright 2020 ArangoDB GmbH, Cologne, Germany
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// Copyright holder is ArangoDB GmbH, Cologne, Germany
+//
+
+package policy
+
+import (
+	"testing"
+	"time"
+
+	"github.com/arangodb/kube-arangodb/pkg/backup/operator/operation"
+	"github.com/arangodb/kube-arangodb/pkg/util"
+
+	backupApi "github.com/arangodb/kube-arangodb/pkg/apis/backup/v1"
+	database "github.com/arangodb/kube-arangodb/pkg/apis/deployment/v1"
+	"github.com/stretchr/testify/require"
+	meta "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/uuid"
+)
+
+func newRetryPolicy(namespace, name string) *backupApi.ArangoBackupPolicy {
+	policy := newArangoBackupPolicy("* * * */2 *", namespace, name, map[string]string{}, backupApi.ArangoBackupTemplate{})
+	policy.Spec.Retry = &backupApi.ArangoBackupPolicyRetrySpec{
+		Deadline: &meta.Duration{Duration: time.Hour},
+		Backoff:  &meta.Duration{Duration: time.Minute},
+	}
+	policy.Status.Scheduled = meta.Time{
+		Time: time.Now().Add(time.Hour),
+	}
+	return policy
+}
+
+func createPolicyBackup(t *testing.T, h *handler, policy *backupApi.ArangoBackupPolicy, deployment *database.ArangoDeployment, age time.Duration, inconsistent bool) *backupApi.ArangoBackup {
+	b := policy.NewBackup(deployment)
+	b.CreationTimestamp = meta.Time{Time: time.Now().Add(-age)}
+	b.Status.State = backupApi.ArangoBackupStateReady
+	b.Status.Backup = &backupApi.ArangoBackupDetails{
+		ID:                      string(uuid.NewUUID()),
+		PotentiallyInconsistent: util.NewBool(inconsistent),
+	}
+
+	b, err := h.client.BackupV1().ArangoBackups(b.Namespace).Create(b)
+	require.NoError(t, err)
+
+	return b
+}
+
+func Test_Retry_InconsistentBackup(t *testing.T) {
+	// Arrange
+	handler := newFakeHandler()
+
+	name := string(uuid.NewUUID())
+	namespace := string(uuid.NewUUID())
+
+	policy := newRetryPolicy(namespace, name)
+	database := newArangoDeployment(namespace, map[string]string{})
+
+	createArangoBackupPolicy(t, handler, policy)
+	createArangoDeployment(t, handler, database)
+	b := createPolicyBackup(t, handler, policy, database, 2*time.Minute, true)
+
+	// Act
+	require.NoError(t, handler.Handle(newItemFromBackupPolicy(operation.Update, policy)))
+
+	// Assert
+	backups := listArangoBackups(t, handler, namespace)
+	require.Len(t, backups, 2)
+
+	for _, backup := range backups {
+		if backup.Name == b.Name {
+			require.Equal(t, backupApi.RetryStatusRetried, backup.Annotations[backupApi.AnnotationRetryStatus])
+			continue
+		}
+
+		require.Equal(t, b.Name, backup.Annotations[backupApi.AnnotationRetryOf])
+		require.Equal(t, "1", backup.Annotations[backupApi.AnnotationRetryAttempt])
+	}
+
+	// Act - retry is not repeated
+	require.NoError(t, handler.Handle(newItemFromBackupPolicy(operation.Update, policy)))
+
+	// Assert
+	require.Len(t, listArangoBackups(t, handler, namespace), 2)
+}
+
+func Test_Retry_Backoff(t *testing.T) {
+	// Arrange
+	handler := newFakeHandler()
+
+	name := string(uuid.NewUUID())
+	namespace := string(uuid.NewUUID())
+
+	policy := newRetryPolicy(namespace, name)
+	database := newArangoDeployment(namespace, map[string]string{})
+
+	createArangoBackupPolicy(t, handler, policy)
+	createArangoDeployment(t, handler, database)
+	createPolicyBackup(t, handler, policy, database, 10*time.Second, true)
+
+	// Act
+	require.NoError(t, handler.Handle(newItemFromBackupPolicy(operation.Update, policy)))
+
+	// Assert
+	require.Len(t, listArangoBackups(t, handler, namespace), 1)
+}
+
+func Test_Retry_DeadlineExceeded(t *testing.T) {
+	// Arrange
+	handler := newFakeHandler()
+
+	name := string(uuid.NewUUID())
+	namespace := string(uuid.NewUUID())
+
+	policy := newRetryPolicy(namespace, name)
+	database := newArangoDeployment(namespace, map[string]string{})
+
+	createArangoBackupPolicy(t, handler, policy)
+	createArangoDeployment(t, handler, database)
+	createPolicyBackup(t, handler, policy, database, 2*time.Hour, true)
+
+	// Act
+	require.NoError(t, handler.Handle(newItemFromBackupPolicy(operation.Update, policy)))
+
+	// Assert
+	backups := listArangoBackups(t, handler, namespace)
+	require.Len(t, backups, 1)
+	require.Equal(t, backupApi.RetryStatusDeadlineExceeded, backups[0].Annotations[backupApi.AnnotationRetryStatus])
+}
+
+func Test_Retry_ConsistentBackup(t *testing.T) {
+	// Arrange
+	handler := newFakeHandler()
+
+	name := string(uuid.NewUUID())
+	namespace := string(uuid.NewUUID())
+
+	policy := newRetryPolicy(namespace, name)
+	database := newArangoDeployment(namespace, map[string]string{})
+
+	createArangoBackupPolicy(t, handler, policy)
+	createArangoDeployment(t, handler, database)
+	createPolicyBackup(t, handler, policy, database, 2*time.Minute, false)
+
+	// Act
+	require.NoError(t, handler.Handle(newItemFromBackupPolicy(operation.Update, policy)))
+
+	// Assert
+	backups := listArangoBackups(t, handler, namespace)
+	require.Len(t, backups, 1)
+	require.NotContains(t, backups[0].Annotations, backupApi.AnnotationRetryStatus)
+}
@@ -38,15 +38,8 @@ func newEventInstance(eventRecorder event.Recorder) event.RecorderInstance {
 		backup.ArangoBackupPolicyResourceKind)
 }
 
-// RegisterInformer in operator
-func RegisterInformer(operator operator.Operator, recorder event.Recorder, client arangoClientSet.Interface, kubeClient kubernetes.Interface, informer arangoInformer.SharedInformerFactory) error {
-	if err := operator.RegisterInformer(informer.Backup().V1().ArangoBackupPolicies().Informer(),
-		backupApi.SchemeGroupVersion.Group,
-		backupApi.SchemeGroupVersion.Version,
-		backup.ArangoBackupPolicyResourceKind); err != nil {
-		return err
-	}
-
+// RegisterHandler in operator.
+func RegisterHandler(operator operator.Operator, recorder event.Recorder, client arangoClientSet.Interface, kubeClient kubernetes.Interface) error {
 	h := &handler{
 		client:        client,
 		kubeClient:    kubeClient,
@@ -61,3 +54,11 @@ func RegisterInformer(operator operator.Operator, recorder event.Recorder, clien
 
 	return nil
 }
+
+// RegisterNamespaceInformer in operator. Called for each namespace watched by the operator.
+func RegisterNamespaceInformer(operator operator.Operator, informer arangoInformer.SharedInformerFactory) error {
+	return operator.RegisterInformer(informer.Backup().V1().ArangoBackupPolicies().Informer(),
+		backupApi.SchemeGroupVersion.Group,
+		backupApi.SchemeGroupVersion.Version,
+		backup.ArangoBackupPolicyResourceKind)
+}
@@ -57,6 +57,7 @@ type Operator interface {
 	RegisterHandler(handler Handler) error
 
 	EnqueueItem(item operation.Item)
+	EnqueueItemAfter(item operation.Item, delay time.Duration)
 	ProcessItem(item operation.Item) error
 }
 
@@ -155,12 +156,19 @@ func (o *operator) EnqueueItem(item operation.Item) {
 	o.workqueue.Add(item.String())
 }
 
+func (o *operator) EnqueueItemAfter(item operation.Item, delay time.Duration) {
+	o.workqueue.AddAfter(item.String(), delay)
+}
+
+// RegisterInformer registers the informer and pushes its events to the queue.
+// Informers registered after the operator is started are started by the caller and the operator does not wait for their caches.
 func (o *operator) RegisterInformer(informer cache.SharedIndexInformer, group, version, kind string) error {
 	o.lock.Lock()
 	defer o.lock.Unlock()
 
 	if o.started {
-		return fmt.Errorf("operator already started")
+		informer.AddEventHandler(newResourceEventHandler(o, group, version, kind))
+		return nil
 	}
 
 	for _, registeredInformer := range o.informers {
@@ -366,3 +366,64 @@ func Test_Operator_InformerProcessing_Namespaced(t *testing.T) {
 	close(stopCh)
 	close(i)
 }
+
+func Test_Operator_EnqueueItemAfter(t *testing.T) {
+	// Arrange
+	name := string(uuid.NewUUID())
+	o := NewOperator(name, name)
+
+	m, i := mockSimpleObject(name, true)
+	require.NoError(t, o.RegisterHandler(m))
+
+	stopCh := make(chan struct{})
+	require.NoError(t, o.Start(1, stopCh))
+
+	item := randomItem()
+	item.Operation = operation.Update
+
+	// Act
+	o.EnqueueItemAfter(item, 200*time.Millisecond)
+
+	// Assert
+	time.Sleep(50 * time.Millisecond)
+	assert.Len(t, i, 0)
+
+	res := waitForItems(t, i, 1, time.Second)
+	assert.Equal(t, item, res[0])
+
+	close(stopCh)
+	close(i)
+}
+
+func Test_Operator_RegisterInformerAfterStart(t *testing.T) {
+	// Arrange
+	name := string(uuid.NewUUID())
+	o := NewOperator(name, name)
+
+	m, i := mockSimpleObject(name, true)
+	require.NoError(t, o.RegisterHandler(m))
+
+	stopCh := make(chan struct{})
+	require.NoError(t, o.Start(1, stopCh))
+
+	client := fake.NewSimpleClientset()
+	informer := informers.NewSharedInformerFactory(client, 0)
+
+	// Act
+	require.NoError(t, o.RegisterInformer(informer.Core().V1().Pods().Informer(), "", "v1", "pods"))
+	informer.Start(stopCh)
+
+	_, err := client.CoreV1().Pods("test").Create(&core.Pod{
+		ObjectMeta: meta.ObjectMeta{
+			Name: randomString(10),
+		},
+	})
+	require.NoError(t, err)
+
+	// Assert
+	res := waitForItems(t, i, 1, time.Second)
+	assert.Len(t, res, 1)
+
+	close(stopCh)
+	close(i)
+}
@@ -108,6 +108,7 @@ func (o *operator) processObject(obj interface{}) error {
 		item.Name)
 
 	if err = o.processItem(item); err != nil {
+		o.objectFailed.Inc()
 		o.workqueue.AddRateLimited(key)
 		return fmt.Errorf("error syncing '%s': %s, requeuing", key, err.Error())
 	}
@@ -28,6 +28,7 @@ type prometheusMetrics struct {
 	operator *operator
 
 	objectProcessed prometheus.Counter
+	objectFailed    prometheus.Counter
 }
 
 func newCollector(operator *operator) *prometheusMetrics {
@@ -41,12 +42,21 @@ func newCollector(operator *operator) *prometheusMetrics {
 				"operator_name": operator.name,
 			},
 		}),
+
+		objectFailed: prometheus.NewCounter(prometheus.CounterOpts{
+			Name: "arango_operator_objects_failed",
+			Help: "Count of the objects which processing failed and were requeued",
+			ConstLabels: map[string]string{
+				"operator_name": operator.name,
+			},
+		}),
 	}
 }
 
 func (p *prometheusMetrics) connectors() []prometheus.Collector {
 	return []prometheus.Collector{
 		p.objectProcessed,
+		p.objectFailed,
 	}
 }
 
@@ -0,0 +1,104 @@
+//
+// DISCLAIMER
+//
+// Copyright 2020 ArangoDB GmbH, Cologne, Germany
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// Copyright holder is ArangoDB GmbH, Cologne, Germany
+//
+
+package debug
+
+import (
+	"archive/tar"
+	"compress/gzip"
+	"encoding/json"
+	"io"
+	"path"
+	"time"
+
+	"github.com/ghodss/yaml"
+)
+
+// Bundle writes the collected debug information as files into a gzip compressed tar archive.
+type Bundle struct {
+	root string
+	now  time.Time
+	gz   *gzip.Writer
+	tar  *tar.Writer
+}
+
+// NewBundle creates a new bundle writing to the given writer.
+// All files are placed in the given root directory of the archive.
+func NewBundle(w io.Writer, root string) *Bundle {
+	gz := gzip.NewWriter(w)
+	return &Bundle{
+		root: root,
+		now:  time.Now(),
+		gz:   gz,
+		tar:  tar.NewWriter(gz),
+	}
+}
+
+// AddFile adds a file with the given content to the bundle.
+func (b *Bundle) AddFile(name string, data []byte) error {
+	if err := b.tar.WriteHeader(&tar.Header{
+		Typeflag: tar.TypeReg,
+		Name:     path.Join(b.root, name),
+		Mode:     0644,
+		Size:     int64(len(data)),
+		ModTime:  b.now,
+	}); err != nil {
+		return maskAny(err)
+	}
+
+	if _, err := b.tar.Write(data); err != nil {
+		return maskAny(err)
+	}
+
+	return nil
+}
+
+// AddYAML adds the given object rendered as YAML to the bundle.
+func (b *Bundle) AddYAML(name string, obj interface{}) error {
+	data, err := yaml.Marshal(obj)
+	if err != nil {
+		return maskAny(err)
+	}
+
+	return b.AddFile(name, data)
+}
+
+// AddJSON adds the given object rendered as indented JSON to the bundle.
+func (b *Bundle) AddJSON(name string, obj interface{}) error {
+	data, err := json.MarshalIndent(obj, "", "  ")
+	if err != nil {
+		return maskAny(err)
+	}
+
+	return b.AddFile(name, data)
+}
+
+// Close flushes the archive. It does not close the underlying writer.
+func (b *Bundle) Close() error {
+	if err := b.tar.Close(); err != nil {
+		return maskAny(err)
+	}
+
+	if err := b.gz.Close(); err != nil {
+		return maskAny(err)
+	}
+
+	return nil
+}
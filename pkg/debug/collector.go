@@ -0,0 +1,292 @@
+//
+// DISCLAIMER
+//
+// Copyright 2020 ArangoDB GmbH, Cologne, Germany
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// Copyright holder is ArangoDB GmbH, Cologne, Germany
+//
+
+package debug
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	driver "github.com/arangodb/go-driver"
+	core "k8s.io/api/core/v1"
+	meta "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/kubernetes"
+
+	"github.com/arangodb/kube-arangodb/pkg/apis/deployment"
+	"github.com/arangodb/kube-arangodb/pkg/deployment/agency"
+	"github.com/arangodb/kube-arangodb/pkg/generated/clientset/versioned"
+	"github.com/arangodb/kube-arangodb/pkg/util/k8sutil"
+)
+
+// Collector gathers the debug information of a single deployment.
+type Collector struct {
+	KubeCli   kubernetes.Interface
+	ArangoCli versioned.Interface
+
+	// Agency is used to fetch the agency Plan and Current, skipped when not set.
+	Agency agency.Fetcher
+	// Database is used to fetch the cluster health, skipped when not set.
+	Database driver.Client
+
+	// OperatorNamespace and OperatorLabels select the operator pods whose logs are collected,
+	// skipped when no labels are set.
+	OperatorNamespace string
+	OperatorLabels    map[string]string
+
+	// Logs enables the collection of the container logs of the deployment pods.
+	Logs bool
+	// LogTailLines limits the number of collected log lines per container, 0 means no limit.
+	LogTailLines int64
+}
+
+// collection keeps the state of a single Collect call.
+type collection struct {
+	bundle *Bundle
+	uids   map[types.UID]bool
+	errors []string
+}
+
+// check records the error of a failed part of the collection.
+func (c *collection) check(part string, err error) {
+	if err != nil {
+		c.errors = append(c.errors, fmt.Sprintf("%s: %v", part, err))
+	}
+}
+
+// add writes the object into the bundle and remembers it as owned by the deployment.
+func (c *collection) add(name string, obj meta.Object) {
+	c.uids[obj.GetUID()] = true
+	c.check(name, c.bundle.AddYAML(name, obj))
+}
+
+// Collect writes all debug information of the given deployment into the bundle.
+// Failures of a single part are recorded in errors.txt and do not stop the collection.
+func (c Collector) Collect(ctx context.Context, b *Bundle, namespace, name string) error {
+	depl, err := c.ArangoCli.DatabaseV1().ArangoDeployments(namespace).Get(name, meta.GetOptions{})
+	if err != nil {
+		return maskAny(err)
+	}
+
+	col := &collection{
+		bundle: b,
+		uids:   map[types.UID]bool{},
+	}
+
+	col.add("deployment.yaml", depl)
+
+	c.collectOwned(col, namespace, name)
+	c.collectBackups(col, namespace, name)
+	events := c.collectEvents(col, namespace)
+
+	col.check("status-history.yaml", b.AddYAML("status-history.yaml", StatusHistory(depl, events)))
+
+	if c.Agency != nil {
+		c.collectAgency(ctx, col)
+	}
+
+	if c.Database != nil && depl.Spec.GetMode().IsCluster() {
+		col.check("health.json", c.collectHealth(ctx, col))
+	}
+
+	if len(c.OperatorLabels) > 0 {
+		c.collectOperator(col)
+	}
+
+	if len(col.errors) > 0 {
+		if err := b.AddFile("errors.txt", []byte(strings.Join(col.errors, "\n")+"\n")); err != nil {
+			return maskAny(err)
+		}
+	}
+
+	return nil
+}
+
+// collectOwned writes all Kubernetes objects owned by the deployment.
+func (c Collector) collectOwned(col *collection, namespace, name string) {
+	coreCli := c.KubeCli.CoreV1()
+	kind := deployment.ArangoDeploymentResourceKind
+
+	if pods, err := k8sutil.GetPodsForParent(coreCli.Pods(namespace), kind, name, namespace); err != nil {
+		col.check("pods", err)
+	} else {
+		for _, pod := range pods {
+			col.add(fmt.Sprintf("pods/%s.yaml", pod.GetName()), pod)
+			if c.Logs {
+				c.collectLogs(col, "pods", pod)
+			}
+		}
+	}
+
+	if services, err := k8sutil.GetServicesForParent(coreCli.Services(namespace), kind, name, namespace); err != nil {
+		col.check("services", err)
+	} else {
+		for _, service := range services {
+			col.add(fmt.Sprintf("services/%s.yaml", service.GetName()), service)
+		}
+	}
+
+	if pvcs, err := k8sutil.GetPVCForParent(coreCli.PersistentVolumeClaims(namespace), kind, name, namespace); err != nil {
+		col.check("pvcs", err)
+	} else {
+		for _, pvc := range pvcs {
+			col.add(fmt.Sprintf("pvcs/%s.yaml", pvc.GetName()), pvc)
+		}
+	}
+
+	if serviceAccounts, err := k8sutil.GetServiceAccountsForParent(coreCli.ServiceAccounts(namespace), kind, name, namespace); err != nil {
+		col.check("serviceaccounts", err)
+	} else {
+		for _, serviceAccount := range serviceAccounts {
+			col.add(fmt.Sprintf("serviceaccounts/%s.yaml", serviceAccount.GetName()), serviceAccount)
+		}
+	}
+
+	if pdbs, err := k8sutil.GetPDBForParent(c.KubeCli.PolicyV1beta1().PodDisruptionBudgets(namespace), kind, name, namespace); err != nil {
+		col.check("pdbs", err)
+	} else {
+		for _, pdb := range pdbs {
+			col.add(fmt.Sprintf("pdbs/%s.yaml", pdb.GetName()), pdb)
+		}
+	}
+
+	if secrets, err := k8sutil.GetSecretsForParent(coreCli.Secrets(namespace), kind, name, namespace); err != nil {
+		col.check("secrets", err)
+	} else {
+		for _, secret := range secrets {
+			col.add(fmt.Sprintf("secrets/%s.yaml", secret.GetName()), RedactSecret(secret))
+		}
+	}
+}
+
+// collectLogs writes the logs of all containers of the pod into the given directory.
+func (c Collector) collectLogs(col *collection, dir string, pod *core.Pod) {
+	containers := append(append([]core.Container{}, pod.Spec.InitContainers...), pod.Spec.Containers...)
+	for _, container := range containers {
+		fileName := fmt.Sprintf("%s/%s/%s.log", dir, pod.GetName(), container.Name)
+
+		opts := &core.PodLogOptions{
+			Container: container.Name,
+		}
+		if c.LogTailLines > 0 {
+			tail := c.LogTailLines
+			opts.TailLines = &tail
+		}
+
+		logs, err := c.KubeCli.CoreV1().Pods(pod.GetNamespace()).GetLogs(pod.GetName(), opts).DoRaw()
+		if err != nil {
+			col.check(fileName, err)
+			continue
+		}
+
+		col.check(fileName, col.bundle.AddFile(fileName, logs))
+	}
+}
+
+// collectBackups writes all backups of the deployment.
+func (c Collector) collectBackups(col *collection, namespace, name string) {
+	backups, err := c.ArangoCli.BackupV1().ArangoBackups(namespace).List(meta.ListOptions{})
+	if err != nil {
+		col.check("backups", err)
+		return
+	}
+
+	for i := range backups.Items {
+		backup := &backups.Items[i]
+		if backup.Spec.Deployment.Name != name {
+			continue
+		}
+		col.add(fmt.Sprintf("backups/%s.yaml", backup.GetName()), backup)
+	}
+}
+
+// collectEvents writes all events of the deployment and its owned objects, oldest first.
+func (c Collector) collectEvents(col *collection, namespace string) []core.Event {
+	events, err := c.KubeCli.CoreV1().Events(namespace).List(meta.ListOptions{})
+	if err != nil {
+		col.check("events.yaml", err)
+		return nil
+	}
+
+	var filtered []core.Event
+	for _, event := range events.Items {
+		if col.uids[event.InvolvedObject.UID] {
+			filtered = append(filtered, event)
+		}
+	}
+
+	sort.SliceStable(filtered, func(i, j int) bool {
+		return filtered[i].LastTimestamp.Before(&filtered[j].LastTimestamp)
+	})
+
+	col.check("events.yaml", col.bundle.AddYAML("events.yaml", filtered))
+
+	return filtered
+}
+
+// collectAgency writes the agency Plan and Current.
+func (c Collector) collectAgency(ctx context.Context, col *collection) {
+	for _, key := range []string{agency.PlanKey, agency.CurrentKey} {
+		fileName := fmt.Sprintf("agency/%s.json", strings.ToLower(key))
+
+		var state interface{}
+		if err := c.Agency(ctx, &state, agency.ArangoKey, key); err != nil {
+			col.check(fileName, err)
+			continue
+		}
+
+		col.check(fileName, col.bundle.AddJSON(fileName, state))
+	}
+}
+
+// collectHealth writes the cluster health.
+func (c Collector) collectHealth(ctx context.Context, col *collection) error {
+	cluster, err := c.Database.Cluster(ctx)
+	if err != nil {
+		return maskAny(err)
+	}
+
+	health, err := cluster.Health(ctx)
+	if err != nil {
+		return maskAny(err)
+	}
+
+	return col.bundle.AddJSON("health.json", health)
+}
+
+// collectOperator writes the operator pods and their logs.
+func (c Collector) collectOperator(col *collection) {
+	pods, err := c.KubeCli.CoreV1().Pods(c.OperatorNamespace).List(meta.ListOptions{
+		LabelSelector: labels.SelectorFromSet(c.OperatorLabels).String(),
+	})
+	if err != nil {
+		col.check("operator", err)
+		return
+	}
+
+	for i := range pods.Items {
+		pod := &pods.Items[i]
+		col.check("operator", col.bundle.AddYAML(fmt.Sprintf("operator/%s.yaml", pod.GetName()), pod))
+		c.collectLogs(col, "operator", pod)
+	}
+}
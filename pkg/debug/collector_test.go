@@ -0,0 +1,188 @@
+//
+// DISCLAIMER
+//
+// Copyright 2020 ArangoDB GmbH, Cologne, Germany
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// Copyright holder is ArangoDB GmbH, Cologne, Germany
+//
+
+package debug
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"context"
+	"encoding/json"
+	"io"
+	"io/ioutil"
+	"testing"
+
+	"github.com/ghodss/yaml"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	core "k8s.io/api/core/v1"
+	meta "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+
+	api "github.com/arangodb/kube-arangodb/pkg/apis/deployment/v1"
+	arangofake "github.com/arangodb/kube-arangodb/pkg/generated/clientset/versioned/fake"
+)
+
+// readBundle returns the content of all files in the archive, keyed by name.
+func readBundle(t *testing.T, data []byte) map[string][]byte {
+	gz, err := gzip.NewReader(bytes.NewReader(data))
+	require.NoError(t, err)
+
+	files := map[string][]byte{}
+	r := tar.NewReader(gz)
+	for {
+		h, err := r.Next()
+		if err == io.EOF {
+			return files
+		}
+		require.NoError(t, err)
+
+		content, err := ioutil.ReadAll(r)
+		require.NoError(t, err)
+		files[h.Name] = content
+	}
+}
+
+func Test_RedactSecret(t *testing.T) {
+	secret := &core.Secret{
+		ObjectMeta: meta.ObjectMeta{
+			Name: "jwt",
+			Annotations: map[string]string{
+				lastAppliedConfigurationAnnotation: `{"data":{"token":"c2VjcmV0"}}`,
+			},
+		},
+		Data: map[string][]byte{
+			"token": []byte("secret"),
+		},
+	}
+
+	redacted := RedactSecret(secret)
+
+	assert.Nil(t, redacted.Data)
+	assert.Equal(t, map[string]string{"token": RedactedValue}, redacted.StringData)
+	assert.Equal(t, RedactedValue, redacted.Annotations[lastAppliedConfigurationAnnotation])
+
+	// Original object is not modified
+	assert.Equal(t, []byte("secret"), secret.Data["token"])
+}
+
+func Test_Collector_Collect(t *testing.T) {
+	depl := &api.ArangoDeployment{
+		ObjectMeta: meta.ObjectMeta{
+			Name:      "example",
+			Namespace: "default",
+			UID:       "deployment-uid",
+		},
+	}
+	owner := depl.AsOwner()
+
+	kubeCli := fake.NewSimpleClientset(
+		&core.Pod{
+			ObjectMeta: meta.ObjectMeta{
+				Name:            "example-agnt-1",
+				Namespace:       "default",
+				UID:             "pod-uid",
+				OwnerReferences: []meta.OwnerReference{owner},
+			},
+		},
+		&core.Pod{
+			ObjectMeta: meta.ObjectMeta{
+				Name:      "other",
+				Namespace: "default",
+			},
+		},
+		&core.Secret{
+			ObjectMeta: meta.ObjectMeta{
+				Name:            "example-jwt",
+				Namespace:       "default",
+				OwnerReferences: []meta.OwnerReference{owner},
+			},
+			Data: map[string][]byte{
+				"token": []byte("secret"),
+			},
+		},
+		&core.Event{
+			ObjectMeta: meta.ObjectMeta{
+				Name:      "pod-event",
+				Namespace: "default",
+			},
+			InvolvedObject: core.ObjectReference{UID: "pod-uid"},
+			Reason:         "Created",
+		},
+		&core.Pod{
+			ObjectMeta: meta.ObjectMeta{
+				Name:      "operator",
+				Namespace: "operators",
+				Labels: map[string]string{
+					"app": "operator",
+				},
+			},
+		},
+		&core.Event{
+			ObjectMeta: meta.ObjectMeta{
+				Name:      "other-event",
+				Namespace: "default",
+			},
+			InvolvedObject: core.ObjectReference{UID: "other-uid"},
+		},
+	)
+
+	var fetched [][]string
+	c := Collector{
+		KubeCli:   kubeCli,
+		ArangoCli: arangofake.NewSimpleClientset(depl),
+		Agency: func(ctx context.Context, i interface{}, keyParts ...string) error {
+			fetched = append(fetched, keyParts)
+			return json.Unmarshal([]byte(`{"key":"value"}`), i)
+		},
+		OperatorNamespace: "operators",
+		OperatorLabels: map[string]string{
+			"app": "operator",
+		},
+	}
+
+	var buffer bytes.Buffer
+	b := NewBundle(&buffer, "example")
+	require.NoError(t, c.Collect(context.Background(), b, "default", "example"))
+	require.NoError(t, b.Close())
+
+	files := readBundle(t, buffer.Bytes())
+
+	assert.Contains(t, files, "example/deployment.yaml")
+	assert.Contains(t, files, "example/pods/example-agnt-1.yaml")
+	assert.NotContains(t, files, "example/pods/other.yaml")
+	assert.NotContains(t, files, "example/errors.txt")
+	assert.Contains(t, files, "example/status-history.yaml")
+	assert.Contains(t, files, "example/operator/operator.yaml")
+
+	assert.Equal(t, [][]string{{"arango", "Plan"}, {"arango", "Current"}}, fetched)
+	assert.JSONEq(t, `{"key":"value"}`, string(files["example/agency/plan.json"]))
+	assert.JSONEq(t, `{"key":"value"}`, string(files["example/agency/current.json"]))
+
+	require.Contains(t, files, "example/secrets/example-jwt.yaml")
+	assert.NotContains(t, string(files["example/secrets/example-jwt.yaml"]), "c2VjcmV0")
+	assert.Contains(t, string(files["example/secrets/example-jwt.yaml"]), RedactedValue)
+
+	var events []core.Event
+	require.NoError(t, yaml.Unmarshal(files["example/events.yaml"], &events))
+	require.Len(t, events, 1)
+	assert.Equal(t, "pod-event", events[0].GetName())
+}
@@ -0,0 +1,138 @@
+//
+// DISCLAIMER
+//
+// Copyright 2020 ArangoDB GmbH, Cologne, Germany
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// Copyright holder is ArangoDB GmbH, Cologne, Germany
+//
+
+package debug
+
+import (
+	"fmt"
+	"sort"
+
+	core "k8s.io/api/core/v1"
+	meta "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	api "github.com/arangodb/kube-arangodb/pkg/apis/deployment/v1"
+)
+
+// StatusHistoryEntry is a single point in the history of a deployment.
+type StatusHistoryEntry struct {
+	Time    meta.Time `json:"time"`
+	Object  string    `json:"object"`
+	Message string    `json:"message"`
+}
+
+// StatusHistory builds the history of the deployment from the timestamps recorded in its status
+// and from the given events. Entries are ordered from the oldest to the newest one.
+func StatusHistory(depl *api.ArangoDeployment, events []core.Event) []StatusHistoryEntry {
+	var history []StatusHistoryEntry
+	add := func(t meta.Time, object, message string, args ...interface{}) {
+		if t.IsZero() {
+			return
+		}
+		history = append(history, StatusHistoryEntry{
+			Time:    t,
+			Object:  object,
+			Message: fmt.Sprintf(message, args...),
+		})
+	}
+
+	status := depl.Status
+	deplObject := "deployment/" + depl.GetName()
+
+	add(depl.GetCreationTimestamp(), deplObject, "Deployment created")
+
+	for _, c := range status.Conditions {
+		add(c.LastTransitionTime, deplObject, "Condition %s changed to %s%s", c.Type, c.Status, conditionDetails(c))
+	}
+
+	status.Members.ForeachServerGroup(func(group api.ServerGroup, list api.MemberStatusList) error {
+		for _, m := range list {
+			memberObject := fmt.Sprintf("%s/%s", group.AsRole(), m.ID)
+			add(m.CreatedAt, memberObject, "Member created")
+			for _, t := range m.RecentTerminations {
+				add(t, memberObject, "Member terminated")
+			}
+			for _, c := range m.Conditions {
+				add(c.LastTransitionTime, memberObject, "Condition %s changed to %s%s", c.Type, c.Status, conditionDetails(c))
+			}
+		}
+		return nil
+	})
+
+	for _, action := range status.Plan {
+		actionObject := "action/" + action.ID
+		target := action.Group.AsRole()
+		if action.MemberID != "" {
+			target += "/" + action.MemberID
+		}
+		add(action.CreationTime, actionObject, "Action %s on %s added: %s", action.Type, target, action.Reason)
+		if action.StartTime != nil {
+			add(*action.StartTime, actionObject, "Action %s started", action.Type)
+		}
+	}
+
+	if r := status.Rollback; r != nil && r.Time != nil {
+		add(*r.Time, deplObject, "Rollback from %s to %s started", r.ToImage, r.FromImage)
+	}
+
+	if c := status.Canary; c != nil && c.SoakStartTime != nil {
+		add(*c.SoakStartTime, deplObject, "Canary soak of %s started", c.Image)
+	}
+
+	addRotation := func(name string, rotation *api.DeploymentStatusSecretRotation) {
+		if rotation == nil {
+			return
+		}
+		for _, e := range rotation.History {
+			add(e.Created, deplObject, "%s key %s created", name, e.SHA)
+			if e.Replaced != nil {
+				add(*e.Replaced, deplObject, "%s key %s replaced", name, e.SHA)
+			}
+		}
+	}
+	addRotation("JWT", status.Hashes.JWT.Rotation)
+	addRotation("Encryption", status.Hashes.Encryption.Rotation)
+
+	for _, e := range events {
+		eventObject := fmt.Sprintf("%s/%s", e.InvolvedObject.Kind, e.InvolvedObject.Name)
+		t := e.LastTimestamp
+		if t.IsZero() {
+			t = e.FirstTimestamp
+		}
+		add(t, eventObject, "Event %s %s: %s (count %d)", e.Type, e.Reason, e.Message, e.Count)
+	}
+
+	sort.SliceStable(history, func(i, j int) bool {
+		return history[i].Time.Before(&history[j].Time)
+	})
+
+	return history
+}
+
+// conditionDetails returns the reason and message of the condition.
+func conditionDetails(c api.Condition) string {
+	switch {
+	case c.Reason == "" && c.Message == "":
+		return ""
+	case c.Message == "":
+		return fmt.Sprintf(" (%s)", c.Reason)
+	default:
+		return fmt.Sprintf(" (%s: %s)", c.Reason, c.Message)
+	}
+}
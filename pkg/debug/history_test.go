@@ -0,0 +1,92 @@
+//
+// DISCLAIMER
+//
+// Copyright 2020 ArangoDB GmbH, Cologne, Germany
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// Copyright holder is ArangoDB GmbH, Cologne, Germany
+//
+
+package debug
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	core "k8s.io/api/core/v1"
+	meta "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	api "github.com/arangodb/kube-arangodb/pkg/apis/deployment/v1"
+)
+
+func Test_StatusHistory(t *testing.T) {
+	at := func(minutes int) meta.Time {
+		return meta.NewTime(time.Date(2020, 10, 1, 12, minutes, 0, 0, time.UTC))
+	}
+	started := at(5)
+
+	depl := &api.ArangoDeployment{
+		ObjectMeta: meta.ObjectMeta{
+			Name:              "example",
+			CreationTimestamp: at(0),
+		},
+		Status: api.DeploymentStatus{
+			Conditions: api.ConditionList{
+				{Type: api.ConditionTypeReady, Status: core.ConditionTrue, LastTransitionTime: at(3)},
+			},
+			Members: api.DeploymentStatusMembers{
+				Agents: api.MemberStatusList{
+					{
+						ID:                 "AGNT-1",
+						CreatedAt:          at(1),
+						RecentTerminations: []meta.Time{at(6)},
+					},
+				},
+			},
+			Plan: api.Plan{
+				{
+					ID:           "action-1",
+					Type:         api.ActionTypeRotateMember,
+					Group:        api.ServerGroupAgents,
+					MemberID:     "AGNT-1",
+					CreationTime: at(4),
+					StartTime:    &started,
+					Reason:       "Pod needs rotation",
+				},
+			},
+		},
+	}
+
+	events := []core.Event{
+		{
+			InvolvedObject: core.ObjectReference{Kind: "Pod", Name: "example-agnt-1"},
+			Type:           core.EventTypeNormal,
+			Reason:         "Created",
+			Message:        "Created container",
+			Count:          1,
+			LastTimestamp:  at(2),
+		},
+	}
+
+	assert.Equal(t, []StatusHistoryEntry{
+		{Time: at(0), Object: "deployment/example", Message: "Deployment created"},
+		{Time: at(1), Object: "agent/AGNT-1", Message: "Member created"},
+		{Time: at(2), Object: "Pod/example-agnt-1", Message: "Event Normal Created: Created container (count 1)"},
+		{Time: at(3), Object: "deployment/example", Message: "Condition Ready changed to True"},
+		{Time: at(4), Object: "action/action-1", Message: "Action RotateMember on agent/AGNT-1 added: Pod needs rotation"},
+		{Time: at(5), Object: "action/action-1", Message: "Action RotateMember started"},
+		{Time: at(6), Object: "agent/AGNT-1", Message: "Member terminated"},
+	}, StatusHistory(depl, events))
+}
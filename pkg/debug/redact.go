@@ -0,0 +1,65 @@
+//
+// DISCLAIMER
+//
+// Copyright 2020 ArangoDB GmbH, Cologne, Germany
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// Copyright holder is ArangoDB GmbH, Cologne, Germany
+//
+
+package debug
+
+import (
+	core "k8s.io/api/core/v1"
+	meta "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+const (
+	// RedactedValue replaces all sensitive values stored in the bundle.
+	RedactedValue = "<redacted>"
+
+	lastAppliedConfigurationAnnotation = "kubectl.kubernetes.io/last-applied-configuration"
+)
+
+// RedactSecret returns a copy of the secret with all values replaced.
+// Keys are kept, so it is still visible which data the secret holds.
+func RedactSecret(secret *core.Secret) *core.Secret {
+	s := secret.DeepCopy()
+
+	stringData := map[string]string{}
+	for k := range s.Data {
+		stringData[k] = RedactedValue
+	}
+	for k := range s.StringData {
+		stringData[k] = RedactedValue
+	}
+
+	s.Data = nil
+	s.StringData = stringData
+
+	redactAnnotations(s)
+
+	return s
+}
+
+// redactAnnotations removes the annotations which can contain a full copy of the object.
+func redactAnnotations(obj meta.Object) {
+	annotations := obj.GetAnnotations()
+	if _, ok := annotations[lastAppliedConfigurationAnnotation]; !ok {
+		return
+	}
+
+	annotations[lastAppliedConfigurationAnnotation] = RedactedValue
+	obj.SetAnnotations(annotations)
+}
@@ -26,4 +26,5 @@ const (
 	ArangoKey          = "arango"
 	PlanKey            = "Plan"
 	PlanCollectionsKey = "Collections"
+	CurrentKey         = "Current"
 )
@@ -55,11 +55,16 @@ import (
 	"github.com/arangodb/go-driver/agency"
 	"github.com/rs/zerolog/log"
 	meta "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/client-go/dynamic"
 	"k8s.io/client-go/kubernetes"
 
 	backupApi "github.com/arangodb/kube-arangodb/pkg/apis/backup/v1"
 	api "github.com/arangodb/kube-arangodb/pkg/apis/deployment/v1"
 	"github.com/arangodb/kube-arangodb/pkg/deployment/resources"
+	"github.com/arangodb/kube-arangodb/pkg/generated/clientset/versioned"
+	backupListers "github.com/arangodb/kube-arangodb/pkg/generated/listers/backup/v1"
+	listers "github.com/arangodb/kube-arangodb/pkg/generated/listers/deployment/v1"
 	"github.com/arangodb/kube-arangodb/pkg/util/k8sutil"
 	v1 "k8s.io/api/core/v1"
 )
@@ -69,6 +74,62 @@ func (d *Deployment) GetBackup(backup string) (*backupApi.ArangoBackup, error) {
 	return d.deps.DatabaseCRCli.BackupV1().ArangoBackups(d.Namespace()).Get(backup, meta.GetOptions{})
 }
 
+// GetBackups returns all backup resources created for this deployment.
+// Backups are listed from the cache, an error is returned when it is not available or not synced yet.
+func (d *Deployment) GetBackups() ([]backupApi.ArangoBackup, error) {
+	indexer, ok := d.resourceWatchers.indexer("arangobackups")
+	if !ok {
+		return nil, fmt.Errorf("ArangoBackup resources are not watched or not synced yet")
+	}
+
+	list, err := backupListers.NewArangoBackupLister(indexer).ArangoBackups(d.Namespace()).List(labels.Everything())
+	if err != nil {
+		return nil, err
+	}
+
+	var backups []backupApi.ArangoBackup
+	for _, backup := range list {
+		if backup.Spec.Deployment.Name == d.GetName() {
+			backups = append(backups, *backup)
+		}
+	}
+
+	return backups, nil
+}
+
+// GetArangoDatabaseLister returns the lister of ArangoDatabase resources in the namespace of the deployment.
+// Returns false when the resources are not watched or the cache is not synced yet.
+func (d *Deployment) GetArangoDatabaseLister() (listers.ArangoDatabaseNamespaceLister, bool) {
+	indexer, ok := d.resourceWatchers.indexer("arangodatabases")
+	if !ok {
+		return nil, false
+	}
+
+	return listers.NewArangoDatabaseLister(indexer).ArangoDatabases(d.Namespace()), true
+}
+
+// GetArangoCollectionLister returns the lister of ArangoCollection resources in the namespace of the deployment.
+// Returns false when the resources are not watched or the cache is not synced yet.
+func (d *Deployment) GetArangoCollectionLister() (listers.ArangoCollectionNamespaceLister, bool) {
+	indexer, ok := d.resourceWatchers.indexer("arangocollections")
+	if !ok {
+		return nil, false
+	}
+
+	return listers.NewArangoCollectionLister(indexer).ArangoCollections(d.Namespace()), true
+}
+
+// GetArangoUserLister returns the lister of ArangoUser resources in the namespace of the deployment.
+// Returns false when the resources are not watched or the cache is not synced yet.
+func (d *Deployment) GetArangoUserLister() (listers.ArangoUserNamespaceLister, bool) {
+	indexer, ok := d.resourceWatchers.indexer("arangousers")
+	if !ok {
+		return nil, false
+	}
+
+	return listers.NewArangoUserLister(indexer).ArangoUsers(d.Namespace()), true
+}
+
 // GetAPIObject returns the deployment as k8s object.
 func (d *Deployment) GetAPIObject() k8sutil.APIObject {
 	return d.apiObject
@@ -88,6 +149,11 @@ func (d *Deployment) GetMonitoringV1Cli() monitoringClient.MonitoringV1Interface
 	return d.deps.KubeMonitoringCli
 }
 
+// GetDynamicCli returns the dynamic client
+func (d *Deployment) GetDynamicCli() dynamic.Interface {
+	return d.deps.KubeDynamicCli
+}
+
 func (d *Deployment) GetScope() scope.Scope {
 	return d.config.Scope
 }
@@ -563,6 +629,11 @@ func (d *Deployment) WithStatusUpdate(action func(s *api.DeploymentStatus) bool,
 	return d.updateStatus(status, version, force...)
 }
 
+// GetArangoCli returns the client for ArangoDB custom resources
+func (d *Deployment) GetArangoCli() versioned.Interface {
+	return d.deps.DatabaseCRCli
+}
+
 func (d *Deployment) SecretsInterface() k8sutil.SecretInterface {
 	return d.GetKubeCli().CoreV1().Secrets(d.GetNamespace())
 }
@@ -43,11 +43,13 @@ import (
 	"github.com/rs/zerolog"
 	apiextensionsclient "k8s.io/apiextensions-apiserver/pkg/client/clientset/clientset"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/dynamic"
 	"k8s.io/client-go/kubernetes"
 	"k8s.io/client-go/tools/record"
 
 	api "github.com/arangodb/kube-arangodb/pkg/apis/deployment/v1"
 	"github.com/arangodb/kube-arangodb/pkg/deployment/chaos"
+	"github.com/arangodb/kube-arangodb/pkg/deployment/provisioning"
 	"github.com/arangodb/kube-arangodb/pkg/deployment/reconcile"
 	"github.com/arangodb/kube-arangodb/pkg/deployment/resilience"
 	"github.com/arangodb/kube-arangodb/pkg/deployment/resources"
@@ -74,6 +76,7 @@ type Dependencies struct {
 	KubeCli           kubernetes.Interface
 	KubeExtCli        apiextensionsclient.Interface
 	KubeMonitoringCli monitoringClient.MonitoringV1Interface
+	KubeDynamicCli    dynamic.Interface
 	DatabaseCRCli     versioned.Interface
 	EventRecorder     record.EventRecorder
 }
@@ -91,6 +94,12 @@ type deploymentEvent struct {
 	Deployment *api.ArangoDeployment
 }
 
+const (
+	deploymentRunning int32 = iota
+	deploymentDeleted
+	deploymentHandedOver
+)
+
 const (
 	deploymentEventQueueSize = 256
 	minInspectionInterval    = 250 * util.Interval(time.Millisecond) // Ensure we inspect the generated resources no less than with this interval
@@ -110,6 +119,7 @@ type Deployment struct {
 
 	eventCh chan *deploymentEvent
 	stopCh  chan struct{}
+	doneCh  chan struct{}
 	stopped int32
 
 	inspectTrigger            trigger.Trigger
@@ -121,9 +131,12 @@ type Deployment struct {
 	reconciler                *reconcile.Reconciler
 	resilience                *resilience.Resilience
 	resources                 *resources.Resources
+	provisioner               *provisioning.Provisioner
+	resourceWatchers          resourceWatchers
 	chaosMonkey               *chaos.Monkey
 	syncClientCache           client.ClientCache
 	haveServiceMonitorCRD     bool
+	images                    imagesState
 }
 
 // New creates a new Deployment from the given API object.
@@ -138,6 +151,7 @@ func New(config Config, deps Dependencies, apiObject *api.ArangoDeployment) (*De
 		deps:      deps,
 		eventCh:   make(chan *deploymentEvent, deploymentEventQueueSize),
 		stopCh:    make(chan struct{}),
+		doneCh:    make(chan struct{}),
 	}
 
 	d.clientCache = newClientCache(d.getArangoDeployment, conn.NewFactory(d.getAuth, d.getConnConfig))
@@ -146,6 +160,7 @@ func New(config Config, deps Dependencies, apiObject *api.ArangoDeployment) (*De
 	d.reconciler = reconcile.NewReconciler(deps.Log, d)
 	d.resilience = resilience.NewResilience(deps.Log, d)
 	d.resources = resources.NewResources(deps.Log, d)
+	d.provisioner = provisioning.NewProvisioner(deps.Log, d)
 	if d.status.last.AcceptedSpec == nil {
 		// We've validated the spec, so let's use it from now.
 		d.status.last.AcceptedSpec = apiObject.Spec.DeepCopy()
@@ -157,6 +172,10 @@ func New(config Config, deps Dependencies, apiObject *api.ArangoDeployment) (*De
 	go d.listenForSecretEvents(d.stopCh)
 	go d.listenForServiceEvents(d.stopCh)
 	go d.listenForCRDEvents(d.stopCh)
+	go d.listenForArangoDatabaseEvents(d.stopCh)
+	go d.listenForArangoCollectionEvents(d.stopCh)
+	go d.listenForArangoUserEvents(d.stopCh)
+	go d.listenForArangoBackupEvents(d.stopCh)
 	if apiObject.Spec.GetMode() == api.DeploymentModeCluster {
 		ci := newClusterScalingIntegration(d)
 		d.clusterScalingIntegration = ci
@@ -185,11 +204,26 @@ func (d *Deployment) Update(apiObject *api.ArangoDeployment) {
 // Called when the deployment was deleted by the user.
 func (d *Deployment) Delete() {
 	d.deps.Log.Info().Msg("deployment is deleted by user")
-	if atomic.CompareAndSwapInt32(&d.stopped, 0, 1) {
+	if atomic.CompareAndSwapInt32(&d.stopped, deploymentRunning, deploymentDeleted) {
+		close(d.stopCh)
+	}
+}
+
+// Stop the deployment without removing finalizers from its resources.
+// Called when the deployment is handed over to another operator replica.
+// The running inspection is not interrupted, use Done to wait for it.
+func (d *Deployment) Stop() {
+	d.deps.Log.Info().Msg("deployment is handed over")
+	if atomic.CompareAndSwapInt32(&d.stopped, deploymentRunning, deploymentHandedOver) {
 		close(d.stopCh)
 	}
 }
 
+// Done returns a channel which is closed when the deployment has stopped.
+func (d *Deployment) Done() <-chan struct{} {
+	return d.doneCh
+}
+
 // send given event into the deployment event queue.
 func (d *Deployment) send(ev *deploymentEvent) {
 	select {
@@ -210,6 +244,7 @@ func (d *Deployment) send(ev *deploymentEvent) {
 // resource on a regular basis.
 func (d *Deployment) run() {
 	log := d.deps.Log
+	defer close(d.doneCh)
 
 	if d.GetPhase() == api.DeploymentPhaseNone {
 		// Create service monitor
@@ -243,6 +278,11 @@ func (d *Deployment) run() {
 	for {
 		select {
 		case <-d.stopCh:
+			if atomic.LoadInt32(&d.stopped) == deploymentHandedOver {
+				// Resources are still managed by another replica
+				return
+			}
+
 			cachedStatus, err := inspector.NewInspector(d.GetKubeCli(), d.GetMonitoringV1Cli(), d.GetNamespace())
 			if err != nil {
 				log.Error().Err(err).Msg("Unable to get resources")
@@ -255,6 +295,9 @@ func (d *Deployment) run() {
 			if err := d.removePVCFinalizers(cachedStatus); err != nil {
 				log.Warn().Err(err).Msg("Failed to remove PVC finalizers")
 			}
+			if err := d.provisioner.RemoveFinalizers(); err != nil {
+				log.Warn().Err(err).Msg("Failed to remove ArangoDatabase, ArangoCollection and ArangoUser finalizers")
+			}
 			// We're being stopped.
 			return
 
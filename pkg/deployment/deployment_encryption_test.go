@@ -24,6 +24,7 @@ package deployment
 
 import (
 	"fmt"
+	"os"
 	"testing"
 
 	"github.com/arangodb/kube-arangodb/pkg/util/constants"
@@ -36,9 +37,13 @@ import (
 
 	api "github.com/arangodb/kube-arangodb/pkg/apis/deployment/v1"
 	core "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
 
 func TestEnsurePod_ArangoDB_Encryption(t *testing.T) {
+	binaryPath, _ := os.Executable()
+
 	testCases := []testCaseStruct{
 		{
 			Name: "Agent CE 3.7.0 Pod with encrypted rocksdb",
@@ -327,6 +332,153 @@ func TestEnsurePod_ArangoDB_Encryption(t *testing.T) {
 				},
 			},
 		},
+		{
+			Name: "Agent EE 3.7.0 Pod with encrypted rocksdb, keys wrapped by provider",
+			ArangoDeployment: &api.ArangoDeployment{
+				Spec: api.DeploymentSpec{
+					Image:          util.NewString(testImage),
+					Authentication: noAuthentication,
+					TLS:            noTLS,
+					RocksDB: api.RocksDBSpec{
+						Encryption: api.RocksDBEncryptionSpec{
+							KeySecretName: util.NewString(testRocksDBEncryptionKey),
+							Provider: &api.RocksDBEncryptionProviderSpec{
+								Type:                  api.NewRocksDBEncryptionProviderType(api.RocksDBEncryptionProviderWebhook),
+								Endpoint:              util.NewString("http://unwrap.kms.svc/unwrap"),
+								CredentialsSecretName: util.NewString(testEncryptionProviderSecretName),
+							},
+						},
+					},
+				},
+			},
+			Features: testCaseFeatures{
+				EncryptionRotation: true,
+			},
+			config: Config{
+				LifecycleImage: testImageLifecycle,
+			},
+			Helper: func(t *testing.T, deployment *Deployment, testCase *testCaseStruct) {
+				deployment.status.last = api.DeploymentStatus{
+					Members: api.DeploymentStatusMembers{
+						Agents: api.MemberStatusList{
+							firstAgentStatus,
+						},
+					},
+					Images: createTestImagesWithVersion(true, "3.7.0"),
+				}
+
+				testCase.createTestPodData(deployment, api.ServerGroupAgents, firstAgentStatus)
+
+				secrets := deployment.GetKubeCli().CoreV1().Secrets(testNamespace)
+				_, err := secrets.Create(&core.Secret{
+					ObjectMeta: metav1.ObjectMeta{Name: testRocksDBEncryptionKey},
+					Data:       map[string][]byte{constants.SecretEncryptionKey: []byte("wrapped")},
+				})
+				require.NoError(t, err)
+				_, err = secrets.Create(&core.Secret{
+					ObjectMeta: metav1.ObjectMeta{Name: testEncryptionProviderSecretName},
+					Data:       map[string][]byte{"token": []byte("token")},
+				})
+				require.NoError(t, err)
+			},
+			ExpectedEvent: "member agent is created",
+			ExpectedPod: core.Pod{
+				Spec: core.PodSpec{
+					Volumes: []core.Volume{
+						k8sutil.CreateVolumeEmptyDir(k8sutil.ArangodVolumeName),
+						k8sutil.CreateVolumeWithSecret(k8sutil.RocksdbEncryptionWrappedVolumeName, fmt.Sprintf("%s-encryption-folder", testDeploymentName)),
+						{
+							Name: k8sutil.RocksdbEncryptionVolumeName,
+							VolumeSource: core.VolumeSource{
+								EmptyDir: &core.EmptyDirVolumeSource{Medium: core.StorageMediumMemory},
+							},
+						},
+						k8sutil.CreateVolumeWithSecret(k8sutil.RocksdbEncryptionProviderVolumeName, testEncryptionProviderSecretName),
+						k8sutil.LifecycleVolume(),
+					},
+					InitContainers: []core.Container{
+						createTestLifecycleContainer(emptyResources),
+						{
+							Name:  "encryption-keys",
+							Image: testImageLifecycle,
+							Command: []string{
+								binaryPath, "encryption", "unwrap",
+								"--input", k8sutil.RocksDBEncryptionWrappedVolumeMountDir,
+								"--output", k8sutil.RocksDBEncryptionVolumeMountDir,
+								"--provider.type", "Webhook",
+								"--provider.endpoint", "http://unwrap.kms.svc/unwrap",
+								"--provider.key-name", "",
+								"--provider.mount-path", "transit",
+								"--report", core.TerminationMessagePathDefault,
+								"--provider.credentials", k8sutil.RocksDBEncryptionProviderVolumeMountDir,
+							},
+							Resources: core.ResourceRequirements{
+								Requests: core.ResourceList{
+									core.ResourceCPU:    resource.MustParse("100m"),
+									core.ResourceMemory: resource.MustParse("10Mi"),
+								},
+								Limits: core.ResourceList{
+									core.ResourceCPU:    resource.MustParse("100m"),
+									core.ResourceMemory: resource.MustParse("50Mi"),
+								},
+							},
+							VolumeMounts: []core.VolumeMount{
+								{
+									Name:      k8sutil.RocksdbEncryptionWrappedVolumeName,
+									MountPath: k8sutil.RocksDBEncryptionWrappedVolumeMountDir,
+									ReadOnly:  true,
+								},
+								k8sutil.RocksdbEncryptionVolumeMount(),
+								{
+									Name:      k8sutil.RocksdbEncryptionProviderVolumeName,
+									MountPath: k8sutil.RocksDBEncryptionProviderVolumeMountDir,
+									ReadOnly:  true,
+								},
+							},
+							ImagePullPolicy: core.PullIfNotPresent,
+							SecurityContext: securityContext.NewSecurityContext(),
+						},
+					},
+					Containers: []core.Container{
+						{
+							Name:  k8sutil.ServerContainerName,
+							Image: testImage,
+							Command: BuildTestAgentArgs(t, firstAgentStatus.ID,
+								AgentArgsWithTLS(firstAgentStatus.ID, false),
+								ArgsWithAuth(false),
+								ArgsWithEncryptionFolder(), func(t *testing.T) map[string]string {
+									return map[string]string{
+										"rocksdb.encryption-key-rotation": "true",
+									}
+								}),
+							Env: []core.EnvVar{
+								k8sutil.CreateEnvFieldPath(constants.EnvOperatorPodName, "metadata.name"),
+								k8sutil.CreateEnvFieldPath(constants.EnvOperatorPodNamespace, "metadata.namespace"),
+								k8sutil.CreateEnvFieldPath(constants.EnvOperatorNodeName, "spec.nodeName"),
+								k8sutil.CreateEnvFieldPath(constants.EnvOperatorNodeNameArango, "spec.nodeName"),
+							},
+							Ports:     createTestPorts(),
+							Lifecycle: createTestLifecycle(),
+							VolumeMounts: []core.VolumeMount{
+								k8sutil.ArangodVolumeMount(),
+								k8sutil.LifecycleVolumeMount(),
+								k8sutil.RocksdbEncryptionReadOnlyVolumeMount(),
+							},
+							Resources:       emptyResources,
+							LivenessProbe:   createTestLivenessProbe(httpProbe, false, "", k8sutil.ArangoPort),
+							ImagePullPolicy: core.PullIfNotPresent,
+							SecurityContext: securityContext.NewSecurityContext(),
+						},
+					},
+					RestartPolicy:                 core.RestartPolicyNever,
+					TerminationGracePeriodSeconds: &defaultAgentTerminationTimeout,
+					Hostname:                      testDeploymentName + "-" + api.ServerGroupAgentsString + "-" + firstAgentStatus.ID,
+					Subdomain:                     testDeploymentName + "-int",
+					Affinity: k8sutil.CreateAffinity(testDeploymentName, api.ServerGroupAgentsString,
+						false, ""),
+				},
+			},
+		},
 	}
 
 	runTestCases(t, testCases...)
@@ -168,6 +168,11 @@ func (d *Deployment) inspectDeploymentWithError(ctx context.Context, lastInterva
 		return minInspectionInterval, errors.Wrapf(err, "License Key Secret invalid")
 	}
 
+	// Check for certificates expiry
+	if err := d.resources.InspectCertificates(cachedStatus); err != nil {
+		return minInspectionInterval, errors.Wrapf(err, "Certificate inspection failed")
+	}
+
 	// Is the deployment in a good state?
 	if status.Conditions.IsTrue(api.ConditionTypeSecretsChanged) {
 		return minInspectionInterval, errors.Errorf("Secrets changed")
@@ -269,6 +274,13 @@ func (d *Deployment) inspectDeploymentWithError(ctx context.Context, lastInterva
 		nextInterval = nextInterval.ReduceTo(x)
 	}
 
+	// Provision databases and users once the deployment is bootstrapped
+	if status.Conditions.IsTrue(api.ConditionTypeBootstrapCompleted) {
+		if err := d.provisioner.Inspect(ctx); err != nil {
+			d.deps.Log.Warn().Err(err).Msg("Database and user provisioning failed")
+		}
+	}
+
 	return
 }
 
@@ -292,6 +304,10 @@ func (d *Deployment) ensureResources(lastInterval util.Interval, cachedStatus in
 		return minInspectionInterval, errors.Wrapf(err, "PDB creation failed")
 	}
 
+	if err := d.resources.EnsureNetworkPolicies(); err != nil {
+		return minInspectionInterval, errors.Wrapf(err, "NetworkPolicy creation failed")
+	}
+
 	if err := d.resources.EnsureAnnotations(cachedStatus); err != nil {
 		return minInspectionInterval, errors.Wrapf(err, "Annotation update failed")
 	}
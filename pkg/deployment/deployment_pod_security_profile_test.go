@@ -0,0 +1,121 @@
+//
+// DISCLAIMER
+//
+// Copyright 2020 ArangoDB GmbH, Cologne, Germany
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// Copyright holder is ArangoDB GmbH, Cologne, Germany
+//
+
+package deployment
+
+import (
+	"testing"
+
+	api "github.com/arangodb/kube-arangodb/pkg/apis/deployment/v1"
+	"github.com/arangodb/kube-arangodb/pkg/deployment/pod"
+	"github.com/arangodb/kube-arangodb/pkg/util"
+	"github.com/arangodb/kube-arangodb/pkg/util/k8sutil"
+	core "k8s.io/api/core/v1"
+)
+
+func TestEnsurePod_ArangoDB_SecurityProfile(t *testing.T) {
+	restrictedSecurityContext := &core.SecurityContext{
+		Privileged:               util.NewBool(false),
+		AllowPrivilegeEscalation: util.NewBool(false),
+		RunAsNonRoot:             util.NewBool(true),
+		Capabilities: &core.Capabilities{
+			Drop: []core.Capability{"ALL"},
+		},
+	}
+
+	testCases := []testCaseStruct{
+		{
+			Name: "Agent Pod with restricted security profile and sidecar",
+			ArangoDeployment: &api.ArangoDeployment{
+				Spec: api.DeploymentSpec{
+					Image:           util.NewString(testImage),
+					Authentication:  noAuthentication,
+					TLS:             noTLS,
+					SecurityProfile: api.SecurityProfileRestricted.New(),
+					Agents: api.ServerGroupSpec{
+						Sidecars: []core.Container{
+							{
+								Name:  "nginx",
+								Image: "nginx:1.7.9",
+							},
+						},
+					},
+				},
+			},
+			Helper: func(t *testing.T, deployment *Deployment, testCase *testCaseStruct) {
+				deployment.status.last = api.DeploymentStatus{
+					Members: api.DeploymentStatusMembers{
+						Agents: api.MemberStatusList{
+							firstAgentStatus,
+						},
+					},
+					Images: createTestImages(false),
+				}
+
+				testCase.createTestPodData(deployment, api.ServerGroupAgents, firstAgentStatus)
+				testCase.ExpectedPod.ObjectMeta.Annotations = map[string]string{
+					pod.SeccompPodAnnotation: pod.SeccompRuntimeDefault,
+				}
+			},
+			ExpectedEvent: "member agent is created",
+			ExpectedPod: core.Pod{
+				Spec: core.PodSpec{
+					Volumes: []core.Volume{
+						k8sutil.CreateVolumeEmptyDir(k8sutil.ArangodVolumeName),
+					},
+					Containers: []core.Container{
+						{
+							Name:    k8sutil.ServerContainerName,
+							Image:   testImage,
+							Command: createTestCommandForAgent(firstAgentStatus.ID, false, false, false),
+							Ports:   createTestPorts(),
+							VolumeMounts: []core.VolumeMount{
+								k8sutil.ArangodVolumeMount(),
+							},
+							Resources:       emptyResources,
+							LivenessProbe:   createTestLivenessProbe(httpProbe, false, "", k8sutil.ArangoPort),
+							ImagePullPolicy: core.PullIfNotPresent,
+							SecurityContext: restrictedSecurityContext,
+						},
+						{
+							Name:            "nginx",
+							Image:           "nginx:1.7.9",
+							SecurityContext: restrictedSecurityContext,
+						},
+					},
+					SecurityContext: &core.PodSecurityContext{
+						RunAsNonRoot: util.NewBool(true),
+						RunAsUser:    util.NewInt64(api.SecurityProfileRestrictedUser),
+						RunAsGroup:   util.NewInt64(api.SecurityProfileRestrictedUser),
+						FSGroup:      util.NewInt64(api.SecurityProfileRestrictedUser),
+					},
+					RestartPolicy:                 core.RestartPolicyNever,
+					TerminationGracePeriodSeconds: &defaultAgentTerminationTimeout,
+					Hostname:                      testDeploymentName + "-" + api.ServerGroupAgentsString + "-" + firstAgentStatus.ID,
+					Subdomain:                     testDeploymentName + "-int",
+					Affinity: k8sutil.CreateAffinity(testDeploymentName, api.ServerGroupAgentsString,
+						false, ""),
+				},
+			},
+		},
+	}
+
+	runTestCases(t, testCases...)
+}
@@ -46,26 +46,29 @@ import (
 	"github.com/rs/zerolog"
 	core "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	dynamicfake "k8s.io/client-go/dynamic/fake"
 	"k8s.io/client-go/kubernetes/fake"
 	recordfake "k8s.io/client-go/tools/record"
 )
 
 const (
-	testNamespace                 = "default"
-	testDeploymentName            = "test"
-	testVersion                   = "3.5.2"
-	testImage                     = "arangodb/arangodb:" + testVersion
-	testCASecretName              = "testCA"
-	testJWTSecretName             = "testJWT"
-	testExporterToken             = "testExporterToken"
-	testRocksDBEncryptionKey      = "testRocksDB"
-	testPersistentVolumeClaimName = "testClaim"
-	testLicense                   = "testLicense"
-	testServiceAccountName        = "testServiceAccountName"
-	testPriorityClassName         = "testPriority"
-	testImageLifecycle            = "arangodb/kube-arangodb:0.3.16"
-	testExporterImage             = "arangodb/arangodb-exporter:0.1.6"
-	testImageOperatorUUIDInit     = "image/test-1234:3.7"
+	testNamespace                    = "default"
+	testDeploymentName               = "test"
+	testVersion                      = "3.5.2"
+	testImage                        = "arangodb/arangodb:" + testVersion
+	testCASecretName                 = "testCA"
+	testJWTSecretName                = "testJWT"
+	testExporterToken                = "testExporterToken"
+	testRocksDBEncryptionKey         = "testRocksDB"
+	testEncryptionProviderSecretName = "testEncryptionProvider"
+	testPersistentVolumeClaimName    = "testClaim"
+	testLicense                      = "testLicense"
+	testServiceAccountName           = "testServiceAccountName"
+	testPriorityClassName            = "testPriority"
+	testImageLifecycle               = "arangodb/kube-arangodb:0.3.16"
+	testExporterImage                = "arangodb/arangodb-exporter:0.1.6"
+	testImageOperatorUUIDInit        = "image/test-1234:3.7"
 
 	testYes = "yes"
 )
@@ -465,6 +468,7 @@ func createTestDeployment(config Config, arangoDeployment *api.ArangoDeployment)
 		Log:               zerolog.New(ioutil.Discard),
 		KubeCli:           kubernetesClientSet,
 		KubeMonitoringCli: monitoringClientSet.MonitoringV1(),
+		KubeDynamicCli:    dynamicfake.NewSimpleDynamicClient(runtime.NewScheme()),
 		DatabaseCRCli:     arangofake.NewSimpleClientset(&api.ArangoDeployment{}),
 		EventRecorder:     eventRecorder,
 	}
@@ -26,6 +26,7 @@ import (
 	"context"
 	"crypto/sha1"
 	"fmt"
+	"net/http"
 	"strings"
 	"time"
 
@@ -44,6 +45,14 @@ import (
 	"github.com/arangodb/kube-arangodb/pkg/util/arangod"
 	"github.com/arangodb/kube-arangodb/pkg/util/constants"
 	"github.com/arangodb/kube-arangodb/pkg/util/k8sutil"
+	"github.com/arangodb/kube-arangodb/pkg/util/registry"
+	"github.com/pkg/errors"
+)
+
+const (
+	imageVerificationRetryInterval = time.Minute
+	imageVerificationInterval      = time.Hour
+	imageRegistryTimeout           = 30 * time.Second
 )
 
 var _ interfaces.PodCreator = &ImageUpdatePod{}
@@ -56,7 +65,7 @@ type ImageUpdatePod struct {
 }
 
 func (i *ImageUpdatePod) Annotations() map[string]string {
-	return nil
+	return pod.SecurityProfileAnnotations(i.spec.SecurityProfile)
 }
 
 func (i *ImageUpdatePod) Labels() map[string]string {
@@ -68,13 +77,30 @@ type ArangoDImageUpdateContainer struct {
 	image string
 }
 
+// verifiedImage keeps the digest of the image with a verified signature
+type verifiedImage struct {
+	digest     string
+	verifiedAt time.Time
+}
+
+// imagesState keeps results of image verification and tag drift checks between inspections
+type imagesState struct {
+	verified             map[string]verifiedImage
+	verificationFailedAt time.Time
+	driftCheckedAt       time.Time
+	registryFallback     map[string]bool
+	registryFailedAt     time.Time
+}
+
 type imagesBuilder struct {
-	APIObject      k8sutil.APIObject
-	Spec           api.DeploymentSpec
-	Status         api.DeploymentStatus
-	Log            zerolog.Logger
-	KubeCli        kubernetes.Interface
-	UpdateCRStatus func(status api.DeploymentStatus) error
+	APIObject          k8sutil.APIObject
+	Spec               api.DeploymentSpec
+	Status             api.DeploymentStatus
+	State              *imagesState
+	Log                zerolog.Logger
+	KubeCli            kubernetes.Interface
+	RegistryHTTPClient *http.Client
+	UpdateCRStatus     func(status api.DeploymentStatus) error
 }
 
 // ensureImages creates pods needed to detect ImageID for specified images.
@@ -85,6 +111,7 @@ func (d *Deployment) ensureImages(apiObject *api.ArangoDeployment) (bool, bool,
 		APIObject: apiObject,
 		Spec:      apiObject.Spec,
 		Status:    status,
+		State:     &d.images,
 		Log:       d.deps.Log,
 		KubeCli:   d.deps.KubeCli,
 		UpdateCRStatus: func(status api.DeploymentStatus) error {
@@ -106,23 +133,268 @@ func (d *Deployment) ensureImages(apiObject *api.ArangoDeployment) (bool, bool,
 // image ID's into the status.Images list.
 // Returns: retrySoon, error
 func (ib *imagesBuilder) Run(ctx context.Context) (bool, bool, error) {
+	image := ib.Spec.GetImage()
+
 	// Check ArangoDB image
-	if _, found := ib.Status.Images.GetByImage(ib.Spec.GetImage()); !found {
+	info, found := ib.Status.Images.GetByImage(image)
+	if !found {
+		podImage := image
+		if ib.Spec.ImagePolicy.IsVerificationEnabled() {
+			// Image ID pod is started only with the verified digest
+			digest, verified, err := ib.verifyImage(ctx, image)
+			if err != nil {
+				return true, false, maskAny(err)
+			}
+			if !verified {
+				return false, false, nil
+			}
+			if podImage, err = pinImage(image, digest); err != nil {
+				return false, false, maskAny(err)
+			}
+		}
+
+		if ib.Spec.ImageDiscoveryMode.Get() == api.DeploymentImageDiscoveryRegistryMode {
+			if usePod, err := ib.fetchArangoDBImageIDAndVersionFromRegistry(ctx, image, podImage); err != nil {
+				return true, false, maskAny(err)
+			} else if !usePod {
+				return false, false, nil
+			}
+		}
+
 		// We need to find the image ID for the ArangoDB image
-		retrySoon, err := ib.fetchArangoDBImageIDAndVersion(ctx, ib.Spec.GetImage())
+		retrySoon, err := ib.fetchArangoDBImageIDAndVersion(ctx, image, podImage)
 		if err != nil {
 			return retrySoon, false, maskAny(err)
 		}
 		return retrySoon, false, nil
 	}
 
+	if ib.Spec.ImagePolicy.IsVerificationEnabled() {
+		// Images already in use are verified again, signature or public key could have changed.
+		// A failure is reported by the condition only, so a registry outage does not stall the running deployment.
+		if verified, err := ib.verifyImageInfo(ctx, info); err != nil {
+			return true, true, maskAny(err)
+		} else if !verified {
+			ib.Log.Warn().Str("image", info.Image).Msg("Verification of image in use failed, last verified image is kept")
+		}
+	}
+
+	if err := ib.checkTagDrift(ctx, info); err != nil {
+		return false, true, maskAny(err)
+	}
+
 	return false, true, nil
 }
 
+// verifyImageInfo verifies the signature of the image from the status and ensures
+// that the discovered image ID is the verified digest.
+func (ib *imagesBuilder) verifyImageInfo(ctx context.Context, info api.ImageInfo) (bool, error) {
+	digest, verified, err := ib.verifyImage(ctx, info.Image)
+	if err != nil || !verified {
+		return false, err
+	}
+
+	if current, ok := k8sutil.GetImageDigest(info.ImageID); !ok || current != digest {
+		ib.Log.Warn().Str("image", info.Image).Str("image-id", info.ImageID).Str("digest", digest).Msg("Image ID does not match verified digest")
+		if ib.Status.Conditions.Update(api.ConditionTypeImageVerificationFailed, true, "Digest Mismatch",
+			fmt.Sprintf("Image ID %s does not match verified digest %s", info.ImageID, digest)) {
+			if err := ib.UpdateCRStatus(ib.Status); err != nil {
+				return false, maskAny(err)
+			}
+		}
+		return false, nil
+	}
+
+	return true, nil
+}
+
+// verifyImage verifies the cosign signature of the given image and returns the verified digest.
+// Verification is repeated periodically. Returns false when the image is not verified yet.
+func (ib *imagesBuilder) verifyImage(ctx context.Context, image string) (string, bool, error) {
+	if v, ok := ib.State.verified[image]; ok && time.Since(v.verifiedAt) < imageVerificationInterval {
+		return v.digest, true, nil
+	}
+
+	if time.Since(ib.State.verificationFailedAt) < imageVerificationRetryInterval {
+		return "", false, nil
+	}
+
+	log := ib.Log.With().Str("image", image).Logger()
+
+	digest, err := ib.verifyImageSignature(ctx, image)
+	if err != nil {
+		ib.State.verificationFailedAt = time.Now()
+		log.Warn().Err(err).Msg("Image signature verification failed")
+		if ib.Status.Conditions.Update(api.ConditionTypeImageVerificationFailed, true, "Verification Failed", err.Error()) {
+			if err := ib.UpdateCRStatus(ib.Status); err != nil {
+				return "", false, maskAny(err)
+			}
+		}
+		return "", false, nil
+	}
+
+	log.Info().Str("digest", digest).Msg("Image signature verified")
+	if ib.State.verified == nil {
+		ib.State.verified = map[string]verifiedImage{}
+	}
+	ib.State.verified[image] = verifiedImage{digest: digest, verifiedAt: time.Now()}
+
+	if ib.Status.Conditions.Remove(api.ConditionTypeImageVerificationFailed) {
+		if err := ib.UpdateCRStatus(ib.Status); err != nil {
+			return "", false, maskAny(err)
+		}
+	}
+
+	return digest, true, nil
+}
+
+// verifyImageSignature resolves the digest of the given image and verifies its signature
+// with the public key from the image policy secret
+func (ib *imagesBuilder) verifyImageSignature(ctx context.Context, image string) (string, error) {
+	ref, err := registry.ParseReference(image)
+	if err != nil {
+		return "", maskAny(err)
+	}
+
+	secretName := ib.Spec.ImagePolicy.GetPublicKeySecretName()
+	secret, err := ib.KubeCli.CoreV1().Secrets(ib.APIObject.GetNamespace()).Get(secretName, metav1.GetOptions{})
+	if err != nil {
+		return "", errors.Wrapf(err, "unable to get public key secret %s", secretName)
+	}
+
+	data, ok := secret.Data[constants.SecretKeyCosignPublicKey]
+	if !ok {
+		return "", errors.Errorf("secret %s does not contain %s", secretName, constants.SecretKeyCosignPublicKey)
+	}
+
+	key, err := registry.ParsePublicKey(data)
+	if err != nil {
+		return "", maskAny(err)
+	}
+
+	client, err := ib.registryClient()
+	if err != nil {
+		return "", maskAny(err)
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, imageRegistryTimeout)
+	defer cancel()
+
+	digest := ref.Digest
+	if digest == "" {
+		if digest, err = client.Resolve(ctx, ref); err != nil {
+			return "", maskAny(err)
+		}
+	}
+
+	if err := registry.VerifySignature(ctx, client, ref, digest, key); err != nil {
+		return "", maskAny(err)
+	}
+
+	return digest, nil
+}
+
+// checkTagDrift resolves the tag of the image in the registry and sets the ImageTagDrift condition
+// when the tag points to a different digest than the one in use.
+func (ib *imagesBuilder) checkTagDrift(ctx context.Context, info api.ImageInfo) error {
+	interval := ib.Spec.ImagePolicy.GetDriftCheckInterval()
+	if interval <= 0 {
+		if ib.Status.Conditions.Remove(api.ConditionTypeImageTagDrift) {
+			return maskAny(ib.UpdateCRStatus(ib.Status))
+		}
+		return nil
+	}
+
+	if time.Since(ib.State.driftCheckedAt) < interval {
+		return nil
+	}
+	ib.State.driftCheckedAt = time.Now()
+
+	ref, err := registry.ParseReference(info.Image)
+	if err != nil || ref.Digest != "" {
+		// Images referenced by digest cannot drift
+		return nil
+	}
+
+	current, ok := k8sutil.GetImageDigest(info.ImageID)
+	if !ok {
+		return nil
+	}
+
+	log := ib.Log.With().Str("image", info.Image).Logger()
+
+	client, err := ib.registryClient()
+	if err != nil {
+		log.Warn().Err(err).Msg("Failed to create registry client")
+		return nil
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, imageRegistryTimeout)
+	defer cancel()
+
+	resolved, err := client.Resolve(ctx, ref)
+	if err != nil {
+		log.Warn().Err(err).Msg("Failed to resolve image tag")
+		return nil
+	}
+
+	var changed bool
+	if resolved != current {
+		log.Warn().Str("digest", current).Str("registry-digest", resolved).Msg("Image tag points to a different digest")
+		changed = ib.Status.Conditions.Update(api.ConditionTypeImageTagDrift, true, "Tag Moved",
+			fmt.Sprintf("Tag %s points to %s, deployment uses %s", ref.Tag, resolved, current))
+	} else {
+		changed = ib.Status.Conditions.Remove(api.ConditionTypeImageTagDrift)
+	}
+
+	if changed {
+		if err := ib.UpdateCRStatus(ib.Status); err != nil {
+			return maskAny(err)
+		}
+	}
+
+	return nil
+}
+
+// registryClient returns a registry client with credentials from the image pull secrets of the deployment
+func (ib *imagesBuilder) registryClient() (*registry.Client, error) {
+	credentials := registry.Credentials{}
+
+	for _, name := range ib.Spec.ImagePullSecrets {
+		secret, err := ib.KubeCli.CoreV1().Secrets(ib.APIObject.GetNamespace()).Get(name, metav1.GetOptions{})
+		if err != nil {
+			return nil, errors.Wrapf(err, "unable to get image pull secret %s", name)
+		}
+
+		switch secret.Type {
+		case core.SecretTypeDockerConfigJson:
+			err = credentials.AddDockerConfigJSON(secret.Data[core.DockerConfigJsonKey])
+		case core.SecretTypeDockercfg:
+			err = credentials.AddDockerConfig(secret.Data[core.DockerConfigKey])
+		}
+		if err != nil {
+			return nil, errors.Wrapf(err, "invalid image pull secret %s", name)
+		}
+	}
+
+	return &registry.Client{HTTP: ib.RegistryHTTPClient, Credentials: credentials}, nil
+}
+
+// pinImage returns the given image referenced by digest
+func pinImage(image, digest string) (string, error) {
+	ref, err := registry.ParseReference(image)
+	if err != nil {
+		return "", maskAny(err)
+	}
+
+	return ref.Pinned(digest), nil
+}
+
 // fetchArangoDBImageIDAndVersion checks a running pod for fetching the ID of the given image.
+// The pod is started with podImage, which is the image itself or the image pinned to its verified digest.
 // When no pod exists, it is created, otherwise the ID is fetched & version detected.
 // Returns: retrySoon, error
-func (ib *imagesBuilder) fetchArangoDBImageIDAndVersion(ctx context.Context, image string) (bool, error) {
+func (ib *imagesBuilder) fetchArangoDBImageIDAndVersion(ctx context.Context, image, podImage string) (bool, error) {
 	role := k8sutil.ImageIDAndVersionRole
 	id := fmt.Sprintf("%0x", sha1.Sum([]byte(image)))[:6]
 	podName := k8sutil.CreatePodName(ib.APIObject.GetName(), role, id, "")
@@ -157,7 +429,7 @@ func (ib *imagesBuilder) fetchArangoDBImageIDAndVersion(ctx context.Context, ima
 		imageID := k8sutil.GetArangoDBImageIDFromPod(pod)
 		if imageID == "" {
 			// Fall back to specified image
-			imageID = image
+			imageID = podImage
 		}
 
 		// Try fetching the ArangoDB version
@@ -208,7 +480,7 @@ func (ib *imagesBuilder) fetchArangoDBImageIDAndVersion(ctx context.Context, ima
 
 	imagePod := ImageUpdatePod{
 		spec:      ib.Spec,
-		image:     image,
+		image:     podImage,
 		apiObject: ib.APIObject,
 	}
 
@@ -398,5 +670,7 @@ func (i *ImageUpdatePod) Validate(cachedStatus inspector.Inspector) error {
 }
 
 func (i *ImageUpdatePod) ApplyPodSpec(spec *core.PodSpec) error {
+	pod.ApplySecurityProfile(spec, i.spec.SecurityProfile)
+
 	return nil
 }
@@ -0,0 +1,139 @@
+//
+// DISCLAIMER
+//
+// Copyright 2020 ArangoDB GmbH, Cologne, Germany
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// Copyright holder is ArangoDB GmbH, Cologne, Germany
+//
+
+package deployment
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	driver "github.com/arangodb/go-driver"
+	api "github.com/arangodb/kube-arangodb/pkg/apis/deployment/v1"
+	"github.com/arangodb/kube-arangodb/pkg/util/k8sutil"
+	"github.com/arangodb/kube-arangodb/pkg/util/registry"
+)
+
+const (
+	// imageLabelVersion is the image label holding the ArangoDB version
+	imageLabelVersion = "org.opencontainers.image.version"
+	// imageLabelLicense is the image label holding the ArangoDB license, community or enterprise
+	imageLabelLicense = "com.arangodb.license"
+	// imageEnvVersion is the environment variable of official images holding the ArangoDB version
+	imageEnvVersion = "ARANGO_VERSION"
+
+	imageLicenseEnterprise = "enterprise"
+	imageLicenseCommunity  = "community"
+
+	imageRegistryRetryInterval = 15 * time.Second
+)
+
+// fetchArangoDBImageIDAndVersionFromRegistry reads the ID and ArangoDB version of the given image
+// from the image config in the registry, without starting a pod.
+// Returns true when the image config does not contain the version or the registry is not reachable
+// and the pod method has to be used.
+// Returns: usePod, error
+func (ib *imagesBuilder) fetchArangoDBImageIDAndVersionFromRegistry(ctx context.Context, image, podImage string) (bool, error) {
+	if ib.State.registryFallback[image] {
+		return true, nil
+	}
+
+	if time.Since(ib.State.registryFailedAt) < imageRegistryRetryInterval {
+		return true, nil
+	}
+
+	log := ib.Log.With().Str("image", image).Logger()
+
+	ref, err := registry.ParseReference(podImage)
+	if err != nil {
+		return false, maskAny(err)
+	}
+
+	client, err := ib.registryClient()
+	if err != nil {
+		ib.State.registryFailedAt = time.Now()
+		log.Warn().Err(err).Msg("Failed to create registry client, falling back to Image ID Pod")
+		return true, nil
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, imageRegistryTimeout)
+	defer cancel()
+
+	// ArangoDB pods are scheduled on nodes of a fixed architecture, which is not necessarily the one of the operator
+	config, digest, err := client.Config(ctx, ref, registry.Platform{OS: "linux", Architecture: k8sutil.NodeArchitecture})
+	if err != nil {
+		ib.State.registryFailedAt = time.Now()
+		log.Warn().Err(err).Msg("Failed to fetch image config from registry, falling back to Image ID Pod")
+		return true, nil
+	}
+
+	version, enterprise, ok := getImageVersion(config)
+	if !ok {
+		log.Info().Msg("Image config does not contain ArangoDB version, falling back to Image ID Pod")
+		if ib.State.registryFallback == nil {
+			ib.State.registryFallback = map[string]bool{}
+		}
+		ib.State.registryFallback[image] = true
+		return true, nil
+	}
+
+	imageID := ref.Pinned(digest)
+	ib.Status.Images.AddOrUpdate(api.ImageInfo{
+		Image:           image,
+		ImageID:         imageID,
+		ArangoDBVersion: version,
+		Enterprise:      enterprise,
+	})
+	if err := ib.UpdateCRStatus(ib.Status); err != nil {
+		log.Warn().Err(err).Msg("Failed to save Image Info in CR status")
+		return false, maskAny(err)
+	}
+
+	log.Debug().
+		Str("image-id", imageID).
+		Str("arangodb-version", string(version)).
+		Msg("Found image ID and ArangoDB version in registry")
+	return false, nil
+}
+
+// getImageVersion returns the ArangoDB version and license from the image config.
+// Returns false when the version or license is missing.
+func getImageVersion(config registry.ImageConfig) (driver.Version, bool, bool) {
+	v, ok := config.Labels[imageLabelVersion]
+	if !ok {
+		if v, ok = config.GetEnv(imageEnvVersion); !ok {
+			return "", false, false
+		}
+	}
+
+	version := driver.Version(v)
+	if version.Major() == 0 {
+		return "", false, false
+	}
+
+	switch strings.ToLower(config.Labels[imageLabelLicense]) {
+	case imageLicenseEnterprise:
+		return version, true, true
+	case imageLicenseCommunity:
+		return version, false, true
+	default:
+		return "", false, false
+	}
+}
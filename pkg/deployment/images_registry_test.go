@@ -0,0 +1,214 @@
+//
+// DISCLAIMER
+//
+// Copyright 2020 ArangoDB GmbH, Cologne, Germany
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// Copyright holder is ArangoDB GmbH, Cologne, Germany
+//
+
+package deployment
+
+import (
+	"context"
+	"testing"
+
+	api "github.com/arangodb/kube-arangodb/pkg/apis/deployment/v1"
+	"github.com/arangodb/kube-arangodb/pkg/util"
+	"github.com/arangodb/kube-arangodb/pkg/util/k8sutil"
+	"github.com/arangodb/kube-arangodb/pkg/util/registry"
+	"github.com/arangodb/kube-arangodb/pkg/util/registry/fake"
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	core "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	kubefake "k8s.io/client-go/kubernetes/fake"
+)
+
+func newTestRegistryImagesBuilder(t *testing.T, r *fake.Registry, image string) *imagesBuilder {
+	kubeCli := kubefake.NewSimpleClientset()
+
+	_, err := kubeCli.CoreV1().Secrets(testNamespace).Create(&core.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: "pull-secret", Namespace: testNamespace},
+		Type:       core.SecretTypeDockerConfigJson,
+		Data:       map[string][]byte{core.DockerConfigJsonKey: r.DockerConfigJSON()},
+	})
+	require.NoError(t, err)
+
+	apiObject := &api.ArangoDeployment{
+		ObjectMeta: metav1.ObjectMeta{Name: testDeploymentName, Namespace: testNamespace},
+		Spec: api.DeploymentSpec{
+			Image:              util.NewString(image),
+			ImagePullSecrets:   []string{"pull-secret"},
+			ImageDiscoveryMode: api.NewDeploymentImageDiscoveryModeSpec(api.DeploymentImageDiscoveryRegistryMode),
+		},
+	}
+	apiObject.Spec.SetDefaults(apiObject.GetName())
+
+	ib := &imagesBuilder{
+		APIObject:          apiObject,
+		Spec:               apiObject.Spec,
+		State:              &imagesState{},
+		Log:                zerolog.Nop(),
+		KubeCli:            kubeCli,
+		RegistryHTTPClient: r.HTTPClient(),
+	}
+	ib.UpdateCRStatus = func(status api.DeploymentStatus) error {
+		ib.Status = status
+		return nil
+	}
+
+	return ib
+}
+
+func TestImagesBuilder_RegistryDiscovery(t *testing.T) {
+	r := fake.NewRegistry()
+	defer r.Close()
+
+	digest := r.AddImage("arangodb/enterprise", "3.7.10", registry.ImageConfig{
+		Labels: map[string]string{
+			imageLabelVersion: "3.7.10",
+			imageLabelLicense: imageLicenseEnterprise,
+		},
+	})
+	r.AddImage("arangodb/arangodb", "3.7.10", registry.ImageConfig{
+		Env: []string{imageEnvVersion + "=3.7.10"},
+	})
+
+	t.Run("Version from labels", func(t *testing.T) {
+		image := r.Domain() + "/arangodb/enterprise:3.7.10"
+		ib := newTestRegistryImagesBuilder(t, r, image)
+
+		retrySoon, exists, err := ib.Run(context.Background())
+		require.NoError(t, err)
+		assert.False(t, retrySoon)
+		assert.False(t, exists)
+
+		info, found := ib.Status.Images.GetByImage(image)
+		require.True(t, found)
+		assert.Equal(t, r.Domain()+"/arangodb/enterprise@"+digest, info.ImageID)
+		assert.EqualValues(t, "3.7.10", info.ArangoDBVersion)
+		assert.True(t, info.Enterprise)
+
+		pods, err := ib.KubeCli.CoreV1().Pods(testNamespace).List(metav1.ListOptions{})
+		require.NoError(t, err)
+		assert.Len(t, pods.Items, 0)
+
+		_, exists, err = ib.Run(context.Background())
+		require.NoError(t, err)
+		assert.True(t, exists)
+	})
+
+	t.Run("Fallback to pod when labels are missing", func(t *testing.T) {
+		image := r.Domain() + "/arangodb/arangodb:3.7.10"
+		ib := newTestRegistryImagesBuilder(t, r, image)
+
+		retrySoon, exists, err := ib.Run(context.Background())
+		require.NoError(t, err)
+		assert.True(t, retrySoon)
+		assert.False(t, exists)
+		assert.True(t, ib.State.registryFallback[image])
+
+		_, found := ib.Status.Images.GetByImage(image)
+		assert.False(t, found)
+
+		pods, err := ib.KubeCli.CoreV1().Pods(testNamespace).List(metav1.ListOptions{})
+		require.NoError(t, err)
+		require.Len(t, pods.Items, 1)
+		assert.Equal(t, image, pods.Items[0].Spec.Containers[0].Image)
+	})
+
+	t.Run("Fallback to pod when registry fails", func(t *testing.T) {
+		image := r.Domain() + "/arangodb/arangodb:3.7.11"
+		ib := newTestRegistryImagesBuilder(t, r, image)
+
+		retrySoon, exists, err := ib.Run(context.Background())
+		require.NoError(t, err)
+		assert.True(t, retrySoon)
+		assert.False(t, exists)
+		assert.False(t, ib.State.registryFailedAt.IsZero())
+		assert.False(t, ib.State.registryFallback[image])
+
+		pods, err := ib.KubeCli.CoreV1().Pods(testNamespace).List(metav1.ListOptions{})
+		require.NoError(t, err)
+		require.Len(t, pods.Items, 1)
+		assert.Equal(t, image, pods.Items[0].Spec.Containers[0].Image)
+
+		requests := r.Requests()
+		_, _, err = ib.Run(context.Background())
+		require.NoError(t, err)
+		assert.Equal(t, requests, r.Requests())
+	})
+
+	t.Run("Platform of the nodes", func(t *testing.T) {
+		r.AddManifest("arangodb/enterprise", "3.7", registry.Manifest{
+			Manifests: []registry.Descriptor{
+				{Digest: r.AddImage("arangodb/enterprise", "3.7-other", registry.ImageConfig{}), Platform: &registry.Platform{OS: "linux", Architecture: "other"}},
+				{Digest: digest, Platform: &registry.Platform{OS: "linux", Architecture: k8sutil.NodeArchitecture}},
+			},
+		})
+
+		image := r.Domain() + "/arangodb/enterprise:3.7"
+		ib := newTestRegistryImagesBuilder(t, r, image)
+
+		_, _, err := ib.Run(context.Background())
+		require.NoError(t, err)
+
+		info, found := ib.Status.Images.GetByImage(image)
+		require.True(t, found)
+		assert.EqualValues(t, "3.7.10", info.ArangoDBVersion)
+	})
+}
+
+func TestGetImageVersion(t *testing.T) {
+	testCases := map[string]struct {
+		config     registry.ImageConfig
+		version    string
+		enterprise bool
+		ok         bool
+	}{
+		"labels": {
+			config: registry.ImageConfig{Labels: map[string]string{
+				imageLabelVersion: "3.7.10", imageLabelLicense: "Community",
+			}},
+			version: "3.7.10", ok: true,
+		},
+		"env": {
+			config: registry.ImageConfig{
+				Labels: map[string]string{imageLabelLicense: imageLicenseEnterprise},
+				Env:    []string{imageEnvVersion + "=3.6.12"},
+			},
+			version: "3.6.12", enterprise: true, ok: true,
+		},
+		"missing license": {
+			config: registry.ImageConfig{Labels: map[string]string{imageLabelVersion: "3.7.10"}},
+		},
+		"invalid version": {
+			config: registry.ImageConfig{Labels: map[string]string{
+				imageLabelVersion: "devel", imageLabelLicense: imageLicenseCommunity,
+			}},
+		},
+	}
+
+	for name, testCase := range testCases {
+		//nolint:scopelint
+		t.Run(name, func(t *testing.T) {
+			version, enterprise, ok := getImageVersion(testCase.config)
+			assert.Equal(t, testCase.ok, ok)
+			assert.EqualValues(t, testCase.version, version)
+			assert.Equal(t, testCase.enterprise, enterprise)
+		})
+	}
+}
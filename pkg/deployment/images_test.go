@@ -54,6 +54,7 @@ type testCaseImageUpdate struct {
 	After            func(*testing.T, *Deployment)
 	ExpectedError    error
 	RetrySoon        bool
+	Exists           bool
 	ExpectedPod      v1.Pod
 }
 
@@ -73,6 +74,7 @@ func TestEnsureImages(t *testing.T) {
 					Image: util.NewString(testImage),
 				},
 			},
+			Exists: true,
 		},
 		{
 			Name: "Image has been changed",
@@ -309,6 +311,43 @@ func TestEnsureImages(t *testing.T) {
 				require.Len(t, pods.Items, 1)
 			},
 		},
+		{
+			Name: "Image signature verification failed",
+			ArangoDeployment: &api.ArangoDeployment{
+				Spec: api.DeploymentSpec{
+					Image: util.NewString(testNewImage),
+					ImagePolicy: &api.ImagePolicySpec{
+						PublicKeySecretName: util.NewString("missing-key"),
+					},
+				},
+			},
+			After: func(t *testing.T, deployment *Deployment) {
+				pods, err := deployment.GetKubeCli().CoreV1().Pods(testNamespace).List(metav1.ListOptions{})
+				require.NoError(t, err)
+				require.Len(t, pods.Items, 0)
+
+				status, _ := deployment.GetStatus()
+				require.True(t, status.Conditions.IsTrue(api.ConditionTypeImageVerificationFailed))
+				require.False(t, deployment.images.verificationFailedAt.IsZero())
+			},
+		},
+		{
+			Name: "Image in status is verified again",
+			ArangoDeployment: &api.ArangoDeployment{
+				Spec: api.DeploymentSpec{
+					Image: util.NewString(testImage),
+					ImagePolicy: &api.ImagePolicySpec{
+						PublicKeySecretName: util.NewString("missing-key"),
+					},
+				},
+			},
+			// Failed verification of the image in use does not block the inspection
+			Exists: true,
+			After: func(t *testing.T, deployment *Deployment) {
+				status, _ := deployment.GetStatus()
+				require.True(t, status.Conditions.IsTrue(api.ConditionTypeImageVerificationFailed))
+			},
+		},
 	}
 
 	for _, testCase := range testCases {
@@ -330,10 +369,11 @@ func TestEnsureImages(t *testing.T) {
 			require.NoError(t, err)
 
 			// Act
-			retrySoon, _, err := d.ensureImages(d.apiObject)
+			retrySoon, exists, err := d.ensureImages(d.apiObject)
 
 			// Assert
 			assert.EqualValues(t, testCase.RetrySoon, retrySoon)
+			assert.EqualValues(t, testCase.Exists, exists)
 			if testCase.ExpectedError != nil {
 				assert.EqualError(t, err, testCase.ExpectedError.Error())
 				return
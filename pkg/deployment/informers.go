@@ -23,10 +23,15 @@
 package deployment
 
 import (
+	"sync"
+
 	v1 "k8s.io/api/core/v1"
 	"k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1beta1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/client-go/tools/cache"
 
+	backupApi "github.com/arangodb/kube-arangodb/pkg/apis/backup/v1"
+	api "github.com/arangodb/kube-arangodb/pkg/apis/deployment/v1"
 	"github.com/arangodb/kube-arangodb/pkg/util/k8sutil"
 )
 
@@ -218,3 +223,208 @@ func (d *Deployment) listenForCRDEvents(stopCh <-chan struct{}) {
 
 	rw.Run(stopCh)
 }
+
+// listenForArangoDatabaseEvents keep listening for changes in ArangoDatabases until the given channel is closed.
+func (d *Deployment) listenForArangoDatabaseEvents(stopCh <-chan struct{}) {
+	getDatabase := func(obj interface{}) (*api.ArangoDatabase, bool) {
+		db, ok := obj.(*api.ArangoDatabase)
+		if !ok {
+			tombstone, ok := obj.(cache.DeletedFinalStateUnknown)
+			if !ok {
+				return nil, false
+			}
+			db, ok = tombstone.Obj.(*api.ArangoDatabase)
+			return db, ok
+		}
+		return db, true
+	}
+
+	// Do not watch when the CRD is not installed
+	if _, err := d.deps.DatabaseCRCli.DatabaseV1().ArangoDatabases(d.apiObject.GetNamespace()).List(metav1.ListOptions{Limit: 1}); err != nil {
+		d.deps.Log.Debug().Err(err).Msg("ArangoDatabase resources are not available")
+		return
+	}
+
+	rw := k8sutil.NewResourceWatcher(
+		d.deps.Log,
+		d.deps.DatabaseCRCli.DatabaseV1().RESTClient(),
+		"arangodatabases",
+		d.apiObject.GetNamespace(),
+		&api.ArangoDatabase{},
+		cache.ResourceEventHandlerFuncs{
+			AddFunc: func(obj interface{}) {
+				if db, ok := getDatabase(obj); ok && db.Spec.DeploymentName == d.apiObject.GetName() {
+					d.triggerInspection()
+				}
+			},
+			UpdateFunc: func(oldObj, newObj interface{}) {
+				if db, ok := getDatabase(newObj); ok && db.Spec.DeploymentName == d.apiObject.GetName() {
+					d.triggerInspection()
+				}
+			},
+			DeleteFunc: func(obj interface{}) {
+				if db, ok := getDatabase(obj); ok && db.Spec.DeploymentName == d.apiObject.GetName() {
+					d.triggerInspection()
+				}
+			},
+		})
+
+	d.resourceWatchers.add("arangodatabases", rw)
+
+	rw.Run(stopCh)
+}
+
+// listenForArangoUserEvents keep listening for changes in ArangoUsers until the given channel is closed.
+func (d *Deployment) listenForArangoUserEvents(stopCh <-chan struct{}) {
+	getUser := func(obj interface{}) (*api.ArangoUser, bool) {
+		user, ok := obj.(*api.ArangoUser)
+		if !ok {
+			tombstone, ok := obj.(cache.DeletedFinalStateUnknown)
+			if !ok {
+				return nil, false
+			}
+			user, ok = tombstone.Obj.(*api.ArangoUser)
+			return user, ok
+		}
+		return user, true
+	}
+
+	// Do not watch when the CRD is not installed
+	if _, err := d.deps.DatabaseCRCli.DatabaseV1().ArangoUsers(d.apiObject.GetNamespace()).List(metav1.ListOptions{Limit: 1}); err != nil {
+		d.deps.Log.Debug().Err(err).Msg("ArangoUser resources are not available")
+		return
+	}
+
+	rw := k8sutil.NewResourceWatcher(
+		d.deps.Log,
+		d.deps.DatabaseCRCli.DatabaseV1().RESTClient(),
+		"arangousers",
+		d.apiObject.GetNamespace(),
+		&api.ArangoUser{},
+		cache.ResourceEventHandlerFuncs{
+			AddFunc: func(obj interface{}) {
+				if u, ok := getUser(obj); ok && u.Spec.DeploymentName == d.apiObject.GetName() {
+					d.triggerInspection()
+				}
+			},
+			UpdateFunc: func(oldObj, newObj interface{}) {
+				if u, ok := getUser(newObj); ok && u.Spec.DeploymentName == d.apiObject.GetName() {
+					d.triggerInspection()
+				}
+			},
+			DeleteFunc: func(obj interface{}) {
+				if u, ok := getUser(obj); ok && u.Spec.DeploymentName == d.apiObject.GetName() {
+					d.triggerInspection()
+				}
+			},
+		})
+
+	d.resourceWatchers.add("arangousers", rw)
+
+	rw.Run(stopCh)
+}
+
+// listenForArangoCollectionEvents keep listening for changes in ArangoCollections until the given channel is closed.
+func (d *Deployment) listenForArangoCollectionEvents(stopCh <-chan struct{}) {
+	getCollection := func(obj interface{}) (*api.ArangoCollection, bool) {
+		col, ok := obj.(*api.ArangoCollection)
+		if !ok {
+			tombstone, ok := obj.(cache.DeletedFinalStateUnknown)
+			if !ok {
+				return nil, false
+			}
+			col, ok = tombstone.Obj.(*api.ArangoCollection)
+			return col, ok
+		}
+		return col, true
+	}
+
+	// Do not watch when the CRD is not installed
+	if _, err := d.deps.DatabaseCRCli.DatabaseV1().ArangoCollections(d.apiObject.GetNamespace()).List(metav1.ListOptions{Limit: 1}); err != nil {
+		d.deps.Log.Debug().Err(err).Msg("ArangoCollection resources are not available")
+		return
+	}
+
+	rw := k8sutil.NewResourceWatcher(
+		d.deps.Log,
+		d.deps.DatabaseCRCli.DatabaseV1().RESTClient(),
+		"arangocollections",
+		d.apiObject.GetNamespace(),
+		&api.ArangoCollection{},
+		cache.ResourceEventHandlerFuncs{
+			AddFunc: func(obj interface{}) {
+				if c, ok := getCollection(obj); ok && c.Spec.DeploymentName == d.apiObject.GetName() {
+					d.triggerInspection()
+				}
+			},
+			UpdateFunc: func(oldObj, newObj interface{}) {
+				if c, ok := getCollection(newObj); ok && c.Spec.DeploymentName == d.apiObject.GetName() {
+					d.triggerInspection()
+				}
+			},
+			DeleteFunc: func(obj interface{}) {
+				if c, ok := getCollection(obj); ok && c.Spec.DeploymentName == d.apiObject.GetName() {
+					d.triggerInspection()
+				}
+			},
+		})
+
+	d.resourceWatchers.add("arangocollections", rw)
+
+	rw.Run(stopCh)
+}
+
+// listenForArangoBackupEvents keep listening for changes in ArangoBackups until the given channel is closed.
+// Changes do not trigger an inspection, the watcher only keeps the cache used to list the backups of the deployment.
+func (d *Deployment) listenForArangoBackupEvents(stopCh <-chan struct{}) {
+	// Do not watch when the CRD is not installed
+	if _, err := d.deps.DatabaseCRCli.BackupV1().ArangoBackups(d.apiObject.GetNamespace()).List(metav1.ListOptions{Limit: 1}); err != nil {
+		d.deps.Log.Debug().Err(err).Msg("ArangoBackup resources are not available")
+		return
+	}
+
+	rw := k8sutil.NewResourceWatcher(
+		d.deps.Log,
+		d.deps.DatabaseCRCli.BackupV1().RESTClient(),
+		"arangobackups",
+		d.apiObject.GetNamespace(),
+		&backupApi.ArangoBackup{},
+		cache.ResourceEventHandlerFuncs{})
+
+	d.resourceWatchers.add("arangobackups", rw)
+
+	rw.Run(stopCh)
+}
+
+// resourceWatchers keeps watchers of the ArangoDatabase, ArangoCollection, ArangoUser and ArangoBackup resources,
+// so the provisioning and the reconciliation can list them from the cache.
+type resourceWatchers struct {
+	mutex    sync.Mutex
+	watchers map[string]*k8sutil.ResourceWatcher
+}
+
+// add registers the watcher of the given resource
+func (p *resourceWatchers) add(resource string, rw *k8sutil.ResourceWatcher) {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+
+	if p.watchers == nil {
+		p.watchers = map[string]*k8sutil.ResourceWatcher{}
+	}
+
+	p.watchers[resource] = rw
+}
+
+// indexer returns the cache of the given resource.
+// Returns false when the resource is not watched or the cache is not synced yet.
+func (p *resourceWatchers) indexer(resource string) (cache.Indexer, bool) {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+
+	rw, ok := p.watchers[resource]
+	if !ok || !rw.HasSynced() {
+		return nil, false
+	}
+
+	return rw.Indexer(), true
+}
@@ -59,9 +59,9 @@ func AppendNodeSelector(a *core.NodeAffinity) {
 	a.RequiredDuringSchedulingIgnoredDuringExecution.NodeSelectorTerms = append(a.RequiredDuringSchedulingIgnoredDuringExecution.NodeSelectorTerms, core.NodeSelectorTerm{
 		MatchExpressions: []core.NodeSelectorRequirement{
 			{
-				Key:      "beta.kubernetes.io/arch",
+				Key:      k8sutil.NodeArchitectureKey,
 				Operator: "In",
-				Values:   []string{"amd64"},
+				Values:   []string{k8sutil.NodeArchitecture},
 			},
 		},
 	})
@@ -64,7 +64,7 @@ func GroupEncryptionSupported(mode api.DeploymentMode, group api.ServerGroup) bo
 	}
 }
 
-func GetEncryptionKey(secrets k8sutil.SecretInterface, name string) (string, []byte, bool, error) {
+func GetEncryptionKey(secrets k8sutil.SecretInterface, spec api.RocksDBEncryptionSpec, name string) (string, []byte, bool, error) {
 	keyfile, err := secrets.Get(name, meta.GetOptions{})
 	if err != nil {
 		if k8sutil.IsNotFound(err) {
@@ -73,7 +73,7 @@ func GetEncryptionKey(secrets k8sutil.SecretInterface, name string) (string, []b
 		return "", nil, false, errors.Wrapf(err, "Unable to fetch secret")
 	}
 
-	sha, data, err := GetEncryptionKeyFromSecret(keyfile)
+	sha, data, err := GetEncryptionKeyFromSecretWithSpec(spec, keyfile)
 
 	return sha, data, true, err
 }
@@ -103,6 +103,11 @@ func GetEncryptionFolderSecretName(name string) string {
 	return n
 }
 
+// GetEncryptionRotationSecretName returns the name of the operator owned secret which keeps keys generated by rotation
+func GetEncryptionRotationSecretName(name string) string {
+	return fmt.Sprintf("%s-encryption-rotation", name)
+}
+
 func IsEncryptionEnabled(i Input) bool {
 	return i.Deployment.RocksDB.IsEncrypted()
 }
@@ -143,6 +148,14 @@ func (e encryption) Volumes(i Input) ([]core.Volume, []core.VolumeMount) {
 	if !IsEncryptionEnabled(i) {
 		return nil, nil
 	}
+	if IsEncryptionProviderEnabled(i) {
+		// Wrapped keys are unwrapped by the init container into the memory volume
+		wrappedSecretName := GetEncryptionFolderSecretName(i.ApiObject.GetName())
+		if !MultiFileMode(i) {
+			wrappedSecretName = i.Deployment.RocksDB.Encryption.GetKeySecretName()
+		}
+		return encryptionProviderVolumes(i, wrappedSecretName), []core.VolumeMount{k8sutil.RocksdbEncryptionReadOnlyVolumeMount()}
+	}
 	if !MultiFileMode(i) {
 		vol := k8sutil.CreateVolumeWithSecret(k8sutil.RocksdbEncryptionVolumeName, i.Deployment.RocksDB.Encryption.GetKeySecretName())
 		return []core.Volume{vol}, []core.VolumeMount{k8sutil.RocksdbEncryptionVolumeMount()}
@@ -167,10 +180,25 @@ func (e encryption) Verify(i Input, cachedStatus inspector.Inspector) error {
 			return errors.Errorf("Encryption key secret does not exist %s", i.Deployment.RocksDB.Encryption.GetKeySecretName())
 		}
 
-		if err := k8sutil.ValidateEncryptionKeyFromSecret(secret); err != nil {
+		if IsEncryptionProviderEnabled(i) {
+			if len(secret.Data[constants.SecretEncryptionKey]) == 0 {
+				return errors.Errorf("RocksDB encryption key secret validation failed - missing wrapped key")
+			}
+		} else if err := k8sutil.ValidateEncryptionKeyFromSecret(secret); err != nil {
 			return errors.Wrapf(err, "RocksDB encryption key secret validation failed")
 		}
-		return nil
+	}
+
+	if IsEncryptionProviderEnabled(i) {
+		provider := i.Deployment.RocksDB.Encryption.Provider
+		for _, name := range []string{provider.GetCredentialsSecretName(), provider.GetCASecretName()} {
+			if name == "" {
+				continue
+			}
+			if _, exists := cachedStatus.Secret(name); !exists {
+				return errors.Errorf("Encryption provider secret does not exist %s", name)
+			}
+		}
 	}
 
 	return nil
@@ -0,0 +1,186 @@
+//
+// DISCLAIMER
+//
+// Copyright 2020 ArangoDB GmbH, Cologne, Germany
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// Copyright holder is ArangoDB GmbH, Cologne, Germany
+//
+
+package pod
+
+import (
+	"crypto/sha256"
+	"encoding/json"
+	"fmt"
+	"path/filepath"
+
+	api "github.com/arangodb/kube-arangodb/pkg/apis/deployment/v1"
+	"github.com/arangodb/kube-arangodb/pkg/util/constants"
+	"github.com/arangodb/kube-arangodb/pkg/util/k8sutil"
+	"github.com/pkg/errors"
+	core "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+)
+
+const (
+	// EncryptionProviderInitContainerName is the name of the init container which unwraps encryption keys
+	EncryptionProviderInitContainerName = "encryption-keys"
+)
+
+// IsEncryptionProviderEnabled returns true when keys are wrapped by an external provider
+func IsEncryptionProviderEnabled(i Input) bool {
+	return i.Deployment.RocksDB.Encryption.HasProvider()
+}
+
+// GetEncryptionKeyFromSecretWithSpec returns the checksum of the key and the data which should be stored
+// in the keyfolder. When keys are wrapped by a provider, data is the wrapped key and the checksum is calculated
+// from the wrapped key and the provider settings, keys are never unwrapped by the operator.
+func GetEncryptionKeyFromSecretWithSpec(spec api.RocksDBEncryptionSpec, keyfile *core.Secret) (string, []byte, error) {
+	if !spec.HasProvider() {
+		return GetEncryptionKeyFromSecret(keyfile)
+	}
+
+	wrapped, ok := keyfile.Data[constants.SecretEncryptionKey]
+	if !ok || len(wrapped) == 0 {
+		return "", nil, errors.Errorf("Current encryption key is not valid - missing field")
+	}
+
+	return EncryptionProviderKeyChecksum(spec.Provider, wrapped), wrapped, nil
+}
+
+// EncryptionProviderKeyChecksum returns the checksum under which the wrapped key is kept in the keyfolder.
+// Provider settings are part of the checksum, as the same wrapped key is unwrapped to another key by another provider.
+func EncryptionProviderKeyChecksum(provider *api.RocksDBEncryptionProviderSpec, wrapped []byte) string {
+	h := sha256.New()
+	for _, field := range []string{string(provider.GetType()), provider.GetEndpoint(), provider.GetKeyName(), provider.GetMountPath()} {
+		h.Write([]byte(field))
+		h.Write([]byte{0})
+	}
+	h.Write(wrapped)
+
+	return fmt.Sprintf("%0x", h.Sum(nil))
+}
+
+// GetEncryptionProviderUnwrappedKeys returns checksums of the unwrapped keys, by names of the wrapped keys,
+// as reported by the init container of the pod. Returns false when the init container did not finish.
+func GetEncryptionProviderUnwrappedKeys(p *core.Pod) (map[string]string, bool) {
+	for _, c := range p.Status.InitContainerStatuses {
+		if c.Name != EncryptionProviderInitContainerName {
+			continue
+		}
+
+		if t := c.State.Terminated; t != nil && t.ExitCode == 0 {
+			keys := map[string]string{}
+			if err := json.Unmarshal([]byte(t.Message), &keys); err != nil {
+				return nil, false
+			}
+			return keys, true
+		}
+	}
+
+	return nil, false
+}
+
+// EncryptionProviderInitContainer creates the init container which unwraps keys into the memory volume used by arangod
+func EncryptionProviderInitContainer(i Input, executable, image string, securityContext *core.SecurityContext) core.Container {
+	provider := i.Deployment.RocksDB.Encryption.Provider
+
+	command := []string{
+		executable,
+		"encryption",
+		"unwrap",
+		"--input", k8sutil.RocksDBEncryptionWrappedVolumeMountDir,
+		"--output", k8sutil.RocksDBEncryptionVolumeMountDir,
+		"--provider.type", string(provider.GetType()),
+		"--provider.endpoint", provider.GetEndpoint(),
+		"--provider.key-name", provider.GetKeyName(),
+		"--provider.mount-path", provider.GetMountPath(),
+		"--report", core.TerminationMessagePathDefault,
+	}
+
+	mounts := []core.VolumeMount{
+		{
+			Name:      k8sutil.RocksdbEncryptionWrappedVolumeName,
+			MountPath: k8sutil.RocksDBEncryptionWrappedVolumeMountDir,
+			ReadOnly:  true,
+		},
+		k8sutil.RocksdbEncryptionVolumeMount(),
+	}
+
+	if provider.GetCredentialsSecretName() != "" {
+		command = append(command, "--provider.credentials", k8sutil.RocksDBEncryptionProviderVolumeMountDir)
+		mounts = append(mounts, core.VolumeMount{
+			Name:      k8sutil.RocksdbEncryptionProviderVolumeName,
+			MountPath: k8sutil.RocksDBEncryptionProviderVolumeMountDir,
+			ReadOnly:  true,
+		})
+	}
+
+	if provider.GetCASecretName() != "" {
+		command = append(command, "--provider.ca", filepath.Join(k8sutil.RocksDBEncryptionProviderCAVolumeMountDir, core.ServiceAccountRootCAKey))
+		mounts = append(mounts, core.VolumeMount{
+			Name:      k8sutil.RocksdbEncryptionProviderCAVolumeName,
+			MountPath: k8sutil.RocksDBEncryptionProviderCAVolumeMountDir,
+			ReadOnly:  true,
+		})
+	}
+
+	return core.Container{
+		Name:    EncryptionProviderInitContainerName,
+		Image:   image,
+		Command: command,
+		Resources: core.ResourceRequirements{
+			Requests: core.ResourceList{
+				core.ResourceCPU:    resource.MustParse("100m"),
+				core.ResourceMemory: resource.MustParse("10Mi"),
+			},
+			Limits: core.ResourceList{
+				core.ResourceCPU:    resource.MustParse("100m"),
+				core.ResourceMemory: resource.MustParse("50Mi"),
+			},
+		},
+		VolumeMounts:    mounts,
+		ImagePullPolicy: core.PullIfNotPresent,
+		SecurityContext: securityContext,
+	}
+}
+
+// encryptionProviderVolumes returns volumes used when keys are unwrapped by the init container
+func encryptionProviderVolumes(i Input, wrappedSecretName string) []core.Volume {
+	provider := i.Deployment.RocksDB.Encryption.Provider
+
+	volumes := []core.Volume{
+		k8sutil.CreateVolumeWithSecret(k8sutil.RocksdbEncryptionWrappedVolumeName, wrappedSecretName),
+		{
+			Name: k8sutil.RocksdbEncryptionVolumeName,
+			VolumeSource: core.VolumeSource{
+				EmptyDir: &core.EmptyDirVolumeSource{
+					// Unwrapped keys are never written to the disk
+					Medium: core.StorageMediumMemory,
+				},
+			},
+		},
+	}
+
+	if name := provider.GetCredentialsSecretName(); name != "" {
+		volumes = append(volumes, k8sutil.CreateVolumeWithSecret(k8sutil.RocksdbEncryptionProviderVolumeName, name))
+	}
+
+	if name := provider.GetCASecretName(); name != "" {
+		volumes = append(volumes, k8sutil.CreateVolumeWithSecret(k8sutil.RocksdbEncryptionProviderCAVolumeName, name))
+	}
+
+	return volumes
+}
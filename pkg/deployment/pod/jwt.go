@@ -57,6 +57,11 @@ func JWTSecretFolder(name string) string {
 	return fmt.Sprintf("%s-jwt-folder", name)
 }
 
+// JWTSecretRotation returns the name of the operator owned secret which keeps tokens generated by rotation
+func JWTSecretRotation(name string) string {
+	return fmt.Sprintf("%s-jwt-rotation", name)
+}
+
 func VersionHasJWTSecretKeyfolder(v driver.Version, enterprise bool) bool {
 	return features.JWTRotation().Supported(v, enterprise)
 }
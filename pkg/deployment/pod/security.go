@@ -0,0 +1,110 @@
+//
+// DISCLAIMER
+//
+// Copyright 2020 ArangoDB GmbH, Cologne, Germany
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// Copyright holder is ArangoDB GmbH, Cologne, Germany
+//
+
+package pod
+
+import (
+	api "github.com/arangodb/kube-arangodb/pkg/apis/deployment/v1"
+	"github.com/arangodb/kube-arangodb/pkg/util"
+	core "k8s.io/api/core/v1"
+)
+
+const (
+	// SeccompPodAnnotation defines the seccomp profile of all containers in the pod
+	SeccompPodAnnotation = "seccomp.security.alpha.kubernetes.io/pod"
+	// SeccompRuntimeDefault is the default seccomp profile of the container runtime
+	SeccompRuntimeDefault = "runtime/default"
+)
+
+// SecurityProfileAnnotations returns pod annotations required by the security profile
+func SecurityProfileAnnotations(profile *api.SecurityProfile) map[string]string {
+	if !profile.IsRestricted() {
+		return nil
+	}
+
+	return map[string]string{
+		SeccompPodAnnotation: SeccompRuntimeDefault,
+	}
+}
+
+// ApplySecurityProfile renders all containers of the pod (init containers and sidecars included)
+// compliant with the security profile. Settings provided by the user are kept when they are compliant.
+func ApplySecurityProfile(p *core.PodSpec, profile *api.SecurityProfile) {
+	if !profile.IsRestricted() {
+		return
+	}
+
+	// Security contexts can be shared with the deployment spec
+	if p.SecurityContext == nil {
+		p.SecurityContext = &core.PodSecurityContext{}
+	} else {
+		p.SecurityContext = p.SecurityContext.DeepCopy()
+	}
+
+	p.SecurityContext.RunAsNonRoot = util.NewBool(true)
+	if p.SecurityContext.RunAsUser == nil {
+		p.SecurityContext.RunAsUser = util.NewInt64(api.SecurityProfileRestrictedUser)
+	}
+	if p.SecurityContext.RunAsGroup == nil {
+		p.SecurityContext.RunAsGroup = util.NewInt64(api.SecurityProfileRestrictedUser)
+	}
+	if p.SecurityContext.FSGroup == nil {
+		p.SecurityContext.FSGroup = util.NewInt64(api.SecurityProfileRestrictedUser)
+	}
+
+	for id := range p.InitContainers {
+		applyRestrictedSecurityContext(&p.InitContainers[id])
+	}
+
+	for id := range p.Containers {
+		applyRestrictedSecurityContext(&p.Containers[id])
+	}
+}
+
+func applyRestrictedSecurityContext(c *core.Container) {
+	if c.SecurityContext == nil {
+		c.SecurityContext = &core.SecurityContext{}
+	} else {
+		c.SecurityContext = c.SecurityContext.DeepCopy()
+	}
+
+	sc := c.SecurityContext
+
+	sc.Privileged = util.NewBool(false)
+	sc.AllowPrivilegeEscalation = util.NewBool(false)
+	sc.RunAsNonRoot = util.NewBool(true)
+	if sc.RunAsUser != nil && *sc.RunAsUser == 0 {
+		sc.RunAsUser = nil
+	}
+
+	var add []core.Capability
+	if sc.Capabilities != nil {
+		for _, capability := range sc.Capabilities.Add {
+			if capability == "NET_BIND_SERVICE" {
+				add = append(add, capability)
+			}
+		}
+	}
+
+	sc.Capabilities = &core.Capabilities{
+		Add:  add,
+		Drop: []core.Capability{"ALL"},
+	}
+}
@@ -0,0 +1,82 @@
+//
+// DISCLAIMER
+//
+// Copyright 2020 ArangoDB GmbH, Cologne, Germany
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// Copyright holder is ArangoDB GmbH, Cologne, Germany
+//
+
+package pod
+
+import (
+	api "github.com/arangodb/kube-arangodb/pkg/apis/deployment/v1"
+	"github.com/arangodb/kube-arangodb/pkg/util/k8sutil"
+	"github.com/arangodb/kube-arangodb/pkg/util/k8sutil/interfaces"
+	core "k8s.io/api/core/v1"
+	meta "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// TopologySpreadConstraints returns the topology spread constraints for pods of the given group.
+// When zone awareness is enabled, agents are required to be spread across zones (quorum must
+// survive a zone failure) and dbservers are spread across zones on best effort basis.
+// Constraints without label selector select all pods of the group.
+func TopologySpreadConstraints(p interfaces.PodCreator, topology *api.TopologySpec, group api.ServerGroup,
+	constraints api.ServerGroupTopologySpreadConstraints) []core.TopologySpreadConstraint {
+	var r []core.TopologySpreadConstraint
+
+	if topology.IsEnabled() {
+		zoneLabel := topology.GetZoneLabel()
+
+		var whenUnsatisfiable core.UnsatisfiableConstraintAction
+		switch group {
+		case api.ServerGroupAgents:
+			whenUnsatisfiable = core.DoNotSchedule
+		case api.ServerGroupDBServers:
+			whenUnsatisfiable = core.ScheduleAnyway
+		}
+
+		if whenUnsatisfiable != "" && !hasTopologyKey(constraints, zoneLabel) {
+			r = append(r, core.TopologySpreadConstraint{
+				MaxSkew:           1,
+				TopologyKey:       zoneLabel,
+				WhenUnsatisfiable: whenUnsatisfiable,
+			})
+		}
+	}
+
+	for _, c := range constraints {
+		r = append(r, *c.DeepCopy())
+	}
+
+	for id := range r {
+		if r[id].LabelSelector == nil {
+			r[id].LabelSelector = &meta.LabelSelector{
+				MatchLabels: k8sutil.LabelsForDeployment(p.GetName(), p.GetRole()),
+			}
+		}
+	}
+
+	return r
+}
+
+func hasTopologyKey(constraints api.ServerGroupTopologySpreadConstraints, key string) bool {
+	for _, c := range constraints {
+		if c.TopologyKey == key {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,544 @@
+//
+// DISCLAIMER
+//
+// Copyright 2020 ArangoDB GmbH, Cologne, Germany
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// Copyright holder is ArangoDB GmbH, Cologne, Germany
+//
+
+package provisioning
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+
+	driver "github.com/arangodb/go-driver"
+	api "github.com/arangodb/kube-arangodb/pkg/apis/deployment/v1"
+	"github.com/arangodb/kube-arangodb/pkg/util"
+	"github.com/arangodb/kube-arangodb/pkg/util/constants"
+	"github.com/arangodb/kube-arangodb/pkg/util/k8sutil"
+	meta "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// inspectCollection creates the collection declared by the given resource, keeps its properties,
+// indexes and view links in sync and drops it when the resource is deleted.
+func (p *Provisioner) inspectCollection(ctx context.Context, client driver.Client, col *api.ArangoCollection) error {
+	name := col.GetCollectionName()
+	dbName := col.GetDatabaseName()
+
+	if col.GetDeletionTimestamp() != nil {
+		if !hasFinalizer(col, constants.FinalizerCollectionDrop) {
+			return nil
+		}
+
+		if err := dropCollection(ctx, client, dbName, name); err != nil {
+			return maskAny(err)
+		}
+
+		p.log.Info().Str("database", dbName).Str("collection", name).Msg("Collection dropped")
+		return p.removeCollectionFinalizers(col, constants.FinalizerCollectionDrop)
+	}
+
+	if err := col.Spec.Validate(col.GetName()); err != nil {
+		return p.updateCollectionStatus(col, func(s *api.ArangoCollectionStatus) bool {
+			return s.Conditions.Update(api.ConditionTypeReady, false, "Invalid specification", err.Error())
+		})
+	}
+
+	if (col.Status.Name != "" && col.Status.Name != col.Spec.GetName(col.GetName())) ||
+		(col.Status.Database != "" && col.Status.Database != col.Spec.Database) {
+		return p.updateCollectionStatus(col, func(s *api.ArangoCollectionStatus) bool {
+			return s.Conditions.Update(api.ConditionTypeReady, false, "Invalid specification", "name and database cannot be changed")
+		})
+	}
+
+	if setFinalizer(col, constants.FinalizerCollectionDrop, col.Spec.GetDropOnDelete()) {
+		updated, err := p.context.GetArangoCli().DatabaseV1().ArangoCollections(col.GetNamespace()).Update(col)
+		if err != nil {
+			return maskAny(err)
+		}
+		*col = *updated
+	}
+
+	db, err := client.Database(ctx, dbName)
+	if driver.IsNotFound(err) {
+		// Database might not be created yet
+		return p.updateCollectionStatus(col, func(s *api.ArangoCollectionStatus) bool {
+			return s.Conditions.Update(api.ConditionTypeReady, false, "Database not found", fmt.Sprintf("database %s does not exist", dbName))
+		})
+	} else if err != nil {
+		return maskAny(err)
+	}
+
+	c, err := db.Collection(ctx, name)
+	if driver.IsNotFound(err) {
+		if c, err = db.CreateCollection(ctx, name, newCollectionOptions(col.Spec)); err != nil {
+			return maskAny(err)
+		}
+		p.log.Info().Str("database", dbName).Str("collection", name).Msg("Collection created")
+	} else if err != nil {
+		return maskAny(err)
+	}
+
+	props, err := c.Properties(ctx)
+	if err != nil {
+		return maskAny(err)
+	}
+
+	drift := collectionDrift(col.Spec, props)
+
+	if opts, changed := collectionPropertiesUpdate(col.Spec, props); changed {
+		if err := c.SetProperties(ctx, opts); err != nil {
+			return maskAny(err)
+		}
+		p.log.Info().Str("database", dbName).Str("collection", name).Msg("Collection properties updated")
+	}
+
+	for _, analyzer := range col.Spec.Analyzers {
+		if _, _, err := db.EnsureAnalyzer(ctx, newAnalyzerDefinition(analyzer)); err != nil {
+			return maskAny(err)
+		}
+	}
+
+	indexDrift, err := inspectCollectionIndexes(ctx, c, col.Status.Indexes, col.Spec.Indexes)
+	if err != nil {
+		return maskAny(err)
+	}
+	drift = append(drift, indexDrift...)
+
+	if err := inspectCollectionViews(ctx, db, name, col.Status.Views, col.Spec.Views); err != nil {
+		return maskAny(err)
+	}
+
+	return p.updateCollectionStatus(col, func(s *api.ArangoCollectionStatus) bool {
+		changed := s.Conditions.Update(api.ConditionTypeReady, true, "Collection created", "")
+		if len(drift) > 0 {
+			if s.Conditions.Update(api.ConditionTypeDriftDetected, true, "Drift detected", strings.Join(drift, ", ")) {
+				changed = true
+			}
+		} else if s.Conditions.Remove(api.ConditionTypeDriftDetected) {
+			changed = true
+		}
+		if s.Name != name || s.Database != dbName {
+			s.Name = name
+			s.Database = dbName
+			changed = true
+		}
+		if !indexesEqual(s.Indexes, col.Spec.Indexes) {
+			s.Indexes = append([]api.ArangoCollectionIndex{}, col.Spec.Indexes...)
+			changed = true
+		}
+		if !viewsEqual(s.Views, col.Spec.Views) {
+			s.Views = append([]api.ArangoCollectionView{}, col.Spec.Views...)
+			changed = true
+		}
+		if !util.CompareStringArray(s.Drift, drift) {
+			s.Drift = drift
+			changed = true
+		}
+		return changed
+	})
+}
+
+// updateCollectionStatus updates the status of the collection when the given function reports a change.
+func (p *Provisioner) updateCollectionStatus(col *api.ArangoCollection, update func(s *api.ArangoCollectionStatus) bool) error {
+	status := col.Status.DeepCopy()
+	if !update(status) {
+		return nil
+	}
+
+	col.Status = *status
+	updated, err := p.context.GetArangoCli().DatabaseV1().ArangoCollections(col.GetNamespace()).UpdateStatus(col)
+	if err != nil {
+		return maskAny(err)
+	}
+	*col = *updated
+	return nil
+}
+
+// removeCollectionFinalizers removes the given finalizers from the collection.
+func (p *Provisioner) removeCollectionFinalizers(col *api.ArangoCollection, finalizers ...string) error {
+	collections := p.context.GetArangoCli().DatabaseV1().ArangoCollections(col.GetNamespace())
+	getFunc := func() (meta.Object, error) {
+		result, err := collections.Get(col.GetName(), meta.GetOptions{})
+		if err != nil {
+			return nil, maskAny(err)
+		}
+		return result, nil
+	}
+	updateFunc := func(updated meta.Object) error {
+		result, err := collections.Update(updated.(*api.ArangoCollection))
+		if err != nil {
+			return maskAny(err)
+		}
+		*col = *result
+		return nil
+	}
+	if err := k8sutil.RemoveFinalizers(p.log, finalizers, getFunc, updateFunc, true); err != nil {
+		return maskAny(err)
+	}
+	return nil
+}
+
+// newCollectionOptions returns the options used to create the collection.
+func newCollectionOptions(spec api.ArangoCollectionSpec) *driver.CreateCollectionOptions {
+	opts := &driver.CreateCollectionOptions{
+		ShardKeys: spec.ShardKeys,
+	}
+
+	if spec.GetType() == api.ArangoCollectionTypeEdge {
+		opts.Type = driver.CollectionTypeEdge
+	}
+
+	if spec.NumberOfShards != nil {
+		opts.NumberOfShards = *spec.NumberOfShards
+	}
+
+	if spec.ReplicationFactor != nil {
+		opts.ReplicationFactor = *spec.ReplicationFactor
+	}
+
+	if spec.WriteConcern != nil {
+		opts.WriteConcern = *spec.WriteConcern
+	}
+
+	if spec.WaitForSync != nil {
+		opts.WaitForSync = *spec.WaitForSync
+	}
+
+	return opts
+}
+
+// collectionDrift returns differences in properties which cannot be changed once the collection is created.
+func collectionDrift(spec api.ArangoCollectionSpec, props driver.CollectionProperties) []string {
+	var drift []string
+
+	if spec.GetType() == api.ArangoCollectionTypeEdge && props.Type != driver.CollectionTypeEdge ||
+		spec.GetType() == api.ArangoCollectionTypeDocument && props.Type != driver.CollectionTypeDocument {
+		drift = append(drift, fmt.Sprintf("collection type is not %s", spec.GetType()))
+	}
+
+	// Sharding properties are reported only in cluster mode
+	if props.NumberOfShards > 0 {
+		if spec.NumberOfShards != nil && *spec.NumberOfShards != props.NumberOfShards {
+			drift = append(drift, fmt.Sprintf("numberOfShards is %d", props.NumberOfShards))
+		}
+
+		if len(spec.ShardKeys) > 0 && !util.CompareStringArray(spec.ShardKeys, props.ShardKeys) {
+			drift = append(drift, fmt.Sprintf("shardKeys are %s", strings.Join(props.ShardKeys, ",")))
+		}
+	}
+
+	return drift
+}
+
+// collectionPropertiesUpdate returns the properties which have to be changed in the collection.
+func collectionPropertiesUpdate(spec api.ArangoCollectionSpec, props driver.CollectionProperties) (driver.SetCollectionPropertiesOptions, bool) {
+	var opts driver.SetCollectionPropertiesOptions
+	changed := false
+
+	if spec.WaitForSync != nil && *spec.WaitForSync != props.WaitForSync {
+		opts.WaitForSync = util.NewBool(*spec.WaitForSync)
+		changed = true
+	}
+
+	// Replication properties are reported only in cluster mode, satellite collections are not changed
+	if props.ReplicationFactor > 0 {
+		if spec.ReplicationFactor != nil && *spec.ReplicationFactor != props.ReplicationFactor {
+			opts.ReplicationFactor = *spec.ReplicationFactor
+			changed = true
+		}
+
+		if spec.WriteConcern != nil && *spec.WriteConcern != props.WriteConcern {
+			opts.WriteConcern = *spec.WriteConcern
+			changed = true
+		}
+	}
+
+	return opts, changed
+}
+
+// inspectCollectionIndexes removes indexes which were changed or are no longer requested and creates missing ones.
+// Returns names of indexes in the collection which are not managed.
+func inspectCollectionIndexes(ctx context.Context, c driver.Collection, applied, requested []api.ArangoCollectionIndex) ([]string, error) {
+	indexes, err := c.Indexes(ctx)
+	if err != nil {
+		return nil, maskAny(err)
+	}
+
+	existing := map[string]driver.Index{}
+	for _, index := range indexes {
+		existing[index.UserName()] = index
+	}
+
+	for _, a := range applied {
+		if r, ok := findIndex(requested, a.Name); ok && reflect.DeepEqual(a, r) {
+			continue
+		}
+
+		if index, ok := existing[a.Name]; ok {
+			if err := index.Remove(ctx); err != nil && !driver.IsNotFound(err) {
+				return nil, maskAny(err)
+			}
+			delete(existing, a.Name)
+		}
+	}
+
+	for _, r := range requested {
+		if _, ok := existing[r.Name]; ok {
+			continue
+		}
+
+		if err := ensureIndex(ctx, c, r); err != nil {
+			return nil, maskAny(err)
+		}
+	}
+
+	var drift []string
+	for name, index := range existing {
+		if index.Type() == driver.PrimaryIndex || index.Type() == driver.EdgeIndex {
+			continue
+		}
+
+		if _, ok := findIndex(requested, name); !ok {
+			drift = append(drift, fmt.Sprintf("index %s is not managed", name))
+		}
+	}
+	sort.Strings(drift)
+
+	return drift, nil
+}
+
+// findIndex returns the index with the given name.
+func findIndex(indexes []api.ArangoCollectionIndex, name string) (api.ArangoCollectionIndex, bool) {
+	for _, index := range indexes {
+		if index.Name == name {
+			return index, true
+		}
+	}
+
+	return api.ArangoCollectionIndex{}, false
+}
+
+// ensureIndex creates the index in the collection.
+func ensureIndex(ctx context.Context, c driver.Collection, index api.ArangoCollectionIndex) error {
+	unique := util.BoolOrDefault(index.Unique, false)
+	sparse := util.BoolOrDefault(index.Sparse, false)
+	inBackground := util.BoolOrDefault(index.InBackground, false)
+
+	var err error
+	switch index.Type {
+	case api.ArangoCollectionIndexTypePersistent:
+		_, _, err = c.EnsurePersistentIndex(ctx, index.Fields, &driver.EnsurePersistentIndexOptions{
+			Unique: unique, Sparse: sparse, InBackground: inBackground, Name: index.Name,
+		})
+	case api.ArangoCollectionIndexTypeHash:
+		_, _, err = c.EnsureHashIndex(ctx, index.Fields, &driver.EnsureHashIndexOptions{
+			Unique: unique, Sparse: sparse, InBackground: inBackground, Name: index.Name,
+		})
+	case api.ArangoCollectionIndexTypeSkiplist:
+		_, _, err = c.EnsureSkipListIndex(ctx, index.Fields, &driver.EnsureSkipListIndexOptions{
+			Unique: unique, Sparse: sparse, InBackground: inBackground, Name: index.Name,
+		})
+	case api.ArangoCollectionIndexTypeGeo:
+		_, _, err = c.EnsureGeoIndex(ctx, index.Fields, &driver.EnsureGeoIndexOptions{
+			GeoJSON: util.BoolOrDefault(index.GeoJSON, false), InBackground: inBackground, Name: index.Name,
+		})
+	case api.ArangoCollectionIndexTypeFulltext:
+		_, _, err = c.EnsureFullTextIndex(ctx, index.Fields, &driver.EnsureFullTextIndexOptions{
+			MinLength: util.IntOrDefault(index.MinLength, 0), InBackground: inBackground, Name: index.Name,
+		})
+	case api.ArangoCollectionIndexTypeTTL:
+		_, _, err = c.EnsureTTLIndex(ctx, index.Fields[0], util.IntOrDefault(index.ExpireAfter, 0), &driver.EnsureTTLIndexOptions{
+			InBackground: inBackground, Name: index.Name,
+		})
+	default:
+		return maskAny(fmt.Errorf("unknown index type %s", index.Type))
+	}
+
+	return maskAny(err)
+}
+
+// newAnalyzerDefinition returns the definition of the analyzer.
+func newAnalyzerDefinition(analyzer api.ArangoCollectionAnalyzer) driver.ArangoSearchAnalyzerDefinition {
+	def := driver.ArangoSearchAnalyzerDefinition{
+		Name: analyzer.Name,
+		Type: driver.ArangoSearchAnalyzerType(analyzer.Type),
+		Properties: driver.ArangoSearchAnalyzerProperties{
+			Locale:           util.StringOrDefault(analyzer.Locale),
+			Delimiter:        util.StringOrDefault(analyzer.Delimiter),
+			Accent:           analyzer.Accent,
+			Case:             driver.ArangoSearchCaseType(util.StringOrDefault(analyzer.Case)),
+			Min:              analyzer.Min,
+			Max:              analyzer.Max,
+			PreserveOriginal: analyzer.PreserveOriginal,
+			Stemming:         analyzer.Stemming,
+			Stopwords:        analyzer.Stopwords,
+		},
+	}
+
+	for _, f := range analyzer.Features {
+		def.Features = append(def.Features, driver.ArangoSearchAnalyzerFeature(f))
+	}
+
+	return def
+}
+
+// inspectCollectionViews links the collection to requested views and unlinks it from views which are no longer requested.
+func inspectCollectionViews(ctx context.Context, db driver.Database, collection string, applied, requested []api.ArangoCollectionView) error {
+	for _, a := range applied {
+		if _, ok := findView(requested, a.Name); ok {
+			continue
+		}
+
+		if err := setViewLink(ctx, db, collection, a.Name, nil, true); err != nil {
+			return maskAny(err)
+		}
+	}
+
+	for _, r := range requested {
+		a, ok := findView(applied, r.Name)
+		link := newViewLink(r)
+		if err := setViewLink(ctx, db, collection, r.Name, &link, !ok || !reflect.DeepEqual(a, r)); err != nil {
+			return maskAny(err)
+		}
+	}
+
+	return nil
+}
+
+// findView returns the view with the given name.
+func findView(views []api.ArangoCollectionView, name string) (api.ArangoCollectionView, bool) {
+	for _, view := range views {
+		if view.Name == name {
+			return view, true
+		}
+	}
+
+	return api.ArangoCollectionView{}, false
+}
+
+// newViewLink returns properties of the link between the collection and the view.
+func newViewLink(view api.ArangoCollectionView) driver.ArangoSearchElementProperties {
+	link := driver.ArangoSearchElementProperties{
+		Analyzers:          view.Analyzers,
+		IncludeAllFields:   view.IncludeAllFields,
+		TrackListPositions: view.TrackListPositions,
+		StoreValues:        driver.ArangoSearchStoreValues(util.StringOrDefault(view.StoreValues)),
+	}
+
+	if len(view.Fields) > 0 {
+		link.Fields = driver.ArangoSearchFields{}
+		for _, f := range view.Fields {
+			link.Fields[f] = driver.ArangoSearchElementProperties{}
+		}
+	}
+
+	return link
+}
+
+// setViewLink links the collection to the view, or unlinks it when link is nil.
+// The view is created when it does not exist. Existing links are replaced only when force is set.
+func setViewLink(ctx context.Context, db driver.Database, collection, name string, link *driver.ArangoSearchElementProperties, force bool) error {
+	view, err := db.View(ctx, name)
+	if driver.IsNotFound(err) {
+		if link == nil {
+			return nil
+		}
+
+		_, err := db.CreateArangoSearchView(ctx, name, &driver.ArangoSearchViewProperties{
+			Links: driver.ArangoSearchLinks{collection: *link},
+		})
+		return maskAny(err)
+	} else if err != nil {
+		return maskAny(err)
+	}
+
+	searchView, err := view.ArangoSearchView()
+	if err != nil {
+		return maskAny(err)
+	}
+
+	props, err := searchView.Properties(ctx)
+	if err != nil {
+		return maskAny(err)
+	}
+
+	_, linked := props.Links[collection]
+	if link == nil && !linked || link != nil && linked && !force {
+		return nil
+	}
+
+	if props.Links == nil {
+		props.Links = driver.ArangoSearchLinks{}
+	}
+
+	if link == nil {
+		delete(props.Links, collection)
+	} else {
+		props.Links[collection] = *link
+	}
+
+	// Primary sort cannot be changed once the view is created
+	props.PrimarySort = nil
+
+	return maskAny(searchView.SetProperties(ctx, props))
+}
+
+// indexesEqual returns true if both lists contain the same indexes.
+func indexesEqual(a, b []api.ArangoCollectionIndex) bool {
+	if len(a) == 0 && len(b) == 0 {
+		return true
+	}
+
+	return reflect.DeepEqual(a, b)
+}
+
+// viewsEqual returns true if both lists contain the same views.
+func viewsEqual(a, b []api.ArangoCollectionView) bool {
+	if len(a) == 0 && len(b) == 0 {
+		return true
+	}
+
+	return reflect.DeepEqual(a, b)
+}
+
+// dropCollection removes the collection if it exists.
+func dropCollection(ctx context.Context, client driver.Client, database, name string) error {
+	db, err := client.Database(ctx, database)
+	if err != nil {
+		if driver.IsNotFound(err) {
+			return nil
+		}
+		return maskAny(err)
+	}
+
+	c, err := db.Collection(ctx, name)
+	if err != nil {
+		if driver.IsNotFound(err) {
+			return nil
+		}
+		return maskAny(err)
+	}
+
+	if err := c.Remove(ctx); err != nil && !driver.IsNotFound(err) {
+		return maskAny(err)
+	}
+
+	return nil
+}
@@ -0,0 +1,106 @@
+//
+// DISCLAIMER
+//
+// Copyright 2020 ArangoDB GmbH, Cologne, Germany
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// Copyright holder is ArangoDB GmbH, Cologne, Germany
+//
+
+package provisioning
+
+import (
+	"testing"
+
+	driver "github.com/arangodb/go-driver"
+	api "github.com/arangodb/kube-arangodb/pkg/apis/deployment/v1"
+	"github.com/arangodb/kube-arangodb/pkg/util"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewCollectionOptions(t *testing.T) {
+	edge := api.ArangoCollectionTypeEdge
+
+	opts := newCollectionOptions(api.ArangoCollectionSpec{
+		Type:              &edge,
+		NumberOfShards:    util.NewInt(3),
+		ShardKeys:         []string{"tenant"},
+		ReplicationFactor: util.NewInt(2),
+		WriteConcern:      util.NewInt(1),
+	})
+
+	require.Equal(t, driver.CollectionTypeEdge, opts.Type)
+	require.Equal(t, 3, opts.NumberOfShards)
+	require.Equal(t, []string{"tenant"}, opts.ShardKeys)
+	require.Equal(t, 2, opts.ReplicationFactor)
+	require.Equal(t, 1, opts.WriteConcern)
+}
+
+func TestCollectionDrift(t *testing.T) {
+	props := driver.CollectionProperties{
+		NumberOfShards: 3,
+		ShardKeys:      []string{"_key"},
+	}
+	props.Type = driver.CollectionTypeDocument
+
+	require.Empty(t, collectionDrift(api.ArangoCollectionSpec{NumberOfShards: util.NewInt(3)}, props))
+	require.Len(t, collectionDrift(api.ArangoCollectionSpec{NumberOfShards: util.NewInt(5), ShardKeys: []string{"tenant"}}, props), 2)
+
+	edge := api.ArangoCollectionTypeEdge
+	require.Equal(t, []string{"collection type is not edge"}, collectionDrift(api.ArangoCollectionSpec{Type: &edge}, props))
+
+	// Sharding is not reported by single servers
+	props.NumberOfShards = 0
+	require.Empty(t, collectionDrift(api.ArangoCollectionSpec{NumberOfShards: util.NewInt(5)}, props))
+}
+
+func TestCollectionPropertiesUpdate(t *testing.T) {
+	props := driver.CollectionProperties{
+		ReplicationFactor: 2,
+		WriteConcern:      1,
+	}
+
+	_, changed := collectionPropertiesUpdate(api.ArangoCollectionSpec{ReplicationFactor: util.NewInt(2)}, props)
+	require.False(t, changed)
+
+	opts, changed := collectionPropertiesUpdate(api.ArangoCollectionSpec{
+		ReplicationFactor: util.NewInt(3),
+		WriteConcern:      util.NewInt(2),
+		WaitForSync:       util.NewBool(true),
+	}, props)
+	require.True(t, changed)
+	require.Equal(t, 3, opts.ReplicationFactor)
+	require.Equal(t, 2, opts.WriteConcern)
+	require.True(t, *opts.WaitForSync)
+
+	// Replication is not changed for single servers and satellite collections
+	props.ReplicationFactor = 0
+	_, changed = collectionPropertiesUpdate(api.ArangoCollectionSpec{ReplicationFactor: util.NewInt(3)}, props)
+	require.False(t, changed)
+}
+
+func TestNewViewLink(t *testing.T) {
+	link := newViewLink(api.ArangoCollectionView{
+		Name:             "search",
+		Analyzers:        []string{"text_en"},
+		IncludeAllFields: util.NewBool(false),
+		StoreValues:      util.NewString("id"),
+		Fields:           []string{"title", "body"},
+	})
+
+	require.Equal(t, []string{"text_en"}, link.Analyzers)
+	require.Equal(t, driver.ArangoSearchStoreValuesID, link.StoreValues)
+	require.Len(t, link.Fields, 2)
+	require.Contains(t, link.Fields, "title")
+}
@@ -0,0 +1,57 @@
+//
+// DISCLAIMER
+//
+// Copyright 2020 ArangoDB GmbH, Cologne, Germany
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// Copyright holder is ArangoDB GmbH, Cologne, Germany
+//
+
+package provisioning
+
+import (
+	"context"
+
+	driver "github.com/arangodb/go-driver"
+	"github.com/arangodb/kube-arangodb/pkg/generated/clientset/versioned"
+	listers "github.com/arangodb/kube-arangodb/pkg/generated/listers/deployment/v1"
+	"github.com/arangodb/kube-arangodb/pkg/util/k8sutil"
+)
+
+// Context provides methods to the provisioning package.
+type Context interface {
+	// GetName returns the name of the deployment
+	GetName() string
+	// GetNamespace returns the namespace that contains the deployment
+	GetNamespace() string
+	// GetDatabaseClient returns a cached client for the entire database (cluster coordinators or single server),
+	// creating one if needed.
+	GetDatabaseClient(ctx context.Context) (driver.Client, error)
+	// GetArangoCli returns the client for ArangoDB custom resources
+	GetArangoCli() versioned.Interface
+	// GetArangoDatabaseLister returns the cached lister of ArangoDatabase resources.
+	// Returns false when the resources are not watched or the cache is not synced yet.
+	GetArangoDatabaseLister() (listers.ArangoDatabaseNamespaceLister, bool)
+	// GetArangoCollectionLister returns the cached lister of ArangoCollection resources.
+	// Returns false when the resources are not watched or the cache is not synced yet.
+	GetArangoCollectionLister() (listers.ArangoCollectionNamespaceLister, bool)
+	// GetArangoUserLister returns the cached lister of ArangoUser resources.
+	// Returns false when the resources are not watched or the cache is not synced yet.
+	GetArangoUserLister() (listers.ArangoUserNamespaceLister, bool)
+	// SecretsInterface return the secret interface.
+	SecretsInterface() k8sutil.SecretInterface
+	// CreateEvent creates a given event.
+	// On error, the error is logged.
+	CreateEvent(evt *k8sutil.Event)
+}
@@ -0,0 +1,173 @@
+//
+// DISCLAIMER
+//
+// Copyright 2020 ArangoDB GmbH, Cologne, Germany
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// Copyright holder is ArangoDB GmbH, Cologne, Germany
+//
+
+package provisioning
+
+import (
+	"context"
+
+	driver "github.com/arangodb/go-driver"
+	api "github.com/arangodb/kube-arangodb/pkg/apis/deployment/v1"
+	"github.com/arangodb/kube-arangodb/pkg/util/constants"
+	"github.com/arangodb/kube-arangodb/pkg/util/k8sutil"
+	meta "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// inspectDatabase creates the database declared by the given resource
+// and drops it when the resource is deleted.
+func (p *Provisioner) inspectDatabase(ctx context.Context, client driver.Client, db *api.ArangoDatabase) error {
+	name := db.GetDatabaseName()
+
+	if db.GetDeletionTimestamp() != nil {
+		if !hasFinalizer(db, constants.FinalizerDatabaseDrop) {
+			return nil
+		}
+
+		if err := dropDatabase(ctx, client, name); err != nil {
+			return maskAny(err)
+		}
+
+		p.log.Info().Str("database", name).Msg("Database dropped")
+		return p.removeDatabaseFinalizers(db, constants.FinalizerDatabaseDrop)
+	}
+
+	if err := db.Spec.Validate(db.GetName()); err != nil {
+		return p.updateDatabaseStatus(db, func(s *api.ArangoDatabaseStatus) bool {
+			return s.Conditions.Update(api.ConditionTypeReady, false, "Invalid specification", err.Error())
+		})
+	}
+
+	if db.Status.Name != "" && db.Status.Name != db.Spec.GetName(db.GetName()) {
+		return p.updateDatabaseStatus(db, func(s *api.ArangoDatabaseStatus) bool {
+			return s.Conditions.Update(api.ConditionTypeReady, false, "Invalid specification", "name cannot be changed")
+		})
+	}
+
+	if setFinalizer(db, constants.FinalizerDatabaseDrop, db.Spec.GetDropOnDelete()) {
+		updated, err := p.context.GetArangoCli().DatabaseV1().ArangoDatabases(db.GetNamespace()).Update(db)
+		if err != nil {
+			return maskAny(err)
+		}
+		*db = *updated
+	}
+
+	exists, err := client.DatabaseExists(ctx, name)
+	if err != nil {
+		return maskAny(err)
+	}
+
+	if !exists {
+		if _, createErr := client.CreateDatabase(ctx, name, newDatabaseOptions(db.Spec)); createErr != nil {
+			if err := p.updateDatabaseStatus(db, func(s *api.ArangoDatabaseStatus) bool {
+				return s.Conditions.Update(api.ConditionTypeReady, false, "Creation failed", createErr.Error())
+			}); err != nil {
+				p.log.Warn().Err(err).Str("database", db.GetName()).Msg("Unable to update database status")
+			}
+			return maskAny(createErr)
+		}
+
+		p.log.Info().Str("database", name).Msg("Database created")
+	}
+
+	return p.updateDatabaseStatus(db, func(s *api.ArangoDatabaseStatus) bool {
+		changed := s.Conditions.Update(api.ConditionTypeReady, true, "Database created", "")
+		if s.Name != name {
+			s.Name = name
+			changed = true
+		}
+		return changed
+	})
+}
+
+// updateDatabaseStatus updates the status of the database when the given function reports a change.
+func (p *Provisioner) updateDatabaseStatus(db *api.ArangoDatabase, update func(s *api.ArangoDatabaseStatus) bool) error {
+	status := db.Status.DeepCopy()
+	if !update(status) {
+		return nil
+	}
+
+	db.Status = *status
+	updated, err := p.context.GetArangoCli().DatabaseV1().ArangoDatabases(db.GetNamespace()).UpdateStatus(db)
+	if err != nil {
+		return maskAny(err)
+	}
+	*db = *updated
+	return nil
+}
+
+// removeDatabaseFinalizers removes the given finalizers from the database.
+func (p *Provisioner) removeDatabaseFinalizers(db *api.ArangoDatabase, finalizers ...string) error {
+	databases := p.context.GetArangoCli().DatabaseV1().ArangoDatabases(db.GetNamespace())
+	getFunc := func() (meta.Object, error) {
+		result, err := databases.Get(db.GetName(), meta.GetOptions{})
+		if err != nil {
+			return nil, maskAny(err)
+		}
+		return result, nil
+	}
+	updateFunc := func(updated meta.Object) error {
+		result, err := databases.Update(updated.(*api.ArangoDatabase))
+		if err != nil {
+			return maskAny(err)
+		}
+		*db = *result
+		return nil
+	}
+	if err := k8sutil.RemoveFinalizers(p.log, finalizers, getFunc, updateFunc, true); err != nil {
+		return maskAny(err)
+	}
+	return nil
+}
+
+// newDatabaseOptions returns the options used to create the database.
+func newDatabaseOptions(spec api.ArangoDatabaseSpec) *driver.CreateDatabaseOptions {
+	opts := &driver.CreateDatabaseOptions{}
+
+	if spec.ReplicationFactor != nil {
+		opts.Options.ReplicationFactor = *spec.ReplicationFactor
+	}
+
+	if spec.WriteConcern != nil {
+		opts.Options.WriteConcern = *spec.WriteConcern
+	}
+
+	if spec.Sharding != nil {
+		opts.Options.Sharding = driver.DatabaseSharding(*spec.Sharding)
+	}
+
+	return opts
+}
+
+// dropDatabase removes the database if it exists.
+func dropDatabase(ctx context.Context, client driver.Client, name string) error {
+	db, err := client.Database(ctx, name)
+	if err != nil {
+		if driver.IsNotFound(err) {
+			return nil
+		}
+		return maskAny(err)
+	}
+
+	if err := db.Remove(ctx); err != nil && !driver.IsNotFound(err) {
+		return maskAny(err)
+	}
+
+	return nil
+}
@@ -0,0 +1,127 @@
+//
+// DISCLAIMER
+//
+// Copyright 2020 ArangoDB GmbH, Cologne, Germany
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// Copyright holder is ArangoDB GmbH, Cologne, Germany
+//
+
+package provisioning
+
+import (
+	"context"
+
+	driver "github.com/arangodb/go-driver"
+	api "github.com/arangodb/kube-arangodb/pkg/apis/deployment/v1"
+)
+
+// samePermissionTarget returns true if both permissions refer to the same database or collection.
+func samePermissionTarget(a, b api.ArangoUserPermission) bool {
+	return a.Database == b.Database && a.Collection == b.Collection
+}
+
+// revokedPermissions returns applied permissions which are no longer requested.
+func revokedPermissions(applied, requested []api.ArangoUserPermission) []api.ArangoUserPermission {
+	var result []api.ArangoUserPermission
+
+	for _, a := range applied {
+		found := false
+		for _, r := range requested {
+			if samePermissionTarget(a, r) {
+				found = true
+				break
+			}
+		}
+		if !found {
+			result = append(result, a)
+		}
+	}
+
+	return result
+}
+
+// changedPermissions returns requested permissions which are not applied yet or have a different grant.
+func changedPermissions(applied, requested []api.ArangoUserPermission) []api.ArangoUserPermission {
+	var result []api.ArangoUserPermission
+
+	for _, r := range requested {
+		found := false
+		for _, a := range applied {
+			if samePermissionTarget(a, r) && a.Grant == r.Grant {
+				found = true
+				break
+			}
+		}
+		if !found {
+			result = append(result, r)
+		}
+	}
+
+	return result
+}
+
+// setPermission grants the user access to the database or collection.
+func setPermission(ctx context.Context, client driver.Client, user driver.User, permission api.ArangoUserPermission) error {
+	db, err := client.Database(ctx, permission.Database)
+	if err != nil {
+		return maskAny(err)
+	}
+
+	grant := driver.Grant(permission.Grant)
+
+	switch permission.Collection {
+	case "":
+		return maskAny(user.SetDatabaseAccess(ctx, db, grant))
+	case api.ArangoUserCollectionAll:
+		return maskAny(user.SetCollectionAccess(ctx, db, grant))
+	}
+
+	col, err := db.Collection(ctx, permission.Collection)
+	if err != nil {
+		return maskAny(err)
+	}
+
+	return maskAny(user.SetCollectionAccess(ctx, col, grant))
+}
+
+// removePermission removes the access of the user to the database or collection,
+// so the access falls back to its default.
+func removePermission(ctx context.Context, client driver.Client, user driver.User, permission api.ArangoUserPermission) error {
+	db, err := client.Database(ctx, permission.Database)
+	if err != nil {
+		if driver.IsNotFound(err) {
+			return nil
+		}
+		return maskAny(err)
+	}
+
+	switch permission.Collection {
+	case "":
+		err = user.RemoveDatabaseAccess(ctx, db)
+	case api.ArangoUserCollectionAll:
+		err = user.RemoveCollectionAccess(ctx, db)
+	default:
+		var col driver.Collection
+		if col, err = db.Collection(ctx, permission.Collection); err == nil {
+			err = user.RemoveCollectionAccess(ctx, col)
+		}
+	}
+
+	if err != nil && !driver.IsNotFound(err) {
+		return maskAny(err)
+	}
+
+	return nil
+}
@@ -0,0 +1,288 @@
+//
+// DISCLAIMER
+//
+// Copyright 2020 ArangoDB GmbH, Cologne, Germany
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// Copyright holder is ArangoDB GmbH, Cologne, Germany
+//
+
+package provisioning
+
+import (
+	"context"
+	"time"
+
+	api "github.com/arangodb/kube-arangodb/pkg/apis/deployment/v1"
+	"github.com/arangodb/kube-arangodb/pkg/util/constants"
+	"github.com/arangodb/kube-arangodb/pkg/util/k8sutil"
+	"github.com/rs/zerolog"
+	meta "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+)
+
+const (
+	provisioningTimeout = time.Minute
+)
+
+// Provisioner is the service that creates databases, collections and users declared
+// with ArangoDatabase, ArangoCollection and ArangoUser resources in the deployment.
+type Provisioner struct {
+	log     zerolog.Logger
+	context Context
+}
+
+// NewProvisioner creates a new provisioner with given context.
+func NewProvisioner(log zerolog.Logger, context Context) *Provisioner {
+	return &Provisioner{
+		log:     log,
+		context: context,
+	}
+}
+
+// Inspect reconciles all ArangoDatabase, ArangoCollection and ArangoUser resources which belong to the deployment.
+// Databases and collections are inspected first, so users can be granted access to them in the same run.
+// Resources are listed from the cache of the deployment watchers.
+func (p *Provisioner) Inspect(ctx context.Context) error {
+	databases, err := p.listDatabases(false)
+	if err != nil {
+		return maskAny(err)
+	}
+
+	collections, err := p.listCollections(false)
+	if err != nil {
+		return maskAny(err)
+	}
+
+	users, err := p.listUsers(false)
+	if err != nil {
+		return maskAny(err)
+	}
+
+	if len(databases) == 0 && len(collections) == 0 && len(users) == 0 {
+		return nil
+	}
+
+	ctxChild, cancel := context.WithTimeout(ctx, provisioningTimeout)
+	defer cancel()
+
+	client, err := p.context.GetDatabaseClient(ctxChild)
+	if err != nil {
+		return maskAny(err)
+	}
+
+	for i := range databases {
+		db := &databases[i]
+		if err := p.inspectDatabase(ctxChild, client, db); err != nil {
+			p.log.Warn().Err(err).Str("database", db.GetName()).Msg("Unable to provision database")
+			p.context.CreateEvent(k8sutil.NewErrorEvent("Database provisioning failed", err, db))
+		}
+	}
+
+	for i := range collections {
+		col := &collections[i]
+		if err := p.inspectCollection(ctxChild, client, col); err != nil {
+			p.log.Warn().Err(err).Str("collection", col.GetName()).Msg("Unable to provision collection")
+			p.context.CreateEvent(k8sutil.NewErrorEvent("Collection provisioning failed", err, col))
+		}
+	}
+
+	for i := range users {
+		user := &users[i]
+		if err := p.inspectUser(ctxChild, client, user); err != nil {
+			p.log.Warn().Err(err).Str("user", user.GetName()).Msg("Unable to provision user")
+			p.context.CreateEvent(k8sutil.NewErrorEvent("User provisioning failed", err, user))
+		}
+	}
+
+	return nil
+}
+
+// RemoveFinalizers removes provisioning finalizers from all resources which belong to the deployment,
+// so they do not block deletion once the deployment is gone.
+// The API is used when the cache is not available, so finalizers are removed also right after the start.
+func (p *Provisioner) RemoveFinalizers() error {
+	databases, err := p.listDatabases(true)
+	if err != nil {
+		return maskAny(err)
+	}
+
+	for i := range databases {
+		if err := p.removeDatabaseFinalizers(&databases[i], constants.FinalizerDatabaseDrop); err != nil {
+			return maskAny(err)
+		}
+	}
+
+	collections, err := p.listCollections(true)
+	if err != nil {
+		return maskAny(err)
+	}
+
+	for i := range collections {
+		if err := p.removeCollectionFinalizers(&collections[i], constants.FinalizerCollectionDrop); err != nil {
+			return maskAny(err)
+		}
+	}
+
+	users, err := p.listUsers(true)
+	if err != nil {
+		return maskAny(err)
+	}
+
+	for i := range users {
+		if err := p.removeUserFinalizers(&users[i], constants.FinalizerUserRemove); err != nil {
+			return maskAny(err)
+		}
+	}
+
+	return nil
+}
+
+// listDatabases returns all ArangoDatabase resources which belong to the deployment.
+// Resources are listed from the cache, the API is used only when uncached is set and the cache is not available.
+// Empty list is returned when the CRD is not installed.
+func (p *Provisioner) listDatabases(uncached bool) ([]api.ArangoDatabase, error) {
+	var items []api.ArangoDatabase
+
+	if lister, ok := p.context.GetArangoDatabaseLister(); ok {
+		list, err := lister.List(labels.Everything())
+		if err != nil {
+			return nil, maskAny(err)
+		}
+		for _, db := range list {
+			items = append(items, *db.DeepCopy())
+		}
+	} else if uncached {
+		list, err := p.context.GetArangoCli().DatabaseV1().ArangoDatabases(p.context.GetNamespace()).List(meta.ListOptions{})
+		if err != nil {
+			if k8sutil.IsNotFound(err) {
+				return nil, nil
+			}
+			return nil, maskAny(err)
+		}
+		items = list.Items
+	}
+
+	var result []api.ArangoDatabase
+	for _, db := range items {
+		if db.Spec.DeploymentName == p.context.GetName() {
+			result = append(result, db)
+		}
+	}
+
+	return result, nil
+}
+
+// listCollections returns all ArangoCollection resources which belong to the deployment.
+// Resources are listed from the cache, the API is used only when uncached is set and the cache is not available.
+// Empty list is returned when the CRD is not installed.
+func (p *Provisioner) listCollections(uncached bool) ([]api.ArangoCollection, error) {
+	var items []api.ArangoCollection
+
+	if lister, ok := p.context.GetArangoCollectionLister(); ok {
+		list, err := lister.List(labels.Everything())
+		if err != nil {
+			return nil, maskAny(err)
+		}
+		for _, col := range list {
+			items = append(items, *col.DeepCopy())
+		}
+	} else if uncached {
+		list, err := p.context.GetArangoCli().DatabaseV1().ArangoCollections(p.context.GetNamespace()).List(meta.ListOptions{})
+		if err != nil {
+			if k8sutil.IsNotFound(err) {
+				return nil, nil
+			}
+			return nil, maskAny(err)
+		}
+		items = list.Items
+	}
+
+	var result []api.ArangoCollection
+	for _, col := range items {
+		if col.Spec.DeploymentName == p.context.GetName() {
+			result = append(result, col)
+		}
+	}
+
+	return result, nil
+}
+
+// listUsers returns all ArangoUser resources which belong to the deployment.
+// Resources are listed from the cache, the API is used only when uncached is set and the cache is not available.
+// Empty list is returned when the CRD is not installed.
+func (p *Provisioner) listUsers(uncached bool) ([]api.ArangoUser, error) {
+	var items []api.ArangoUser
+
+	if lister, ok := p.context.GetArangoUserLister(); ok {
+		list, err := lister.List(labels.Everything())
+		if err != nil {
+			return nil, maskAny(err)
+		}
+		for _, user := range list {
+			items = append(items, *user.DeepCopy())
+		}
+	} else if uncached {
+		list, err := p.context.GetArangoCli().DatabaseV1().ArangoUsers(p.context.GetNamespace()).List(meta.ListOptions{})
+		if err != nil {
+			if k8sutil.IsNotFound(err) {
+				return nil, nil
+			}
+			return nil, maskAny(err)
+		}
+		items = list.Items
+	}
+
+	var result []api.ArangoUser
+	for _, user := range items {
+		if user.Spec.DeploymentName == p.context.GetName() {
+			result = append(result, user)
+		}
+	}
+
+	return result, nil
+}
+
+// hasFinalizer returns true if the object contains the given finalizer.
+func hasFinalizer(obj meta.Object, finalizer string) bool {
+	for _, f := range obj.GetFinalizers() {
+		if f == finalizer {
+			return true
+		}
+	}
+
+	return false
+}
+
+// setFinalizer adds or removes the finalizer from the object (in memory).
+// Returns true when finalizers were changed.
+func setFinalizer(obj meta.Object, finalizer string, enabled bool) bool {
+	if hasFinalizer(obj, finalizer) == enabled {
+		return false
+	}
+
+	if enabled {
+		obj.SetFinalizers(append(obj.GetFinalizers(), finalizer))
+		return true
+	}
+
+	finalizers := make([]string, 0, len(obj.GetFinalizers()))
+	for _, f := range obj.GetFinalizers() {
+		if f != finalizer {
+			finalizers = append(finalizers, f)
+		}
+	}
+	obj.SetFinalizers(finalizers)
+	return true
+}
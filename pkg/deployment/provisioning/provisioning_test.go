@@ -0,0 +1,77 @@
+//
+// DISCLAIMER
+//
+// Copyright 2020 ArangoDB GmbH, Cologne, Germany
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// Copyright holder is ArangoDB GmbH, Cologne, Germany
+//
+
+package provisioning
+
+import (
+	"testing"
+
+	api "github.com/arangodb/kube-arangodb/pkg/apis/deployment/v1"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRevokedPermissions(t *testing.T) {
+	applied := []api.ArangoUserPermission{
+		{Database: "db", Grant: api.ArangoUserGrantReadWrite},
+		{Database: "db", Collection: "col", Grant: api.ArangoUserGrantReadOnly},
+		{Database: "other", Grant: api.ArangoUserGrantReadOnly},
+	}
+	requested := []api.ArangoUserPermission{
+		{Database: "db", Grant: api.ArangoUserGrantReadOnly},
+	}
+
+	require.Equal(t, []api.ArangoUserPermission{
+		{Database: "db", Collection: "col", Grant: api.ArangoUserGrantReadOnly},
+		{Database: "other", Grant: api.ArangoUserGrantReadOnly},
+	}, revokedPermissions(applied, requested))
+	require.Empty(t, revokedPermissions(nil, requested))
+	require.Equal(t, applied, revokedPermissions(applied, nil))
+}
+
+func TestChangedPermissions(t *testing.T) {
+	applied := []api.ArangoUserPermission{
+		{Database: "db", Grant: api.ArangoUserGrantReadWrite},
+		{Database: "db", Collection: "col", Grant: api.ArangoUserGrantReadOnly},
+	}
+	requested := []api.ArangoUserPermission{
+		{Database: "db", Grant: api.ArangoUserGrantReadOnly},
+		{Database: "db", Collection: "col", Grant: api.ArangoUserGrantReadOnly},
+		{Database: "other", Grant: api.ArangoUserGrantNone},
+	}
+
+	require.Equal(t, []api.ArangoUserPermission{
+		{Database: "db", Grant: api.ArangoUserGrantReadOnly},
+		{Database: "other", Grant: api.ArangoUserGrantNone},
+	}, changedPermissions(applied, requested))
+	require.Empty(t, changedPermissions(requested, requested))
+	require.Equal(t, requested, changedPermissions(nil, requested))
+}
+
+func TestSetFinalizer(t *testing.T) {
+	db := &api.ArangoDatabase{}
+	db.SetFinalizers([]string{"other"})
+
+	require.False(t, setFinalizer(db, "test", false))
+	require.True(t, setFinalizer(db, "test", true))
+	require.Equal(t, []string{"other", "test"}, db.GetFinalizers())
+	require.False(t, setFinalizer(db, "test", true))
+	require.True(t, setFinalizer(db, "test", false))
+	require.Equal(t, []string{"other"}, db.GetFinalizers())
+}
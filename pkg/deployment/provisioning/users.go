@@ -0,0 +1,264 @@
+//
+// DISCLAIMER
+//
+// Copyright 2020 ArangoDB GmbH, Cologne, Germany
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// Copyright holder is ArangoDB GmbH, Cologne, Germany
+//
+
+package provisioning
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+
+	driver "github.com/arangodb/go-driver"
+	api "github.com/arangodb/kube-arangodb/pkg/apis/deployment/v1"
+	"github.com/arangodb/kube-arangodb/pkg/util"
+	"github.com/arangodb/kube-arangodb/pkg/util/constants"
+	"github.com/arangodb/kube-arangodb/pkg/util/k8sutil"
+	core "k8s.io/api/core/v1"
+	meta "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// inspectUser creates the user declared by the given resource, keeps its password
+// and permissions in sync and removes the user when the resource is deleted.
+// Existing users are managed only when adoption is enabled in the resource.
+func (p *Provisioner) inspectUser(ctx context.Context, client driver.Client, u *api.ArangoUser) error {
+	name := u.GetUserName()
+
+	if u.GetDeletionTimestamp() != nil {
+		if !hasFinalizer(u, constants.FinalizerUserRemove) {
+			return nil
+		}
+
+		if err := removeUser(ctx, client, name); err != nil {
+			return maskAny(err)
+		}
+
+		p.log.Info().Str("user", name).Msg("User removed")
+		return p.removeUserFinalizers(u, constants.FinalizerUserRemove)
+	}
+
+	if err := u.Spec.Validate(u.GetName()); err != nil {
+		return p.updateUserStatus(u, func(s *api.ArangoUserStatus) bool {
+			return s.Conditions.Update(api.ConditionTypeReady, false, "Invalid specification", err.Error())
+		})
+	}
+
+	if u.Status.Name != "" && u.Status.Name != u.Spec.GetName(u.GetName()) {
+		return p.updateUserStatus(u, func(s *api.ArangoUserStatus) bool {
+			return s.Conditions.Update(api.ConditionTypeReady, false, "Invalid specification", "name cannot be changed")
+		})
+	}
+
+	user, err := client.User(ctx, name)
+	if err != nil && !driver.IsNotFound(err) {
+		return maskAny(err)
+	}
+	exists := err == nil
+
+	if exists && u.Status.Name == "" {
+		// User was not created by the operator
+		if !u.Spec.GetAdopt() {
+			return p.updateUserStatus(u, func(s *api.ArangoUserStatus) bool {
+				return s.Conditions.Update(api.ConditionTypeReady, false, "User exists",
+					fmt.Sprintf("user %s already exists in the deployment, set adopt to manage it", name))
+			})
+		}
+
+		if err := p.updateUserStatus(u, func(s *api.ArangoUserStatus) bool {
+			s.Name = name
+			s.Adopted = true
+			return true
+		}); err != nil {
+			return maskAny(err)
+		}
+		p.log.Info().Str("user", name).Msg("User adopted")
+	}
+
+	if setFinalizer(u, constants.FinalizerUserRemove, u.Spec.GetDropOnDelete(u.Status.Adopted)) {
+		updated, err := p.context.GetArangoCli().DatabaseV1().ArangoUsers(u.GetNamespace()).Update(u)
+		if err != nil {
+			return maskAny(err)
+		}
+		*u = *updated
+	}
+
+	password, passwordVersion, err := p.ensureUserPasswordSecret(u, name)
+	if err != nil {
+		return maskAny(err)
+	}
+	active := u.Spec.GetActive()
+
+	if !exists {
+		if user, err = client.CreateUser(ctx, name, &driver.UserOptions{Password: password, Active: util.NewBool(active)}); err != nil {
+			return maskAny(err)
+		}
+		p.log.Info().Str("user", name).Msg("User created")
+
+		// Name is kept right away, so the created user is not considered as existing one
+		if err := p.updateUserStatus(u, func(s *api.ArangoUserStatus) bool {
+			s.Name = name
+			s.PasswordSecretVersion = passwordVersion
+			return true
+		}); err != nil {
+			return maskAny(err)
+		}
+	} else if u.Status.PasswordSecretVersion != passwordVersion || user.IsActive() != active {
+		if err := user.Update(ctx, driver.UserOptions{Password: password, Active: util.NewBool(active)}); err != nil {
+			return maskAny(err)
+		}
+		p.log.Info().Str("user", name).Msg("User updated")
+	}
+
+	for _, permission := range revokedPermissions(u.Status.Permissions, u.Spec.Permissions) {
+		if err := removePermission(ctx, client, user, permission); err != nil {
+			return maskAny(err)
+		}
+	}
+
+	for _, permission := range changedPermissions(u.Status.Permissions, u.Spec.Permissions) {
+		if permissionErr := setPermission(ctx, client, user, permission); permissionErr != nil {
+			// Database or collection might not be created yet, keep the previous state and retry later
+			if err := p.updateUserStatus(u, func(s *api.ArangoUserStatus) bool {
+				return s.Conditions.Update(api.ConditionTypeReady, false, "Permissions not applied", permissionErr.Error())
+			}); err != nil {
+				p.log.Warn().Err(err).Str("user", u.GetName()).Msg("Unable to update user status")
+			}
+			return maskAny(permissionErr)
+		}
+	}
+
+	return p.updateUserStatus(u, func(s *api.ArangoUserStatus) bool {
+		changed := s.Conditions.Update(api.ConditionTypeReady, true, "User created", "")
+		if s.Name != name {
+			s.Name = name
+			changed = true
+		}
+		if s.PasswordSecretVersion != passwordVersion {
+			s.PasswordSecretVersion = passwordVersion
+			changed = true
+		}
+		if len(changedPermissions(s.Permissions, u.Spec.Permissions)) > 0 || len(revokedPermissions(s.Permissions, u.Spec.Permissions)) > 0 {
+			s.Permissions = append([]api.ArangoUserPermission{}, u.Spec.Permissions...)
+			changed = true
+		}
+		return changed
+	})
+}
+
+// ensureUserPasswordSecret returns the password of the user and the resource version of the secret.
+// Secret with a random password is created when it does not exist.
+func (p *Provisioner) ensureUserPasswordSecret(u *api.ArangoUser, name string) (string, string, error) {
+	secretName := u.Spec.GetPasswordSecretName(u.GetName())
+	secrets := p.context.SecretsInterface()
+
+	secret, err := secrets.Get(secretName, meta.GetOptions{})
+	if k8sutil.IsNotFound(err) {
+		tokenData := make([]byte, 32)
+		if _, err := rand.Read(tokenData); err != nil {
+			return "", "", maskAny(err)
+		}
+		password := hex.EncodeToString(tokenData)
+
+		secret = &core.Secret{
+			ObjectMeta: meta.ObjectMeta{
+				Name: secretName,
+			},
+			Data: map[string][]byte{
+				constants.SecretUsername: []byte(name),
+				constants.SecretPassword: []byte(password),
+			},
+		}
+		owner := u.AsOwner()
+		k8sutil.AddOwnerRefToObject(secret, &owner)
+
+		created, err := secrets.Create(secret)
+		if err != nil {
+			return "", "", maskAny(err)
+		}
+
+		return password, created.GetResourceVersion(), nil
+	} else if err != nil {
+		return "", "", maskAny(err)
+	}
+
+	_, password, err := k8sutil.GetSecretAuthCredentials(secret)
+	if err != nil {
+		return "", "", maskAny(err)
+	}
+
+	return password, secret.GetResourceVersion(), nil
+}
+
+// updateUserStatus updates the status of the user when the given function reports a change.
+func (p *Provisioner) updateUserStatus(u *api.ArangoUser, update func(s *api.ArangoUserStatus) bool) error {
+	status := u.Status.DeepCopy()
+	if !update(status) {
+		return nil
+	}
+
+	u.Status = *status
+	updated, err := p.context.GetArangoCli().DatabaseV1().ArangoUsers(u.GetNamespace()).UpdateStatus(u)
+	if err != nil {
+		return maskAny(err)
+	}
+	*u = *updated
+	return nil
+}
+
+// removeUserFinalizers removes the given finalizers from the user.
+func (p *Provisioner) removeUserFinalizers(u *api.ArangoUser, finalizers ...string) error {
+	users := p.context.GetArangoCli().DatabaseV1().ArangoUsers(u.GetNamespace())
+	getFunc := func() (meta.Object, error) {
+		result, err := users.Get(u.GetName(), meta.GetOptions{})
+		if err != nil {
+			return nil, maskAny(err)
+		}
+		return result, nil
+	}
+	updateFunc := func(updated meta.Object) error {
+		result, err := users.Update(updated.(*api.ArangoUser))
+		if err != nil {
+			return maskAny(err)
+		}
+		*u = *result
+		return nil
+	}
+	if err := k8sutil.RemoveFinalizers(p.log, finalizers, getFunc, updateFunc, true); err != nil {
+		return maskAny(err)
+	}
+	return nil
+}
+
+// removeUser removes the user if it exists.
+func removeUser(ctx context.Context, client driver.Client, name string) error {
+	user, err := client.User(ctx, name)
+	if err != nil {
+		if driver.IsNotFound(err) {
+			return nil
+		}
+		return maskAny(err)
+	}
+
+	if err := user.Remove(ctx); err != nil && !driver.IsNotFound(err) {
+		return maskAny(err)
+	}
+
+	return nil
+}
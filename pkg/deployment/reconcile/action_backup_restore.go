@@ -24,9 +24,11 @@ package reconcile
 
 import (
 	"context"
+	"fmt"
 
 	"github.com/arangodb/go-driver"
 
+	backupApi "github.com/arangodb/kube-arangodb/pkg/apis/backup/v1"
 	api "github.com/arangodb/kube-arangodb/pkg/apis/deployment/v1"
 	"github.com/rs/zerolog"
 )
@@ -80,6 +82,24 @@ func (a actionBackupRestore) Start(ctx context.Context) (bool, error) {
 		return true, nil
 	}
 
+	if err := validateRestoreTopology(spec, backupResource); err != nil {
+		a.log.Error().Err(err).Msg("Backup can not be restored")
+
+		if err := a.actionCtx.WithStatusUpdate(func(s *api.DeploymentStatus) bool {
+			s.Restore = &api.DeploymentRestoreResult{
+				RequestedFrom: spec.GetRestoreFrom(),
+				State:         api.DeploymentRestoreStateRestoreFailed,
+				Message:       err.Error(),
+			}
+
+			return true
+		}); err != nil {
+			return false, err
+		}
+
+		return true, nil
+	}
+
 	if err := a.actionCtx.WithStatusUpdate(func(s *api.DeploymentStatus) bool {
 		result := &api.DeploymentRestoreResult{
 			RequestedFrom: spec.GetRestoreFrom(),
@@ -121,3 +141,21 @@ func (a actionBackupRestore) Start(ctx context.Context) (bool, error) {
 
 	return true, nil
 }
+
+// validateRestoreTopology ensures that the backup, which can be created on the other deployment, fits into this one
+func validateRestoreTopology(spec api.DeploymentSpec, backup *backupApi.ArangoBackup) error {
+	if spec.GetMode() != api.DeploymentModeCluster {
+		return nil
+	}
+
+	expected := int(backup.Status.Backup.NumberOfDBServers)
+	if expected == 0 {
+		return nil
+	}
+
+	if current := spec.DBServers.GetCount(); current != expected {
+		return fmt.Errorf("backup requires %d DBServers, deployment has %d", expected, current)
+	}
+
+	return nil
+}
@@ -0,0 +1,69 @@
+//
+// DISCLAIMER
+//
+// Copyright 2020 ArangoDB GmbH, Cologne, Germany
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// Copyright holder is ArangoDB GmbH, Cologne, Germany
+//
+
+package reconcile
+
+import (
+	"testing"
+
+	backupApi "github.com/arangodb/kube-arangodb/pkg/apis/backup/v1"
+	api "github.com/arangodb/kube-arangodb/pkg/apis/deployment/v1"
+	"github.com/arangodb/kube-arangodb/pkg/util"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_validateRestoreTopology(t *testing.T) {
+	newBackup := func(dbservers uint) *backupApi.ArangoBackup {
+		return &backupApi.ArangoBackup{
+			Status: backupApi.ArangoBackupStatus{
+				Backup: &backupApi.ArangoBackupDetails{
+					ID:                "id",
+					NumberOfDBServers: dbservers,
+				},
+			},
+		}
+	}
+
+	newSpec := func(mode api.DeploymentMode, dbservers int) api.DeploymentSpec {
+		return api.DeploymentSpec{
+			Mode: api.NewMode(mode),
+			DBServers: api.ServerGroupSpec{
+				Count: util.NewInt(dbservers),
+			},
+		}
+	}
+
+	t.Run("Same number of DBServers", func(t *testing.T) {
+		require.NoError(t, validateRestoreTopology(newSpec(api.DeploymentModeCluster, 3), newBackup(3)))
+	})
+
+	t.Run("Different number of DBServers", func(t *testing.T) {
+		require.Error(t, validateRestoreTopology(newSpec(api.DeploymentModeCluster, 5), newBackup(3)))
+		require.Error(t, validateRestoreTopology(newSpec(api.DeploymentModeCluster, 2), newBackup(3)))
+	})
+
+	t.Run("Unknown number of DBServers", func(t *testing.T) {
+		require.NoError(t, validateRestoreTopology(newSpec(api.DeploymentModeCluster, 5), newBackup(0)))
+	})
+
+	t.Run("Single server", func(t *testing.T) {
+		require.NoError(t, validateRestoreTopology(newSpec(api.DeploymentModeSingle, 5), newBackup(3)))
+	})
+}
@@ -125,6 +125,8 @@ type ActionContext interface {
 	WithStatusUpdate(action func(s *api.DeploymentStatus) bool, force ...bool) error
 	// GetBackup receives information about a backup resource
 	GetBackup(backup string) (*backupApi.ArangoBackup, error)
+	// GetBackups returns all backup resources created for this deployment
+	GetBackups() ([]backupApi.ArangoBackup, error)
 	// GetName receives information about a deployment name
 	GetName() string
 	// GetNameget current cached state of deployment
@@ -167,6 +169,10 @@ func (ac *actionContext) GetBackup(backup string) (*backupApi.ArangoBackup, erro
 	return ac.context.GetBackup(backup)
 }
 
+func (ac *actionContext) GetBackups() ([]backupApi.ArangoBackup, error) {
+	return ac.context.GetBackups()
+}
+
 func (ac *actionContext) WithStatusUpdate(action func(s *api.DeploymentStatus) bool, force ...bool) error {
 	return ac.context.WithStatusUpdate(action, force...)
 }
@@ -76,19 +76,33 @@ func (a *encryptionKeyAddAction) Start(ctx context.Context) (bool, error) {
 		return true, nil
 	}
 
-	secret := a.actionCtx.GetSpec().RocksDB.Encryption.GetKeySecretName()
-	if s, ok := a.action.Params[secretActionParam]; ok {
-		secret = s
-	}
+	var sha string
+	var d []byte
+
+	if secret, ok := a.action.Params[secretActionParam]; ok {
+		keySha, key, exists, err := pod.GetEncryptionKey(a.actionCtx.SecretsInterface(), a.actionCtx.GetSpec().RocksDB.Encryption, secret)
+		if err != nil {
+			a.log.Error().Err(err).Msgf("Unable to fetch current encryption key")
+			return true, nil
+		}
 
-	sha, d, exists, err := pod.GetEncryptionKey(a.actionCtx.SecretsInterface(), secret)
-	if err != nil {
-		a.log.Error().Err(err).Msgf("Unable to fetch current encryption key")
-		return true, nil
-	}
+		if !exists {
+			return true, nil
+		}
 
-	if !exists {
-		return true, nil
+		sha, d = keySha, key
+	} else {
+		active, exists, err := getActiveEncryptionKey(a.actionCtx.GetSpec(), a.actionCtx.GetStatus(), a.actionCtx.GetCachedStatus(), a.actionCtx.GetName())
+		if err != nil {
+			a.log.Error().Err(err).Msgf("Unable to fetch current encryption key")
+			return true, nil
+		}
+
+		if !exists {
+			return true, nil
+		}
+
+		sha, d = active.SHA, active.Key
 	}
 
 	p := patch.NewPatch()
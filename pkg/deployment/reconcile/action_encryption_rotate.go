@@ -0,0 +1,124 @@
+//
+// DISCLAIMER
+//
+// Copyright 2020 ArangoDB GmbH, Cologne, Germany
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// Copyright holder is ArangoDB GmbH, Cologne, Germany
+//
+
+package reconcile
+
+import (
+	"context"
+	"crypto/rand"
+	"fmt"
+
+	api "github.com/arangodb/kube-arangodb/pkg/apis/deployment/v1"
+	"github.com/arangodb/kube-arangodb/pkg/deployment/pod"
+	"github.com/arangodb/kube-arangodb/pkg/util"
+	"github.com/arangodb/kube-arangodb/pkg/util/errors"
+	"github.com/rs/zerolog"
+	meta "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func init() {
+	registerAction(api.ActionTypeEncryptionKeyRotate, newEncryptionKeyRotate)
+}
+
+func newEncryptionKeyRotate(log zerolog.Logger, action api.Action, actionCtx ActionContext) Action {
+	a := &encryptionKeyRotateAction{}
+
+	a.actionImpl = newActionImplDefRef(log, action, actionCtx, defaultTimeout)
+
+	return a
+}
+
+// encryptionKeyRotateAction generates a new encryption key and stores it in the operator owned secret.
+// User provided secret is not modified. Propagation of the new key is done by the encryption key plan.
+type encryptionKeyRotateAction struct {
+	actionImpl
+
+	actionEmptyCheckProgress
+}
+
+func (a *encryptionKeyRotateAction) Start(ctx context.Context) (bool, error) {
+	if err := ensureEncryptionSupport(a.actionCtx); err != nil {
+		a.log.Error().Err(err).Msgf("Action not supported")
+		return true, nil
+	}
+
+	encryption := a.actionCtx.GetSpec().RocksDB.Encryption
+	if encryption.HasProvider() {
+		a.log.Error().Msgf("Keys wrapped by a provider cannot be rotated")
+		return true, nil
+	}
+
+	current, exists := a.action.Params[checksum]
+	if !exists {
+		a.log.Warn().Msgf("Key %s is missing in action", checksum)
+		return true, nil
+	}
+
+	active, exists, err := getActiveEncryptionKey(a.actionCtx.GetSpec(), a.actionCtx.GetStatus(), a.actionCtx.GetCachedStatus(), a.actionCtx.GetName())
+	if err != nil {
+		a.log.Error().Err(err).Msgf("Encryption key is invalid, no rotation will take place")
+		return true, nil
+	}
+
+	if !exists {
+		a.log.Error().Msgf("Encryption key secret is missing, no rotation will take place")
+		return true, nil
+	}
+
+	if current != active.SHA {
+		a.log.Info().Msgf("Encryption key changed, no rotation will take place")
+		return true, nil
+	}
+
+	key := make([]byte, 32)
+	if _, err := rand.Read(key); err != nil {
+		return false, errors.Wrapf(err, "Unable to generate encryption key")
+	}
+	keySha := util.SHA256(key)
+
+	now := meta.Now()
+	rotation := a.actionCtx.GetStatus().Hashes.Encryption.Rotation.DeepCopy()
+	if rotation == nil {
+		rotation = &api.DeploymentStatusSecretRotation{}
+	}
+	rotation.Rotate(fmt.Sprintf("sha256:%s", active.Source), fmt.Sprintf("sha256:%s", active.SHA), fmt.Sprintf("sha256:%s", keySha), now)
+
+	backupKeys, err := getBackupsEncryptionKeys(a.actionCtx.GetBackups)
+	if err != nil {
+		return false, errors.Wrapf(err, "Unable to list backups")
+	}
+
+	if err := storeGeneratedKey(a.actionCtx, pod.GetEncryptionRotationSecretName(a.actionCtx.GetName()), keySha, key, func(sha string) bool {
+		// Keys are kept as long as they are tracked in the history or required to restore a backup
+		_, ok := rotation.Get(fmt.Sprintf("sha256:%s", sha))
+		return ok || backupKeys.ContainsSHA256(sha)
+	}); err != nil {
+		return false, err
+	}
+
+	if err := a.actionCtx.WithStatusUpdate(func(s *api.DeploymentStatus) bool {
+		s.Hashes.Encryption.Rotation = rotation
+		return true
+	}); err != nil {
+		return false, err
+	}
+
+	return true, nil
+}
@@ -26,12 +26,9 @@ import (
 	"context"
 	"encoding/base64"
 
-	"github.com/arangodb/kube-arangodb/pkg/util/constants"
-
 	api "github.com/arangodb/kube-arangodb/pkg/apis/deployment/v1"
 	"github.com/arangodb/kube-arangodb/pkg/deployment/patch"
 	"github.com/arangodb/kube-arangodb/pkg/deployment/pod"
-	"github.com/arangodb/kube-arangodb/pkg/util"
 	"github.com/arangodb/kube-arangodb/pkg/util/errors"
 	"github.com/arangodb/kube-arangodb/pkg/util/k8sutil"
 	"github.com/rs/zerolog"
@@ -74,19 +71,18 @@ func (a *jwtAddAction) Start(ctx context.Context) (bool, error) {
 		return true, nil
 	}
 
-	s, ok := a.actionCtx.GetCachedStatus().Secret(a.actionCtx.GetSpec().Authentication.GetJWTSecretName())
-	if !ok {
-		a.log.Error().Msgf("JWT Secret is missing, no rotation will take place")
+	active, ok, err := getActiveJWTToken(a.actionCtx.GetSpec(), a.actionCtx.GetStatus(), a.actionCtx.GetCachedStatus(), a.actionCtx.GetName())
+	if err != nil {
+		a.log.Error().Err(err).Msgf("JWT Secret is invalid, no rotation will take place")
 		return true, nil
 	}
 
-	jwt, ok := s.Data[constants.SecretKeyToken]
 	if !ok {
-		a.log.Error().Msgf("JWT Secret is invalid, no rotation will take place")
+		a.log.Error().Msgf("JWT Secret is missing, no rotation will take place")
 		return true, nil
 	}
 
-	jwtSha := util.SHA256(jwt)
+	jwt, jwtSha := active.Key, active.SHA
 
 	if appendToken != jwtSha {
 		a.log.Error().Msgf("JWT Secret changed")
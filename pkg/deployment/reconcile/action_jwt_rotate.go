@@ -0,0 +1,121 @@
+//
+// DISCLAIMER
+//
+// Copyright 2020 ArangoDB GmbH, Cologne, Germany
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// Copyright holder is ArangoDB GmbH, Cologne, Germany
+//
+
+package reconcile
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+
+	api "github.com/arangodb/kube-arangodb/pkg/apis/deployment/v1"
+	"github.com/arangodb/kube-arangodb/pkg/deployment/pod"
+	"github.com/arangodb/kube-arangodb/pkg/util"
+	"github.com/arangodb/kube-arangodb/pkg/util/errors"
+	"github.com/rs/zerolog"
+	meta "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func init() {
+	registerAction(api.ActionTypeJWTRotate, newJWTRotate)
+}
+
+func newJWTRotate(log zerolog.Logger, action api.Action, actionCtx ActionContext) Action {
+	a := &jwtRotateAction{}
+
+	a.actionImpl = newActionImplDefRef(log, action, actionCtx, defaultTimeout)
+
+	return a
+}
+
+// jwtRotateAction generates a new JWT token and stores it in the operator owned secret.
+// User provided secret is not modified. Propagation of the new token is done by the JWT key update plan.
+type jwtRotateAction struct {
+	actionImpl
+
+	actionEmptyCheckProgress
+}
+
+func (a *jwtRotateAction) Start(ctx context.Context) (bool, error) {
+	folder, err := ensureJWTFolderSupportFromAction(a.actionCtx)
+	if err != nil {
+		a.log.Error().Err(err).Msgf("Action not supported")
+		return true, nil
+	}
+
+	if !folder {
+		a.log.Error().Msgf("Action not supported")
+		return true, nil
+	}
+
+	current, exists := a.action.Params[checksum]
+	if !exists {
+		a.log.Warn().Msgf("Key %s is missing in action", checksum)
+		return true, nil
+	}
+
+	active, exists, err := getActiveJWTToken(a.actionCtx.GetSpec(), a.actionCtx.GetStatus(), a.actionCtx.GetCachedStatus(), a.actionCtx.GetName())
+	if err != nil {
+		a.log.Error().Err(err).Msgf("JWT token is invalid, no rotation will take place")
+		return true, nil
+	}
+
+	if !exists {
+		a.log.Error().Msgf("JWT Secret is missing, no rotation will take place")
+		return true, nil
+	}
+
+	if current != active.SHA {
+		a.log.Info().Msgf("JWT token changed, no rotation will take place")
+		return true, nil
+	}
+
+	tokenData := make([]byte, 32)
+	if _, err := rand.Read(tokenData); err != nil {
+		return false, errors.Wrapf(err, "Unable to generate JWT token")
+	}
+	token := []byte(hex.EncodeToString(tokenData))
+	tokenSha := util.SHA256(token)
+
+	now := meta.Now()
+	rotation := a.actionCtx.GetStatus().Hashes.JWT.Rotation.DeepCopy()
+	if rotation == nil {
+		rotation = &api.DeploymentStatusSecretRotation{}
+	}
+	rotation.Rotate(fmt.Sprintf("sha256:%s", active.Source), fmt.Sprintf("sha256:%s", active.SHA), fmt.Sprintf("sha256:%s", tokenSha), now)
+
+	if err := storeGeneratedKey(a.actionCtx, pod.JWTSecretRotation(a.actionCtx.GetName()), tokenSha, token, func(sha string) bool {
+		// Tokens are kept as long as they are tracked in the history
+		_, ok := rotation.Get(fmt.Sprintf("sha256:%s", sha))
+		return ok
+	}); err != nil {
+		return false, err
+	}
+
+	if err := a.actionCtx.WithStatusUpdate(func(s *api.DeploymentStatus) bool {
+		s.Hashes.JWT.Rotation = rotation
+		return true
+	}); err != nil {
+		return false, err
+	}
+
+	return true, nil
+}
@@ -0,0 +1,129 @@
+//
+// DISCLAIMER
+//
+// Copyright 2020 ArangoDB GmbH, Cologne, Germany
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// Copyright holder is ArangoDB GmbH, Cologne, Germany
+//
+
+package reconcile
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/arangodb/kube-arangodb/pkg/apis/deployment"
+	api "github.com/arangodb/kube-arangodb/pkg/apis/deployment/v1"
+	"github.com/rs/zerolog"
+	meta "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func init() {
+	registerAction(api.ActionTypeUpgradeCanaryStatusUpdate, newUpgradeCanaryStatusUpdateAction)
+}
+
+// newUpgradeCanaryStatusUpdateAction creates a new Action that implements the given
+// planned UpgradeCanaryStatusUpdate action.
+func newUpgradeCanaryStatusUpdateAction(log zerolog.Logger, action api.Action, actionCtx ActionContext) Action {
+	a := &actionUpgradeCanaryStatusUpdate{}
+
+	a.actionImpl = newActionImplDefRef(log, action, actionCtx, defaultTimeout)
+
+	return a
+}
+
+// actionUpgradeCanaryStatusUpdate implements an UpgradeCanaryStatusUpdate.
+// The action records the state of the canary upgrade to the image of the action
+// and sets the UpgradePaused condition until the upgrade is continued.
+type actionUpgradeCanaryStatusUpdate struct {
+	// actionImpl implement timeout and member id functions
+	actionImpl
+
+	actionEmptyCheckProgress
+}
+
+// Start performs the start of the action.
+// Returns true, the action is finished once the status is updated.
+func (a *actionUpgradeCanaryStatusUpdate) Start(ctx context.Context) (bool, error) {
+	image := a.action.Image
+	soakPeriod := a.actionCtx.GetSpec().Upgrade.GetCanarySoakPeriod()
+	continued, healthy := canaryUpgradeState(a.actionCtx.GetAPIObject().GetAnnotations(), a.actionCtx.GetStatus(),
+		a.actionCtx.GetShardSyncStatus(), image)
+
+	var passed bool
+	if err := a.actionCtx.WithStatusUpdate(func(s *api.DeploymentStatus) bool {
+		canary := nextCanaryStatus(s.Canary, image, continued, healthy, soakPeriod, meta.Now())
+		changed := !canary.Equal(s.Canary)
+		s.Canary = canary
+
+		if canary.Passed {
+			passed = true
+			return s.Conditions.Remove(api.ConditionTypeUpgradePaused) || changed
+		}
+
+		message := fmt.Sprintf("Upgrade to image %s is paused after canary members. Set annotation %s=%s on the deployment to continue",
+			image, deployment.ArangoDeploymentUpgradeContinueAnnotation, image)
+		if soakPeriod > 0 {
+			message = fmt.Sprintf("%s or wait until the deployment is healthy for %s", message, soakPeriod)
+		}
+
+		return s.Conditions.Update(api.ConditionTypeUpgradePaused, true, "Canary Upgraded", message) || changed
+	}); err != nil {
+		return false, maskAny(err)
+	}
+
+	if passed {
+		a.log.Info().Str("image", image).Msg("Canary upgrade continued")
+	}
+
+	return true, nil
+}
+
+// canaryUpgradeState returns if the upgrade to the image is continued by annotation
+// and if the deployment is healthy.
+func canaryUpgradeState(annotations map[string]string, status api.DeploymentStatus, shardsInSync bool, image string) (bool, bool) {
+	continued := annotations[deployment.ArangoDeploymentUpgradeContinueAnnotation] == image
+	healthy := shardsInSync && status.Conditions.IsTrue(api.ConditionTypeReady)
+
+	return continued, healthy
+}
+
+// nextCanaryStatus returns the state of the canary upgrade to the image at the given time.
+// The upgrade passes the canary members when it is continued or the deployment was healthy for the soak period.
+func nextCanaryStatus(current *api.DeploymentStatusCanary, image string, continued, healthy bool,
+	soakPeriod time.Duration, now meta.Time) *api.DeploymentStatusCanary {
+	next := api.DeploymentStatusCanary{Image: image}
+	if current != nil && current.Image == image {
+		next = *current
+	}
+
+	if soakPeriod > 0 {
+		if !healthy {
+			// Soak period starts again when the deployment is healthy
+			next.SoakStartTime = nil
+		} else if next.SoakStartTime == nil {
+			next.SoakStartTime = &now
+		} else if now.Sub(next.SoakStartTime.Time) >= soakPeriod {
+			continued = true
+		}
+	}
+
+	if continued {
+		next.Passed = true
+	}
+
+	return &next
+}
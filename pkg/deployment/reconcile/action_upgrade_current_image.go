@@ -72,6 +72,18 @@ func (a *setCurrentImageAction) CheckProgress(ctx context.Context) (bool, bool,
 	if err := a.actionCtx.SetCurrentImage(imageInfo); err != nil {
 		return false, false, maskAny(err)
 	}
+	if err := a.actionCtx.WithStatusUpdate(func(s *api.DeploymentStatus) bool {
+		if s.Rollback != nil && s.Rollback.FromImage == a.action.Image {
+			// Rollback is in progress
+			return false
+		}
+		// Another image is used, so outcome of the previous rollback is not relevant anymore
+		changed := s.Rollback != nil
+		s.Rollback = nil
+		return s.Conditions.Remove(api.ConditionTypeUpgradeRolledBack) || changed
+	}); err != nil {
+		return false, false, maskAny(err)
+	}
 	log.Info().Str("image", a.action.Image).Str("to", imageInfo.Image).Msg("Changed current main image")
 	return true, false, nil
 }
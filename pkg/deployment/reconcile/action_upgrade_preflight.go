@@ -0,0 +1,154 @@
+//
+// DISCLAIMER
+//
+// Copyright 2020 ArangoDB GmbH, Cologne, Germany
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// Copyright holder is ArangoDB GmbH, Cologne, Germany
+//
+
+package reconcile
+
+import (
+	"context"
+
+	"github.com/arangodb/go-driver/agency"
+	api "github.com/arangodb/kube-arangodb/pkg/apis/deployment/v1"
+	"github.com/arangodb/kube-arangodb/pkg/util"
+	"github.com/pkg/errors"
+	"github.com/rs/zerolog"
+)
+
+func init() {
+	registerAction(api.ActionTypeUpgradePreflight, newUpgradePreflightAction)
+}
+
+// newUpgradePreflightAction creates a new Action that implements the given
+// planned UpgradePreflight action.
+func newUpgradePreflightAction(log zerolog.Logger, action api.Action, actionCtx ActionContext) Action {
+	a := &actionUpgradePreflight{}
+
+	a.actionImpl = newActionImplDefRef(log, action, actionCtx, upgradePreflightTimeout)
+
+	return a
+}
+
+// actionUpgradePreflight implements an UpgradePreflight.
+// The action waits until the deployment is ready to be upgraded to the image of the action.
+type actionUpgradePreflight struct {
+	// actionImpl implement timeout and member id functions
+	actionImpl
+}
+
+// Start performs the start of the action.
+// Returns true if the action is completely finished, false in case
+// the start time needs to be recorded and a ready condition needs to be checked.
+func (a *actionUpgradePreflight) Start(ctx context.Context) (bool, error) {
+	ready, _, err := a.CheckProgress(ctx)
+	if err != nil {
+		return false, maskAny(err)
+	}
+	return ready, nil
+}
+
+// CheckProgress checks the progress of the action.
+// Returns true if the action is completely finished, false otherwise.
+func (a *actionUpgradePreflight) CheckProgress(ctx context.Context) (bool, bool, error) {
+	if err := a.check(ctx); err != nil {
+		a.log.Info().Err(err).Msg("Upgrade preflight checks failed")
+		if err := a.actionCtx.UpdateClusterCondition(api.ConditionTypeUpgradePreflightFailed, true, "Preflight Checks Failed", err.Error()); err != nil {
+			return false, false, maskAny(err)
+		}
+		return false, false, nil
+	}
+
+	if err := a.actionCtx.WithStatusUpdate(func(s *api.DeploymentStatus) bool {
+		return s.Conditions.Remove(api.ConditionTypeUpgradePreflightFailed)
+	}); err != nil {
+		return false, false, maskAny(err)
+	}
+
+	a.log.Info().Str("image", a.action.Image).Msg("Upgrade preflight checks passed")
+	return true, false, nil
+}
+
+// check returns an error describing why the deployment is not ready to be upgraded.
+func (a *actionUpgradePreflight) check(ctx context.Context) error {
+	spec := a.actionCtx.GetSpec()
+	status := a.actionCtx.GetStatus()
+
+	target, found := a.actionCtx.GetImageInfo(a.action.Image)
+	if !found {
+		return errors.Errorf("image %s is not discovered yet", a.action.Image)
+	}
+
+	if err := checkMembersUpgradeRules(status.Members, target); err != nil {
+		return errors.Wrapf(err, "upgrade is not allowed")
+	}
+
+	if len(status.Members.Agents) > 0 {
+		clients, err := a.actionCtx.GetAgencyClients(ctx)
+		if err != nil {
+			return errors.Wrapf(err, "unable to create agency clients")
+		}
+
+		if err := agency.AreAgentsHealthy(ctx, clients); err != nil {
+			return errors.Wrapf(err, "agency is not healthy")
+		}
+	}
+
+	if !util.BoolOrDefault(spec.AllowUnsafeUpgrade, false) && !a.actionCtx.GetShardSyncStatus() {
+		return errors.Errorf("not all shards are in sync")
+	}
+
+	return a.checkFreeDiskSpace(ctx, status, spec.Upgrade.GetMinFreeDiskPercentage())
+}
+
+// checkFreeDiskSpace returns an error when any member with data has less free disk space than required.
+// Members which do not expose disk metrics are skipped.
+func (a *actionUpgradePreflight) checkFreeDiskSpace(ctx context.Context, status api.DeploymentStatus, minFreePercentage int) error {
+	if minFreePercentage <= 0 {
+		return nil
+	}
+
+	return status.Members.ForeachServerInGroups(func(group api.ServerGroup, list api.MemberStatusList) error {
+		for _, m := range list {
+			if m.Phase != api.MemberPhaseCreated {
+				continue
+			}
+
+			c, err := a.actionCtx.GetServerClient(ctx, group, m.ID)
+			if err != nil {
+				return errors.Wrapf(err, "unable to create client for member %s", m.ID)
+			}
+
+			requestCtx, cancel := context.WithTimeout(ctx, preflightRequestTimeout)
+			free, total, found, err := getMemberDiskSpace(requestCtx, c)
+			cancel()
+			if err != nil {
+				return errors.Wrapf(err, "unable to read disk space of member %s", m.ID)
+			}
+
+			if !found {
+				a.log.Debug().Str("id", m.ID).Msg("Member does not expose disk metrics")
+				continue
+			}
+
+			if percentage := free * 100 / total; percentage < float64(minFreePercentage) {
+				return errors.Errorf("member %s has %.1f%% free disk space, required %d%%", m.ID, percentage, minFreePercentage)
+			}
+		}
+		return nil
+	}, api.ServerGroupAgents, api.ServerGroupSingle, api.ServerGroupDBServers)
+}
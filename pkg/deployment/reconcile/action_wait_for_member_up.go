@@ -24,6 +24,8 @@ package reconcile
 
 import (
 	"context"
+	"fmt"
+	"time"
 
 	driver "github.com/arangodb/go-driver"
 	"github.com/arangodb/go-driver/agency"
@@ -31,6 +33,8 @@ import (
 	"github.com/rs/zerolog"
 
 	api "github.com/arangodb/kube-arangodb/pkg/apis/deployment/v1"
+	"github.com/arangodb/kube-arangodb/pkg/util/k8sutil"
+	meta "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
 
 func init() {
@@ -42,7 +46,13 @@ func init() {
 func newWaitForMemberUpAction(log zerolog.Logger, action api.Action, actionCtx ActionContext) Action {
 	a := &actionWaitForMemberUp{}
 
-	a.actionImpl = newActionImplDefRef(log, action, actionCtx, waitForMemberUpTimeout)
+	a.actionImpl = newBaseActionImplDefRef(log, action, actionCtx, func(deploymentSpec api.DeploymentSpec) time.Duration {
+		if _, ok := action.GetParam(rollbackImage); ok {
+			// Rollback has to be started before the action times out
+			return waitForMemberUpTimeout + deploymentSpec.Upgrade.GetRollbackTimeout()
+		}
+		return waitForMemberUpTimeout
+	})
 
 	return a
 }
@@ -67,6 +77,77 @@ func (a *actionWaitForMemberUp) Start(ctx context.Context) (bool, error) {
 // CheckProgress checks the progress of the action.
 // Returns true if the action is completely finished, false otherwise.
 func (a *actionWaitForMemberUp) CheckProgress(ctx context.Context) (bool, bool, error) {
+	ready, abort, err := a.checkProgress(ctx)
+	if ready || abort {
+		return ready, abort, err
+	}
+
+	if a.checkRollback() {
+		return false, true, nil
+	}
+
+	return ready, abort, err
+}
+
+// checkRollback starts the rollback of a failed upgrade when the member did not come up on the new image in time.
+// Returns true when the rollback is started and the plan has to be aborted.
+func (a *actionWaitForMemberUp) checkRollback() bool {
+	log := a.log
+
+	fromImage, ok := a.action.GetParam(rollbackImage)
+	if !ok || a.action.StartTime == nil {
+		return false
+	}
+
+	spec := a.actionCtx.GetSpec()
+	if time.Since(a.action.StartTime.Time) < spec.Upgrade.GetRollbackTimeout() {
+		return false
+	}
+
+	status := a.actionCtx.GetStatus()
+	if status.CurrentImage == nil || status.CurrentImage.Image == fromImage {
+		return false
+	}
+	toImage := status.CurrentImage.Image
+
+	fromImageInfo, found := a.actionCtx.GetImageInfo(fromImage)
+	if !found {
+		log.Warn().Str("image", fromImage).Msg("Image to roll back to is not known")
+		return false
+	}
+
+	if err := checkMembersUpgradeRules(status.Members, fromImageInfo); err != nil {
+		log.Warn().Err(err).Msg("Failed upgrade can not be rolled back")
+		if err := a.actionCtx.UpdateClusterCondition(api.ConditionTypeUpgradeRolledBack, false, "Rollback Not Allowed",
+			fmt.Sprintf("Member %s did not come up on image %s, rollback is not allowed: %s", a.MemberID(), toImage, err.Error())); err != nil {
+			log.Warn().Err(err).Msg("Unable to update condition")
+		}
+		return false
+	}
+
+	now := meta.Now()
+	if err := a.actionCtx.WithStatusUpdate(func(s *api.DeploymentStatus) bool {
+		s.Rollback = &api.DeploymentStatusRollback{
+			FromImage: fromImage,
+			ToImage:   toImage,
+			Time:      &now,
+		}
+		s.Conditions.Update(api.ConditionTypeUpgradeRolledBack, true, "Rolled Back",
+			fmt.Sprintf("Member %s did not come up on image %s, deployment is rolled back to image %s", a.MemberID(), toImage, fromImage))
+		return true
+	}); err != nil {
+		log.Warn().Err(err).Msg("Unable to start rollback")
+		return false
+	}
+
+	log.Warn().Str("from", toImage).Str("to", fromImage).Msg("Rolling back failed upgrade")
+	a.actionCtx.CreateEvent(k8sutil.NewUpgradeRollbackEvent(a.actionCtx.GetAPIObject(), a.MemberID(), fromImage, toImage))
+
+	return true
+}
+
+// checkProgress checks if the member is up.
+func (a *actionWaitForMemberUp) checkProgress(ctx context.Context) (bool, bool, error) {
 	member, ok := a.actionCtx.GetMemberStatusByID(a.MemberID())
 	if !ok || member.Phase == api.MemberPhaseFailed {
 		a.log.Debug().Msg("Member in failed phase")
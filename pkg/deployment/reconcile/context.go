@@ -116,6 +116,8 @@ type Context interface {
 	SecretsInterface() k8sutil.SecretInterface
 	// GetBackup receives information about a backup resource
 	GetBackup(backup string) (*backupApi.ArangoBackup, error)
+	// GetBackups returns all backup resources created for this deployment
+	GetBackups() ([]backupApi.ArangoBackup, error)
 	// GetName receives deployment name
 	GetName() string
 	// GetAuthentication return authentication for members
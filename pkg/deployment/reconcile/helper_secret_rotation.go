@@ -0,0 +1,182 @@
+//
+// DISCLAIMER
+//
+// Copyright 2020 ArangoDB GmbH, Cologne, Germany
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// Copyright holder is ArangoDB GmbH, Cologne, Germany
+//
+
+package reconcile
+
+import (
+	"encoding/base64"
+	"fmt"
+	"strings"
+
+	backupApi "github.com/arangodb/kube-arangodb/pkg/apis/backup/v1"
+	api "github.com/arangodb/kube-arangodb/pkg/apis/deployment/v1"
+	shared "github.com/arangodb/kube-arangodb/pkg/apis/shared/v1"
+	"github.com/arangodb/kube-arangodb/pkg/deployment/patch"
+	"github.com/arangodb/kube-arangodb/pkg/deployment/pod"
+	"github.com/arangodb/kube-arangodb/pkg/deployment/resources/inspector"
+	"github.com/arangodb/kube-arangodb/pkg/util"
+	"github.com/arangodb/kube-arangodb/pkg/util/constants"
+	"github.com/arangodb/kube-arangodb/pkg/util/k8sutil"
+	"github.com/pkg/errors"
+	core "k8s.io/api/core/v1"
+	meta "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+)
+
+// activeKey describes the key which should be used by the deployment.
+// Keys generated by the rotation are kept in the operator owned secret, user provided secret is never modified.
+type activeKey struct {
+	// Source is the checksum of the user provided key
+	Source string
+	// SHA is the checksum of the active key
+	SHA string
+	// Key is the active key
+	Key []byte
+}
+
+// getActiveEncryptionKey returns the encryption key which should be used by the deployment.
+// Key generated by the rotation takes precedence as long as the user provided key does not change.
+func getActiveEncryptionKey(spec api.DeploymentSpec, status api.DeploymentStatus,
+	cachedStatus inspector.Inspector, name string) (activeKey, bool, error) {
+	secret, exists := cachedStatus.Secret(spec.RocksDB.Encryption.GetKeySecretName())
+	if !exists {
+		return activeKey{}, false, nil
+	}
+
+	sha, key, err := pod.GetEncryptionKeyFromSecretWithSpec(spec.RocksDB.Encryption, secret)
+	if err != nil {
+		return activeKey{}, false, err
+	}
+
+	return getActiveKey(cachedStatus, status.Hashes.Encryption.Rotation, pod.GetEncryptionRotationSecretName(name), sha, key)
+}
+
+// getActiveJWTToken returns the JWT token which should be used by the deployment.
+// Token generated by the rotation takes precedence as long as the user provided token does not change.
+func getActiveJWTToken(spec api.DeploymentSpec, status api.DeploymentStatus,
+	cachedStatus inspector.Inspector, name string) (activeKey, bool, error) {
+	secret, exists := cachedStatus.Secret(spec.Authentication.GetJWTSecretName())
+	if !exists {
+		return activeKey{}, false, nil
+	}
+
+	token, ok := secret.Data[constants.SecretKeyToken]
+	if !ok {
+		return activeKey{}, false, errors.Errorf("JWT Secret is invalid")
+	}
+
+	return getActiveKey(cachedStatus, status.Hashes.JWT.Rotation, pod.JWTSecretRotation(name), util.SHA256(token), token)
+}
+
+func getActiveKey(cachedStatus inspector.Inspector, rotation *api.DeploymentStatusSecretRotation,
+	secretName, sha string, key []byte) (activeKey, bool, error) {
+	generated, ok := rotation.Active(fmt.Sprintf("sha256:%s", sha))
+	if !ok {
+		return activeKey{Source: sha, SHA: sha, Key: key}, true, nil
+	}
+
+	generated = strings.TrimPrefix(generated, "sha256:")
+
+	secret, exists := cachedStatus.Secret(secretName)
+	if !exists {
+		return activeKey{}, false, errors.Errorf("Secret %s with generated keys is missing", secretName)
+	}
+
+	generatedKey, ok := secret.Data[generated]
+	if !ok {
+		return activeKey{}, false, errors.Errorf("Generated key %s is missing in secret %s", generated, secretName)
+	}
+
+	return activeKey{Source: sha, SHA: generated, Key: generatedKey}, true, nil
+}
+
+// storeGeneratedKey saves the generated key in the operator owned secret.
+// Keys which are not kept are removed from the secret.
+func storeGeneratedKey(actionCtx ActionContext, secretName, sha string, key []byte, keep func(sha string) bool) error {
+	secrets := actionCtx.SecretsInterface()
+
+	s, exists := actionCtx.GetCachedStatus().Secret(secretName)
+	if !exists {
+		secret := &core.Secret{
+			ObjectMeta: meta.ObjectMeta{
+				Name: secretName,
+			},
+			Data: map[string][]byte{
+				sha: key,
+			},
+		}
+
+		owner := actionCtx.GetAPIObject().AsOwner()
+		k8sutil.AddOwnerRefToObject(secret, &owner)
+
+		if _, err := secrets.Create(secret); err != nil {
+			return errors.Wrapf(err, "Unable to create secret: %s", secretName)
+		}
+
+		return nil
+	}
+
+	p := patch.NewPatch()
+
+	if len(s.Data) == 0 {
+		p.ItemAdd(patch.NewPath("data"), map[string]string{
+			sha: base64.StdEncoding.EncodeToString(key),
+		})
+	} else {
+		for k := range s.Data {
+			if k != sha && !keep(k) {
+				p.ItemRemove(patch.NewPath("data", k))
+			}
+		}
+
+		p.ItemAdd(patch.NewPath("data", sha), base64.StdEncoding.EncodeToString(key))
+	}
+
+	data, err := p.Marshal()
+	if err != nil {
+		return errors.Wrapf(err, "Unable to encrypt patch")
+	}
+
+	if _, err := secrets.Patch(secretName, types.JSONPatchType, data); err != nil {
+		return errors.Wrapf(err, "Unable to update secret: %s", secretName)
+	}
+
+	return nil
+}
+
+// getBackupsEncryptionKeys returns checksums of the encryption keys required to restore backups of the deployment
+func getBackupsEncryptionKeys(getBackups func() ([]backupApi.ArangoBackup, error)) (shared.HashList, error) {
+	backups, err := getBackups()
+	if err != nil {
+		return nil, err
+	}
+
+	var keys shared.HashList
+
+	for _, backup := range backups {
+		if backup.Status.Backup == nil {
+			continue
+		}
+
+		keys = append(keys, backup.Status.Backup.Keys...)
+	}
+
+	return keys, nil
+}
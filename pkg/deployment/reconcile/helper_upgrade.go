@@ -0,0 +1,164 @@
+//
+// DISCLAIMER
+//
+// Copyright 2020 ArangoDB GmbH, Cologne, Germany
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// Copyright holder is ArangoDB GmbH, Cologne, Germany
+//
+
+package reconcile
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"net/http"
+	"strconv"
+	"strings"
+
+	driver "github.com/arangodb/go-driver"
+	upgraderules "github.com/arangodb/go-upgrade-rules"
+	api "github.com/arangodb/kube-arangodb/pkg/apis/deployment/v1"
+	"github.com/pkg/errors"
+)
+
+const (
+	// rollbackImage is the action parameter holding the image a failed upgrade is rolled back to
+	rollbackImage = "rollbackImage"
+
+	metricFreeDiskSpace  = "rocksdb_free_disk_space"
+	metricTotalDiskSpace = "rocksdb_total_disk_space"
+)
+
+// imageLicense returns the license of the given image
+func imageLicense(info api.ImageInfo) upgraderules.License {
+	if info.Enterprise {
+		return upgraderules.LicenseEnterprise
+	}
+	return upgraderules.LicenseCommunity
+}
+
+// upgradeTargetImage returns the image members should run.
+// While a failed upgrade is rolled back this is the image from before the upgrade.
+func upgradeTargetImage(spec api.DeploymentSpec, status api.DeploymentStatus) string {
+	if status.Rollback.IsActive(spec.GetImage()) {
+		return status.Rollback.FromImage
+	}
+
+	return spec.GetImage()
+}
+
+// countMembersWithImage returns the number of members which use the given image
+func countMembersWithImage(members api.MemberStatusList, image string) int {
+	count := 0
+	for _, m := range members {
+		if m.Image != nil && m.Image.Image == image {
+			count++
+		}
+	}
+
+	return count
+}
+
+// withRollbackImage sets the image to roll back to on all WaitForMemberUp actions of the plan.
+func withRollbackImage(plan api.Plan, image string) api.Plan {
+	for id, action := range plan {
+		if action.Type == api.ActionTypeWaitForMemberUp {
+			plan[id] = action.AddParam(rollbackImage, image)
+		}
+	}
+
+	return plan
+}
+
+// checkMembersUpgradeRules returns an error when any member is not allowed to change its image to the given one.
+func checkMembersUpgradeRules(members api.DeploymentStatusMembers, target api.ImageInfo) error {
+	return members.ForeachServerGroup(func(group api.ServerGroup, list api.MemberStatusList) error {
+		for _, m := range list {
+			if m.Image == nil || m.Image.Image == target.Image {
+				continue
+			}
+
+			if err := upgraderules.CheckUpgradeRulesWithLicense(m.Image.ArangoDBVersion, target.ArangoDBVersion,
+				imageLicense(*m.Image), imageLicense(target)); err != nil {
+				return errors.Wrapf(err, "member %s from version %s to version %s", m.ID, m.Image.ArangoDBVersion, target.ArangoDBVersion)
+			}
+		}
+		return nil
+	})
+}
+
+// getMemberDiskSpace returns free and total disk space of the member read from its metrics.
+// Returns false when the server does not expose disk metrics.
+func getMemberDiskSpace(ctx context.Context, c driver.Client) (float64, float64, bool, error) {
+	req, err := c.Connection().NewRequest("GET", "_admin/metrics")
+	if err != nil {
+		return 0, 0, false, maskAny(err)
+	}
+
+	var body []byte
+	resp, err := c.Connection().Do(driver.WithRawResponse(ctx, &body), req)
+	if err != nil {
+		return 0, 0, false, maskAny(err)
+	}
+
+	if resp.StatusCode() == http.StatusNotFound {
+		return 0, 0, false, nil
+	}
+
+	if err := resp.CheckStatus(http.StatusOK); err != nil {
+		return 0, 0, false, maskAny(err)
+	}
+
+	free, total, found := parseDiskSpaceMetrics(body)
+	return free, total, found, nil
+}
+
+// parseDiskSpaceMetrics reads free and total disk space from metrics in the prometheus text format.
+func parseDiskSpaceMetrics(data []byte) (float64, float64, bool) {
+	var free, total float64
+	var freeFound, totalFound bool
+
+	scanner := bufio.NewScanner(bytes.NewReader(data))
+	for scanner.Scan() {
+		fields := strings.Fields(scanner.Text())
+		if len(fields) < 2 || strings.HasPrefix(fields[0], "#") {
+			continue
+		}
+
+		// Labels are not used by disk metrics
+		name := strings.SplitN(fields[0], "{", 2)[0]
+		if name != metricFreeDiskSpace && name != metricTotalDiskSpace {
+			continue
+		}
+
+		v, err := strconv.ParseFloat(fields[1], 64)
+		if err != nil {
+			continue
+		}
+
+		if name == metricFreeDiskSpace {
+			free, freeFound = v, true
+		} else {
+			total, totalFound = v, true
+		}
+	}
+
+	if !freeFound || !totalFound || total <= 0 {
+		return 0, 0, false
+	}
+
+	return free, total, true
+}
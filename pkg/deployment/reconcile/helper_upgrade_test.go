@@ -0,0 +1,102 @@
+//
+// DISCLAIMER
+//
+// Copyright 2020 ArangoDB GmbH, Cologne, Germany
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// Copyright holder is ArangoDB GmbH, Cologne, Germany
+//
+
+package reconcile
+
+import (
+	"testing"
+
+	driver "github.com/arangodb/go-driver"
+	api "github.com/arangodb/kube-arangodb/pkg/apis/deployment/v1"
+	"github.com/arangodb/kube-arangodb/pkg/util"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseDiskSpaceMetrics(t *testing.T) {
+	free, total, found := parseDiskSpaceMetrics([]byte(`# HELP rocksdb_free_disk_space Free disk space
+# TYPE rocksdb_free_disk_space gauge
+rocksdb_free_disk_space 2500
+# TYPE rocksdb_total_disk_space gauge
+rocksdb_total_disk_space{role="DBSERVER"} 10000
+arangodb_client_connection_statistics_total_time_count 12
+`))
+	require.True(t, found)
+	assert.Equal(t, float64(2500), free)
+	assert.Equal(t, float64(10000), total)
+
+	_, _, found = parseDiskSpaceMetrics([]byte("rocksdb_free_disk_space 2500\n"))
+	assert.False(t, found)
+
+	_, _, found = parseDiskSpaceMetrics([]byte("rocksdb_free_disk_space 0\nrocksdb_total_disk_space 0\n"))
+	assert.False(t, found)
+}
+
+func TestUpgradeTargetImage(t *testing.T) {
+	spec := api.DeploymentSpec{Image: util.NewString("arangodb:3.7.2")}
+	status := api.DeploymentStatus{}
+	assert.Equal(t, "arangodb:3.7.2", upgradeTargetImage(spec, status))
+
+	status.Rollback = &api.DeploymentStatusRollback{FromImage: "arangodb:3.7.1", ToImage: "arangodb:3.7.2"}
+	assert.Equal(t, "arangodb:3.7.1", upgradeTargetImage(spec, status))
+
+	spec.Image = util.NewString("arangodb:3.7.3")
+	assert.Equal(t, "arangodb:3.7.3", upgradeTargetImage(spec, status))
+}
+
+func TestWithRollbackImage(t *testing.T) {
+	plan := withRollbackImage(api.Plan{
+		api.NewAction(api.ActionTypeRotateMember, api.ServerGroupCoordinators, "CRDN-1"),
+		api.NewAction(api.ActionTypeWaitForMemberUp, api.ServerGroupCoordinators, "CRDN-1"),
+	}, "arangodb:3.7.1")
+
+	_, ok := plan[0].GetParam(rollbackImage)
+	assert.False(t, ok)
+
+	image, ok := plan[1].GetParam(rollbackImage)
+	require.True(t, ok)
+	assert.Equal(t, "arangodb:3.7.1", image)
+}
+
+func TestCheckMembersUpgradeRules(t *testing.T) {
+	image := func(name string, version driver.Version, enterprise bool) *api.ImageInfo {
+		return &api.ImageInfo{Image: name, ArangoDBVersion: version, Enterprise: enterprise}
+	}
+
+	var members api.DeploymentStatusMembers
+	members.Agents = api.MemberStatusList{
+		{ID: "AGNT-1", Image: image("arangodb:3.7.2", "3.7.2", false)},
+		{ID: "AGNT-2", Image: image("arangodb:3.7.1", "3.7.1", false)},
+		{ID: "AGNT-3"},
+	}
+
+	// Patch downgrade
+	assert.NoError(t, checkMembersUpgradeRules(members, *image("arangodb:3.7.1", "3.7.1", false)))
+	// Minor upgrade
+	assert.NoError(t, checkMembersUpgradeRules(members, *image("arangodb:3.8.0", "3.8.0", false)))
+	// Minor downgrade
+	assert.Error(t, checkMembersUpgradeRules(members, *image("arangodb:3.6.5", "3.6.5", false)))
+	// Major upgrade
+	assert.Error(t, checkMembersUpgradeRules(members, *image("arangodb:4.0.0", "4.0.0", false)))
+
+	members.Agents[0].Image = image("arangodb/enterprise:3.7.2", "3.7.2", true)
+	// Enterprise to community
+	assert.Error(t, checkMembersUpgradeRules(members, *image("arangodb:3.7.1", "3.7.1", false)))
+}
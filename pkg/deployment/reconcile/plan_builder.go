@@ -269,6 +269,15 @@ func createPlan(ctx context.Context, log zerolog.Logger, apiObject k8sutil.APIOb
 		plan = pb.ApplySubPlan(createTLSStatusPropagatedFieldUpdate, createCACleanPlan)
 	}
 
+	// Scheduled key rotation
+	if plan.IsEmpty() {
+		plan = pb.Apply(createEncryptionKeyRotationPlan)
+	}
+
+	if plan.IsEmpty() {
+		plan = pb.Apply(createJWTRotationPlan)
+	}
+
 	if plan.IsEmpty() {
 		plan = pb.Apply(createClusterOperationPlan)
 	}
@@ -73,6 +73,8 @@ type PlanBuilderContext interface {
 	SecretsInterface() k8sutil.SecretInterface
 	// GetBackup receives information about a backup resource
 	GetBackup(backup string) (*backupApi.ArangoBackup, error)
+	// GetBackups returns all backup resources created for this deployment
+	GetBackups() ([]backupApi.ArangoBackup, error)
 	// GetName receives deployment name
 	GetName() string
 	// GetAgency returns a connection to the entire agency.
@@ -24,6 +24,8 @@ package reconcile
 
 import (
 	"context"
+	"fmt"
+	"time"
 
 	"github.com/arangodb/kube-arangodb/pkg/deployment/features"
 
@@ -39,6 +41,7 @@ import (
 	"github.com/arangodb/kube-arangodb/pkg/deployment/pod"
 
 	api "github.com/arangodb/kube-arangodb/pkg/apis/deployment/v1"
+	shared "github.com/arangodb/kube-arangodb/pkg/apis/shared/v1"
 	"github.com/rs/zerolog"
 )
 
@@ -99,12 +102,7 @@ func createEncryptionKey(ctx context.Context,
 		return nil
 	}
 
-	secret, exists := cachedStatus.Secret(spec.RocksDB.Encryption.GetKeySecretName())
-	if !exists {
-		return nil
-	}
-
-	name, _, err := pod.GetEncryptionKeyFromSecret(secret)
+	active, exists, err := getActiveEncryptionKey(spec, status, cachedStatus, context.GetName())
 	if err != nil {
 		log.Error().Err(err).Msgf("Unable to fetch encryption key")
 		return nil
@@ -114,6 +112,8 @@ func createEncryptionKey(ctx context.Context,
 		return nil
 	}
 
+	name := active.SHA
+
 	keyfolder, exists := cachedStatus.Secret(pod.GetEncryptionFolderSecretName(context.GetName()))
 	if !exists {
 		log.Error().Msgf("Encryption key folder does not exist")
@@ -208,29 +208,54 @@ func createEncryptionKeyCleanPlan(ctx context.Context,
 		return nil
 	}
 
-	secret, exists := cachedStatus.Secret(spec.RocksDB.Encryption.GetKeySecretName())
-	if !exists {
+	active, exists, err := getActiveEncryptionKey(spec, status, cachedStatus, context.GetName())
+	if err != nil || !exists {
 		return nil
 	}
 
-	name, _, err := pod.GetEncryptionKeyFromSecret(secret)
-	if err != nil {
-		return nil
-	}
-
-	if !exists {
-		return nil
-	}
+	name := active.SHA
 
 	if _, ok := keyfolder.Data[name]; !ok {
-		log.Err(err).Msgf("Key from encryption is not in keyfolder - do nothing")
+		log.Info().Msgf("Key from encryption is not in keyfolder - do nothing")
 		return nil
 	}
 
+	var backupKeys shared.HashList
+	var backupKeysLoaded bool
+
 	for key := range keyfolder.Data {
-		if key != name {
-			plan = append(plan, api.NewAction(api.ActionTypeEncryptionKeyRemove, api.ServerGroupUnknown, "").AddParam("key", key))
+		if key == name {
+			continue
 		}
+
+		if status.Hashes.Encryption.Rotation.InGracePeriod(fmt.Sprintf("sha256:%s", key), spec.RocksDB.Encryption.Rotation.GetGracePeriod(), time.Now()) {
+			// Replaced key is still kept until the end of the grace period
+			continue
+		}
+
+		if !backupKeysLoaded {
+			if backupKeys, err = getBackupsEncryptionKeys(context.GetBackups); err != nil {
+				log.Warn().Err(err).Msgf("Unable to list backups, keys will not be removed")
+				return nil
+			}
+			backupKeysLoaded = true
+		}
+
+		sha := key
+		if spec.RocksDB.Encryption.HasProvider() {
+			// Backups keep checksums of unwrapped keys, keys not unwrapped by any member are kept
+			var ok bool
+			if sha, ok = getEncryptionProviderUnwrappedKey(status, cachedStatus, key); !ok {
+				continue
+			}
+		}
+
+		if backupKeys.ContainsSHA256(sha) {
+			// Key is required to restore a backup
+			continue
+		}
+
+		plan = append(plan, api.NewAction(api.ActionTypeEncryptionKeyRemove, api.ServerGroupUnknown, "").AddParam("key", key))
 	}
 
 	if !plan.IsEmpty() {
@@ -240,6 +265,41 @@ func createEncryptionKeyCleanPlan(ctx context.Context,
 	return api.Plan{}
 }
 
+// createEncryptionKeyRotationPlan generates a new encryption key when the rotation interval passed
+func createEncryptionKeyRotationPlan(ctx context.Context,
+	log zerolog.Logger, apiObject k8sutil.APIObject,
+	spec api.DeploymentSpec, status api.DeploymentStatus,
+	cachedStatus inspector.Inspector, context PlanBuilderContext) api.Plan {
+	if skipEncryptionPlan(spec, status) {
+		return nil
+	}
+
+	if !spec.RocksDB.Encryption.Rotation.IsEnabled() || spec.RocksDB.Encryption.HasProvider() {
+		return nil
+	}
+
+	if !status.Hashes.Encryption.Propagated {
+		// Previous change is still in progress
+		return nil
+	}
+
+	if !spec.RocksDB.Encryption.Rotation.IsRotationRequired(status.Hashes.Encryption.Rotation.GetLastRotation(apiObject.GetCreationTimestamp()), time.Now()) {
+		return nil
+	}
+
+	active, exists, err := getActiveEncryptionKey(spec, status, cachedStatus, context.GetName())
+	if err != nil {
+		log.Error().Err(err).Msgf("Unable to fetch encryption key")
+		return nil
+	}
+
+	if !exists {
+		return nil
+	}
+
+	return api.Plan{api.NewAction(api.ActionTypeEncryptionKeyRotate, api.ServerGroupUnknown, "", "Rotate encryption key").AddParam(checksum, active.SHA)}
+}
+
 func areEncryptionKeysUpToDate(ctx context.Context,
 	log zerolog.Logger, apiObject k8sutil.APIObject,
 	spec api.DeploymentSpec, status api.DeploymentStatus,
@@ -256,6 +316,13 @@ func areEncryptionKeysUpToDate(ctx context.Context,
 				failed = true
 				continue
 			} else if updateRequired {
+				if spec.RocksDB.Encryption.HasProvider() {
+					// Keys are unwrapped only at pod start, so member needs to be restarted one by one
+					if plan.IsEmpty() {
+						plan = append(plan, createRotateMemberPlan(log, m, group, "Encryption keys changed")...)
+					}
+					continue
+				}
 				plan = append(plan, api.NewAction(api.ActionTypeEncryptionKeyRefresh, group, m.ID))
 				continue
 			}
@@ -283,6 +350,29 @@ func isEncryptionKeyUpToDate(ctx context.Context,
 
 	mlog := log.With().Str("group", group.AsRole()).Str("member", m.ID).Logger()
 
+	if spec.RocksDB.Encryption.HasProvider() {
+		// Keys are unwrapped by the init container, arangod reports checksums of unwrapped keys only
+		p, ok := cachedStatus.Pod(m.PodName)
+		if !ok {
+			return false, true
+		}
+
+		keys, ok := pod.GetEncryptionProviderUnwrappedKeys(p)
+		if !ok {
+			mlog.Info().Msgf("Unwrapped encryption keys are not reported")
+			return true, false
+		}
+
+		for key := range folder.Data {
+			if _, ok := keys[key]; !ok {
+				mlog.Info().Msgf("Refresh of encryption keys required")
+				return true, false
+			}
+		}
+
+		return false, false
+	}
+
 	c, err := context.GetServerClient(ctx, group, m.ID)
 	if err != nil {
 		mlog.Warn().Err(err).Msg("Unable to get client")
@@ -304,3 +394,29 @@ func isEncryptionKeyUpToDate(ctx context.Context,
 
 	return false, false
 }
+
+// getEncryptionProviderUnwrappedKey returns the checksum of the unwrapped key, as reported by any member
+func getEncryptionProviderUnwrappedKey(status api.DeploymentStatus, cachedStatus inspector.Inspector, key string) (string, bool) {
+	var sha string
+
+	status.Members.ForeachServerGroup(func(group api.ServerGroup, list api.MemberStatusList) error {
+		for _, m := range list {
+			if sha != "" {
+				return nil
+			}
+
+			p, ok := cachedStatus.Pod(m.PodName)
+			if !ok {
+				continue
+			}
+
+			if keys, ok := pod.GetEncryptionProviderUnwrappedKeys(p); ok {
+				sha = keys[key]
+			}
+		}
+
+		return nil
+	})
+
+	return sha, sha != ""
+}
@@ -26,6 +26,7 @@ import (
 	"context"
 	"fmt"
 	"sort"
+	"time"
 
 	"github.com/arangodb/kube-arangodb/pkg/deployment/features"
 
@@ -57,19 +58,18 @@ func createJWTKeyUpdate(ctx context.Context,
 		return nil
 	}
 
-	s, ok := cachedStatus.Secret(spec.Authentication.GetJWTSecretName())
-	if !ok {
-		log.Info().Msgf("JWT Secret is missing, no rotation will take place")
-		return nil
+	active, ok, err := getActiveJWTToken(spec, status, cachedStatus, apiObject.GetName())
+	if err != nil {
+		log.Warn().Err(err).Msgf("JWT Secret is invalid, no rotation will take place")
+		return addJWTPropagatedPlanAction(status)
 	}
 
-	jwt, ok := s.Data[constants.SecretKeyToken]
 	if !ok {
-		log.Warn().Msgf("JWT Secret is invalid, no rotation will take place")
-		return addJWTPropagatedPlanAction(status)
+		log.Info().Msgf("JWT Secret is missing, no rotation will take place")
+		return nil
 	}
 
-	jwtSha := util.SHA256(jwt)
+	jwtSha := active.SHA
 
 	if _, ok := folder.Data[jwtSha]; !ok {
 		return addJWTPropagatedPlanAction(status, api.NewAction(api.ActionTypeJWTAdd, api.ServerGroupUnknown, "", "Add JWTRotation key").AddParam(checksum, jwtSha))
@@ -108,12 +108,58 @@ func createJWTKeyUpdate(ctx context.Context,
 			continue
 		}
 
+		if key == active.Source && spec.Sync.IsEnabled() {
+			// Sync masters authenticate with the token from the user provided secret
+			continue
+		}
+
+		if status.Hashes.JWT.Rotation.InGracePeriod(fmt.Sprintf("sha256:%s", key), spec.Authentication.Rotation.GetGracePeriod(), time.Now()) {
+			// Replaced key is still accepted until the end of the grace period
+			continue
+		}
+
 		return addJWTPropagatedPlanAction(status, api.NewAction(api.ActionTypeJWTClean, api.ServerGroupUnknown, "", "Remove old key").AddParam(checksum, key))
 	}
 
 	return addJWTPropagatedPlanAction(status)
 }
 
+// createJWTRotationPlan generates a new JWT token when the rotation interval passed
+func createJWTRotationPlan(ctx context.Context,
+	log zerolog.Logger, apiObject k8sutil.APIObject,
+	spec api.DeploymentSpec, status api.DeploymentStatus,
+	cachedStatus inspector.Inspector, context PlanBuilderContext) api.Plan {
+	if !spec.Authentication.Rotation.IsEnabled() {
+		return nil
+	}
+
+	if folder, err := ensureJWTFolderSupport(spec, status); err != nil || !folder {
+		return nil
+	}
+
+	if !status.Hashes.JWT.Propagated {
+		// Previous change is still in progress
+		return nil
+	}
+
+	if !spec.Authentication.Rotation.IsRotationRequired(status.Hashes.JWT.Rotation.GetLastRotation(apiObject.GetCreationTimestamp()), time.Now()) {
+		return nil
+	}
+
+	active, ok, err := getActiveJWTToken(spec, status, cachedStatus, apiObject.GetName())
+	if err != nil {
+		log.Warn().Err(err).Msgf("JWT Secret is invalid, no rotation will take place")
+		return nil
+	}
+
+	if !ok {
+		log.Info().Msgf("JWT Secret is missing, no rotation will take place")
+		return nil
+	}
+
+	return api.Plan{api.NewAction(api.ActionTypeJWTRotate, api.ServerGroupUnknown, "", "Rotate JWT key").AddParam(checksum, active.SHA)}
+}
+
 func createJWTStatusUpdate(ctx context.Context,
 	log zerolog.Logger, apiObject k8sutil.APIObject,
 	spec api.DeploymentSpec, status api.DeploymentStatus,
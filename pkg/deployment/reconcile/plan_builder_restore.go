@@ -111,7 +111,7 @@ func createRestorePlanEncryption(ctx context.Context, log zerolog.Logger, spec a
 		secret := *spec.RestoreEncryptionSecret
 
 		// Additional logic to do restore with encryption key
-		name, _, exists, err := pod.GetEncryptionKey(builderCtx.SecretsInterface(), secret)
+		name, _, exists, err := pod.GetEncryptionKey(builderCtx.SecretsInterface(), spec.RocksDB.Encryption, secret)
 		if err != nil {
 			log.Err(err).Msgf("Unable to fetch encryption key")
 			return false, nil
@@ -76,7 +76,21 @@ func createRotateOrUpgradePlanInternal(log zerolog.Logger, apiObject k8sutil.API
 	var fromVersion, toVersion driver.Version
 	var fromLicense, toLicense upgraderules.License
 
+	// Members of one group are rotated/upgraded in a batch, limited by the parallelism of the group
+	var batch []api.Plan
+	var batchGroup api.ServerGroup
+	var batchUpgrade bool
+
+	targetImage := upgradeTargetImage(spec, status)
+	rollback := status.Rollback.IsActive(spec.GetImage())
+
+	// Canary members of each group are upgraded first, then the upgrade is paused
+	canary := spec.Upgrade.IsCanary() && !rollback && !status.Canary.IsPassed(targetImage)
+	var canaryPaused bool
+
 	status.Members.ForeachServerGroup(func(group api.ServerGroup, members api.MemberStatusList) error {
+		canaryUpgraded := countMembersWithImage(members, targetImage)
+		canaryLimit := spec.Upgrade.GetCanaryMembers(len(members))
 
 		for _, m := range members {
 			if m.Phase != api.MemberPhaseCreated || m.PodName == "" {
@@ -90,7 +104,7 @@ func createRotateOrUpgradePlanInternal(log zerolog.Logger, apiObject k8sutil.API
 			}
 
 			// Got pod, compare it with what it should be
-			decision := podNeedsUpgrading(log, pod, spec, status.Images)
+			decision := podNeedsUpgrading(log, pod, targetImage, status.Images)
 			if decision.UpgradeNeeded && !decision.UpgradeAllowed {
 				// Oops, upgrade is not allowed
 				upgradeNotAllowed = true
@@ -101,31 +115,53 @@ func createRotateOrUpgradePlanInternal(log zerolog.Logger, apiObject k8sutil.API
 				return nil
 			}
 
-			if !newPlan.IsEmpty() {
-				// Only rotate/upgrade 1 pod at a time
+			if len(batch) > 0 && (batchGroup != group || len(batch) >= rotationParallelism(spec, group)) {
+				// Only rotate/upgrade members of one group at a time
 				continue
 			}
 
-			if decision.UpgradeNeeded {
+			var memberPlan api.Plan
+			if decision.UpgradeNeeded && canary && canaryUpgraded >= canaryLimit &&
+				(m.Image == nil || m.Image.Image != targetImage) {
+				// Canary members of the group are already upgraded
+				canaryPaused = true
+			} else if decision.UpgradeNeeded {
 				// Yes, upgrade is needed (and allowed)
-				newPlan = createUpgradeMemberPlan(log, m, group, "Version upgrade", spec.GetImage(), status,
+				memberPlan = createUpgradeMemberPlan(log, m, group, "Version upgrade", targetImage, status,
 					!decision.AutoUpgradeNeeded)
+				if !rollback && spec.Upgrade.IsRollbackEnabled() && m.Image != nil {
+					memberPlan = withRollbackImage(memberPlan, m.Image.Image)
+				}
+				batchUpgrade = true
+				if m.Image == nil || m.Image.Image != targetImage {
+					canaryUpgraded++
+				}
 			} else {
 				// Use new level of rotate logic
 				rotNeeded, reason := podNeedsRotation(log, pod, apiObject, spec, group, status, m, cachedStatus, context)
 				if rotNeeded {
-					newPlan = createRotateMemberPlan(log, m, group, reason)
+					memberPlan = createRotateMemberPlan(log, m, group, reason)
 				}
 			}
 
-			if !newPlan.IsEmpty() {
-				// Only rotate/upgrade 1 pod at a time
-				continue
+			if !memberPlan.IsEmpty() {
+				batch = append(batch, memberPlan)
+				batchGroup = group
 			}
 		}
 		return nil
 	})
 
+	newPlan = mergeMemberPlans(batch...)
+
+	if batchUpgrade && !rollback && spec.Upgrade.IsPreflightChecksEnabled() &&
+		(status.CurrentImage == nil || status.CurrentImage.Image != targetImage) {
+		// Upgrade did not start yet, check if the deployment is ready for it
+		newPlan = append(api.Plan{
+			api.NewAction(api.ActionTypeUpgradePreflight, api.ServerGroupUnknown, "", "Upgrade preflight checks").SetImage(targetImage),
+		}, newPlan...)
+	}
+
 	status.Members.ForeachServerInGroups(func(group api.ServerGroup, members api.MemberStatusList) error {
 		for _, m := range members {
 			if m.Phase != api.MemberPhaseCreated || m.PodName == "" {
@@ -162,6 +198,11 @@ func createRotateOrUpgradePlanInternal(log zerolog.Logger, apiObject k8sutil.API
 	if upgradeNotAllowed {
 		context.CreateEvent(k8sutil.NewUpgradeNotAllowedEvent(apiObject, fromVersion, toVersion, fromLicense, toLicense))
 	} else if !newPlan.IsEmpty() {
+		if rollback && batchUpgrade {
+			// Members of a failed upgrade are not ready, so readiness of the cluster is not checked
+			log.Info().Str("image", targetImage).Msg("Rolling back failed upgrade")
+			return newPlan, false
+		}
 		if clusterReadyForUpgrade(context) {
 			// Use the new plan
 			return newPlan, false
@@ -175,15 +216,88 @@ func createRotateOrUpgradePlanInternal(log zerolog.Logger, apiObject k8sutil.API
 				return nil, true
 			}
 		}
+	} else if canaryPaused {
+		// Upgrade actions are not created while paused, so other plans are not blocked
+		if canaryStatusUpdateNeeded(apiObject, spec, status, context, targetImage) {
+			log.Info().Str("image", targetImage).Msg("Canary members are upgraded, updating state of paused upgrade")
+			return api.Plan{
+				api.NewAction(api.ActionTypeUpgradeCanaryStatusUpdate, api.ServerGroupUnknown, "", "Canary members upgraded").SetImage(targetImage),
+			}, false
+		}
 	}
 	return nil, false
 }
 
+// canaryStatusUpdateNeeded returns true when the state of the paused canary upgrade to the image
+// or the UpgradePaused condition is not up to date.
+func canaryStatusUpdateNeeded(apiObject k8sutil.APIObject, spec api.DeploymentSpec, status api.DeploymentStatus,
+	context PlanBuilderContext, image string) bool {
+	continued, healthy := canaryUpgradeState(apiObject.GetAnnotations(), status, context.GetShardSyncStatus(), image)
+	canary := nextCanaryStatus(status.Canary, image, continued, healthy, spec.Upgrade.GetCanarySoakPeriod(), metav1.Now())
+
+	return !canary.Equal(status.Canary) || !status.Conditions.IsTrue(api.ConditionTypeUpgradePaused)
+}
+
+// rotationParallelism returns the number of members of the group which can be rotated/upgraded at the same time.
+// The number is limited by the PDB of the group.
+func rotationParallelism(spec api.DeploymentSpec, group api.ServerGroup) int {
+	if !group.IsStateless() {
+		return 1
+	}
+
+	groupSpec := spec.GetServerGroupSpec(group)
+	parallelism := groupSpec.GetMaxUnavailable()
+
+	if minAvailable := resources.PDBMinAvailable(spec, group); minAvailable > 0 {
+		if allowed := groupSpec.GetCount() - minAvailable; allowed < parallelism {
+			parallelism = allowed
+		}
+	}
+
+	if parallelism < 1 {
+		return 1
+	}
+
+	return parallelism
+}
+
+// mergeMemberPlans combines rotation/upgrade plans of multiple members into one plan.
+// All members are restarted first, then the plan waits for all of them to come back.
+func mergeMemberPlans(plans ...api.Plan) api.Plan {
+	if len(plans) == 1 {
+		return plans[0]
+	}
+
+	var restart, wait api.Plan
+	var currentImage string
+
+	for _, plan := range plans {
+		for id, action := range plan {
+			if action.Type == api.ActionTypeWaitForMemberUp || action.Type == api.ActionTypeWaitForMemberInSync {
+				wait = append(wait, plan[id:]...)
+				break
+			}
+
+			if action.Type == api.ActionTypeSetCurrentImage {
+				// Deployment image is set only once
+				if action.Image == currentImage {
+					continue
+				}
+				currentImage = action.Image
+			}
+
+			restart = append(restart, action)
+		}
+	}
+
+	return append(restart, wait...)
+}
+
 // podNeedsUpgrading decides if an upgrade of the pod is needed (to comply with
 // the given spec) and if that is allowed.
-func podNeedsUpgrading(log zerolog.Logger, p *core.Pod, spec api.DeploymentSpec, images api.ImageInfoList) upgradeDecision {
+func podNeedsUpgrading(log zerolog.Logger, p *core.Pod, image string, images api.ImageInfoList) upgradeDecision {
 	if c, found := k8sutil.GetContainerByName(p, k8sutil.ServerContainerName); found {
-		specImageInfo, found := images.GetByImage(spec.GetImage())
+		specImageInfo, found := images.GetByImage(image)
 		if !found {
 			return upgradeDecision{UpgradeNeeded: false}
 		}
@@ -198,14 +312,8 @@ func podNeedsUpgrading(log zerolog.Logger, p *core.Pod, spec api.DeploymentSpec,
 		// Image changed, check if change is allowed
 		specVersion := specImageInfo.ArangoDBVersion
 		podVersion := podImageInfo.ArangoDBVersion
-		asLicense := func(info api.ImageInfo) upgraderules.License {
-			if info.Enterprise {
-				return upgraderules.LicenseEnterprise
-			}
-			return upgraderules.LicenseCommunity
-		}
-		specLicense := asLicense(specImageInfo)
-		podLicense := asLicense(podImageInfo)
+		specLicense := imageLicense(specImageInfo)
+		podLicense := imageLicense(podImageInfo)
 		if err := upgraderules.CheckUpgradeRulesWithLicense(podVersion, specVersion, podLicense, specLicense); err != nil {
 			// E.g. 3.x -> 4.x, we cannot allow automatically
 			return upgradeDecision{
@@ -0,0 +1,235 @@
+//
+// DISCLAIMER
+//
+// Copyright 2020 ArangoDB GmbH, Cologne, Germany
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// Copyright holder is ArangoDB GmbH, Cologne, Germany
+//
+
+package reconcile
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/arangodb/kube-arangodb/pkg/apis/deployment"
+	api "github.com/arangodb/kube-arangodb/pkg/apis/deployment/v1"
+	"github.com/arangodb/kube-arangodb/pkg/deployment/resources/inspector"
+	"github.com/arangodb/kube-arangodb/pkg/util"
+	"github.com/arangodb/kube-arangodb/pkg/util/k8sutil"
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/require"
+	core "k8s.io/api/core/v1"
+	meta "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestRotationParallelism(t *testing.T) {
+	spec := api.DeploymentSpec{
+		Mode: api.NewMode(api.DeploymentModeCluster),
+		Coordinators: api.ServerGroupSpec{
+			Count:          util.NewInt(30),
+			MaxUnavailable: util.NewInt(10),
+		},
+		DBServers: api.ServerGroupSpec{
+			Count:          util.NewInt(5),
+			MaxUnavailable: util.NewInt(3),
+		},
+	}
+
+	require.Equal(t, 10, rotationParallelism(spec, api.ServerGroupCoordinators))
+	// Stateful groups are always rotated one at a time
+	require.Equal(t, 1, rotationParallelism(spec, api.ServerGroupDBServers))
+	require.Equal(t, 1, rotationParallelism(spec, api.ServerGroupAgents))
+
+	// PDB keeps 2 coordinators available in production
+	spec.Environment = api.NewEnvironment(api.EnvironmentProduction)
+	spec.Coordinators.Count = util.NewInt(5)
+	require.Equal(t, 3, rotationParallelism(spec, api.ServerGroupCoordinators))
+
+	spec.Coordinators.Count = util.NewInt(2)
+	require.Equal(t, 1, rotationParallelism(spec, api.ServerGroupCoordinators))
+
+	spec.Coordinators.MaxUnavailable = nil
+	spec.Coordinators.Count = util.NewInt(30)
+	require.Equal(t, 1, rotationParallelism(spec, api.ServerGroupCoordinators))
+}
+
+func TestMergeMemberPlans(t *testing.T) {
+	log := zerolog.Nop()
+	m1 := api.MemberStatus{ID: "1"}
+	m2 := api.MemberStatus{ID: "2"}
+
+	require.Empty(t, mergeMemberPlans())
+
+	single := createRotateMemberPlan(log, m1, api.ServerGroupCoordinators, "test")
+	require.Equal(t, single, mergeMemberPlans(single))
+
+	plan := mergeMemberPlans(
+		createRotateMemberPlan(log, m1, api.ServerGroupCoordinators, "test"),
+		createRotateMemberPlan(log, m2, api.ServerGroupCoordinators, "test"),
+	)
+	require.Len(t, plan, 6)
+	require.Equal(t, api.ActionTypeRotateMember, plan[0].Type)
+	require.Equal(t, "1", plan[0].MemberID)
+	require.Equal(t, api.ActionTypeRotateMember, plan[1].Type)
+	require.Equal(t, "2", plan[1].MemberID)
+	require.Equal(t, api.ActionTypeWaitForMemberUp, plan[2].Type)
+	require.Equal(t, api.ActionTypeWaitForMemberInSync, plan[3].Type)
+	require.Equal(t, "1", plan[3].MemberID)
+	require.Equal(t, api.ActionTypeWaitForMemberUp, plan[4].Type)
+	require.Equal(t, "2", plan[4].MemberID)
+
+	status := api.DeploymentStatus{}
+	plan = mergeMemberPlans(
+		createUpgradeMemberPlan(log, m1, api.ServerGroupCoordinators, "test", "arangodb:new", status, false),
+		createUpgradeMemberPlan(log, m2, api.ServerGroupCoordinators, "test", "arangodb:new", status, false),
+	)
+	require.Equal(t, api.ActionTypeSetCurrentImage, plan[0].Type)
+	require.Equal(t, api.ActionTypeSetMemberCurrentImage, plan[1].Type)
+	require.Equal(t, api.ActionTypeRotateMember, plan[2].Type)
+	require.Equal(t, api.ActionTypeSetMemberCurrentImage, plan[3].Type)
+	require.Equal(t, api.ActionTypeRotateMember, plan[4].Type)
+	require.Len(t, plan, 7)
+}
+
+func TestCreateRotateOrUpgradePlanCanary(t *testing.T) {
+	log := zerolog.Nop()
+	oldImage := api.ImageInfo{Image: "arangodb:3.7.1", ImageID: "arangodb@sha256:1", ArangoDBVersion: "3.7.1"}
+	newImage := api.ImageInfo{Image: "arangodb:3.7.2", ImageID: "arangodb@sha256:2", ArangoDBVersion: "3.7.2"}
+
+	spec := api.DeploymentSpec{
+		Mode:  api.NewMode(api.DeploymentModeCluster),
+		Image: util.NewString(newImage.Image),
+		Upgrade: &api.DeploymentUpgradeSpec{
+			Strategy: api.NewUpgradeStrategy(api.UpgradeStrategyCanary),
+		},
+	}
+	status := api.DeploymentStatus{
+		Images:       api.ImageInfoList{oldImage, newImage},
+		CurrentImage: &oldImage,
+	}
+	status.Conditions.Update(api.ConditionTypeReady, true, "", "")
+
+	pods := map[string]*core.Pod{}
+	for i := 0; i < 3; i++ {
+		image := oldImage
+		m := api.MemberStatus{
+			ID:      fmt.Sprintf("CRDN-%d", i),
+			PodName: fmt.Sprintf("crdn-%d", i),
+			Phase:   api.MemberPhaseCreated,
+			Image:   &image,
+		}
+		require.NoError(t, status.Members.Add(m, api.ServerGroupCoordinators))
+		pods[m.PodName] = &core.Pod{
+			ObjectMeta: meta.ObjectMeta{Name: m.PodName},
+			Spec: core.PodSpec{
+				Containers: []core.Container{{Name: k8sutil.ServerContainerName, Image: oldImage.ImageID}},
+			},
+		}
+	}
+
+	var annotations map[string]string
+	plan := func() api.Plan {
+		c := &testContext{ArangoDeployment: &api.ArangoDeployment{
+			ObjectMeta: meta.ObjectMeta{Annotations: annotations},
+			Spec:       spec,
+			Status:     status,
+		}}
+		plan, idle := createRotateOrUpgradePlanInternal(log, c.GetAPIObject(), spec, status,
+			inspector.NewInspectorFromData(pods, nil, nil, nil, nil, nil, nil), c)
+		require.False(t, idle)
+		return plan
+	}
+
+	// Preflight checks are done before the canary member is upgraded
+	p := plan()
+	require.Len(t, p, 5)
+	require.Equal(t, api.ActionTypeUpgradePreflight, p[0].Type)
+	require.Equal(t, api.ActionTypeSetCurrentImage, p[1].Type)
+	require.Equal(t, api.ActionTypeRotateMember, p[3].Type)
+	require.Equal(t, "CRDN-0", p[3].MemberID)
+
+	// Canary member is upgraded, its pod is being recreated
+	status.CurrentImage = &newImage
+	status.Members.Coordinators[0].Image = &newImage
+	delete(pods, "crdn-0")
+
+	p = plan()
+	require.Len(t, p, 1)
+	require.Equal(t, api.ActionTypeUpgradeCanaryStatusUpdate, p[0].Type)
+	require.Equal(t, newImage.Image, p[0].Image)
+
+	// Upgrade is paused, no plan is created
+	status.Canary = &api.DeploymentStatusCanary{Image: newImage.Image}
+	status.Conditions.Update(api.ConditionTypeUpgradePaused, true, "", "")
+
+	require.Empty(t, plan())
+
+	// Upgrade is continued by annotation
+	annotations = map[string]string{deployment.ArangoDeploymentUpgradeContinueAnnotation: newImage.Image}
+
+	p = plan()
+	require.Len(t, p, 1)
+	require.Equal(t, api.ActionTypeUpgradeCanaryStatusUpdate, p[0].Type)
+
+	// Upgrade is continued
+	status.Canary = &api.DeploymentStatusCanary{Image: newImage.Image, Passed: true}
+	status.Conditions.Remove(api.ConditionTypeUpgradePaused)
+
+	p = plan()
+	require.Len(t, p, 3)
+	require.Equal(t, api.ActionTypeRotateMember, p[1].Type)
+	require.Equal(t, "CRDN-1", p[1].MemberID)
+}
+
+func TestNextCanaryStatus(t *testing.T) {
+	image := "arangodb:3.7.2"
+	now := meta.Now()
+	soakStart := meta.NewTime(now.Add(-time.Hour))
+
+	t.Run("New image", func(t *testing.T) {
+		canary := nextCanaryStatus(&api.DeploymentStatusCanary{Image: "arangodb:3.7.1", Passed: true}, image, false, true, 0, now)
+		require.Equal(t, &api.DeploymentStatusCanary{Image: image}, canary)
+	})
+
+	t.Run("Continued", func(t *testing.T) {
+		canary := nextCanaryStatus(&api.DeploymentStatusCanary{Image: image}, image, true, false, 0, now)
+		require.True(t, canary.IsPassed(image))
+	})
+
+	t.Run("Soak period starts", func(t *testing.T) {
+		canary := nextCanaryStatus(&api.DeploymentStatusCanary{Image: image}, image, false, true, 2*time.Hour, now)
+		require.False(t, canary.Passed)
+		require.NotNil(t, canary.SoakStartTime)
+	})
+
+	t.Run("Soak period is reset when not healthy", func(t *testing.T) {
+		canary := nextCanaryStatus(&api.DeploymentStatusCanary{Image: image, SoakStartTime: &soakStart}, image, false, false, 2*time.Hour, now)
+		require.False(t, canary.Passed)
+		require.Nil(t, canary.SoakStartTime)
+	})
+
+	t.Run("Soak period is not finished", func(t *testing.T) {
+		current := &api.DeploymentStatusCanary{Image: image, SoakStartTime: &soakStart}
+		canary := nextCanaryStatus(current, image, false, true, 2*time.Hour, now)
+		require.True(t, canary.Equal(current))
+	})
+
+	t.Run("Soak period is finished", func(t *testing.T) {
+		canary := nextCanaryStatus(&api.DeploymentStatusCanary{Image: image, SoakStartTime: &soakStart}, image, false, true, time.Hour, now)
+		require.True(t, canary.IsPassed(image))
+	})
+}
@@ -27,7 +27,10 @@ import (
 	"fmt"
 	"io/ioutil"
 	"testing"
+	"time"
 
+	"github.com/arangodb/kube-arangodb/pkg/deployment/features"
+	"github.com/arangodb/kube-arangodb/pkg/deployment/pod"
 	"github.com/arangodb/kube-arangodb/pkg/util/arangod/conn"
 
 	monitoring "github.com/coreos/prometheus-operator/pkg/apis/monitoring/v1"
@@ -50,6 +53,7 @@ import (
 	driver "github.com/arangodb/go-driver"
 	api "github.com/arangodb/kube-arangodb/pkg/apis/deployment/v1"
 	"github.com/arangodb/kube-arangodb/pkg/util"
+	"github.com/arangodb/kube-arangodb/pkg/util/constants"
 	"github.com/arangodb/kube-arangodb/pkg/util/k8sutil"
 	core "k8s.io/api/core/v1"
 )
@@ -64,6 +68,7 @@ type testContext struct {
 	PVC              *core.PersistentVolumeClaim
 	PVCErr           error
 	RecordedEvent    *k8sutil.Event
+	Backups          []backupApi.ArangoBackup
 }
 
 func (c *testContext) GetAuthentication() conn.Auth {
@@ -77,13 +82,17 @@ func (c *testContext) RenderPodForMember(cachedStatus inspector.Inspector, spec
 }
 
 func (c *testContext) GetName() string {
-	panic("implement me")
+	return c.ArangoDeployment.GetName()
 }
 
 func (c *testContext) GetBackup(backup string) (*backupApi.ArangoBackup, error) {
 	panic("implement me")
 }
 
+func (c *testContext) GetBackups() ([]backupApi.ArangoBackup, error) {
+	return c.Backups, nil
+}
+
 func (c *testContext) SecretsInterface() k8sutil.SecretInterface {
 	panic("implement me")
 }
@@ -845,3 +854,141 @@ func TestCreatePlan(t *testing.T) {
 		})
 	}
 }
+
+func TestCreateJWTRotationPlan(t *testing.T) {
+	// Arrange
+	enabled := *features.JWTRotation().EnabledPointer()
+	*features.JWTRotation().EnabledPointer() = true
+	defer func() {
+		*features.JWTRotation().EnabledPointer() = enabled
+	}()
+
+	log := zerolog.Nop()
+	token := []byte("token")
+	depl := &api.ArangoDeployment{
+		ObjectMeta: meta.ObjectMeta{
+			Name:              "test_depl",
+			Namespace:         "test",
+			CreationTimestamp: meta.NewTime(time.Now().Add(-2 * time.Hour)),
+		},
+	}
+	secrets := map[string]*core.Secret{
+		"jwt": {
+			ObjectMeta: meta.ObjectMeta{Name: "jwt"},
+			Data:       map[string][]byte{constants.SecretKeyToken: token},
+		},
+	}
+	spec := api.DeploymentSpec{
+		Authentication: api.AuthenticationSpec{
+			JWTSecretName: util.NewString("jwt"),
+			Rotation: &api.SecretRotationSpec{
+				Interval: api.NewDuration("1h"),
+			},
+		},
+	}
+	status := api.DeploymentStatus{
+		CurrentImage: &api.ImageInfo{ArangoDBVersion: "3.7.0", Enterprise: true},
+	}
+	status.Hashes.JWT.Propagated = true
+	cachedStatus := inspector.NewInspectorFromData(nil, secrets, nil, nil, nil, nil, nil)
+
+	// Act & Assert
+	plan := createJWTRotationPlan(context.Background(), log, depl, spec, status, cachedStatus, nil)
+	require.Len(t, plan, 1)
+	assert.Equal(t, api.ActionTypeJWTRotate, plan[0].Type)
+	assert.Equal(t, util.SHA256(token), plan[0].Params[checksum])
+
+	lastRotation := meta.NewTime(time.Now().Add(-30 * time.Minute))
+	status.Hashes.JWT.Rotation = &api.DeploymentStatusSecretRotation{LastRotation: &lastRotation}
+	assert.Empty(t, createJWTRotationPlan(context.Background(), log, depl, spec, status, cachedStatus, nil))
+
+	status.Hashes.JWT.Rotation = nil
+	status.Hashes.JWT.Propagated = false
+	assert.Empty(t, createJWTRotationPlan(context.Background(), log, depl, spec, status, cachedStatus, nil))
+
+	status.Hashes.JWT.Propagated = true
+	spec.Authentication.Rotation = nil
+	assert.Empty(t, createJWTRotationPlan(context.Background(), log, depl, spec, status, cachedStatus, nil))
+
+	// Generated token is rotated while the user provided secret stays the same
+	generated := []byte("generated")
+	secrets[pod.JWTSecretRotation(depl.GetName())] = &core.Secret{
+		ObjectMeta: meta.ObjectMeta{Name: pod.JWTSecretRotation(depl.GetName())},
+		Data:       map[string][]byte{util.SHA256(generated): generated},
+	}
+	cachedStatus = inspector.NewInspectorFromData(nil, secrets, nil, nil, nil, nil, nil)
+	spec.Authentication.Rotation = &api.SecretRotationSpec{Interval: api.NewDuration("1h")}
+	status.Hashes.JWT.Rotation = &api.DeploymentStatusSecretRotation{}
+	status.Hashes.JWT.Rotation.Rotate("sha256:"+util.SHA256(token), "sha256:"+util.SHA256(token), "sha256:"+util.SHA256(generated), meta.NewTime(time.Now().Add(-2*time.Hour)))
+
+	plan = createJWTRotationPlan(context.Background(), log, depl, spec, status, cachedStatus, nil)
+	require.Len(t, plan, 1)
+	assert.Equal(t, util.SHA256(generated), plan[0].Params[checksum])
+}
+
+func TestCreateEncryptionKeyCleanPlan(t *testing.T) {
+	// Arrange
+	enabled := *features.EncryptionRotation().EnabledPointer()
+	*features.EncryptionRotation().EnabledPointer() = true
+	defer func() {
+		*features.EncryptionRotation().EnabledPointer() = enabled
+	}()
+
+	log := zerolog.Nop()
+	key := make([]byte, 32)
+	oldKey := make([]byte, 32)
+	oldKey[0] = 1
+	backupKey := make([]byte, 32)
+	backupKey[0] = 2
+	depl := &api.ArangoDeployment{
+		ObjectMeta: meta.ObjectMeta{
+			Name:      "test_depl",
+			Namespace: "test",
+		},
+	}
+	secrets := map[string]*core.Secret{
+		"enc": {
+			ObjectMeta: meta.ObjectMeta{Name: "enc"},
+			Data:       map[string][]byte{constants.SecretEncryptionKey: key},
+		},
+		pod.GetEncryptionFolderSecretName(depl.GetName()): {
+			ObjectMeta: meta.ObjectMeta{Name: pod.GetEncryptionFolderSecretName(depl.GetName())},
+			Data: map[string][]byte{
+				util.SHA256(key):       key,
+				util.SHA256(oldKey):    oldKey,
+				util.SHA256(backupKey): backupKey,
+			},
+		},
+	}
+	spec := api.DeploymentSpec{
+		RocksDB: api.RocksDBSpec{
+			Encryption: api.RocksDBEncryptionSpec{
+				KeySecretName: util.NewString("enc"),
+			},
+		},
+	}
+	status := api.DeploymentStatus{
+		CurrentImage: &api.ImageInfo{ArangoDBVersion: "3.7.0", Enterprise: true},
+	}
+	status.Hashes.Encryption.Propagated = true
+	c := &testContext{
+		ArangoDeployment: depl,
+		Backups: []backupApi.ArangoBackup{
+			{
+				Spec: backupApi.ArangoBackupSpec{Deployment: backupApi.ArangoBackupSpecDeployment{Name: depl.GetName()}},
+				Status: backupApi.ArangoBackupStatus{
+					Backup: &backupApi.ArangoBackupDetails{Keys: []string{"sha256:" + util.SHA256(backupKey)}},
+				},
+			},
+		},
+	}
+	cachedStatus := inspector.NewInspectorFromData(nil, secrets, nil, nil, nil, nil, nil)
+
+	// Act
+	plan := createEncryptionKeyCleanPlan(context.Background(), log, depl, spec, status, cachedStatus, c)
+
+	// Assert
+	require.Len(t, plan, 1)
+	assert.Equal(t, api.ActionTypeEncryptionKeyRemove, plan[0].Type)
+	assert.Equal(t, util.SHA256(oldKey), plan[0].Params["key"])
+}
@@ -47,7 +47,7 @@ import (
 	"github.com/rs/zerolog"
 )
 
-const CertificateRenewalMargin = 7 * 24 * time.Hour
+const CertificateRenewalMargin = resources.CertificateRenewalMargin
 
 func createTLSStatusPropagatedFieldUpdate(ctx context.Context,
 	log zerolog.Logger, apiObject k8sutil.APIObject,
@@ -168,7 +168,7 @@ func createCAAppendPlan(ctx context.Context,
 		return nil
 	}
 
-	ca, _, err := resources.GetKeyCertFromSecret(log, caSecret, resources.CACertName, resources.CAKeyName)
+	ca, err := resources.GetCACertsFromSecret(log, spec.TLS, caSecret)
 	if err != nil {
 		log.Warn().Err(err).Str("secret", spec.TLS.GetCASecretName()).Msg("CA Secret does not contains Cert")
 		return nil
@@ -210,6 +210,11 @@ func createCARenewalPlan(ctx context.Context,
 		return nil
 	}
 
+	if spec.TLS.IsIssued() {
+		// CA is managed by the cert-manager issuer
+		return nil
+	}
+
 	caSecret, exists := cachedStatus.Secret(spec.TLS.GetCASecretName())
 	if !exists {
 		log.Warn().Str("secret", spec.TLS.GetCASecretName()).Msg("CA Secret does not exists")
@@ -221,7 +226,7 @@ func createCARenewalPlan(ctx context.Context,
 		return nil
 	}
 
-	cas, _, err := resources.GetKeyCertFromSecret(log, caSecret, resources.CACertName, resources.CAKeyName)
+	cas, err := resources.GetCACertsFromSecret(log, spec.TLS, caSecret)
 	if err != nil {
 		log.Warn().Err(err).Str("secret", spec.TLS.GetCASecretName()).Msg("CA Secret does not contains Cert")
 		return nil
@@ -252,7 +257,7 @@ func createCACleanPlan(ctx context.Context,
 		return nil
 	}
 
-	ca, _, err := resources.GetKeyCertFromSecret(log, caSecret, resources.CACertName, resources.CAKeyName)
+	ca, err := resources.GetCACertsFromSecret(log, spec.TLS, caSecret)
 	if err != nil {
 		log.Warn().Err(err).Str("secret", spec.TLS.GetCASecretName()).Msg("CA Secret does not contains Cert")
 		return nil
@@ -449,7 +454,7 @@ func keyfileRenewalRequired(ctx context.Context,
 		return false, false
 	}
 
-	ca, _, err := resources.GetKeyCertFromSecret(log, caSecret, resources.CACertName, resources.CAKeyName)
+	ca, err := resources.GetCACertsFromSecret(log, spec.TLS, caSecret)
 	if err != nil {
 		log.Warn().Err(err).Str("secret", spec.TLS.GetCASecretName()).Msg("CA Secret does not contains Cert")
 		return false, false
@@ -472,19 +477,26 @@ func keyfileRenewalRequired(ctx context.Context,
 		return false, false
 	}
 
-	// Check if cert is not expired
-	for _, cert := range res.PeerCertificates {
-		if cert == nil {
-			continue
+	if spec.TLS.IsIssued() {
+		// Certificates are renewed by cert-manager, member needs to pick up the renewed keyfile
+		if issuedKeyfileChanged(log, apiObject, cachedStatus, group, member, res) {
+			return true, false
 		}
+	} else {
+		// Check if cert is not expired
+		for _, cert := range res.PeerCertificates {
+			if cert == nil {
+				continue
+			}
 
-		if ca.Contains(cert) {
-			continue
-		}
+			if ca.Contains(cert) {
+				continue
+			}
 
-		if time.Now().Add(CertificateRenewalMargin).After(cert.NotAfter) {
-			log.Warn().Msg("Renewal margin exceeded")
-			return true, true
+			if time.Now().Add(CertificateRenewalMargin).After(cert.NotAfter) {
+				log.Warn().Msg("Renewal margin exceeded")
+				return true, true
+			}
 		}
 	}
 
@@ -525,3 +537,37 @@ func keyfileRenewalRequired(ctx context.Context,
 
 	return false, false
 }
+
+// issuedKeyfileChanged checks if the certificate served by the member differs from the one in its keyfile secret
+func issuedKeyfileChanged(log zerolog.Logger, apiObject k8sutil.APIObject, cachedStatus inspector.Inspector,
+	group api.ServerGroup, member api.MemberStatus, state *tls.ConnectionState) bool {
+	s, exists := cachedStatus.Secret(k8sutil.CreateTLSKeyfileSecretName(apiObject.GetName(), group.AsRole(), member.ID))
+	if !exists {
+		log.Warn().Msg("Keyfile secret is missing")
+		return false
+	}
+
+	keyfile, ok := s.Data[constants.SecretTLSKeyfile]
+	if !ok {
+		log.Warn().Msg("Keyfile secret is invalid")
+		return false
+	}
+
+	certs := resources.GetCertsFromData(log, keyfile)
+	if len(certs) == 0 {
+		log.Warn().Msg("Keyfile secret does not contain certificates")
+		return false
+	}
+
+	if len(state.PeerCertificates) == 0 || state.PeerCertificates[0] == nil {
+		return false
+	}
+
+	// Leaf certificate is always the first one in the chain
+	if !state.PeerCertificates[0].Equal(certs[0]) {
+		log.Info().Msg("Served certificate differs from the issued one")
+		return true
+	}
+
+	return false
+}
@@ -38,9 +38,11 @@ const (
 	backupRestoreTimeout             = time.Minute * 15
 	shutdownMemberTimeout            = time.Minute * 30
 	upgradeMemberTimeout             = time.Hour * 6
+	upgradePreflightTimeout          = time.Minute * 30
 	waitForMemberUpTimeout           = time.Minute * 30
 	tlsSNIUpdateTimeout              = time.Minute * 10
 	defaultTimeout                   = time.Minute * 10
 
-	shutdownTimeout = time.Second * 15
+	shutdownTimeout         = time.Second * 15
+	preflightRequestTimeout = time.Second * 15
 )
@@ -116,6 +116,25 @@ func GetCertsFromSecret(log zerolog.Logger, secret *core.Secret) Certificates {
 	return GetCertsFromData(log, caPem)
 }
 
+// GetCACertsFromSecret returns the CA certificates of the given TLS spec. When certificates are issued by cert-manager
+// the CA secret holds only the public certificate, otherwise the private key is loaded and verified as well.
+func GetCACertsFromSecret(log zerolog.Logger, spec api.TLSSpec, secret *core.Secret) (Certificates, error) {
+	if spec.IsIssued() {
+		certs := GetCertsFromSecret(log, secret)
+		if len(certs) == 0 {
+			return nil, errors.Errorf("Key %s missing in secret", CACertName)
+		}
+		return certs, nil
+	}
+
+	certs, _, err := GetKeyCertFromSecret(log, secret, CACertName, CAKeyName)
+	if err != nil {
+		return nil, err
+	}
+
+	return certs, nil
+}
+
 func GetKeyCertFromCache(log zerolog.Logger, cachedStatus inspector.Inspector, spec api.DeploymentSpec, certName, keyName string) (Certificates, interface{}, error) {
 	caSecret, exists := cachedStatus.Secret(spec.TLS.GetCASecretName())
 	if !exists {
@@ -0,0 +1,209 @@
+//
+// DISCLAIMER
+//
+// Copyright 2020 ArangoDB GmbH, Cologne, Germany
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// Copyright holder is ArangoDB GmbH, Cologne, Germany
+//
+
+package resources
+
+import (
+	"crypto/x509"
+	"encoding/pem"
+	"fmt"
+	"time"
+
+	api "github.com/arangodb/kube-arangodb/pkg/apis/deployment/v1"
+	"github.com/arangodb/kube-arangodb/pkg/deployment/resources/inspector"
+	"github.com/arangodb/kube-arangodb/pkg/metrics"
+	"github.com/arangodb/kube-arangodb/pkg/util/constants"
+	"github.com/arangodb/kube-arangodb/pkg/util/k8sutil"
+	core "k8s.io/api/core/v1"
+	meta "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// CertificateRenewalMargin defines how long before expiry certificates are renewed
+const CertificateRenewalMargin = 7 * 24 * time.Hour
+
+var (
+	caCertificateExpiryGauges     = metrics.MustRegisterGaugeVec(metricsComponent, "ca_certificate_expiry_seconds", "Number of seconds until the CA certificate of a deployment expires", metrics.DeploymentName)
+	memberCertificateExpiryGauges = metrics.MustRegisterGaugeVec(metricsComponent, "member_certificate_expiry_seconds", "Number of seconds until the TLS certificate of a member expires", metrics.DeploymentName, metrics.ServerGroup, metrics.MemberID)
+)
+
+// InspectCertificates keeps the expiry time of the CA and member certificates in the status and metrics.
+// When the CA, which is not renewed by the operator, expires soon the CertificateExpiring condition is set.
+func (r *Resources) InspectCertificates(cachedStatus inspector.Inspector) error {
+	log := r.log
+	spec := r.context.GetSpec()
+	apiObject := r.context.GetAPIObject()
+	deploymentName := apiObject.GetName()
+	status, lastVersion := r.context.GetStatus()
+	now := time.Now()
+
+	var certificates *api.DeploymentStatusCertificates
+	var expiring *x509.Certificate
+
+	if spec.IsSecure() {
+		if caSecret, exists := cachedStatus.Secret(spec.TLS.GetCASecretName()); exists {
+			if cas, err := GetCACertsFromSecret(log, spec.TLS, caSecret); err != nil {
+				log.Debug().Err(err).Str("secret", spec.TLS.GetCASecretName()).Msg("CA Secret does not contains Cert")
+			} else if ca := firstExpiringCertificate(cas); ca != nil {
+				certificates = &api.DeploymentStatusCertificates{
+					CANotAfter: timeRef(ca.NotAfter),
+					CAOwned:    !spec.TLS.IsIssued() && k8sutil.IsOwner(apiObject.AsOwner(), caSecret),
+				}
+
+				caCertificateExpiryGauges.WithLabelValues(deploymentName).Set(ca.NotAfter.Sub(now).Seconds())
+
+				if !certificates.CAOwned && now.Add(CertificateRenewalMargin).After(ca.NotAfter) {
+					expiring = ca
+				}
+			}
+		}
+	}
+
+	if certificates == nil {
+		caCertificateExpiryGauges.DeleteLabelValues(deploymentName)
+	}
+
+	changed := false
+
+	if !certificates.Equal(status.Certificates) {
+		status.Certificates = certificates
+		changed = true
+	}
+
+	reported := map[string]string{}
+
+	if err := status.Members.ForeachServerGroup(func(group api.ServerGroup, list api.MemberStatusList) error {
+		for _, m := range list {
+			var notAfter *meta.Time
+
+			if spec.IsSecure() {
+				if s, exists := cachedStatus.Secret(k8sutil.CreateTLSKeyfileSecretName(deploymentName, group.AsRole(), m.ID)); exists {
+					if cert := getKeyfileCertificate(s); cert != nil {
+						notAfter = timeRef(cert.NotAfter)
+						memberCertificateExpiryGauges.WithLabelValues(deploymentName, group.AsRole(), m.ID).Set(cert.NotAfter.Sub(now).Seconds())
+						reported[m.ID] = group.AsRole()
+					}
+				}
+			}
+
+			if (m.CertificateNotAfter == nil) != (notAfter == nil) || (notAfter != nil && !m.CertificateNotAfter.Equal(notAfter)) {
+				m.CertificateNotAfter = notAfter
+				if err := status.Members.Update(m, group); err != nil {
+					return err
+				}
+				changed = true
+			}
+		}
+
+		return nil
+	}); err != nil {
+		return maskAny(err)
+	}
+
+	r.cleanupMemberCertificateExpiryGauges(deploymentName, reported)
+
+	var event *k8sutil.Event
+
+	if expiring != nil {
+		if status.Conditions.Update(api.ConditionTypeCertificateExpiring, true, "CA Certificate Expiring",
+			fmt.Sprintf("CA certificate expires at %s and it will not be renewed by the operator", expiring.NotAfter.UTC().Format(time.RFC3339))) {
+			log.Warn().Str("secret", spec.TLS.GetCASecretName()).Time("not-after", expiring.NotAfter).Msg("CA certificate expires soon and it will not be renewed by the operator")
+			event = k8sutil.NewCertificateExpiringEvent(apiObject, spec.TLS.GetCASecretName(), expiring.NotAfter)
+			changed = true
+		}
+	} else if status.Conditions.Remove(api.ConditionTypeCertificateExpiring) {
+		changed = true
+	}
+
+	if changed {
+		if err := r.context.UpdateStatus(status, lastVersion); err != nil {
+			return maskAny(err)
+		}
+	}
+
+	if event != nil {
+		r.context.CreateEvent(event)
+	}
+
+	return nil
+}
+
+// cleanupMemberCertificateExpiryGauges removes gauges of members which are no longer reported
+func (r *Resources) cleanupMemberCertificateExpiryGauges(deploymentName string, reported map[string]string) {
+	r.certificates.mutex.Lock()
+	defer r.certificates.mutex.Unlock()
+
+	for id, role := range r.certificates.members {
+		if reportedRole, ok := reported[id]; !ok || reportedRole != role {
+			memberCertificateExpiryGauges.DeleteLabelValues(deploymentName, role, id)
+		}
+	}
+
+	r.certificates.members = reported
+}
+
+// firstExpiringCertificate returns the certificate which expires first
+func firstExpiringCertificate(certs Certificates) *x509.Certificate {
+	var first *x509.Certificate
+
+	for _, cert := range certs {
+		if cert == nil {
+			continue
+		}
+
+		if first == nil || cert.NotAfter.Before(first.NotAfter) {
+			first = cert
+		}
+	}
+
+	return first
+}
+
+// getKeyfileCertificate returns the server certificate from the member keyfile secret
+func getKeyfileCertificate(secret *core.Secret) *x509.Certificate {
+	data, ok := secret.Data[constants.SecretTLSKeyfile]
+	if !ok {
+		return nil
+	}
+
+	for {
+		block, rest := pem.Decode(data)
+		if block == nil {
+			return nil
+		}
+
+		data = rest
+
+		if block.Type != "CERTIFICATE" {
+			continue
+		}
+
+		cert, err := x509.ParseCertificate(block.Bytes)
+		if err != nil {
+			return nil
+		}
+
+		return cert
+	}
+}
+
+func timeRef(t time.Time) *meta.Time {
+	m := meta.NewTime(t)
+	return &m
+}
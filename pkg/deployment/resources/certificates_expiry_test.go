@@ -0,0 +1,76 @@
+//
+// DISCLAIMER
+//
+// Copyright 2020 ArangoDB GmbH, Cologne, Germany
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// Copyright holder is ArangoDB GmbH, Cologne, Germany
+//
+
+package resources
+
+import (
+	"testing"
+	"time"
+
+	certificates "github.com/arangodb-helper/go-certificates"
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	core "k8s.io/api/core/v1"
+
+	"github.com/arangodb/kube-arangodb/pkg/util/constants"
+)
+
+func testCertificate(t *testing.T, validFor time.Duration) (string, string) {
+	cert, key, err := certificates.CreateCertificate(certificates.CreateCertificateOptions{
+		CommonName: "test",
+		ValidFrom:  time.Now(),
+		ValidFor:   validFor,
+		ECDSACurve: "P256",
+	}, nil)
+	require.NoError(t, err)
+
+	return cert, key
+}
+
+func TestFirstExpiringCertificate(t *testing.T) {
+	long, _ := testCertificate(t, 48*time.Hour)
+	short, _ := testCertificate(t, time.Hour)
+
+	certs := GetCertsFromData(zerolog.Nop(), []byte(long+short))
+	require.Len(t, certs, 2)
+
+	first := firstExpiringCertificate(certs)
+	require.NotNil(t, first)
+	assert.True(t, first.Equal(certs[1]))
+
+	assert.Nil(t, firstExpiringCertificate(nil))
+}
+
+func TestGetKeyfileCertificate(t *testing.T) {
+	cert, key := testCertificate(t, time.Hour)
+
+	certs := GetCertsFromData(zerolog.Nop(), []byte(cert))
+	require.Len(t, certs, 1)
+
+	// Key first, certificate needs to be found anyway
+	secret := &core.Secret{Data: map[string][]byte{constants.SecretTLSKeyfile: []byte(key + "\n" + cert)}}
+	keyfileCert := getKeyfileCertificate(secret)
+	require.NotNil(t, keyfileCert)
+	assert.True(t, keyfileCert.Equal(certs[0]))
+
+	assert.Nil(t, getKeyfileCertificate(&core.Secret{}))
+	assert.Nil(t, getKeyfileCertificate(&core.Secret{Data: map[string][]byte{constants.SecretTLSKeyfile: []byte(key)}}))
+}
@@ -0,0 +1,338 @@
+//
+// DISCLAIMER
+//
+// Copyright 2020 ArangoDB GmbH, Cologne, Germany
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// Copyright holder is ArangoDB GmbH, Cologne, Germany
+//
+
+package resources
+
+import (
+	"bytes"
+	"net"
+	"strings"
+
+	"github.com/rs/zerolog"
+	core "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
+	meta "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/dynamic"
+
+	api "github.com/arangodb/kube-arangodb/pkg/apis/deployment/v1"
+	"github.com/arangodb/kube-arangodb/pkg/deployment/resources/inspector"
+	"github.com/arangodb/kube-arangodb/pkg/util/constants"
+	operatorErrors "github.com/arangodb/kube-arangodb/pkg/util/errors"
+	"github.com/arangodb/kube-arangodb/pkg/util/k8sutil"
+)
+
+const (
+	tlsCertificateKind          = "Certificate"
+	tlsCertificateAPIVersion    = "cert-manager.io/v1"
+	tlsCertificateSecretCertKey = core.TLSCertKey
+	tlsCertificateSecretKeyKey  = core.TLSPrivateKeyKey
+)
+
+var (
+	// TLSCertificateResource is the cert-manager resource used to request member certificates
+	TLSCertificateResource = schema.GroupVersionResource{
+		Group:    "cert-manager.io",
+		Version:  "v1",
+		Resource: "certificates",
+	}
+)
+
+// ensureTLSIssuerSecrets requests a certificate for every arangod member from the cert-manager issuer
+// and converts the issued secrets into keyfiles and the CA secret used by the operator.
+func (r *Resources) ensureTLSIssuerSecrets(log zerolog.Logger, cachedStatus inspector.Inspector, secrets k8sutil.SecretInterface,
+	spec api.DeploymentSpec, status api.DeploymentStatus) error {
+	apiObject := r.context.GetAPIObject()
+	owner := apiObject.AsOwner()
+	certificates := r.context.GetDynamicCli().Resource(TLSCertificateResource).Namespace(apiObject.GetNamespace())
+
+	var certificateNames []string
+
+	if err := status.Members.ForeachServerGroup(func(group api.ServerGroup, list api.MemberStatusList) error {
+		if !group.IsArangod() {
+			return nil
+		}
+
+		role := group.AsRole()
+
+		for _, m := range list {
+			certificateName := k8sutil.CreateTLSCertificateName(apiObject.GetName(), role, m.ID)
+			certificateNames = append(certificateNames, certificateName)
+
+			serverNames := []string{
+				k8sutil.CreateDatabaseClientServiceDNSName(apiObject),
+				k8sutil.CreatePodDNSName(apiObject, role, m.ID),
+			}
+			if ip := spec.ExternalAccess.GetLoadBalancerIP(); ip != "" {
+				serverNames = append(serverNames, ip)
+			}
+
+			certificate, err := createTLSIssuerCertificate(serverNames, spec.TLS, certificateName, &owner)
+			if err != nil {
+				return maskAny(err)
+			}
+
+			if err := r.refreshCache(cachedStatus, ensureTLSIssuerCertificate(log, certificates, certificate)); err != nil {
+				return maskAny(err)
+			}
+
+			tlsKeyfileSecretName := k8sutil.CreateTLSKeyfileSecretName(apiObject.GetName(), role, m.ID)
+			if err := r.refreshCache(cachedStatus, ensureTLSIssuerKeyfileSecret(log, cachedStatus, secrets, certificateName, tlsKeyfileSecretName, &owner)); err != nil {
+				return maskAny(err)
+			}
+		}
+		return nil
+	}); err != nil {
+		return maskAny(err)
+	}
+
+	if err := r.refreshCache(cachedStatus, ensureTLSIssuerCASecret(log, cachedStatus, secrets, spec.TLS, certificateNames, &owner)); err != nil {
+		return maskAny(err)
+	}
+
+	return nil
+}
+
+// createTLSIssuerCertificate creates the cert-manager Certificate object for a specific server.
+// The issued certificate is stored by cert-manager in a secret with the same name.
+func createTLSIssuerCertificate(serverNames []string, spec api.TLSSpec, name string, ownerRef *meta.OwnerReference) (*unstructured.Unstructured, error) {
+	dnsNames, ipAddresses, emailAddresses, err := spec.GetParsedAltNames()
+	if err != nil {
+		return nil, maskAny(err)
+	}
+
+	for _, serverName := range serverNames {
+		if net.ParseIP(serverName) != nil {
+			ipAddresses = append(ipAddresses, serverName)
+		} else {
+			dnsNames = append(dnsNames, serverName)
+		}
+	}
+
+	certSpec := map[string]interface{}{
+		"secretName": name,
+		"commonName": serverNames[0],
+		"duration":   spec.GetTTL().AsDuration().String(),
+		"privateKey": map[string]interface{}{
+			"algorithm": "ECDSA",
+			"size":      int64(256),
+		},
+		"usages": asInterfaceList([]string{"server auth", "client auth", "digital signature", "key encipherment"}),
+		"issuerRef": map[string]interface{}{
+			"name":  spec.Issuer.GetName(),
+			"kind":  string(spec.Issuer.GetKind()),
+			"group": spec.Issuer.GetGroup(),
+		},
+	}
+
+	if len(dnsNames) > 0 {
+		certSpec["dnsNames"] = asInterfaceList(dnsNames)
+	}
+	if len(ipAddresses) > 0 {
+		certSpec["ipAddresses"] = asInterfaceList(ipAddresses)
+	}
+	if len(emailAddresses) > 0 {
+		certSpec["emailAddresses"] = asInterfaceList(emailAddresses)
+	}
+
+	certificate := &unstructured.Unstructured{
+		Object: map[string]interface{}{
+			"spec": certSpec,
+		},
+	}
+	certificate.SetAPIVersion(tlsCertificateAPIVersion)
+	certificate.SetKind(tlsCertificateKind)
+	certificate.SetName(name)
+	if ownerRef != nil {
+		certificate.SetOwnerReferences([]meta.OwnerReference{*ownerRef})
+	}
+
+	return certificate, nil
+}
+
+// ensureTLSIssuerCertificate creates the Certificate object or updates its spec when it changed.
+func ensureTLSIssuerCertificate(log zerolog.Logger, certificates dynamic.ResourceInterface, certificate *unstructured.Unstructured) error {
+	log = log.With().Str("certificate", certificate.GetName()).Logger()
+
+	current, err := certificates.Get(certificate.GetName(), meta.GetOptions{})
+	if err != nil {
+		if !k8sutil.IsNotFound(err) {
+			return maskAny(err)
+		}
+
+		if _, err := certificates.Create(certificate, meta.CreateOptions{}); err != nil {
+			if k8sutil.IsAlreadyExists(err) {
+				return nil
+			}
+			log.Debug().Err(err).Msg("Failed to create Certificate")
+			return maskAny(err)
+		}
+		log.Debug().Msg("Created Certificate")
+		return operatorErrors.Reconcile()
+	}
+
+	if equality.Semantic.DeepEqual(current.Object["spec"], certificate.Object["spec"]) {
+		return nil
+	}
+
+	current.Object["spec"] = certificate.Object["spec"]
+	if _, err := certificates.Update(current, meta.UpdateOptions{}); err != nil {
+		log.Debug().Err(err).Msg("Failed to update Certificate")
+		return maskAny(err)
+	}
+	log.Debug().Msg("Updated Certificate")
+	return operatorErrors.Reconcile()
+}
+
+// ensureTLSIssuerKeyfileSecret converts the secret issued by cert-manager into the keyfile secret used by arangod.
+// The keyfile is rewritten when cert-manager renews the certificate.
+func ensureTLSIssuerKeyfileSecret(log zerolog.Logger, cachedStatus inspector.Inspector, secrets k8sutil.SecretInterface,
+	certificateSecretName, keyfileSecretName string, ownerRef *meta.OwnerReference) error {
+	log = log.With().Str("secret", keyfileSecretName).Logger()
+
+	issued, exists := cachedStatus.Secret(certificateSecretName)
+	if !exists {
+		// Certificate is not yet issued
+		return nil
+	}
+
+	keyfile, ok := getTLSIssuerKeyfile(issued)
+	if !ok {
+		return nil
+	}
+
+	current, exists := cachedStatus.Secret(keyfileSecretName)
+	if !exists {
+		if err := k8sutil.CreateTLSKeyfileSecret(secrets, keyfileSecretName, keyfile, ownerRef); err != nil {
+			if k8sutil.IsAlreadyExists(err) {
+				return nil
+			}
+			log.Debug().Err(err).Msg("Failed to create server Secret")
+			return maskAny(err)
+		}
+		log.Debug().Msg("Created server Secret")
+		return operatorErrors.Reconcile()
+	}
+
+	if string(current.Data[constants.SecretTLSKeyfile]) == keyfile {
+		return nil
+	}
+
+	updated := current.DeepCopy()
+	if updated.Data == nil {
+		updated.Data = map[string][]byte{}
+	}
+	updated.Data[constants.SecretTLSKeyfile] = []byte(keyfile)
+	if _, err := secrets.Update(updated); err != nil {
+		log.Debug().Err(err).Msg("Failed to update server Secret")
+		return maskAny(err)
+	}
+	log.Debug().Msg("Updated server Secret with renewed certificate")
+	return operatorErrors.Reconcile()
+}
+
+// ensureTLSIssuerCASecret keeps the CA secret in sync with the CA reported by cert-manager.
+// A CA secret which is not owned by the deployment is never modified.
+func ensureTLSIssuerCASecret(log zerolog.Logger, cachedStatus inspector.Inspector, secrets k8sutil.SecretInterface,
+	spec api.TLSSpec, certificateSecretNames []string, ownerRef *meta.OwnerReference) error {
+	caSecretName := spec.GetCASecretName()
+	log = log.With().Str("secret", caSecretName).Logger()
+
+	var ca []byte
+	for _, name := range certificateSecretNames {
+		if s, exists := cachedStatus.Secret(name); exists {
+			if c, ok := s.Data[CACertName]; ok && len(c) > 0 {
+				ca = c
+				break
+			}
+		}
+	}
+
+	if len(ca) == 0 {
+		// Nothing issued yet or issuer does not expose its CA
+		return nil
+	}
+
+	current, exists := cachedStatus.Secret(caSecretName)
+	if !exists {
+		secret := &core.Secret{
+			ObjectMeta: meta.ObjectMeta{
+				Name: caSecretName,
+			},
+			Data: map[string][]byte{
+				CACertName: ca,
+			},
+		}
+		k8sutil.AddOwnerRefToObject(secret, ownerRef)
+		if _, err := secrets.Create(secret); err != nil {
+			if k8sutil.IsAlreadyExists(err) {
+				return nil
+			}
+			log.Debug().Err(err).Msg("Failed to create CA Secret")
+			return maskAny(err)
+		}
+		log.Debug().Msg("Created CA Secret")
+		return operatorErrors.Reconcile()
+	}
+
+	if ownerRef == nil || !k8sutil.IsOwner(*ownerRef, current) {
+		return nil
+	}
+
+	if bytes.Equal(current.Data[CACertName], ca) {
+		return nil
+	}
+
+	updated := current.DeepCopy()
+	if updated.Data == nil {
+		updated.Data = map[string][]byte{}
+	}
+	updated.Data[CACertName] = ca
+	if _, err := secrets.Update(updated); err != nil {
+		log.Debug().Err(err).Msg("Failed to update CA Secret")
+		return maskAny(err)
+	}
+	log.Debug().Msg("Updated CA Secret")
+	return operatorErrors.Reconcile()
+}
+
+// getTLSIssuerKeyfile builds the arangod keyfile from a secret issued by cert-manager.
+func getTLSIssuerKeyfile(secret *core.Secret) (string, bool) {
+	cert, ok := secret.Data[tlsCertificateSecretCertKey]
+	if !ok || len(cert) == 0 {
+		return "", false
+	}
+
+	key, ok := secret.Data[tlsCertificateSecretKeyKey]
+	if !ok || len(key) == 0 {
+		return "", false
+	}
+
+	return strings.TrimSpace(string(cert)) + "\n" + strings.TrimSpace(string(key)), true
+}
+
+func asInterfaceList(in []string) []interface{} {
+	r := make([]interface{}, len(in))
+	for id, s := range in {
+		r[id] = s
+	}
+	return r
+}
@@ -0,0 +1,122 @@
+//
+// DISCLAIMER
+//
+// Copyright 2020 ArangoDB GmbH, Cologne, Germany
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// Copyright holder is ArangoDB GmbH, Cologne, Germany
+//
+
+package resources
+
+import (
+	"io/ioutil"
+	"testing"
+
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	core "k8s.io/api/core/v1"
+	meta "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	dynamicfake "k8s.io/client-go/dynamic/fake"
+
+	api "github.com/arangodb/kube-arangodb/pkg/apis/deployment/v1"
+	"github.com/arangodb/kube-arangodb/pkg/util"
+	operatorErrors "github.com/arangodb/kube-arangodb/pkg/util/errors"
+)
+
+func testTLSIssuerSpec() api.TLSSpec {
+	kind := api.TLSIssuerKindClusterIssuer
+	return api.TLSSpec{
+		CASecretName: util.NewString("ca"),
+		AltNames:     []string{"db.example.com", "10.0.0.1", "admin@example.com"},
+		TTL:          api.NewDuration("48h"),
+		Issuer: &api.TLSIssuerSpec{
+			Name: util.NewString("issuer"),
+			Kind: &kind,
+		},
+	}
+}
+
+func TestCreateTLSIssuerCertificate(t *testing.T) {
+	owner := meta.OwnerReference{Name: "test", UID: "uid"}
+	certificate, err := createTLSIssuerCertificate([]string{"test.ns.svc", "test-prmr-1.test-int.ns.svc", "10.0.0.2"}, testTLSIssuerSpec(), "test-prmr-1-tls-certificate", &owner)
+	require.NoError(t, err)
+
+	assert.Equal(t, "cert-manager.io/v1", certificate.GetAPIVersion())
+	assert.Equal(t, "Certificate", certificate.GetKind())
+	assert.Equal(t, "test-prmr-1-tls-certificate", certificate.GetName())
+	require.Len(t, certificate.GetOwnerReferences(), 1)
+	assert.Equal(t, owner.UID, certificate.GetOwnerReferences()[0].UID)
+
+	secretName, _, _ := unstructured.NestedString(certificate.Object, "spec", "secretName")
+	assert.Equal(t, "test-prmr-1-tls-certificate", secretName)
+	commonName, _, _ := unstructured.NestedString(certificate.Object, "spec", "commonName")
+	assert.Equal(t, "test.ns.svc", commonName)
+	duration, _, _ := unstructured.NestedString(certificate.Object, "spec", "duration")
+	assert.Equal(t, "48h0m0s", duration)
+
+	dnsNames, _, _ := unstructured.NestedStringSlice(certificate.Object, "spec", "dnsNames")
+	assert.Equal(t, []string{"db.example.com", "test.ns.svc", "test-prmr-1.test-int.ns.svc"}, dnsNames)
+	ipAddresses, _, _ := unstructured.NestedStringSlice(certificate.Object, "spec", "ipAddresses")
+	assert.Equal(t, []string{"10.0.0.1", "10.0.0.2"}, ipAddresses)
+	emailAddresses, _, _ := unstructured.NestedStringSlice(certificate.Object, "spec", "emailAddresses")
+	assert.Equal(t, []string{"admin@example.com"}, emailAddresses)
+
+	issuerRef, _, _ := unstructured.NestedStringMap(certificate.Object, "spec", "issuerRef")
+	assert.Equal(t, map[string]string{"name": "issuer", "kind": "ClusterIssuer", "group": "cert-manager.io"}, issuerRef)
+
+	// Object needs to be deep-copyable to be stored by clients
+	assert.NotPanics(t, func() { certificate.DeepCopy() })
+}
+
+func TestEnsureTLSIssuerCertificate(t *testing.T) {
+	log := zerolog.New(ioutil.Discard)
+	certificates := dynamicfake.NewSimpleDynamicClient(runtime.NewScheme()).Resource(TLSCertificateResource).Namespace("ns")
+
+	spec := testTLSIssuerSpec()
+	certificate, err := createTLSIssuerCertificate([]string{"test.ns.svc"}, spec, "cert", nil)
+	require.NoError(t, err)
+
+	// Create
+	assert.True(t, operatorErrors.IsReconcile(ensureTLSIssuerCertificate(log, certificates, certificate)))
+
+	// No changes
+	assert.NoError(t, ensureTLSIssuerCertificate(log, certificates, certificate))
+
+	// Issuer changed
+	spec.Issuer.Name = util.NewString("other")
+	certificate, err = createTLSIssuerCertificate([]string{"test.ns.svc"}, spec, "cert", nil)
+	require.NoError(t, err)
+	assert.True(t, operatorErrors.IsReconcile(ensureTLSIssuerCertificate(log, certificates, certificate)))
+
+	current, err := certificates.Get("cert", meta.GetOptions{})
+	require.NoError(t, err)
+	name, _, _ := unstructured.NestedString(current.Object, "spec", "issuerRef", "name")
+	assert.Equal(t, "other", name)
+}
+
+func TestGetTLSIssuerKeyfile(t *testing.T) {
+	_, ok := getTLSIssuerKeyfile(&core.Secret{Data: map[string][]byte{core.TLSCertKey: []byte("cert")}})
+	assert.False(t, ok)
+
+	keyfile, ok := getTLSIssuerKeyfile(&core.Secret{Data: map[string][]byte{
+		core.TLSCertKey:       []byte("cert\n"),
+		core.TLSPrivateKeyKey: []byte("\nkey\n"),
+	}})
+	require.True(t, ok)
+	assert.Equal(t, "cert\nkey", keyfile)
+}
@@ -36,6 +36,7 @@ import (
 	api "github.com/arangodb/kube-arangodb/pkg/apis/deployment/v1"
 	"github.com/arangodb/kube-arangodb/pkg/util/k8sutil"
 	v1 "k8s.io/api/core/v1"
+	"k8s.io/client-go/dynamic"
 	"k8s.io/client-go/kubernetes"
 )
 
@@ -67,6 +68,8 @@ type Context interface {
 	GetKubeCli() kubernetes.Interface
 	// GetMonitoringV1Cli returns monitoring client
 	GetMonitoringV1Cli() monitoringClient.MonitoringV1Interface
+	// GetDynamicCli returns the dynamic client
+	GetDynamicCli() dynamic.Interface
 	// GetLifecycleImage returns the image name containing the lifecycle helper (== name of operator image)
 	GetLifecycleImage() string
 	// GetOperatorUUIDImage returns the image name containing the uuid helper (== name of operator image)
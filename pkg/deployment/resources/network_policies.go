@@ -0,0 +1,248 @@
+//
+// DISCLAIMER
+//
+// Copyright 2020 ArangoDB GmbH, Cologne, Germany
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// Copyright holder is ArangoDB GmbH, Cologne, Germany
+//
+
+package resources
+
+import (
+	"fmt"
+
+	api "github.com/arangodb/kube-arangodb/pkg/apis/deployment/v1"
+	"github.com/arangodb/kube-arangodb/pkg/util/k8sutil"
+	core "k8s.io/api/core/v1"
+	networking "k8s.io/api/networking/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
+	meta "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+)
+
+// NetworkPolicyNameForGroup returns the name of the NetworkPolicy of the given server group
+func NetworkPolicyNameForGroup(depl string, group api.ServerGroup) string {
+	return fmt.Sprintf("%s-%s-np", depl, group.AsRole())
+}
+
+// EnsureNetworkPolicies creates, updates or removes NetworkPolicies of all server groups.
+// When policies are disabled, existing policies are removed once and no further requests are made.
+func (r *Resources) EnsureNetworkPolicies() error {
+	apiObject := r.context.GetAPIObject()
+	spec := r.context.GetSpec()
+
+	if !spec.NetworkPolicy.IsEnabled() && r.networkPoliciesRemoved {
+		return nil
+	}
+
+	for _, group := range api.AllServerGroups {
+		var policy *networking.NetworkPolicy
+		if spec.NetworkPolicy.IsEnabled() && isServerGroupUsed(spec, group) {
+			policy = NewNetworkPolicyForGroup(apiObject.GetName(), spec, group, apiObject.AsOwner())
+		}
+
+		if err := r.ensureNetworkPolicy(NetworkPolicyNameForGroup(apiObject.GetName(), group), policy); err != nil {
+			return maskAny(err)
+		}
+	}
+
+	r.networkPoliciesRemoved = !spec.NetworkPolicy.IsEnabled()
+
+	return nil
+}
+
+// ensureNetworkPolicy brings the NetworkPolicy with given name to the wanted state, nil policy removes it
+func (r *Resources) ensureNetworkPolicy(name string, policy *networking.NetworkPolicy) error {
+	cli := r.context.GetKubeCli().NetworkingV1().NetworkPolicies(r.context.GetNamespace())
+	log := r.log.With().Str("network-policy", name).Logger()
+
+	existing, err := cli.Get(name, meta.GetOptions{})
+	if err != nil {
+		if !k8sutil.IsNotFound(err) {
+			return maskAny(err)
+		}
+
+		if policy == nil {
+			return nil
+		}
+
+		log.Debug().Msg("Creating NetworkPolicy")
+		if _, err := cli.Create(policy); err != nil && !k8sutil.IsAlreadyExists(err) {
+			return maskAny(err)
+		}
+		return nil
+	}
+
+	if !r.isChildResource(existing) {
+		log.Debug().Msg("NetworkPolicy is not owned by the deployment, will not touch it")
+		return nil
+	}
+
+	if policy == nil {
+		log.Debug().Msg("Removing NetworkPolicy")
+		if err := cli.Delete(name, &meta.DeleteOptions{}); err != nil && !k8sutil.IsNotFound(err) {
+			return maskAny(err)
+		}
+		return nil
+	}
+
+	if equality.Semantic.DeepEqual(existing.Spec, policy.Spec) {
+		return nil
+	}
+
+	log.Debug().Msg("Updating NetworkPolicy")
+	existing.Spec = policy.Spec
+	if _, err := cli.Update(existing); err != nil {
+		return maskAny(err)
+	}
+
+	return nil
+}
+
+// NewNetworkPolicyForGroup creates the NetworkPolicy of the given server group.
+// Members of the deployment and the operator can reach all ports of the group,
+// clients can reach coordinators and single servers, external sources can reach
+// sync masters and monitoring can reach the metrics endpoints, including the ones of sync members.
+func NewNetworkPolicyForGroup(depl string, spec api.DeploymentSpec, group api.ServerGroup, owner meta.OwnerReference) *networking.NetworkPolicy {
+	rules := []networking.NetworkPolicyIngressRule{
+		{
+			From: []networking.NetworkPolicyPeer{
+				{
+					PodSelector: &meta.LabelSelector{
+						MatchLabels: k8sutil.LabelsForDeployment(depl, ""),
+					},
+				},
+				{
+					PodSelector:       spec.NetworkPolicy.GetOperatorSelector().DeepCopy(),
+					NamespaceSelector: &meta.LabelSelector{},
+				},
+			},
+		},
+	}
+
+	switch group {
+	case api.ServerGroupCoordinators, api.ServerGroupSingle:
+		rules = append(rules, networking.NetworkPolicyIngressRule{
+			Ports: networkPolicyPorts(k8sutil.ArangoPort),
+			From:  spec.NetworkPolicy.GetClients(),
+		})
+	case api.ServerGroupSyncMasters:
+		var from []networking.NetworkPolicyPeer
+		for _, cidr := range spec.Sync.ExternalAccess.LoadBalancerSourceRanges {
+			from = append(from, networking.NetworkPolicyPeer{
+				IPBlock: &networking.IPBlock{
+					CIDR: cidr,
+				},
+			})
+		}
+
+		rules = append(rules, networking.NetworkPolicyIngressRule{
+			Ports: networkPolicyPorts(k8sutil.ArangoSyncMasterPort),
+			From:  from,
+		})
+	}
+
+	if port, ok := metricsPortForGroup(spec, group); ok {
+		rules = append(rules, networking.NetworkPolicyIngressRule{
+			Ports: networkPolicyPorts(port),
+			From: []networking.NetworkPolicyPeer{
+				{
+					NamespaceSelector: spec.NetworkPolicy.GetMonitoringNamespaceSelector().DeepCopy(),
+				},
+			},
+		})
+	}
+
+	return &networking.NetworkPolicy{
+		ObjectMeta: meta.ObjectMeta{
+			Name:            NetworkPolicyNameForGroup(depl, group),
+			Labels:          k8sutil.LabelsForDeployment(depl, group.AsRole()),
+			OwnerReferences: []meta.OwnerReference{owner},
+		},
+		Spec: networking.NetworkPolicySpec{
+			PodSelector: meta.LabelSelector{
+				MatchLabels: k8sutil.LabelsForDeployment(depl, group.AsRole()),
+			},
+			PolicyTypes: []networking.PolicyType{
+				networking.PolicyTypeIngress,
+			},
+			Ingress: rules,
+		},
+	}
+}
+
+// metricsPortForGroup returns the port on which metrics of the group are scraped
+func metricsPortForGroup(spec api.DeploymentSpec, group api.ServerGroup) (int, bool) {
+	if !spec.Metrics.IsEnabled() {
+		return 0, false
+	}
+
+	switch group {
+	case api.ServerGroupSyncMasters:
+		// Sync members serve metrics on their server port
+		return k8sutil.ArangoSyncMasterPort, true
+	case api.ServerGroupSyncWorkers:
+		return k8sutil.ArangoSyncWorkerPort, true
+	}
+
+	if !group.IsArangod() {
+		return 0, false
+	}
+
+	switch spec.Metrics.Mode.Get() {
+	case api.MetricsModeInternal:
+		if group.IsExportMetrics() {
+			return k8sutil.ArangoPort, true
+		}
+	case api.MetricsModeSidecar:
+		return int(spec.Metrics.GetPort()), true
+	default:
+		if group.IsExportMetrics() {
+			return int(spec.Metrics.GetPort()), true
+		}
+	}
+
+	return 0, false
+}
+
+func networkPolicyPorts(port int) []networking.NetworkPolicyPort {
+	protocol := core.ProtocolTCP
+	p := intstr.FromInt(port)
+
+	return []networking.NetworkPolicyPort{
+		{
+			Protocol: &protocol,
+			Port:     &p,
+		},
+	}
+}
+
+// isServerGroupUsed returns true when the deployment has members in the given group
+func isServerGroupUsed(spec api.DeploymentSpec, group api.ServerGroup) bool {
+	switch group {
+	case api.ServerGroupSingle:
+		return spec.GetMode().HasSingleServers()
+	case api.ServerGroupAgents:
+		return spec.GetMode().HasAgents()
+	case api.ServerGroupDBServers:
+		return spec.GetMode().HasDBServers()
+	case api.ServerGroupCoordinators:
+		return spec.GetMode().HasCoordinators()
+	case api.ServerGroupSyncMasters, api.ServerGroupSyncWorkers:
+		return spec.Sync.IsEnabled()
+	default:
+		return false
+	}
+}
@@ -0,0 +1,128 @@
+//
+// DISCLAIMER
+//
+// Copyright 2020 ArangoDB GmbH, Cologne, Germany
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// Copyright holder is ArangoDB GmbH, Cologne, Germany
+//
+
+package resources
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	networking "k8s.io/api/networking/v1"
+	meta "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	api "github.com/arangodb/kube-arangodb/pkg/apis/deployment/v1"
+	"github.com/arangodb/kube-arangodb/pkg/util"
+	"github.com/arangodb/kube-arangodb/pkg/util/k8sutil"
+)
+
+func TestNewNetworkPolicyForGroup(t *testing.T) {
+	clients := []networking.NetworkPolicyPeer{
+		{
+			PodSelector: &meta.LabelSelector{MatchLabels: map[string]string{"app": "client"}},
+		},
+	}
+	monitoring := &meta.LabelSelector{MatchLabels: map[string]string{"name": "monitoring"}}
+
+	spec := api.DeploymentSpec{
+		Mode: api.NewMode(api.DeploymentModeCluster),
+		NetworkPolicy: &api.NetworkPolicySpec{
+			Enabled:                     util.NewBool(true),
+			Clients:                     clients,
+			MonitoringNamespaceSelector: monitoring,
+		},
+		Metrics: api.MetricsSpec{
+			Enabled: util.NewBool(true),
+		},
+	}
+	spec.Sync.ExternalAccess.LoadBalancerSourceRanges = []string{"10.0.0.0/8"}
+
+	t.Run("Agents", func(t *testing.T) {
+		np := NewNetworkPolicyForGroup("test", spec, api.ServerGroupAgents, meta.OwnerReference{})
+
+		assert.Equal(t, "test-agent-np", np.GetName())
+		assert.Equal(t, k8sutil.LabelsForDeployment("test", api.ServerGroupAgentsString), np.Spec.PodSelector.MatchLabels)
+		require.Len(t, np.Spec.Ingress, 1)
+
+		from := np.Spec.Ingress[0].From
+		require.Len(t, from, 2)
+		assert.Nil(t, np.Spec.Ingress[0].Ports)
+		assert.Equal(t, k8sutil.LabelsForDeployment("test", ""), from[0].PodSelector.MatchLabels)
+		assert.Equal(t, api.DefaultNetworkPolicyOperatorLabels, from[1].PodSelector.MatchLabels)
+		assert.NotNil(t, from[1].NamespaceSelector)
+	})
+
+	t.Run("Coordinators", func(t *testing.T) {
+		np := NewNetworkPolicyForGroup("test", spec, api.ServerGroupCoordinators, meta.OwnerReference{})
+
+		require.Len(t, np.Spec.Ingress, 3)
+
+		assert.Equal(t, clients, np.Spec.Ingress[1].From)
+		require.Len(t, np.Spec.Ingress[1].Ports, 1)
+		assert.Equal(t, k8sutil.ArangoPort, np.Spec.Ingress[1].Ports[0].Port.IntValue())
+
+		require.Len(t, np.Spec.Ingress[2].From, 1)
+		assert.Equal(t, monitoring, np.Spec.Ingress[2].From[0].NamespaceSelector)
+		assert.Equal(t, k8sutil.ArangoExporterPort, np.Spec.Ingress[2].Ports[0].Port.IntValue())
+	})
+
+	t.Run("SyncMasters", func(t *testing.T) {
+		np := NewNetworkPolicyForGroup("test", spec, api.ServerGroupSyncMasters, meta.OwnerReference{})
+
+		require.Len(t, np.Spec.Ingress, 3)
+		require.Len(t, np.Spec.Ingress[1].From, 1)
+		assert.Equal(t, "10.0.0.0/8", np.Spec.Ingress[1].From[0].IPBlock.CIDR)
+		assert.Equal(t, k8sutil.ArangoSyncMasterPort, np.Spec.Ingress[1].Ports[0].Port.IntValue())
+
+		require.Len(t, np.Spec.Ingress[2].From, 1)
+		assert.Equal(t, monitoring, np.Spec.Ingress[2].From[0].NamespaceSelector)
+		assert.Equal(t, k8sutil.ArangoSyncMasterPort, np.Spec.Ingress[2].Ports[0].Port.IntValue())
+	})
+
+	t.Run("SyncWorkers", func(t *testing.T) {
+		np := NewNetworkPolicyForGroup("test", spec, api.ServerGroupSyncWorkers, meta.OwnerReference{})
+
+		require.Len(t, np.Spec.Ingress, 2)
+		require.Len(t, np.Spec.Ingress[1].From, 1)
+		assert.Equal(t, monitoring, np.Spec.Ingress[1].From[0].NamespaceSelector)
+		assert.Equal(t, k8sutil.ArangoSyncWorkerPort, np.Spec.Ingress[1].Ports[0].Port.IntValue())
+	})
+
+	t.Run("Coordinators without clients and metrics", func(t *testing.T) {
+		s := spec
+		s.NetworkPolicy = &api.NetworkPolicySpec{Enabled: util.NewBool(true)}
+		s.Metrics = api.MetricsSpec{}
+
+		np := NewNetworkPolicyForGroup("test", s, api.ServerGroupCoordinators, meta.OwnerReference{})
+
+		require.Len(t, np.Spec.Ingress, 2)
+		// Rule without peers allows all sources
+		assert.Nil(t, np.Spec.Ingress[1].From)
+	})
+}
+
+func TestIsServerGroupUsed(t *testing.T) {
+	spec := api.DeploymentSpec{Mode: api.NewMode(api.DeploymentModeActiveFailover)}
+
+	assert.True(t, isServerGroupUsed(spec, api.ServerGroupSingle))
+	assert.True(t, isServerGroupUsed(spec, api.ServerGroupAgents))
+	assert.False(t, isServerGroupUsed(spec, api.ServerGroupDBServers))
+	assert.False(t, isServerGroupUsed(spec, api.ServerGroupSyncMasters))
+}
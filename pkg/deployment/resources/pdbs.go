@@ -47,40 +47,50 @@ func (r *Resources) EnsurePDBs() error {
 	// Only in Cluster and Production Mode
 	spec := r.context.GetSpec()
 	if spec.IsProduction() && spec.GetMode().IsCluster() {
+		// Ensure all PDBs as calculated
+		for _, group := range []api.ServerGroup{
+			api.ServerGroupAgents,
+			api.ServerGroupDBServers,
+			api.ServerGroupCoordinators,
+			api.ServerGroupSyncMasters,
+			api.ServerGroupSyncWorkers,
+		} {
+			if err := r.ensurePDBForGroup(group, PDBMinAvailable(spec, group)); err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+// PDBMinAvailable returns the number of members of the group which have to stay available.
+// Zero is returned when the group is not guarded by a PDB.
+func PDBMinAvailable(spec api.DeploymentSpec, group api.ServerGroup) int {
+	// Only in Cluster and Production Mode
+	if !spec.IsProduction() || !spec.GetMode().IsCluster() {
+		return 0
+	}
 
+	switch group {
+	case api.ServerGroupAgents, api.ServerGroupDBServers:
 		// We want to lose at most one agent and dbserver.
+		return spec.GetServerGroupSpec(group).GetCount() - 1
+	case api.ServerGroupCoordinators:
 		// Coordinators are not that critical. To keep the service available two should be enough
-		minAgents := spec.GetServerGroupSpec(api.ServerGroupAgents).GetCount() - 1
-		minDBServers := spec.GetServerGroupSpec(api.ServerGroupDBServers).GetCount() - 1
-		minCoordinators := min(spec.GetServerGroupSpec(api.ServerGroupCoordinators).GetCount()-1, 2)
-
+		return min(spec.GetServerGroupSpec(group).GetCount()-1, 2)
+	case api.ServerGroupSyncMasters, api.ServerGroupSyncWorkers:
 		// Setting those to zero triggers a remove of the PDB
-		minSyncMaster := 0
-		minSyncWorker := 0
 		if spec.Sync.IsEnabled() {
-			minSyncMaster = spec.GetServerGroupSpec(api.ServerGroupSyncMasters).GetCount() - 1
-			minSyncWorker = spec.GetServerGroupSpec(api.ServerGroupSyncWorkers).GetCount() - 1
-		}
-
-		// Ensure all PDBs as calculated
-		if err := r.ensurePDBForGroup(api.ServerGroupAgents, minAgents); err != nil {
-			return err
-		}
-		if err := r.ensurePDBForGroup(api.ServerGroupDBServers, minDBServers); err != nil {
-			return err
-		}
-		if err := r.ensurePDBForGroup(api.ServerGroupCoordinators, minCoordinators); err != nil {
-			return err
-		}
-		if err := r.ensurePDBForGroup(api.ServerGroupSyncMasters, minSyncMaster); err != nil {
-			return err
-		}
-		if err := r.ensurePDBForGroup(api.ServerGroupSyncWorkers, minSyncWorker); err != nil {
-			return err
+			// Stateless sync members are rotated in batches of maxUnavailable members
+			groupSpec := spec.GetServerGroupSpec(group)
+			if minAvailable := groupSpec.GetCount() - groupSpec.GetMaxUnavailable(); minAvailable > 0 {
+				return minAvailable
+			}
 		}
 	}
 
-	return nil
+	return 0
 }
 
 func PDBNameForGroup(depl string, group api.ServerGroup) string {
@@ -0,0 +1,76 @@
+//
+// DISCLAIMER
+//
+// Copyright 2020 ArangoDB GmbH, Cologne, Germany
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// Copyright holder is ArangoDB GmbH, Cologne, Germany
+//
+
+package resources
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	api "github.com/arangodb/kube-arangodb/pkg/apis/deployment/v1"
+	"github.com/arangodb/kube-arangodb/pkg/util"
+)
+
+func TestPDBMinAvailable(t *testing.T) {
+	newSpec := func() api.DeploymentSpec {
+		spec := api.DeploymentSpec{
+			Mode:        api.NewMode(api.DeploymentModeCluster),
+			Environment: api.NewEnvironment(api.EnvironmentProduction),
+		}
+		spec.Sync.Enabled = util.NewBool(true)
+		spec.SetDefaults("test")
+		spec.SyncMasters.Count = util.NewInt(4)
+		spec.SyncWorkers.Count = util.NewInt(4)
+		return spec
+	}
+
+	t.Run("Stateful groups", func(t *testing.T) {
+		spec := newSpec()
+		assert.Equal(t, 2, PDBMinAvailable(spec, api.ServerGroupAgents))
+		assert.Equal(t, 2, PDBMinAvailable(spec, api.ServerGroupDBServers))
+		assert.Equal(t, 2, PDBMinAvailable(spec, api.ServerGroupCoordinators))
+	})
+
+	t.Run("Sync groups", func(t *testing.T) {
+		spec := newSpec()
+		assert.Equal(t, 3, PDBMinAvailable(spec, api.ServerGroupSyncMasters))
+		assert.Equal(t, 3, PDBMinAvailable(spec, api.ServerGroupSyncWorkers))
+
+		spec.SyncMasters.MaxUnavailable = util.NewInt(2)
+		spec.SyncWorkers.MaxUnavailable = util.NewInt(4)
+		assert.Equal(t, 2, PDBMinAvailable(spec, api.ServerGroupSyncMasters))
+		assert.Equal(t, 0, PDBMinAvailable(spec, api.ServerGroupSyncWorkers))
+	})
+
+	t.Run("Sync disabled", func(t *testing.T) {
+		spec := newSpec()
+		spec.Sync.Enabled = util.NewBool(false)
+		assert.Equal(t, 0, PDBMinAvailable(spec, api.ServerGroupSyncMasters))
+		assert.Equal(t, 0, PDBMinAvailable(spec, api.ServerGroupSyncWorkers))
+	})
+
+	t.Run("Development", func(t *testing.T) {
+		spec := newSpec()
+		spec.Environment = api.NewEnvironment(api.EnvironmentDevelopment)
+		assert.Equal(t, 0, PDBMinAvailable(spec, api.ServerGroupSyncMasters))
+		assert.Equal(t, 0, PDBMinAvailable(spec, api.ServerGroupAgents))
+	})
+}
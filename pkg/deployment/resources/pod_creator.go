@@ -322,7 +322,12 @@ func (r *Resources) RenderPodForMember(cachedStatus inspector.Inspector, spec ap
 			return nil, maskAny(errors.Wrapf(err, "Validation of pods resources failed"))
 		}
 
-		return RenderArangoPod(apiObject, role, m.ID, m.PodName, args, &memberPod)
+		pod, err := RenderArangoPod(apiObject, role, m.ID, m.PodName, args, &memberPod)
+		if err != nil {
+			return nil, maskAny(err)
+		}
+
+		return ApplyPodTemplatePatch(pod, groupSpec.PodTemplatePatch)
 	} else if group.IsArangosync() {
 		// Check image
 		if !imageInfo.Enterprise {
@@ -380,7 +385,12 @@ func (r *Resources) RenderPodForMember(cachedStatus inspector.Inspector, spec ap
 			imageInfo:              imageInfo,
 		}
 
-		return RenderArangoPod(apiObject, role, m.ID, m.PodName, args, &memberSyncPod)
+		pod, err := RenderArangoPod(apiObject, role, m.ID, m.PodName, args, &memberSyncPod)
+		if err != nil {
+			return nil, maskAny(err)
+		}
+
+		return ApplyPodTemplatePatch(pod, groupSpec.PodTemplatePatch)
 	} else {
 		return nil, errors.Errorf("unable to render Pod")
 	}
@@ -526,6 +536,9 @@ func (r *Resources) createPodForMember(spec api.DeploymentSpec, memberID string,
 	}
 	// Record new member phase
 	m.Phase = newPhase
+	if group.IsStateless() {
+		m.Zone = "" // Pod can be scheduled in a different zone
+	}
 	m.Conditions.Remove(api.ConditionTypeReady)
 	m.Conditions.Remove(api.ConditionTypeTerminated)
 	m.Conditions.Remove(api.ConditionTypeTerminating)
@@ -623,6 +636,12 @@ func ChecksumArangoPod(groupSpec api.ServerGroupSpec, pod *core.Pod) (string, er
 		return "", err
 	}
 
+	if patch := groupSpec.PodTemplatePatch; !patch.IsEmpty() {
+		// Changes of the patch (also outside of pod spec) needs to rotate members
+		data = append(data, []byte(patch.GetType())...)
+		data = append(data, []byte(patch.Patch)...)
+	}
+
 	return fmt.Sprintf("%0x", sha256.Sum256(data)), nil
 }
 
@@ -40,6 +40,7 @@ import (
 
 	api "github.com/arangodb/kube-arangodb/pkg/apis/deployment/v1"
 	"github.com/arangodb/kube-arangodb/pkg/util/k8sutil"
+	"github.com/pkg/errors"
 	core "k8s.io/api/core/v1"
 )
 
@@ -130,6 +131,12 @@ func (a *ArangoDContainer) GetProbes() (*core.Probe, *core.Probe, error) {
 }
 
 func (a *ArangoDContainer) GetImage() string {
+	if a.spec.ImagePolicy.IsPinDigest() {
+		if image, ok := k8sutil.GetPinnedImage(a.imageInfo.Image, a.imageInfo.ImageID); ok {
+			return image
+		}
+	}
+
 	switch a.spec.ImageDiscoveryMode.Get() {
 	case api.DeploymentImageDiscoveryDirectMode:
 		// In case of direct mode ignore discovery
@@ -424,6 +431,15 @@ func (m *MemberArangoDPod) GetInitContainers() ([]core.Container, error) {
 		initContainers = append(initContainers, c)
 	}
 
+	if input := m.AsInput(); pod.IsEncryptionEnabled(input) && pod.IsEncryptionProviderEnabled(input) {
+		if lifecycleImage == "" {
+			return nil, errors.Errorf("Operator image is required to unwrap encryption keys")
+		}
+
+		c := pod.EncryptionProviderInitContainer(input, executable, lifecycleImage, m.groupSpec.SecurityContext.NewSecurityContext())
+		initContainers = append(initContainers, c)
+	}
+
 	return initContainers, nil
 }
 
@@ -479,12 +495,14 @@ func (m *MemberArangoDPod) createMetricsExporterSidecar() *core.Container {
 
 func (m *MemberArangoDPod) ApplyPodSpec(p *core.PodSpec) error {
 	p.SecurityContext = m.groupSpec.SecurityContext.NewPodSecurityContext()
+	p.TopologySpreadConstraints = pod.TopologySpreadConstraints(m, m.spec.Topology, m.group, m.groupSpec.TopologySpreadConstraints)
+	pod.ApplySecurityProfile(p, m.spec.SecurityProfile)
 
 	return nil
 }
 
 func (m *MemberArangoDPod) Annotations() map[string]string {
-	return collection.MergeAnnotations(m.spec.Annotations, m.groupSpec.Annotations)
+	return collection.MergeAnnotations(m.spec.Annotations, m.groupSpec.Annotations, pod.SecurityProfileAnnotations(m.spec.SecurityProfile))
 }
 
 func (m *MemberArangoDPod) Labels() map[string]string {
@@ -124,6 +124,12 @@ func (a *ArangoSyncContainer) GetImagePullPolicy() core.PullPolicy {
 }
 
 func (a *ArangoSyncContainer) GetImage() string {
+	if a.spec.ImagePolicy.IsPinDigest() {
+		if image, ok := k8sutil.GetPinnedImage(a.imageInfo.Image, a.imageInfo.ImageID); ok {
+			return image
+		}
+	}
+
 	return a.imageInfo.Image
 }
 
@@ -313,11 +319,14 @@ func (m *MemberSyncPod) Validate(cachedStatus inspector.Inspector) error {
 }
 
 func (m *MemberSyncPod) ApplyPodSpec(spec *core.PodSpec) error {
+	spec.TopologySpreadConstraints = pod.TopologySpreadConstraints(m, m.spec.Topology, m.group, m.groupSpec.TopologySpreadConstraints)
+	pod.ApplySecurityProfile(spec, m.spec.SecurityProfile)
+
 	return nil
 }
 
 func (m *MemberSyncPod) Annotations() map[string]string {
-	return collection.MergeAnnotations(m.spec.Annotations, m.groupSpec.Annotations)
+	return collection.MergeAnnotations(m.spec.Annotations, m.groupSpec.Annotations, pod.SecurityProfileAnnotations(m.spec.SecurityProfile))
 }
 
 func (m *MemberSyncPod) Labels() map[string]string {
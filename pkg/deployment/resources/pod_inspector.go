@@ -61,6 +61,7 @@ func (r *Resources) InspectPods(ctx context.Context, cachedStatus inspector.Insp
 	nextInterval := maxPodInspectorInterval // Large by default, will be made smaller if needed in the rest of the function
 	defer metrics.SetDuration(inspectPodsDurationGauges.WithLabelValues(deploymentName), start)
 
+	spec := r.context.GetSpec()
 	status, lastVersion := r.context.GetStatus()
 	var podNamesWithScheduleTimeout []string
 	var unscheduledPodNames []string
@@ -154,6 +155,17 @@ func (r *Resources) InspectPods(ctx context.Context, cachedStatus inspector.Insp
 			}
 		}
 
+		if spec.Topology.IsEnabled() && memberStatus.Zone == "" && pod.Spec.NodeName != "" {
+			// Record zone of the member once pod is scheduled
+			if zone, err := r.getNodeZone(pod.Spec.NodeName, spec.Topology.GetZoneLabel()); err != nil {
+				log.Warn().Err(err).Str("pod-name", pod.GetName()).Msg("Unable to get zone of the node")
+			} else if zone != "" {
+				log.Debug().Str("pod-name", pod.GetName()).Str("zone", zone).Msg("Updating member zone")
+				memberStatus.Zone = zone
+				updateMemberStatusNeeded = true
+			}
+		}
+
 		if k8sutil.IsPodNotScheduledFor(pod, podScheduleTimeout) {
 			// Pod cannot be scheduled for to long
 			log.Debug().Str("pod-name", pod.GetName()).Msg("Pod scheduling timeout")
@@ -254,7 +266,6 @@ func (r *Resources) InspectPods(ctx context.Context, cachedStatus inspector.Insp
 		// Ready was never set, set BootstrapComplete to false
 		status.Conditions.Update(api.ConditionTypeBootstrapCompleted, false, "Bootstrap waiting", "Waiting for deployment")
 	}
-	spec := r.context.GetSpec()
 	allMembersReady := status.Members.AllMembersReady(spec.GetMode(), spec.Sync.IsEnabled())
 	status.Conditions.Update(api.ConditionTypeReady, allMembersReady, "", "")
 
@@ -285,3 +296,42 @@ func (r *Resources) InspectPods(ctx context.Context, cachedStatus inspector.Insp
 	}
 	return nextInterval, nil
 }
+
+// getNodeZone returns the value of the zone label of the given node.
+// Zones are cached, nodes are not fetched at all once the operator is not allowed to get them.
+func (r *Resources) getNodeZone(nodeName, zoneLabel string) (string, error) {
+	r.nodes.mutex.Lock()
+	defer r.nodes.mutex.Unlock()
+
+	if r.nodes.forbidden {
+		return "", nil
+	}
+
+	key := zoneLabel + "/" + nodeName
+	if zone, ok := r.nodes.zones[key]; ok {
+		return zone, nil
+	}
+
+	node, err := r.context.GetKubeCli().CoreV1().Nodes().Get(nodeName, metav1.GetOptions{})
+	if err != nil {
+		if k8sutil.IsForbidden(err) {
+			r.log.Warn().Err(err).Msg("Operator is not allowed to get nodes, zones of members are not recorded")
+			r.nodes.forbidden = true
+			return "", nil
+		}
+		return "", maskAny(err)
+	}
+
+	zone := node.GetLabels()[zoneLabel]
+	if zone == "" {
+		// Node can be labeled later
+		return "", nil
+	}
+
+	if r.nodes.zones == nil {
+		r.nodes.zones = map[string]string{}
+	}
+	r.nodes.zones[key] = zone
+
+	return zone, nil
+}
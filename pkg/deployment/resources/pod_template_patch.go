@@ -0,0 +1,85 @@
+//
+// DISCLAIMER
+//
+// Copyright 2020 ArangoDB GmbH, Cologne, Germany
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// Copyright holder is ArangoDB GmbH, Cologne, Germany
+//
+
+package resources
+
+import (
+	"encoding/json"
+
+	jsonpatch "github.com/evanphx/json-patch"
+	"github.com/pkg/errors"
+	core "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/util/strategicpatch"
+
+	api "github.com/arangodb/kube-arangodb/pkg/apis/deployment/v1"
+)
+
+// ApplyPodTemplatePatch applies the pod template patch of the server group to the rendered pod.
+// Name, namespace and labels set by the operator cannot be changed by the patch.
+func ApplyPodTemplatePatch(pod *core.Pod, patch *api.ServerGroupPodTemplatePatch) (*core.Pod, error) {
+	if patch.IsEmpty() {
+		return pod, nil
+	}
+
+	patchData, err := patch.AsJSON()
+	if err != nil {
+		return nil, maskAny(err)
+	}
+
+	podData, err := json.Marshal(pod)
+	if err != nil {
+		return nil, maskAny(err)
+	}
+
+	var patched []byte
+
+	switch patch.GetType() {
+	case api.ServerGroupPodTemplatePatchJSON:
+		p, err := jsonpatch.DecodePatch(patchData)
+		if err != nil {
+			return nil, maskAny(errors.Wrapf(err, "Invalid JSON patch"))
+		}
+
+		if patched, err = p.Apply(podData); err != nil {
+			return nil, maskAny(errors.Wrapf(err, "Unable to apply JSON patch"))
+		}
+	default:
+		if patched, err = strategicpatch.StrategicMergePatch(podData, patchData, core.Pod{}); err != nil {
+			return nil, maskAny(errors.Wrapf(err, "Unable to apply strategic merge patch"))
+		}
+	}
+
+	var result core.Pod
+	if err := json.Unmarshal(patched, &result); err != nil {
+		return nil, maskAny(errors.Wrapf(err, "Patched pod is invalid"))
+	}
+
+	if result.GetName() != pod.GetName() || result.GetNamespace() != pod.GetNamespace() {
+		return nil, maskAny(errors.Errorf("Pod template patch cannot change name or namespace of the pod"))
+	}
+
+	for k, v := range pod.GetLabels() {
+		if l, ok := result.GetLabels()[k]; !ok || l != v {
+			return nil, maskAny(errors.Errorf("Pod template patch cannot change label %s", k))
+		}
+	}
+
+	return &result, nil
+}
@@ -0,0 +1,106 @@
+//
+// DISCLAIMER
+//
+// Copyright 2020 ArangoDB GmbH, Cologne, Germany
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// Copyright holder is ArangoDB GmbH, Cologne, Germany
+//
+
+package resources
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	core "k8s.io/api/core/v1"
+	meta "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	api "github.com/arangodb/kube-arangodb/pkg/apis/deployment/v1"
+)
+
+func testPatchPod() *core.Pod {
+	return &core.Pod{
+		ObjectMeta: meta.ObjectMeta{
+			Name:   "pod",
+			Labels: map[string]string{"role": "dbserver"},
+		},
+		Spec: core.PodSpec{
+			Containers: []core.Container{
+				{Name: "server", Image: "arangodb"},
+				{Name: "sidecar", Image: "sidecar"},
+			},
+		},
+	}
+}
+
+func TestApplyPodTemplatePatch(t *testing.T) {
+	t.Run("Empty", func(t *testing.T) {
+		pod := testPatchPod()
+		patched, err := ApplyPodTemplatePatch(pod, nil)
+		require.NoError(t, err)
+		assert.Equal(t, pod, patched)
+	})
+
+	t.Run("Strategic merge", func(t *testing.T) {
+		patched, err := ApplyPodTemplatePatch(testPatchPod(), &api.ServerGroupPodTemplatePatch{
+			Patch: "metadata:\n  labels:\n    custom: value\nspec:\n  containers:\n  - name: sidecar\n    image: other\n",
+		})
+		require.NoError(t, err)
+		assert.Equal(t, map[string]string{"role": "dbserver", "custom": "value"}, patched.Labels)
+		require.Len(t, patched.Spec.Containers, 2)
+		assert.Equal(t, "arangodb", patched.Spec.Containers[0].Image)
+		assert.Equal(t, "other", patched.Spec.Containers[1].Image)
+	})
+
+	t.Run("JSON", func(t *testing.T) {
+		patched, err := ApplyPodTemplatePatch(testPatchPod(), &api.ServerGroupPodTemplatePatch{
+			Type:  api.ServerGroupPodTemplatePatchJSON.New(),
+			Patch: `[{"op": "remove", "path": "/spec/containers/1"}]`,
+		})
+		require.NoError(t, err)
+		require.Len(t, patched.Spec.Containers, 1)
+	})
+
+	t.Run("Name change", func(t *testing.T) {
+		_, err := ApplyPodTemplatePatch(testPatchPod(), &api.ServerGroupPodTemplatePatch{
+			Patch: `{"metadata": {"name": "other"}}`,
+		})
+		require.Error(t, err)
+	})
+
+	t.Run("Label change", func(t *testing.T) {
+		_, err := ApplyPodTemplatePatch(testPatchPod(), &api.ServerGroupPodTemplatePatch{
+			Type:  api.ServerGroupPodTemplatePatchJSON.New(),
+			Patch: `[{"op": "remove", "path": "/metadata/labels/role"}]`,
+		})
+		require.Error(t, err)
+	})
+}
+
+func TestChecksumArangoPodWithPatch(t *testing.T) {
+	pod := testPatchPod()
+
+	plain, err := ChecksumArangoPod(api.ServerGroupSpec{}, pod)
+	require.NoError(t, err)
+
+	empty, err := ChecksumArangoPod(api.ServerGroupSpec{PodTemplatePatch: &api.ServerGroupPodTemplatePatch{}}, pod)
+	require.NoError(t, err)
+	assert.Equal(t, plain, empty)
+
+	patched, err := ChecksumArangoPod(api.ServerGroupSpec{PodTemplatePatch: &api.ServerGroupPodTemplatePatch{Patch: `{"metadata": {"labels": {"a": "b"}}}`}}, pod)
+	require.NoError(t, err)
+	assert.NotEqual(t, plain, patched)
+}
@@ -48,7 +48,17 @@ type Resources struct {
 		mutex                 sync.Mutex
 		triggerSyncInspection trigger.Trigger
 	}
-	monitoringClient *clientv1.MonitoringV1Client
+	certificates struct {
+		members map[string]string // Members (ID to role) with reported certificate expiry
+		mutex   sync.Mutex
+	}
+	nodes struct {
+		zones     map[string]string // Zones of the nodes by zone label and node name
+		forbidden bool              // Set when operator is not allowed to get nodes
+		mutex     sync.Mutex
+	}
+	networkPoliciesRemoved bool // Set when network policies are disabled and existing ones are removed
+	monitoringClient       *clientv1.MonitoringV1Client
 }
 
 // NewResources creates a new Resources service, used to
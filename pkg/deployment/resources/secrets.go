@@ -110,7 +110,16 @@ func (r *Resources) EnsureSecrets(log zerolog.Logger, cachedStatus inspector.Ins
 			}
 		}
 	}
-	if spec.IsSecure() {
+	if spec.IsSecure() && spec.TLS.IsIssued() {
+		counterMetric.Inc()
+		if err := r.refreshCache(cachedStatus, r.ensureSecretWithEmptyKey(cachedStatus, secrets, GetCASecretName(r.context.GetAPIObject()), "empty")); err != nil {
+			return maskAny(err)
+		}
+
+		if err := r.ensureTLSIssuerSecrets(log, cachedStatus, secrets, spec, status); err != nil {
+			return maskAny(err)
+		}
+	} else if spec.IsSecure() {
 		counterMetric.Inc()
 		if err := r.refreshCache(cachedStatus, r.ensureTLSCACertificateSecret(cachedStatus, secrets, spec.TLS)); err != nil {
 			return maskAny(err)
@@ -405,6 +414,24 @@ func (r *Resources) ensureEncryptionKeyfolderSecret(cachedStatus inspector.Inspe
 	}
 
 	owner := r.context.GetAPIObject().AsOwner()
+
+	if encryption := r.context.GetSpec().RocksDB.Encryption; encryption.HasProvider() {
+		if folderExists {
+			return nil
+		}
+
+		// Keyfolder keeps wrapped keys under the checksum of the unwrapped key
+		sha, wrapped, err := pod.GetEncryptionKeyFromSecretWithSpec(encryption, keyfile)
+		if err != nil {
+			return errors.Wrapf(err, "Unable to get encryption key checksum")
+		}
+
+		if err := appendKeyfileToKeyfolder(cachedStatus, secrets, &owner, secretName, sha, wrapped); err != nil {
+			return errors.Wrapf(err, "Unable to create keyfolder secret")
+		}
+		return nil
+	}
+
 	if err := AppendKeyfileToKeyfolder(cachedStatus, secrets, &owner, secretName, d); err != nil {
 		return errors.Wrapf(err, "Unable to create keyfolder secret")
 	}
@@ -413,6 +440,10 @@ func (r *Resources) ensureEncryptionKeyfolderSecret(cachedStatus inspector.Inspe
 
 func AppendKeyfileToKeyfolder(cachedStatus inspector.Inspector, secrets k8sutil.SecretInterface, ownerRef *meta.OwnerReference, secretName string, encryptionKey []byte) error {
 	encSha := fmt.Sprintf("%0x", sha256.Sum256(encryptionKey))
+	return appendKeyfileToKeyfolder(cachedStatus, secrets, ownerRef, secretName, encSha, encryptionKey)
+}
+
+func appendKeyfileToKeyfolder(cachedStatus inspector.Inspector, secrets k8sutil.SecretInterface, ownerRef *meta.OwnerReference, secretName, encSha string, encryptionKey []byte) error {
 	if _, exists := cachedStatus.Secret(secretName); !exists {
 
 		// Create secret
@@ -0,0 +1,195 @@
+//
+// DISCLAIMER
+//
+// Copyright 2020 ArangoDB GmbH, Cologne, Germany
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// Copyright holder is ArangoDB GmbH, Cologne, Germany
+//
+
+// Code generated by client-gen. DO NOT EDIT.
+
+package v1
+
+import (
+	"time"
+
+	v1 "github.com/arangodb/kube-arangodb/pkg/apis/deployment/v1"
+	scheme "github.com/arangodb/kube-arangodb/pkg/generated/clientset/versioned/scheme"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	types "k8s.io/apimachinery/pkg/types"
+	watch "k8s.io/apimachinery/pkg/watch"
+	rest "k8s.io/client-go/rest"
+)
+
+// ArangoCollectionsGetter has a method to return a ArangoCollectionInterface.
+// A group's client should implement this interface.
+type ArangoCollectionsGetter interface {
+	ArangoCollections(namespace string) ArangoCollectionInterface
+}
+
+// ArangoCollectionInterface has methods to work with ArangoCollection resources.
+type ArangoCollectionInterface interface {
+	Create(*v1.ArangoCollection) (*v1.ArangoCollection, error)
+	Update(*v1.ArangoCollection) (*v1.ArangoCollection, error)
+	UpdateStatus(*v1.ArangoCollection) (*v1.ArangoCollection, error)
+	Delete(name string, options *metav1.DeleteOptions) error
+	DeleteCollection(options *metav1.DeleteOptions, listOptions metav1.ListOptions) error
+	Get(name string, options metav1.GetOptions) (*v1.ArangoCollection, error)
+	List(opts metav1.ListOptions) (*v1.ArangoCollectionList, error)
+	Watch(opts metav1.ListOptions) (watch.Interface, error)
+	Patch(name string, pt types.PatchType, data []byte, subresources ...string) (result *v1.ArangoCollection, err error)
+	ArangoCollectionExpansion
+}
+
+// arangoCollections implements ArangoCollectionInterface
+type arangoCollections struct {
+	client rest.Interface
+	ns     string
+}
+
+// newArangoCollections returns a ArangoCollections
+func newArangoCollections(c *DatabaseV1Client, namespace string) *arangoCollections {
+	return &arangoCollections{
+		client: c.RESTClient(),
+		ns:     namespace,
+	}
+}
+
+// Get takes name of the arangoCollection, and returns the corresponding arangoCollection object, and an error if there is any.
+func (c *arangoCollections) Get(name string, options metav1.GetOptions) (result *v1.ArangoCollection, err error) {
+	result = &v1.ArangoCollection{}
+	err = c.client.Get().
+		Namespace(c.ns).
+		Resource("arangocollections").
+		Name(name).
+		VersionedParams(&options, scheme.ParameterCodec).
+		Do().
+		Into(result)
+	return
+}
+
+// List takes label and field selectors, and returns the list of ArangoCollections that match those selectors.
+func (c *arangoCollections) List(opts metav1.ListOptions) (result *v1.ArangoCollectionList, err error) {
+	var timeout time.Duration
+	if opts.TimeoutSeconds != nil {
+		timeout = time.Duration(*opts.TimeoutSeconds) * time.Second
+	}
+	result = &v1.ArangoCollectionList{}
+	err = c.client.Get().
+		Namespace(c.ns).
+		Resource("arangocollections").
+		VersionedParams(&opts, scheme.ParameterCodec).
+		Timeout(timeout).
+		Do().
+		Into(result)
+	return
+}
+
+// Watch returns a watch.Interface that watches the requested arangoCollections.
+func (c *arangoCollections) Watch(opts metav1.ListOptions) (watch.Interface, error) {
+	var timeout time.Duration
+	if opts.TimeoutSeconds != nil {
+		timeout = time.Duration(*opts.TimeoutSeconds) * time.Second
+	}
+	opts.Watch = true
+	return c.client.Get().
+		Namespace(c.ns).
+		Resource("arangocollections").
+		VersionedParams(&opts, scheme.ParameterCodec).
+		Timeout(timeout).
+		Watch()
+}
+
+// Create takes the representation of a arangoCollection and creates it.  Returns the server's representation of the arangoCollection, and an error, if there is any.
+func (c *arangoCollections) Create(arangoCollection *v1.ArangoCollection) (result *v1.ArangoCollection, err error) {
+	result = &v1.ArangoCollection{}
+	err = c.client.Post().
+		Namespace(c.ns).
+		Resource("arangocollections").
+		Body(arangoCollection).
+		Do().
+		Into(result)
+	return
+}
+
+// Update takes the representation of a arangoCollection and updates it. Returns the server's representation of the arangoCollection, and an error, if there is any.
+func (c *arangoCollections) Update(arangoCollection *v1.ArangoCollection) (result *v1.ArangoCollection, err error) {
+	result = &v1.ArangoCollection{}
+	err = c.client.Put().
+		Namespace(c.ns).
+		Resource("arangocollections").
+		Name(arangoCollection.Name).
+		Body(arangoCollection).
+		Do().
+		Into(result)
+	return
+}
+
+// UpdateStatus was generated because the type contains a Status member.
+// Add a +genclient:noStatus comment above the type to avoid generating UpdateStatus().
+
+func (c *arangoCollections) UpdateStatus(arangoCollection *v1.ArangoCollection) (result *v1.ArangoCollection, err error) {
+	result = &v1.ArangoCollection{}
+	err = c.client.Put().
+		Namespace(c.ns).
+		Resource("arangocollections").
+		Name(arangoCollection.Name).
+		SubResource("status").
+		Body(arangoCollection).
+		Do().
+		Into(result)
+	return
+}
+
+// Delete takes name of the arangoCollection and deletes it. Returns an error if one occurs.
+func (c *arangoCollections) Delete(name string, options *metav1.DeleteOptions) error {
+	return c.client.Delete().
+		Namespace(c.ns).
+		Resource("arangocollections").
+		Name(name).
+		Body(options).
+		Do().
+		Error()
+}
+
+// DeleteCollection deletes a collection of objects.
+func (c *arangoCollections) DeleteCollection(options *metav1.DeleteOptions, listOptions metav1.ListOptions) error {
+	var timeout time.Duration
+	if listOptions.TimeoutSeconds != nil {
+		timeout = time.Duration(*listOptions.TimeoutSeconds) * time.Second
+	}
+	return c.client.Delete().
+		Namespace(c.ns).
+		Resource("arangocollections").
+		VersionedParams(&listOptions, scheme.ParameterCodec).
+		Timeout(timeout).
+		Body(options).
+		Do().
+		Error()
+}
+
+// Patch applies the patch and returns the patched arangoCollection.
+func (c *arangoCollections) Patch(name string, pt types.PatchType, data []byte, subresources ...string) (result *v1.ArangoCollection, err error) {
+	result = &v1.ArangoCollection{}
+	err = c.client.Patch(pt).
+		Namespace(c.ns).
+		Resource("arangocollections").
+		SubResource(subresources...).
+		Name(name).
+		Body(data).
+		Do().
+		Into(result)
+	return
+}
@@ -0,0 +1,195 @@
+//
+// DISCLAIMER
+//
+// Copyright 2020 ArangoDB GmbH, Cologne, Germany
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// Copyright holder is ArangoDB GmbH, Cologne, Germany
+//
+
+// Code generated by client-gen. DO NOT EDIT.
+
+package v1
+
+import (
+	"time"
+
+	v1 "github.com/arangodb/kube-arangodb/pkg/apis/deployment/v1"
+	scheme "github.com/arangodb/kube-arangodb/pkg/generated/clientset/versioned/scheme"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	types "k8s.io/apimachinery/pkg/types"
+	watch "k8s.io/apimachinery/pkg/watch"
+	rest "k8s.io/client-go/rest"
+)
+
+// ArangoDatabasesGetter has a method to return a ArangoDatabaseInterface.
+// A group's client should implement this interface.
+type ArangoDatabasesGetter interface {
+	ArangoDatabases(namespace string) ArangoDatabaseInterface
+}
+
+// ArangoDatabaseInterface has methods to work with ArangoDatabase resources.
+type ArangoDatabaseInterface interface {
+	Create(*v1.ArangoDatabase) (*v1.ArangoDatabase, error)
+	Update(*v1.ArangoDatabase) (*v1.ArangoDatabase, error)
+	UpdateStatus(*v1.ArangoDatabase) (*v1.ArangoDatabase, error)
+	Delete(name string, options *metav1.DeleteOptions) error
+	DeleteCollection(options *metav1.DeleteOptions, listOptions metav1.ListOptions) error
+	Get(name string, options metav1.GetOptions) (*v1.ArangoDatabase, error)
+	List(opts metav1.ListOptions) (*v1.ArangoDatabaseList, error)
+	Watch(opts metav1.ListOptions) (watch.Interface, error)
+	Patch(name string, pt types.PatchType, data []byte, subresources ...string) (result *v1.ArangoDatabase, err error)
+	ArangoDatabaseExpansion
+}
+
+// arangoDatabases implements ArangoDatabaseInterface
+type arangoDatabases struct {
+	client rest.Interface
+	ns     string
+}
+
+// newArangoDatabases returns a ArangoDatabases
+func newArangoDatabases(c *DatabaseV1Client, namespace string) *arangoDatabases {
+	return &arangoDatabases{
+		client: c.RESTClient(),
+		ns:     namespace,
+	}
+}
+
+// Get takes name of the arangoDatabase, and returns the corresponding arangoDatabase object, and an error if there is any.
+func (c *arangoDatabases) Get(name string, options metav1.GetOptions) (result *v1.ArangoDatabase, err error) {
+	result = &v1.ArangoDatabase{}
+	err = c.client.Get().
+		Namespace(c.ns).
+		Resource("arangodatabases").
+		Name(name).
+		VersionedParams(&options, scheme.ParameterCodec).
+		Do().
+		Into(result)
+	return
+}
+
+// List takes label and field selectors, and returns the list of ArangoDatabases that match those selectors.
+func (c *arangoDatabases) List(opts metav1.ListOptions) (result *v1.ArangoDatabaseList, err error) {
+	var timeout time.Duration
+	if opts.TimeoutSeconds != nil {
+		timeout = time.Duration(*opts.TimeoutSeconds) * time.Second
+	}
+	result = &v1.ArangoDatabaseList{}
+	err = c.client.Get().
+		Namespace(c.ns).
+		Resource("arangodatabases").
+		VersionedParams(&opts, scheme.ParameterCodec).
+		Timeout(timeout).
+		Do().
+		Into(result)
+	return
+}
+
+// Watch returns a watch.Interface that watches the requested arangoDatabases.
+func (c *arangoDatabases) Watch(opts metav1.ListOptions) (watch.Interface, error) {
+	var timeout time.Duration
+	if opts.TimeoutSeconds != nil {
+		timeout = time.Duration(*opts.TimeoutSeconds) * time.Second
+	}
+	opts.Watch = true
+	return c.client.Get().
+		Namespace(c.ns).
+		Resource("arangodatabases").
+		VersionedParams(&opts, scheme.ParameterCodec).
+		Timeout(timeout).
+		Watch()
+}
+
+// Create takes the representation of a arangoDatabase and creates it.  Returns the server's representation of the arangoDatabase, and an error, if there is any.
+func (c *arangoDatabases) Create(arangoDatabase *v1.ArangoDatabase) (result *v1.ArangoDatabase, err error) {
+	result = &v1.ArangoDatabase{}
+	err = c.client.Post().
+		Namespace(c.ns).
+		Resource("arangodatabases").
+		Body(arangoDatabase).
+		Do().
+		Into(result)
+	return
+}
+
+// Update takes the representation of a arangoDatabase and updates it. Returns the server's representation of the arangoDatabase, and an error, if there is any.
+func (c *arangoDatabases) Update(arangoDatabase *v1.ArangoDatabase) (result *v1.ArangoDatabase, err error) {
+	result = &v1.ArangoDatabase{}
+	err = c.client.Put().
+		Namespace(c.ns).
+		Resource("arangodatabases").
+		Name(arangoDatabase.Name).
+		Body(arangoDatabase).
+		Do().
+		Into(result)
+	return
+}
+
+// UpdateStatus was generated because the type contains a Status member.
+// Add a +genclient:noStatus comment above the type to avoid generating UpdateStatus().
+
+func (c *arangoDatabases) UpdateStatus(arangoDatabase *v1.ArangoDatabase) (result *v1.ArangoDatabase, err error) {
+	result = &v1.ArangoDatabase{}
+	err = c.client.Put().
+		Namespace(c.ns).
+		Resource("arangodatabases").
+		Name(arangoDatabase.Name).
+		SubResource("status").
+		Body(arangoDatabase).
+		Do().
+		Into(result)
+	return
+}
+
+// Delete takes name of the arangoDatabase and deletes it. Returns an error if one occurs.
+func (c *arangoDatabases) Delete(name string, options *metav1.DeleteOptions) error {
+	return c.client.Delete().
+		Namespace(c.ns).
+		Resource("arangodatabases").
+		Name(name).
+		Body(options).
+		Do().
+		Error()
+}
+
+// DeleteCollection deletes a collection of objects.
+func (c *arangoDatabases) DeleteCollection(options *metav1.DeleteOptions, listOptions metav1.ListOptions) error {
+	var timeout time.Duration
+	if listOptions.TimeoutSeconds != nil {
+		timeout = time.Duration(*listOptions.TimeoutSeconds) * time.Second
+	}
+	return c.client.Delete().
+		Namespace(c.ns).
+		Resource("arangodatabases").
+		VersionedParams(&listOptions, scheme.ParameterCodec).
+		Timeout(timeout).
+		Body(options).
+		Do().
+		Error()
+}
+
+// Patch applies the patch and returns the patched arangoDatabase.
+func (c *arangoDatabases) Patch(name string, pt types.PatchType, data []byte, subresources ...string) (result *v1.ArangoDatabase, err error) {
+	result = &v1.ArangoDatabase{}
+	err = c.client.Patch(pt).
+		Namespace(c.ns).
+		Resource("arangodatabases").
+		SubResource(subresources...).
+		Name(name).
+		Body(data).
+		Do().
+		Into(result)
+	return
+}
@@ -0,0 +1,195 @@
+//
+// DISCLAIMER
+//
+// Copyright 2020 ArangoDB GmbH, Cologne, Germany
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// Copyright holder is ArangoDB GmbH, Cologne, Germany
+//
+
+// Code generated by client-gen. DO NOT EDIT.
+
+package v1
+
+import (
+	"time"
+
+	v1 "github.com/arangodb/kube-arangodb/pkg/apis/deployment/v1"
+	scheme "github.com/arangodb/kube-arangodb/pkg/generated/clientset/versioned/scheme"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	types "k8s.io/apimachinery/pkg/types"
+	watch "k8s.io/apimachinery/pkg/watch"
+	rest "k8s.io/client-go/rest"
+)
+
+// ArangoUsersGetter has a method to return a ArangoUserInterface.
+// A group's client should implement this interface.
+type ArangoUsersGetter interface {
+	ArangoUsers(namespace string) ArangoUserInterface
+}
+
+// ArangoUserInterface has methods to work with ArangoUser resources.
+type ArangoUserInterface interface {
+	Create(*v1.ArangoUser) (*v1.ArangoUser, error)
+	Update(*v1.ArangoUser) (*v1.ArangoUser, error)
+	UpdateStatus(*v1.ArangoUser) (*v1.ArangoUser, error)
+	Delete(name string, options *metav1.DeleteOptions) error
+	DeleteCollection(options *metav1.DeleteOptions, listOptions metav1.ListOptions) error
+	Get(name string, options metav1.GetOptions) (*v1.ArangoUser, error)
+	List(opts metav1.ListOptions) (*v1.ArangoUserList, error)
+	Watch(opts metav1.ListOptions) (watch.Interface, error)
+	Patch(name string, pt types.PatchType, data []byte, subresources ...string) (result *v1.ArangoUser, err error)
+	ArangoUserExpansion
+}
+
+// arangoUsers implements ArangoUserInterface
+type arangoUsers struct {
+	client rest.Interface
+	ns     string
+}
+
+// newArangoUsers returns a ArangoUsers
+func newArangoUsers(c *DatabaseV1Client, namespace string) *arangoUsers {
+	return &arangoUsers{
+		client: c.RESTClient(),
+		ns:     namespace,
+	}
+}
+
+// Get takes name of the arangoUser, and returns the corresponding arangoUser object, and an error if there is any.
+func (c *arangoUsers) Get(name string, options metav1.GetOptions) (result *v1.ArangoUser, err error) {
+	result = &v1.ArangoUser{}
+	err = c.client.Get().
+		Namespace(c.ns).
+		Resource("arangousers").
+		Name(name).
+		VersionedParams(&options, scheme.ParameterCodec).
+		Do().
+		Into(result)
+	return
+}
+
+// List takes label and field selectors, and returns the list of ArangoUsers that match those selectors.
+func (c *arangoUsers) List(opts metav1.ListOptions) (result *v1.ArangoUserList, err error) {
+	var timeout time.Duration
+	if opts.TimeoutSeconds != nil {
+		timeout = time.Duration(*opts.TimeoutSeconds) * time.Second
+	}
+	result = &v1.ArangoUserList{}
+	err = c.client.Get().
+		Namespace(c.ns).
+		Resource("arangousers").
+		VersionedParams(&opts, scheme.ParameterCodec).
+		Timeout(timeout).
+		Do().
+		Into(result)
+	return
+}
+
+// Watch returns a watch.Interface that watches the requested arangoUsers.
+func (c *arangoUsers) Watch(opts metav1.ListOptions) (watch.Interface, error) {
+	var timeout time.Duration
+	if opts.TimeoutSeconds != nil {
+		timeout = time.Duration(*opts.TimeoutSeconds) * time.Second
+	}
+	opts.Watch = true
+	return c.client.Get().
+		Namespace(c.ns).
+		Resource("arangousers").
+		VersionedParams(&opts, scheme.ParameterCodec).
+		Timeout(timeout).
+		Watch()
+}
+
+// Create takes the representation of a arangoUser and creates it.  Returns the server's representation of the arangoUser, and an error, if there is any.
+func (c *arangoUsers) Create(arangoUser *v1.ArangoUser) (result *v1.ArangoUser, err error) {
+	result = &v1.ArangoUser{}
+	err = c.client.Post().
+		Namespace(c.ns).
+		Resource("arangousers").
+		Body(arangoUser).
+		Do().
+		Into(result)
+	return
+}
+
+// Update takes the representation of a arangoUser and updates it. Returns the server's representation of the arangoUser, and an error, if there is any.
+func (c *arangoUsers) Update(arangoUser *v1.ArangoUser) (result *v1.ArangoUser, err error) {
+	result = &v1.ArangoUser{}
+	err = c.client.Put().
+		Namespace(c.ns).
+		Resource("arangousers").
+		Name(arangoUser.Name).
+		Body(arangoUser).
+		Do().
+		Into(result)
+	return
+}
+
+// UpdateStatus was generated because the type contains a Status member.
+// Add a +genclient:noStatus comment above the type to avoid generating UpdateStatus().
+
+func (c *arangoUsers) UpdateStatus(arangoUser *v1.ArangoUser) (result *v1.ArangoUser, err error) {
+	result = &v1.ArangoUser{}
+	err = c.client.Put().
+		Namespace(c.ns).
+		Resource("arangousers").
+		Name(arangoUser.Name).
+		SubResource("status").
+		Body(arangoUser).
+		Do().
+		Into(result)
+	return
+}
+
+// Delete takes name of the arangoUser and deletes it. Returns an error if one occurs.
+func (c *arangoUsers) Delete(name string, options *metav1.DeleteOptions) error {
+	return c.client.Delete().
+		Namespace(c.ns).
+		Resource("arangousers").
+		Name(name).
+		Body(options).
+		Do().
+		Error()
+}
+
+// DeleteCollection deletes a collection of objects.
+func (c *arangoUsers) DeleteCollection(options *metav1.DeleteOptions, listOptions metav1.ListOptions) error {
+	var timeout time.Duration
+	if listOptions.TimeoutSeconds != nil {
+		timeout = time.Duration(*listOptions.TimeoutSeconds) * time.Second
+	}
+	return c.client.Delete().
+		Namespace(c.ns).
+		Resource("arangousers").
+		VersionedParams(&listOptions, scheme.ParameterCodec).
+		Timeout(timeout).
+		Body(options).
+		Do().
+		Error()
+}
+
+// Patch applies the patch and returns the patched arangoUser.
+func (c *arangoUsers) Patch(name string, pt types.PatchType, data []byte, subresources ...string) (result *v1.ArangoUser, err error) {
+	result = &v1.ArangoUser{}
+	err = c.client.Patch(pt).
+		Namespace(c.ns).
+		Resource("arangousers").
+		SubResource(subresources...).
+		Name(name).
+		Body(data).
+		Do().
+		Into(result)
+	return
+}
@@ -30,7 +30,10 @@ import (
 
 type DatabaseV1Interface interface {
 	RESTClient() rest.Interface
+	ArangoCollectionsGetter
+	ArangoDatabasesGetter
 	ArangoDeploymentsGetter
+	ArangoUsersGetter
 }
 
 // DatabaseV1Client is used to interact with features provided by the database.arangodb.com group.
@@ -38,10 +41,22 @@ type DatabaseV1Client struct {
 	restClient rest.Interface
 }
 
+func (c *DatabaseV1Client) ArangoCollections(namespace string) ArangoCollectionInterface {
+	return newArangoCollections(c, namespace)
+}
+
+func (c *DatabaseV1Client) ArangoDatabases(namespace string) ArangoDatabaseInterface {
+	return newArangoDatabases(c, namespace)
+}
+
 func (c *DatabaseV1Client) ArangoDeployments(namespace string) ArangoDeploymentInterface {
 	return newArangoDeployments(c, namespace)
 }
 
+func (c *DatabaseV1Client) ArangoUsers(namespace string) ArangoUserInterface {
+	return newArangoUsers(c, namespace)
+}
+
 // NewForConfig creates a new DatabaseV1Client for the given config.
 func NewForConfig(c *rest.Config) (*DatabaseV1Client, error) {
 	config := *c
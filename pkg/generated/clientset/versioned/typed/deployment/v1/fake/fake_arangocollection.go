@@ -0,0 +1,144 @@
+//
+// DISCLAIMER
+//
+// Copyright 2020 ArangoDB GmbH, Cologne, Germany
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// Copyright holder is ArangoDB GmbH, Cologne, Germany
+//
+
+// Code generated by client-gen. DO NOT EDIT.
+
+package fake
+
+import (
+	deploymentv1 "github.com/arangodb/kube-arangodb/pkg/apis/deployment/v1"
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	labels "k8s.io/apimachinery/pkg/labels"
+	schema "k8s.io/apimachinery/pkg/runtime/schema"
+	types "k8s.io/apimachinery/pkg/types"
+	watch "k8s.io/apimachinery/pkg/watch"
+	testing "k8s.io/client-go/testing"
+)
+
+// FakeArangoCollections implements ArangoCollectionInterface
+type FakeArangoCollections struct {
+	Fake *FakeDatabaseV1
+	ns   string
+}
+
+var arangocollectionsResource = schema.GroupVersionResource{Group: "database.arangodb.com", Version: "v1", Resource: "arangocollections"}
+
+var arangocollectionsKind = schema.GroupVersionKind{Group: "database.arangodb.com", Version: "v1", Kind: "ArangoCollection"}
+
+// Get takes name of the arangoCollection, and returns the corresponding arangoCollection object, and an error if there is any.
+func (c *FakeArangoCollections) Get(name string, options v1.GetOptions) (result *deploymentv1.ArangoCollection, err error) {
+	obj, err := c.Fake.
+		Invokes(testing.NewGetAction(arangocollectionsResource, c.ns, name), &deploymentv1.ArangoCollection{})
+
+	if obj == nil {
+		return nil, err
+	}
+	return obj.(*deploymentv1.ArangoCollection), err
+}
+
+// List takes label and field selectors, and returns the list of ArangoCollections that match those selectors.
+func (c *FakeArangoCollections) List(opts v1.ListOptions) (result *deploymentv1.ArangoCollectionList, err error) {
+	obj, err := c.Fake.
+		Invokes(testing.NewListAction(arangocollectionsResource, arangocollectionsKind, c.ns, opts), &deploymentv1.ArangoCollectionList{})
+
+	if obj == nil {
+		return nil, err
+	}
+
+	label, _, _ := testing.ExtractFromListOptions(opts)
+	if label == nil {
+		label = labels.Everything()
+	}
+	list := &deploymentv1.ArangoCollectionList{ListMeta: obj.(*deploymentv1.ArangoCollectionList).ListMeta}
+	for _, item := range obj.(*deploymentv1.ArangoCollectionList).Items {
+		if label.Matches(labels.Set(item.Labels)) {
+			list.Items = append(list.Items, item)
+		}
+	}
+	return list, err
+}
+
+// Watch returns a watch.Interface that watches the requested arangoCollections.
+func (c *FakeArangoCollections) Watch(opts v1.ListOptions) (watch.Interface, error) {
+	return c.Fake.
+		InvokesWatch(testing.NewWatchAction(arangocollectionsResource, c.ns, opts))
+
+}
+
+// Create takes the representation of a arangoCollection and creates it.  Returns the server's representation of the arangoCollection, and an error, if there is any.
+func (c *FakeArangoCollections) Create(arangoCollection *deploymentv1.ArangoCollection) (result *deploymentv1.ArangoCollection, err error) {
+	obj, err := c.Fake.
+		Invokes(testing.NewCreateAction(arangocollectionsResource, c.ns, arangoCollection), &deploymentv1.ArangoCollection{})
+
+	if obj == nil {
+		return nil, err
+	}
+	return obj.(*deploymentv1.ArangoCollection), err
+}
+
+// Update takes the representation of a arangoCollection and updates it. Returns the server's representation of the arangoCollection, and an error, if there is any.
+func (c *FakeArangoCollections) Update(arangoCollection *deploymentv1.ArangoCollection) (result *deploymentv1.ArangoCollection, err error) {
+	obj, err := c.Fake.
+		Invokes(testing.NewUpdateAction(arangocollectionsResource, c.ns, arangoCollection), &deploymentv1.ArangoCollection{})
+
+	if obj == nil {
+		return nil, err
+	}
+	return obj.(*deploymentv1.ArangoCollection), err
+}
+
+// UpdateStatus was generated because the type contains a Status member.
+// Add a +genclient:noStatus comment above the type to avoid generating UpdateStatus().
+func (c *FakeArangoCollections) UpdateStatus(arangoCollection *deploymentv1.ArangoCollection) (*deploymentv1.ArangoCollection, error) {
+	obj, err := c.Fake.
+		Invokes(testing.NewUpdateSubresourceAction(arangocollectionsResource, "status", c.ns, arangoCollection), &deploymentv1.ArangoCollection{})
+
+	if obj == nil {
+		return nil, err
+	}
+	return obj.(*deploymentv1.ArangoCollection), err
+}
+
+// Delete takes name of the arangoCollection and deletes it. Returns an error if one occurs.
+func (c *FakeArangoCollections) Delete(name string, options *v1.DeleteOptions) error {
+	_, err := c.Fake.
+		Invokes(testing.NewDeleteAction(arangocollectionsResource, c.ns, name), &deploymentv1.ArangoCollection{})
+
+	return err
+}
+
+// DeleteCollection deletes a collection of objects.
+func (c *FakeArangoCollections) DeleteCollection(options *v1.DeleteOptions, listOptions v1.ListOptions) error {
+	action := testing.NewDeleteCollectionAction(arangocollectionsResource, c.ns, listOptions)
+
+	_, err := c.Fake.Invokes(action, &deploymentv1.ArangoCollectionList{})
+	return err
+}
+
+// Patch applies the patch and returns the patched arangoCollection.
+func (c *FakeArangoCollections) Patch(name string, pt types.PatchType, data []byte, subresources ...string) (result *deploymentv1.ArangoCollection, err error) {
+	obj, err := c.Fake.
+		Invokes(testing.NewPatchSubresourceAction(arangocollectionsResource, c.ns, name, pt, data, subresources...), &deploymentv1.ArangoCollection{})
+
+	if obj == nil {
+		return nil, err
+	}
+	return obj.(*deploymentv1.ArangoCollection), err
+}
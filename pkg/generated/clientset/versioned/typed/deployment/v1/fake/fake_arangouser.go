@@ -0,0 +1,144 @@
+//
+// DISCLAIMER
+//
+// Copyright 2020 ArangoDB GmbH, Cologne, Germany
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// Copyright holder is ArangoDB GmbH, Cologne, Germany
+//
+
+// Code generated by client-gen. DO NOT EDIT.
+
+package fake
+
+import (
+	deploymentv1 "github.com/arangodb/kube-arangodb/pkg/apis/deployment/v1"
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	labels "k8s.io/apimachinery/pkg/labels"
+	schema "k8s.io/apimachinery/pkg/runtime/schema"
+	types "k8s.io/apimachinery/pkg/types"
+	watch "k8s.io/apimachinery/pkg/watch"
+	testing "k8s.io/client-go/testing"
+)
+
+// FakeArangoUsers implements ArangoUserInterface
+type FakeArangoUsers struct {
+	Fake *FakeDatabaseV1
+	ns   string
+}
+
+var arangousersResource = schema.GroupVersionResource{Group: "database.arangodb.com", Version: "v1", Resource: "arangousers"}
+
+var arangousersKind = schema.GroupVersionKind{Group: "database.arangodb.com", Version: "v1", Kind: "ArangoUser"}
+
+// Get takes name of the arangoUser, and returns the corresponding arangoUser object, and an error if there is any.
+func (c *FakeArangoUsers) Get(name string, options v1.GetOptions) (result *deploymentv1.ArangoUser, err error) {
+	obj, err := c.Fake.
+		Invokes(testing.NewGetAction(arangousersResource, c.ns, name), &deploymentv1.ArangoUser{})
+
+	if obj == nil {
+		return nil, err
+	}
+	return obj.(*deploymentv1.ArangoUser), err
+}
+
+// List takes label and field selectors, and returns the list of ArangoUsers that match those selectors.
+func (c *FakeArangoUsers) List(opts v1.ListOptions) (result *deploymentv1.ArangoUserList, err error) {
+	obj, err := c.Fake.
+		Invokes(testing.NewListAction(arangousersResource, arangousersKind, c.ns, opts), &deploymentv1.ArangoUserList{})
+
+	if obj == nil {
+		return nil, err
+	}
+
+	label, _, _ := testing.ExtractFromListOptions(opts)
+	if label == nil {
+		label = labels.Everything()
+	}
+	list := &deploymentv1.ArangoUserList{ListMeta: obj.(*deploymentv1.ArangoUserList).ListMeta}
+	for _, item := range obj.(*deploymentv1.ArangoUserList).Items {
+		if label.Matches(labels.Set(item.Labels)) {
+			list.Items = append(list.Items, item)
+		}
+	}
+	return list, err
+}
+
+// Watch returns a watch.Interface that watches the requested arangoUsers.
+func (c *FakeArangoUsers) Watch(opts v1.ListOptions) (watch.Interface, error) {
+	return c.Fake.
+		InvokesWatch(testing.NewWatchAction(arangousersResource, c.ns, opts))
+
+}
+
+// Create takes the representation of a arangoUser and creates it.  Returns the server's representation of the arangoUser, and an error, if there is any.
+func (c *FakeArangoUsers) Create(arangoUser *deploymentv1.ArangoUser) (result *deploymentv1.ArangoUser, err error) {
+	obj, err := c.Fake.
+		Invokes(testing.NewCreateAction(arangousersResource, c.ns, arangoUser), &deploymentv1.ArangoUser{})
+
+	if obj == nil {
+		return nil, err
+	}
+	return obj.(*deploymentv1.ArangoUser), err
+}
+
+// Update takes the representation of a arangoUser and updates it. Returns the server's representation of the arangoUser, and an error, if there is any.
+func (c *FakeArangoUsers) Update(arangoUser *deploymentv1.ArangoUser) (result *deploymentv1.ArangoUser, err error) {
+	obj, err := c.Fake.
+		Invokes(testing.NewUpdateAction(arangousersResource, c.ns, arangoUser), &deploymentv1.ArangoUser{})
+
+	if obj == nil {
+		return nil, err
+	}
+	return obj.(*deploymentv1.ArangoUser), err
+}
+
+// UpdateStatus was generated because the type contains a Status member.
+// Add a +genclient:noStatus comment above the type to avoid generating UpdateStatus().
+func (c *FakeArangoUsers) UpdateStatus(arangoUser *deploymentv1.ArangoUser) (*deploymentv1.ArangoUser, error) {
+	obj, err := c.Fake.
+		Invokes(testing.NewUpdateSubresourceAction(arangousersResource, "status", c.ns, arangoUser), &deploymentv1.ArangoUser{})
+
+	if obj == nil {
+		return nil, err
+	}
+	return obj.(*deploymentv1.ArangoUser), err
+}
+
+// Delete takes name of the arangoUser and deletes it. Returns an error if one occurs.
+func (c *FakeArangoUsers) Delete(name string, options *v1.DeleteOptions) error {
+	_, err := c.Fake.
+		Invokes(testing.NewDeleteAction(arangousersResource, c.ns, name), &deploymentv1.ArangoUser{})
+
+	return err
+}
+
+// DeleteCollection deletes a collection of objects.
+func (c *FakeArangoUsers) DeleteCollection(options *v1.DeleteOptions, listOptions v1.ListOptions) error {
+	action := testing.NewDeleteCollectionAction(arangousersResource, c.ns, listOptions)
+
+	_, err := c.Fake.Invokes(action, &deploymentv1.ArangoUserList{})
+	return err
+}
+
+// Patch applies the patch and returns the patched arangoUser.
+func (c *FakeArangoUsers) Patch(name string, pt types.PatchType, data []byte, subresources ...string) (result *deploymentv1.ArangoUser, err error) {
+	obj, err := c.Fake.
+		Invokes(testing.NewPatchSubresourceAction(arangousersResource, c.ns, name, pt, data, subresources...), &deploymentv1.ArangoUser{})
+
+	if obj == nil {
+		return nil, err
+	}
+	return obj.(*deploymentv1.ArangoUser), err
+}
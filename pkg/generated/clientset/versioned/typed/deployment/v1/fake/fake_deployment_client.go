@@ -32,10 +32,22 @@ type FakeDatabaseV1 struct {
 	*testing.Fake
 }
 
+func (c *FakeDatabaseV1) ArangoCollections(namespace string) v1.ArangoCollectionInterface {
+	return &FakeArangoCollections{c, namespace}
+}
+
+func (c *FakeDatabaseV1) ArangoDatabases(namespace string) v1.ArangoDatabaseInterface {
+	return &FakeArangoDatabases{c, namespace}
+}
+
 func (c *FakeDatabaseV1) ArangoDeployments(namespace string) v1.ArangoDeploymentInterface {
 	return &FakeArangoDeployments{c, namespace}
 }
 
+func (c *FakeDatabaseV1) ArangoUsers(namespace string) v1.ArangoUserInterface {
+	return &FakeArangoUsers{c, namespace}
+}
+
 // RESTClient returns a RESTClient that is used to communicate
 // with API server by this client implementation.
 func (c *FakeDatabaseV1) RESTClient() rest.Interface {
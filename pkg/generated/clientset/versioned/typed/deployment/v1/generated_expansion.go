@@ -22,4 +22,10 @@
 
 package v1
 
+type ArangoCollectionExpansion interface{}
+
+type ArangoDatabaseExpansion interface{}
+
 type ArangoDeploymentExpansion interface{}
+
+type ArangoUserExpansion interface{}
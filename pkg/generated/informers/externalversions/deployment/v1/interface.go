@@ -28,8 +28,14 @@ import (
 
 // Interface provides access to all the informers in this group version.
 type Interface interface {
+	// ArangoCollections returns a ArangoCollectionInformer.
+	ArangoCollections() ArangoCollectionInformer
+	// ArangoDatabases returns a ArangoDatabaseInformer.
+	ArangoDatabases() ArangoDatabaseInformer
 	// ArangoDeployments returns a ArangoDeploymentInformer.
 	ArangoDeployments() ArangoDeploymentInformer
+	// ArangoUsers returns a ArangoUserInformer.
+	ArangoUsers() ArangoUserInformer
 }
 
 type version struct {
@@ -43,7 +49,22 @@ func New(f internalinterfaces.SharedInformerFactory, namespace string, tweakList
 	return &version{factory: f, namespace: namespace, tweakListOptions: tweakListOptions}
 }
 
+// ArangoCollections returns a ArangoCollectionInformer.
+func (v *version) ArangoCollections() ArangoCollectionInformer {
+	return &arangoCollectionInformer{factory: v.factory, namespace: v.namespace, tweakListOptions: v.tweakListOptions}
+}
+
+// ArangoDatabases returns a ArangoDatabaseInformer.
+func (v *version) ArangoDatabases() ArangoDatabaseInformer {
+	return &arangoDatabaseInformer{factory: v.factory, namespace: v.namespace, tweakListOptions: v.tweakListOptions}
+}
+
 // ArangoDeployments returns a ArangoDeploymentInformer.
 func (v *version) ArangoDeployments() ArangoDeploymentInformer {
 	return &arangoDeploymentInformer{factory: v.factory, namespace: v.namespace, tweakListOptions: v.tweakListOptions}
 }
+
+// ArangoUsers returns a ArangoUserInformer.
+func (v *version) ArangoUsers() ArangoUserInformer {
+	return &arangoUserInformer{factory: v.factory, namespace: v.namespace, tweakListOptions: v.tweakListOptions}
+}
@@ -68,8 +68,14 @@ func (f *sharedInformerFactory) ForResource(resource schema.GroupVersionResource
 		return &genericInformer{resource: resource.GroupResource(), informer: f.Backup().V1().ArangoBackupPolicies().Informer()}, nil
 
 		// Group=database.arangodb.com, Version=v1
+	case deploymentv1.SchemeGroupVersion.WithResource("arangocollections"):
+		return &genericInformer{resource: resource.GroupResource(), informer: f.Database().V1().ArangoCollections().Informer()}, nil
+	case deploymentv1.SchemeGroupVersion.WithResource("arangodatabases"):
+		return &genericInformer{resource: resource.GroupResource(), informer: f.Database().V1().ArangoDatabases().Informer()}, nil
 	case deploymentv1.SchemeGroupVersion.WithResource("arangodeployments"):
 		return &genericInformer{resource: resource.GroupResource(), informer: f.Database().V1().ArangoDeployments().Informer()}, nil
+	case deploymentv1.SchemeGroupVersion.WithResource("arangousers"):
+		return &genericInformer{resource: resource.GroupResource(), informer: f.Database().V1().ArangoUsers().Informer()}, nil
 
 		// Group=database.arangodb.com, Version=v2alpha1
 	case v2alpha1.SchemeGroupVersion.WithResource("arangodeployments"):
@@ -0,0 +1,98 @@
+//
+// DISCLAIMER
+//
+// Copyright 2020 ArangoDB GmbH, Cologne, Germany
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// Copyright holder is ArangoDB GmbH, Cologne, Germany
+//
+
+// Code generated by lister-gen. DO NOT EDIT.
+
+package v1
+
+import (
+	v1 "github.com/arangodb/kube-arangodb/pkg/apis/deployment/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/client-go/tools/cache"
+)
+
+// ArangoUserLister helps list ArangoUsers.
+type ArangoUserLister interface {
+	// List lists all ArangoUsers in the indexer.
+	List(selector labels.Selector) (ret []*v1.ArangoUser, err error)
+	// ArangoUsers returns an object that can list and get ArangoUsers.
+	ArangoUsers(namespace string) ArangoUserNamespaceLister
+	ArangoUserListerExpansion
+}
+
+// arangoUserLister implements the ArangoUserLister interface.
+type arangoUserLister struct {
+	indexer cache.Indexer
+}
+
+// NewArangoUserLister returns a new ArangoUserLister.
+func NewArangoUserLister(indexer cache.Indexer) ArangoUserLister {
+	return &arangoUserLister{indexer: indexer}
+}
+
+// List lists all ArangoUsers in the indexer.
+func (s *arangoUserLister) List(selector labels.Selector) (ret []*v1.ArangoUser, err error) {
+	err = cache.ListAll(s.indexer, selector, func(m interface{}) {
+		ret = append(ret, m.(*v1.ArangoUser))
+	})
+	return ret, err
+}
+
+// ArangoUsers returns an object that can list and get ArangoUsers.
+func (s *arangoUserLister) ArangoUsers(namespace string) ArangoUserNamespaceLister {
+	return arangoUserNamespaceLister{indexer: s.indexer, namespace: namespace}
+}
+
+// ArangoUserNamespaceLister helps list and get ArangoUsers.
+type ArangoUserNamespaceLister interface {
+	// List lists all ArangoUsers in the indexer for a given namespace.
+	List(selector labels.Selector) (ret []*v1.ArangoUser, err error)
+	// Get retrieves the ArangoUser from the indexer for a given namespace and name.
+	Get(name string) (*v1.ArangoUser, error)
+	ArangoUserNamespaceListerExpansion
+}
+
+// arangoUserNamespaceLister implements the ArangoUserNamespaceLister
+// interface.
+type arangoUserNamespaceLister struct {
+	indexer   cache.Indexer
+	namespace string
+}
+
+// List lists all ArangoUsers in the indexer for a given namespace.
+func (s arangoUserNamespaceLister) List(selector labels.Selector) (ret []*v1.ArangoUser, err error) {
+	err = cache.ListAllByNamespace(s.indexer, s.namespace, selector, func(m interface{}) {
+		ret = append(ret, m.(*v1.ArangoUser))
+	})
+	return ret, err
+}
+
+// Get retrieves the ArangoUser from the indexer for a given namespace and name.
+func (s arangoUserNamespaceLister) Get(name string) (*v1.ArangoUser, error) {
+	obj, exists, err := s.indexer.GetByKey(s.namespace + "/" + name)
+	if err != nil {
+		return nil, err
+	}
+	if !exists {
+		return nil, errors.NewNotFound(v1.Resource("arangouser"), name)
+	}
+	return obj.(*v1.ArangoUser), nil
+}
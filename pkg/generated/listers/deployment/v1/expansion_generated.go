@@ -22,6 +22,22 @@
 
 package v1
 
+// ArangoCollectionListerExpansion allows custom methods to be added to
+// ArangoCollectionLister.
+type ArangoCollectionListerExpansion interface{}
+
+// ArangoCollectionNamespaceListerExpansion allows custom methods to be added to
+// ArangoCollectionNamespaceLister.
+type ArangoCollectionNamespaceListerExpansion interface{}
+
+// ArangoDatabaseListerExpansion allows custom methods to be added to
+// ArangoDatabaseLister.
+type ArangoDatabaseListerExpansion interface{}
+
+// ArangoDatabaseNamespaceListerExpansion allows custom methods to be added to
+// ArangoDatabaseNamespaceLister.
+type ArangoDatabaseNamespaceListerExpansion interface{}
+
 // ArangoDeploymentListerExpansion allows custom methods to be added to
 // ArangoDeploymentLister.
 type ArangoDeploymentListerExpansion interface{}
@@ -29,3 +45,11 @@ type ArangoDeploymentListerExpansion interface{}
 // ArangoDeploymentNamespaceListerExpansion allows custom methods to be added to
 // ArangoDeploymentNamespaceLister.
 type ArangoDeploymentNamespaceListerExpansion interface{}
+
+// ArangoUserListerExpansion allows custom methods to be added to
+// ArangoUserLister.
+type ArangoUserListerExpansion interface{}
+
+// ArangoUserNamespaceListerExpansion allows custom methods to be added to
+// ArangoUserNamespaceLister.
+type ArangoUserNamespaceListerExpansion interface{}
@@ -33,6 +33,10 @@ const (
 
 	// DeploymentName is a label key used for the name of a deployment
 	DeploymentName = "deployment"
+	// ServerGroup is a label key used for the role of a deployment member
+	ServerGroup = "group"
+	// MemberID is a label key used for the ID of a deployment member
+	MemberID = "member"
 	// Result is a label key used for the result of an action (Success|Failed)
 	Result = "result"
 	// Success is a label value used for successful actions
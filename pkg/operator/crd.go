@@ -40,8 +40,10 @@ func (o *Operator) waitForCRD(enableDeployment, enableDeploymentReplication, ena
 		if enableDeployment {
 			log.Debug().Msg("Waiting for ArangoDeployment CRD to be ready")
 			if err := crd.WaitReady(func() error {
-				_, err := o.CRCli.DatabaseV1().ArangoDeployments(o.Namespace).List(meta.ListOptions{})
-				return err
+				return o.forEachNamespace(func(namespace string) error {
+					_, err := o.CRCli.DatabaseV1().ArangoDeployments(namespace).List(meta.ListOptions{})
+					return err
+				})
 			}); err != nil {
 				return maskAny(err)
 			}
@@ -50,8 +52,10 @@ func (o *Operator) waitForCRD(enableDeployment, enableDeploymentReplication, ena
 		if enableDeploymentReplication {
 			log.Debug().Msg("Waiting for ArangoDeploymentReplication CRD to be ready")
 			if err := crd.WaitReady(func() error {
-				_, err := o.CRCli.ReplicationV1().ArangoDeploymentReplications(o.Namespace).List(meta.ListOptions{})
-				return err
+				return o.forEachNamespace(func(namespace string) error {
+					_, err := o.CRCli.ReplicationV1().ArangoDeploymentReplications(namespace).List(meta.ListOptions{})
+					return err
+				})
 			}); err != nil {
 				return maskAny(err)
 			}
@@ -60,8 +64,10 @@ func (o *Operator) waitForCRD(enableDeployment, enableDeploymentReplication, ena
 		if enableBackup {
 			log.Debug().Msg("Wait for ArangoBackup CRD to be ready")
 			if err := crd.WaitReady(func() error {
-				_, err := o.CRCli.BackupV1().ArangoBackups(o.Namespace).List(meta.ListOptions{})
-				return err
+				return o.forEachNamespace(func(namespace string) error {
+					_, err := o.CRCli.BackupV1().ArangoBackups(namespace).List(meta.ListOptions{})
+					return err
+				})
 			}); err != nil {
 				return maskAny(err)
 			}
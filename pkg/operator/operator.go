@@ -23,29 +23,26 @@
 package operator
 
 import (
-	"context"
 	"math/rand"
+	"sync"
 	"time"
 
 	"github.com/arangodb/kube-arangodb/pkg/operator/scope"
+	"github.com/arangodb/kube-arangodb/pkg/operator/sharding"
+	"github.com/arangodb/kube-arangodb/pkg/util/trigger"
 
 	monitoringClient "github.com/coreos/prometheus-operator/pkg/client/versioned/typed/monitoring/v1"
 
 	"github.com/arangodb/kube-arangodb/pkg/backup/operator/event"
 	"github.com/arangodb/kube-arangodb/pkg/util/constants"
 
-	"github.com/prometheus/client_golang/prometheus"
-
 	"github.com/rs/zerolog"
 	"github.com/rs/zerolog/log"
 	apiextensionsclient "k8s.io/apiextensions-apiserver/pkg/client/clientset/clientset"
-	kwatch "k8s.io/apimachinery/pkg/watch"
+	"k8s.io/client-go/dynamic"
 	"k8s.io/client-go/kubernetes"
 	"k8s.io/client-go/tools/record"
 
-	deplapi "github.com/arangodb/kube-arangodb/pkg/apis/deployment/v1"
-	replapi "github.com/arangodb/kube-arangodb/pkg/apis/replication/v1"
-	lsapi "github.com/arangodb/kube-arangodb/pkg/apis/storage/v1alpha"
 	"github.com/arangodb/kube-arangodb/pkg/backup/handlers/arango/backup"
 	"github.com/arangodb/kube-arangodb/pkg/backup/handlers/arango/policy"
 	backupOper "github.com/arangodb/kube-arangodb/pkg/backup/operator"
@@ -65,21 +62,21 @@ const (
 	initRetryWaitTime = 30 * time.Second
 )
 
-type Event struct {
-	Type                  kwatch.EventType
-	Deployment            *deplapi.ArangoDeployment
-	DeploymentReplication *replapi.ArangoDeploymentReplication
-	LocalStorage          *lsapi.ArangoLocalStorage
-}
-
 type Operator struct {
 	Config
 	Dependencies
 
-	log                    zerolog.Logger
-	deployments            map[string]*deployment.Deployment
-	deploymentReplications map[string]*replication.DeploymentReplication
-	localStorages          map[string]*storage.LocalStorage
+	log                        zerolog.Logger
+	namespaces                 *namespaceSet
+	deploymentsLock            sync.Mutex
+	deployments                map[string]*deployment.Deployment
+	deploymentReplicationsLock sync.Mutex
+	deploymentReplications     map[string]*replication.DeploymentReplication
+	localStoragesLock          sync.Mutex
+	localStorages              map[string]*storage.LocalStorage
+
+	sharding       *sharding.Coordinator
+	shardingResync trigger.Trigger
 }
 
 type Config struct {
@@ -97,7 +94,11 @@ type Config struct {
 	EnableBackup                bool
 	AllowChaos                  bool
 	SingleMode                  bool
+	Sharded                     bool
+	Shards                      int
 	Scope                       scope.Scope
+	Namespaces                  []string
+	NamespaceSelector           string
 }
 
 type Dependencies struct {
@@ -105,6 +106,7 @@ type Dependencies struct {
 	KubeCli                    kubernetes.Interface
 	KubeExtCli                 apiextensionsclient.Interface
 	KubeMonitoringCli          monitoringClient.MonitoringV1Interface
+	KubeDynamicCli             dynamic.Interface
 	CRCli                      versioned.Interface
 	EventRecorder              record.EventRecorder
 	LivenessProbe              *probe.LivenessProbe
@@ -124,13 +126,22 @@ func NewOperator(config Config, deps Dependencies) (*Operator, error) {
 		deploymentReplications: make(map[string]*replication.DeploymentReplication),
 		localStorages:          make(map[string]*storage.LocalStorage),
 	}
+
+	namespaces, err := o.resolveNamespaces()
+	if err != nil {
+		return nil, maskAny(err)
+	}
+	o.namespaces = newNamespaceSet(namespaces)
+
 	return o, nil
 }
 
-// Run the operator
-func (o *Operator) Run() {
+// Run the operator until the given channel is closed
+func (o *Operator) Run(stop <-chan struct{}) {
 	if o.Config.EnableDeployment {
-		if !o.Config.SingleMode {
+		if o.Config.Sharded {
+			go o.runWithSharding("arango-deployment-operator", constants.LabelRole, o.onStartDeployment, o.Dependencies.DeploymentProbe)
+		} else if !o.Config.SingleMode {
 			go o.runLeaderElection("arango-deployment-operator", constants.LabelRole, o.onStartDeployment, o.Dependencies.DeploymentProbe)
 		} else {
 			go o.runWithoutLeaderElection("arango-deployment-operator", constants.LabelRole, o.onStartDeployment, o.Dependencies.DeploymentProbe)
@@ -157,8 +168,9 @@ func (o *Operator) Run() {
 			go o.runWithoutLeaderElection("arango-backup-operator", constants.BackupLabelRole, o.onStartBackup, o.Dependencies.BackupProbe)
 		}
 	}
+	go o.watchNamespaces(stop)
 	// Wait until process terminates
-	<-context.TODO().Done()
+	<-stop
 }
 
 // onStartDeployment starts the deployment operator and run till given channel is closed.
@@ -215,7 +227,6 @@ func (o *Operator) onStartBackup(stop <-chan struct{}) {
 		}
 	}
 	operatorName := "arangodb-backup-operator"
-	operator := backupOper.NewOperator(operatorName, o.Namespace)
 
 	rand.Seed(time.Now().Unix())
 
@@ -238,24 +249,38 @@ func (o *Operator) onStartBackup(stop <-chan struct{}) {
 
 	eventRecorder := event.NewEventRecorder(operatorName, kubeClientSet)
 
-	arangoInformer := arangoInformer.NewSharedInformerFactoryWithOptions(arangoClientSet, 10*time.Second, arangoInformer.WithNamespace(o.Namespace))
-
-	if err = backup.RegisterInformer(operator, eventRecorder, arangoClientSet, kubeClientSet, arangoInformer); err != nil {
-		panic(err)
-	}
+	o.runControllerWith(operatorName, arangoClientSet, 10*time.Second, 8, o.namespaces, o.Dependencies.BackupProbe, stop,
+		&backupController{
+			recorder:   eventRecorder,
+			client:     arangoClientSet,
+			kubeClient: kubeClientSet,
+			namespaces: o.namespaces,
+		})
+}
 
-	if err = policy.RegisterInformer(operator, eventRecorder, arangoClientSet, kubeClientSet, arangoInformer); err != nil {
-		panic(err)
-	}
+// backupController handles ArangoBackups and ArangoBackupPolicies of the watched namespaces
+type backupController struct {
+	recorder   event.Recorder
+	client     arangoClientSet.Interface
+	kubeClient kubernetes.Interface
+	namespaces *namespaceSet
+}
 
-	if err = operator.RegisterStarter(arangoInformer); err != nil {
-		panic(err)
+func (c *backupController) Register(operator backupOper.Operator) error {
+	if err := backup.RegisterHandler(operator, c.recorder, c.client, c.kubeClient, c.namespaces.Get); err != nil {
+		return err
 	}
 
-	prometheus.MustRegister(operator)
+	return policy.RegisterHandler(operator, c.recorder, c.client, c.kubeClient)
+}
 
-	operator.Start(8, stop)
-	o.Dependencies.BackupProbe.SetReady()
+func (c *backupController) AddNamespace(operator backupOper.Operator, _ string, informers arangoInformer.SharedInformerFactory) error {
+	if err := backup.RegisterNamespaceInformer(operator, informers); err != nil {
+		return err
+	}
 
-	<-stop
+	return policy.RegisterNamespaceInformer(operator, informers)
 }
+
+// RemoveNamespace does nothing, backups keep no state in the operator.
+func (c *backupController) RemoveNamespace(string) {}
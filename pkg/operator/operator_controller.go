@@ -0,0 +1,278 @@
+//
+// DISCLAIMER
+//
+// Copyright 2020 ArangoDB GmbH, Cologne, Germany
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// Copyright holder is ArangoDB GmbH, Cologne, Germany
+//
+
+package operator
+
+import (
+	"sync"
+	"time"
+
+	backupOper "github.com/arangodb/kube-arangodb/pkg/backup/operator"
+	"github.com/arangodb/kube-arangodb/pkg/backup/operator/operation"
+	"github.com/arangodb/kube-arangodb/pkg/generated/clientset/versioned"
+	arangoInformer "github.com/arangodb/kube-arangodb/pkg/generated/informers/externalversions"
+	"github.com/arangodb/kube-arangodb/pkg/util/probe"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/rs/zerolog"
+	core "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+)
+
+const (
+	// controllerThreadiness is the number of workers per controller.
+	// A single worker handles all objects of a controller, so an object is never handled by two workers at once
+	// and lookup and creation of its state do not race.
+	controllerThreadiness = 1
+)
+
+// namespaceKind is the kind of the items which start or stop the informers of a namespace
+const namespaceKind = "Namespace"
+
+// controller registers the handlers of a controller and the informers of its namespaces.
+type controller interface {
+	// Register registers handlers and starters of the controller.
+	Register(operator backupOper.Operator) error
+	// AddNamespace registers informers of a namespace which is watched from now on.
+	AddNamespace(operator backupOper.Operator, namespace string, informers arangoInformer.SharedInformerFactory) error
+	// RemoveNamespace releases the objects of a namespace which is not watched anymore. Its informers are stopped already.
+	RemoveNamespace(namespace string)
+}
+
+// runController runs a controller with the default client, resync period and threadiness.
+func (o *Operator) runController(name string, namespaces *namespaceSet, readyProbe *probe.ReadyProbe, stop <-chan struct{}, c controller) {
+	o.runControllerWith(name, o.Dependencies.CRCli, 0, controllerThreadiness, namespaces, readyProbe, stop, c)
+}
+
+// runControllerWith runs a controller and waits until the process stops.
+// Informers of the watched namespaces are started and stopped when the set of watched namespaces changes.
+// An empty namespace watches cluster scoped objects or all namespaces.
+func (o *Operator) runControllerWith(name string, client versioned.Interface, resync time.Duration, threadiness int,
+	namespaces *namespaceSet, readyProbe *probe.ReadyProbe, stop <-chan struct{}, c controller) {
+	log := o.log.With().Str("controller", name).Logger()
+
+	changed, unsubscribe := namespaces.Subscribe()
+	defer unsubscribe()
+
+	watched := namespaces.Get()
+
+	namespace := o.Config.Namespace
+	if len(watched) > 0 {
+		namespace = watched[0]
+	}
+	operator := backupOper.NewOperator(name, namespace)
+
+	handler := &controllerNamespaces{
+		log:        log,
+		operator:   operator,
+		controller: c,
+		client:     client,
+		resync:     resync,
+		namespaces: namespaces,
+		running:    map[string]chan struct{}{},
+		stop:       stop,
+	}
+
+	if err := c.Register(operator); err != nil {
+		log.Fatal().Err(err).Msg("Failed to register controller handlers")
+	}
+
+	if err := operator.RegisterHandler(handler); err != nil {
+		log.Fatal().Err(err).Msg("Failed to register controller handlers")
+	}
+
+	for _, namespace := range watched {
+		if err := handler.add(namespace); err != nil {
+			log.Fatal().Err(err).Msg("Failed to register controller informers")
+		}
+	}
+
+	prometheus.MustRegister(operator)
+
+	if err := operator.Start(threadiness, stop); err != nil {
+		log.Fatal().Err(err).Msg("Failed to start controller")
+	}
+
+	readyProbe.SetReady()
+
+	for {
+		select {
+		case <-stop:
+			return
+		case <-changed:
+			handler.enqueue()
+		}
+	}
+}
+
+// controllerNamespaces starts and stops the informers of the watched namespaces.
+// Changes are handled on the work queue, so a namespace is not removed while the controller handles one of its objects.
+type controllerNamespaces struct {
+	log zerolog.Logger
+
+	operator   backupOper.Operator
+	controller controller
+
+	client versioned.Interface
+	resync time.Duration
+
+	namespaces *namespaceSet
+
+	lock    sync.Mutex
+	running map[string]chan struct{}
+	stop    <-chan struct{}
+}
+
+func (c *controllerNamespaces) Name() string {
+	return namespaceKind
+}
+
+func (c *controllerNamespaces) CanBeHandled(item operation.Item) bool {
+	return item.Group == core.SchemeGroupVersion.Group &&
+		item.Version == core.SchemeGroupVersion.Version &&
+		item.Kind == namespaceKind
+}
+
+// Handle starts informers of a namespace which is watched and stops informers of a namespace which is not watched anymore.
+func (c *controllerNamespaces) Handle(item operation.Item) error {
+	watched := false
+	for _, namespace := range c.namespaces.Get() {
+		if namespace == item.Name {
+			watched = true
+		}
+	}
+
+	c.lock.Lock()
+	_, running := c.running[item.Name]
+	c.lock.Unlock()
+
+	if watched && !running {
+		return c.add(item.Name)
+	}
+
+	if !watched && running {
+		c.remove(item.Name)
+	}
+
+	return nil
+}
+
+// enqueue enqueues the watched namespaces and the namespaces with running informers.
+func (c *controllerNamespaces) enqueue() {
+	namespaces := c.namespaces.Get()
+
+	c.lock.Lock()
+	for namespace := range c.running {
+		namespaces = append(namespaces, namespace)
+	}
+	c.lock.Unlock()
+
+	for _, namespace := range namespaces {
+		item, err := operation.NewItem(operation.Update, core.SchemeGroupVersion.Group, core.SchemeGroupVersion.Version,
+			namespaceKind, "", namespace)
+		if err != nil {
+			continue
+		}
+
+		c.operator.EnqueueItem(item)
+	}
+}
+
+// add registers and starts informers of the given namespace and waits until their caches are synced.
+func (c *controllerNamespaces) add(namespace string) error {
+	var options []arangoInformer.SharedInformerOption
+	if namespace != "" {
+		options = append(options, arangoInformer.WithNamespace(namespace))
+	}
+	informers := arangoInformer.NewSharedInformerFactoryWithOptions(c.client, c.resync, options...)
+
+	if err := c.controller.AddNamespace(c.operator, namespace, informers); err != nil {
+		return maskAny(err)
+	}
+
+	removed := make(chan struct{})
+	informersStop := make(chan struct{})
+	go func() {
+		defer close(informersStop)
+
+		select {
+		case <-c.stop:
+		case <-removed:
+		}
+	}()
+
+	informers.Start(informersStop)
+	informers.WaitForCacheSync(informersStop)
+
+	c.lock.Lock()
+	c.running[namespace] = removed
+	c.lock.Unlock()
+
+	c.log.Info().Str("namespace", namespace).Msg("Started informers of namespace")
+	return nil
+}
+
+// remove stops informers of the given namespace and releases its objects.
+func (c *controllerNamespaces) remove(namespace string) {
+	c.lock.Lock()
+	removed, ok := c.running[namespace]
+	delete(c.running, namespace)
+	c.lock.Unlock()
+
+	if !ok {
+		return
+	}
+
+	close(removed)
+	c.controller.RemoveNamespace(namespace)
+
+	c.log.Info().Str("namespace", namespace).Msg("Stopped informers of namespace")
+}
+
+// newControllerHandler creates a handler for objects of given kind.
+func (o *Operator) newControllerHandler(gv schema.GroupVersion, kind string, handle func(item operation.Item) error) backupOper.Handler {
+	return &controllerHandler{
+		gv:     gv,
+		kind:   kind,
+		handle: handle,
+	}
+}
+
+type controllerHandler struct {
+	gv   schema.GroupVersion
+	kind string
+
+	handle func(item operation.Item) error
+}
+
+func (c *controllerHandler) Name() string {
+	return c.kind
+}
+
+// Handle handles the given item. The liveness probe is not locked here, handlers can call slow endpoints.
+// Handlers lock it only around the access to the state shared with other goroutines.
+func (c *controllerHandler) Handle(item operation.Item) error {
+	return c.handle(item)
+}
+
+func (c *controllerHandler) CanBeHandled(item operation.Item) bool {
+	return item.Group == c.gv.Group &&
+		item.Version == c.gv.Version &&
+		item.Kind == c.kind
+}
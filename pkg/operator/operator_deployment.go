@@ -24,17 +24,20 @@ package operator
 
 import (
 	"fmt"
+	"sync"
+	"time"
 
 	deploymentType "github.com/arangodb/kube-arangodb/pkg/apis/deployment"
 
-	"github.com/pkg/errors"
-	kwatch "k8s.io/apimachinery/pkg/watch"
-	"k8s.io/client-go/tools/cache"
-
 	api "github.com/arangodb/kube-arangodb/pkg/apis/deployment/v1"
+	backupOper "github.com/arangodb/kube-arangodb/pkg/backup/operator"
+	"github.com/arangodb/kube-arangodb/pkg/backup/operator/operation"
 	"github.com/arangodb/kube-arangodb/pkg/deployment"
+	arangoInformer "github.com/arangodb/kube-arangodb/pkg/generated/informers/externalversions"
+	databaseLister "github.com/arangodb/kube-arangodb/pkg/generated/listers/deployment/v1"
 	"github.com/arangodb/kube-arangodb/pkg/metrics"
 	"github.com/arangodb/kube-arangodb/pkg/util/k8sutil"
+	"k8s.io/apimachinery/pkg/labels"
 )
 
 var (
@@ -46,159 +49,245 @@ var (
 )
 
 // run the deployments part of the operator.
-// This registers a listener and waits until the process stops.
+// This registers a controller and waits until the process stops.
+// The handler only creates, updates or stops a Deployment, which keeps running its own inspection loop.
+// That loop reacts on changes of pods, PVC's and services of the deployment, so it is not moved to the work queue.
 func (o *Operator) runDeployments(stop <-chan struct{}) {
-	rw := k8sutil.NewResourceWatcher(
-		o.log,
-		o.Dependencies.CRCli.DatabaseV1().RESTClient(),
-		deploymentType.ArangoDeploymentResourcePlural,
-		o.Config.Namespace,
-		&api.ArangoDeployment{},
-		cache.ResourceEventHandlerFuncs{
-			AddFunc:    o.onAddArangoDeployment,
-			UpdateFunc: o.onUpdateArangoDeployment,
-			DeleteFunc: o.onDeleteArangoDeployment,
+	o.runController("arangodb-deployment-operator", o.namespaces, o.Dependencies.DeploymentProbe, stop,
+		&deploymentController{
+			operator: o,
+			listers:  map[string]databaseLister.ArangoDeploymentNamespaceLister{},
 		})
+}
+
+// deploymentController handles ArangoDeployments of the watched namespaces
+type deploymentController struct {
+	operator *Operator
 
-	o.Dependencies.DeploymentProbe.SetReady()
-	rw.Run(stop)
+	lock    sync.Mutex
+	listers map[string]databaseLister.ArangoDeploymentNamespaceLister
 }
 
-// onAddArangoDeployment deployment addition callback
-func (o *Operator) onAddArangoDeployment(obj interface{}) {
-	o.Dependencies.LivenessProbe.Lock()
-	defer o.Dependencies.LivenessProbe.Unlock()
+func (c *deploymentController) Register(operator backupOper.Operator) error {
+	o := c.operator
+
+	if o.sharding != nil {
+		if err := operator.RegisterStarter(&shardingResyncStarter{
+			trigger: &o.shardingResync,
+			resync: func() {
+				c.lock.Lock()
+				defer c.lock.Unlock()
+
+				for _, lister := range c.listers {
+					enqueueArangoDeployments(operator, lister)
+				}
+			},
+		}); err != nil {
+			return err
+		}
+	}
+
+	return operator.RegisterHandler(o.newControllerHandler(api.SchemeGroupVersion, deploymentType.ArangoDeploymentResourceKind,
+		func(item operation.Item) error {
+			lister, ok := c.lister(item.Namespace)
+			if !ok {
+				return nil
+			}
 
-	apiObject := obj.(*api.ArangoDeployment)
-	o.log.Debug().
-		Str("name", apiObject.GetObjectMeta().GetName()).
-		Msg("ArangoDeployment added")
-	o.syncArangoDeployment(apiObject)
+			apiObject, err := lister.Get(item.Name)
+			if err != nil {
+				if k8sutil.IsNotFound(err) {
+					o.deleteArangoDeployment(item.Namespace, item.Name)
+					return nil
+				}
+				return maskAny(err)
+			}
+
+			if !o.ownsObject(apiObject.GetUID()) {
+				// Deployment is handled by another replica
+				o.stopArangoDeployment(objectKey(item.Namespace, item.Name))
+				return nil
+			}
+
+			return o.syncArangoDeployment(apiObject.DeepCopy())
+		}))
 }
 
-// onUpdateArangoDeployment deployment update callback
-func (o *Operator) onUpdateArangoDeployment(oldObj, newObj interface{}) {
-	o.Dependencies.LivenessProbe.Lock()
-	defer o.Dependencies.LivenessProbe.Unlock()
+func (c *deploymentController) AddNamespace(operator backupOper.Operator, namespace string, informers arangoInformer.SharedInformerFactory) error {
+	deployments := informers.Database().V1().ArangoDeployments()
+
+	if err := operator.RegisterInformer(deployments.Informer(),
+		api.SchemeGroupVersion.Group,
+		api.SchemeGroupVersion.Version,
+		deploymentType.ArangoDeploymentResourceKind); err != nil {
+		return err
+	}
+
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	c.listers[namespace] = deployments.Lister().ArangoDeployments(namespace)
+	return nil
+}
+
+// RemoveNamespace stops the deployments of the namespace without touching their resources,
+// the namespace can be watched by another operator.
+func (c *deploymentController) RemoveNamespace(namespace string) {
+	c.lock.Lock()
+	delete(c.listers, namespace)
+	c.lock.Unlock()
+
+	c.operator.stopArangoDeployments(namespace)
+}
+
+func (c *deploymentController) lister(namespace string) (databaseLister.ArangoDeploymentNamespaceLister, bool) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
 
-	apiObject := newObj.(*api.ArangoDeployment)
-	o.log.Debug().
-		Str("name", apiObject.GetObjectMeta().GetName()).
-		Msg("ArangoDeployment updated")
-	o.syncArangoDeployment(apiObject)
+	lister, ok := c.listers[namespace]
+	return lister, ok
 }
 
-// onDeleteArangoDeployment deployment delete callback
-func (o *Operator) onDeleteArangoDeployment(obj interface{}) {
+// syncArangoDeployment creates or updates the given deployment.
+func (o *Operator) syncArangoDeployment(apiObject *api.ArangoDeployment) error {
 	o.Dependencies.LivenessProbe.Lock()
 	defer o.Dependencies.LivenessProbe.Unlock()
+	o.deploymentsLock.Lock()
+	defer o.deploymentsLock.Unlock()
 
-	log := o.log
-	apiObject, ok := obj.(*api.ArangoDeployment)
-	if !ok {
-		tombstone, ok := obj.(cache.DeletedFinalStateUnknown)
-		if !ok {
-			log.Error().Interface("event-object", obj).Msg("unknown object from ArangoDeployment delete event")
-			return
-		}
-		apiObject, ok = tombstone.Obj.(*api.ArangoDeployment)
-		if !ok {
-			log.Error().Interface("event-object", obj).Msg("Tombstone contained object that is not an ArangoDeployment")
-			return
-		}
+	log := o.log.With().Str("namespace", apiObject.GetNamespace()).Str("name", apiObject.GetName()).Logger()
+	key := objectKey(apiObject.GetNamespace(), apiObject.GetName())
+
+	if apiObject.Status.Phase.IsFailed() {
+		deploymentsFailed.Inc()
+		log.Warn().Msg("Ignore failed deployment. Please delete its CR")
+		return nil
 	}
-	log.Debug().
-		Str("name", apiObject.GetObjectMeta().GetName()).
-		Msg("ArangoDeployment deleted")
-	ev := &Event{
-		Type:       kwatch.Deleted,
-		Deployment: apiObject,
+
+	if depl, ok := o.deployments[key]; ok {
+		log.Debug().Msg("ArangoDeployment updated")
+		depl.Update(apiObject)
+		deploymentsModified.Inc()
+		return nil
 	}
 
-	//	pt.start()
-	err := o.handleDeploymentEvent(ev)
+	log.Debug().Msg("ArangoDeployment added")
+
+	// Fill in defaults
+	apiObject.Spec.SetDefaults(apiObject.GetName())
+	// Validate deployment spec
+	if err := apiObject.Spec.Validate(); err != nil {
+		log.Warn().Err(err).Msg("Invalid deployment spec. Please fix the following problem with the deployment spec")
+		return nil
+	}
+
+	cfg, deps := o.makeDeploymentConfigAndDeps(apiObject)
+	nc, err := deployment.New(cfg, deps, apiObject)
 	if err != nil {
-		log.Warn().Err(err).Msg("Failed to handle event")
+		return maskAny(fmt.Errorf("failed to create deployment: %s", err))
 	}
-	//pt.stop()
+	o.deployments[key] = nc
+
+	deploymentsCreated.Inc()
+	deploymentsCurrent.Set(float64(len(o.deployments)))
+
+	return nil
 }
 
-// syncArangoDeployment synchronized the given deployment.
-func (o *Operator) syncArangoDeployment(apiObject *api.ArangoDeployment) {
-	ev := &Event{
-		Type:       kwatch.Added,
-		Deployment: apiObject,
-	}
-	// re-watch or restart could give ADD event.
-	// If for an ADD event the cluster spec is invalid then it is not added to the local cache
-	// so modifying that deployment will result in another ADD event
-	if _, ok := o.deployments[apiObject.Name]; ok {
-		ev.Type = kwatch.Modified
-	}
+// deleteArangoDeployment stops the deployment with given namespace and name.
+// Finalizers are removed from resources of the deployment.
+func (o *Operator) deleteArangoDeployment(namespace, name string) {
+	o.Dependencies.LivenessProbe.Lock()
+	defer o.Dependencies.LivenessProbe.Unlock()
+	o.deploymentsLock.Lock()
+	defer o.deploymentsLock.Unlock()
 
-	//pt.start()
-	err := o.handleDeploymentEvent(ev)
-	if err != nil {
-		o.log.Warn().Err(err).Msg("Failed to handle event")
+	key := objectKey(namespace, name)
+	depl, ok := o.deployments[key]
+	if !ok {
+		return
 	}
-	//pt.stop()
+
+	depl.Delete()
+	delete(o.deployments, key)
+	deploymentsCurrent.Set(float64(len(o.deployments)))
+
+	o.log.Debug().Str("namespace", namespace).Str("name", name).Msg("ArangoDeployment deleted")
+	deploymentsDeleted.Inc()
 }
 
-// handleDeploymentEvent processed the given event.
-func (o *Operator) handleDeploymentEvent(event *Event) error {
-	apiObject := event.Deployment
+// stopArangoDeployment stops the deployment with given key without touching its resources,
+// so it can be handed over to another replica.
+func (o *Operator) stopArangoDeployment(key string) {
+	o.Dependencies.LivenessProbe.Lock()
+	defer o.Dependencies.LivenessProbe.Unlock()
+	o.deploymentsLock.Lock()
+	defer o.deploymentsLock.Unlock()
 
-	if apiObject.Status.Phase.IsFailed() {
-		deploymentsFailed.Inc()
-		if event.Type == kwatch.Deleted {
-			delete(o.deployments, apiObject.Name)
-			return nil
+	o.handOverArangoDeployment(key)
+}
+
+// stopArangoDeployments stops all deployments of the given namespace without touching their resources.
+func (o *Operator) stopArangoDeployments(namespace string) {
+	o.Dependencies.LivenessProbe.Lock()
+	defer o.Dependencies.LivenessProbe.Unlock()
+	o.deploymentsLock.Lock()
+	defer o.deploymentsLock.Unlock()
+
+	for key, depl := range o.deployments {
+		if depl.GetNamespace() == namespace {
+			o.handOverArangoDeployment(key)
 		}
-		return maskAny(fmt.Errorf("ignore failed deployment (%s). Please delete its CR", apiObject.Name))
 	}
+}
 
-	switch event.Type {
-	case kwatch.Added:
-		if _, ok := o.deployments[apiObject.Name]; ok {
-			return maskAny(fmt.Errorf("unsafe state. deployment (%s) was created before but we received event (%s)", apiObject.Name, event.Type))
-		}
+// handOverArangoDeployment signals the deployment with given key to stop and removes it.
+// It does not wait for the running inspection. Requires deploymentsLock to be held.
+func (o *Operator) handOverArangoDeployment(key string) {
+	depl, ok := o.deployments[key]
+	if !ok {
+		return
+	}
 
-		// Fill in defaults
-		apiObject.Spec.SetDefaults(apiObject.GetName())
-		// Validate deployment spec
-		if err := apiObject.Spec.Validate(); err != nil {
-			return maskAny(errors.Wrapf(err, "invalid deployment spec. please fix the following problem with the deployment spec: %v", err))
-		}
+	depl.Stop()
+	delete(o.deployments, key)
+	deploymentsCurrent.Set(float64(len(o.deployments)))
 
-		cfg, deps := o.makeDeploymentConfigAndDeps(apiObject)
-		nc, err := deployment.New(cfg, deps, apiObject)
-		if err != nil {
-			return maskAny(fmt.Errorf("failed to create deployment: %s", err))
-		}
-		o.deployments[apiObject.Name] = nc
+	go o.waitForHandOver(key, depl)
+}
 
-		deploymentsCreated.Inc()
-		deploymentsCurrent.Set(float64(len(o.deployments)))
+// waitForHandOver waits until the running inspection of the stopped deployment is finished.
+// Another replica can take the deployment over once the shard lease expired, so a longer inspection is reported.
+func (o *Operator) waitForHandOver(key string, depl *deployment.Deployment) {
+	log := o.log.With().Str("deployment", key).Logger()
 
-	case kwatch.Modified:
-		depl, ok := o.deployments[apiObject.Name]
-		if !ok {
-			return maskAny(fmt.Errorf("unsafe state. deployment (%s) was never created but we received event (%s)", apiObject.Name, event.Type))
-		}
-		depl.Update(apiObject)
-		deploymentsModified.Inc()
+	select {
+	case <-depl.Done():
+		log.Info().Msg("ArangoDeployment handed over")
+	case <-time.After(shardingLeaseDuration):
+		log.Warn().Msg("Inspection of handed over ArangoDeployment is still running after the shard lease expired")
+	}
+}
 
-	case kwatch.Deleted:
-		depl, ok := o.deployments[apiObject.Name]
-		if !ok {
-			return maskAny(fmt.Errorf("unsafe state. deployment (%s) was never created but we received event (%s)", apiObject.Name, event.Type))
+// enqueueArangoDeployments enqueues all deployments known by the lister.
+func enqueueArangoDeployments(operator backupOper.Operator, lister databaseLister.ArangoDeploymentNamespaceLister) {
+	deployments, err := lister.List(labels.Everything())
+	if err != nil {
+		return
+	}
+
+	for _, deployment := range deployments {
+		item, err := operation.NewItemFromObject(operation.Update,
+			api.SchemeGroupVersion.Group,
+			api.SchemeGroupVersion.Version,
+			deploymentType.ArangoDeploymentResourceKind,
+			deployment)
+		if err != nil {
+			continue
 		}
-		depl.Delete()
-		delete(o.deployments, apiObject.Name)
-		deploymentsDeleted.Inc()
-		deploymentsCurrent.Set(float64(len(o.deployments)))
+
+		operator.EnqueueItem(item)
 	}
-	return nil
 }
 
 // makeDeploymentConfigAndDeps creates a Config & Dependencies object for a new Deployment.
@@ -219,6 +308,7 @@ func (o *Operator) makeDeploymentConfigAndDeps(apiObject *api.ArangoDeployment)
 		KubeCli:           o.Dependencies.KubeCli,
 		KubeMonitoringCli: o.Dependencies.KubeMonitoringCli,
 		KubeExtCli:        o.Dependencies.KubeExtCli,
+		KubeDynamicCli:    o.Dependencies.KubeDynamicCli,
 		DatabaseCRCli:     o.Dependencies.CRCli,
 		EventRecorder:     o.Dependencies.EventRecorder,
 	}
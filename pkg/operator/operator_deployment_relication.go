@@ -24,14 +24,16 @@ package operator
 
 import (
 	"fmt"
+	"sync"
+	"time"
 
 	replication2 "github.com/arangodb/kube-arangodb/pkg/apis/replication"
 
-	"github.com/pkg/errors"
-	kwatch "k8s.io/apimachinery/pkg/watch"
-	"k8s.io/client-go/tools/cache"
-
 	api "github.com/arangodb/kube-arangodb/pkg/apis/replication/v1"
+	backupOper "github.com/arangodb/kube-arangodb/pkg/backup/operator"
+	"github.com/arangodb/kube-arangodb/pkg/backup/operator/operation"
+	arangoInformer "github.com/arangodb/kube-arangodb/pkg/generated/informers/externalversions"
+	replicationLister "github.com/arangodb/kube-arangodb/pkg/generated/listers/replication/v1"
 	"github.com/arangodb/kube-arangodb/pkg/metrics"
 	"github.com/arangodb/kube-arangodb/pkg/replication"
 	"github.com/arangodb/kube-arangodb/pkg/util/k8sutil"
@@ -46,165 +48,197 @@ var (
 )
 
 // run the deployment replications part of the operator.
-// This registers a listener and waits until the process stops.
+// This registers a controller and waits until the process stops.
 func (o *Operator) runDeploymentReplications(stop <-chan struct{}) {
-	rw := k8sutil.NewResourceWatcher(
-		o.log,
-		o.Dependencies.CRCli.ReplicationV1().RESTClient(),
-		replication2.ArangoDeploymentReplicationResourcePlural,
-		o.Config.Namespace,
-		&api.ArangoDeploymentReplication{},
-		cache.ResourceEventHandlerFuncs{
-			AddFunc:    o.onAddArangoDeploymentReplication,
-			UpdateFunc: o.onUpdateArangoDeploymentReplication,
-			DeleteFunc: o.onDeleteArangoDeploymentReplication,
+	o.runController("arangodb-deployment-replication-operator", o.namespaces, o.Dependencies.DeploymentReplicationProbe, stop,
+		&deploymentReplicationController{
+			operator: o,
+			listers:  map[string]replicationLister.ArangoDeploymentReplicationNamespaceLister{},
 		})
+}
 
-	o.Dependencies.DeploymentReplicationProbe.SetReady()
-	rw.Run(stop)
+// deploymentReplicationController handles ArangoDeploymentReplications of the watched namespaces
+type deploymentReplicationController struct {
+	operator *Operator
+
+	lock    sync.Mutex
+	listers map[string]replicationLister.ArangoDeploymentReplicationNamespaceLister
 }
 
-// onAddArangoDeploymentReplication deployment replication addition callback
-func (o *Operator) onAddArangoDeploymentReplication(obj interface{}) {
-	o.Dependencies.LivenessProbe.Lock()
-	defer o.Dependencies.LivenessProbe.Unlock()
+func (c *deploymentReplicationController) Register(operator backupOper.Operator) error {
+	o := c.operator
+
+	return operator.RegisterHandler(o.newControllerHandler(api.SchemeGroupVersion, replication2.ArangoDeploymentReplicationResourceKind,
+		func(item operation.Item) error {
+			lister, ok := c.lister(item.Namespace)
+			if !ok {
+				return nil
+			}
+
+			apiObject, err := lister.Get(item.Name)
+			if err != nil {
+				if k8sutil.IsNotFound(err) {
+					o.deleteArangoDeploymentReplication(item.Namespace, item.Name)
+					return nil
+				}
+				return maskAny(err)
+			}
+
+			delay, err := o.syncArangoDeploymentReplication(apiObject.DeepCopy())
+			if err != nil {
+				return maskAny(err)
+			}
+
+			if delay > 0 {
+				// Inspect the deployment replication again after the delay
+				operator.EnqueueItemAfter(item, delay)
+			}
 
-	apiObject := obj.(*api.ArangoDeploymentReplication)
-	o.log.Debug().
-		Str("name", apiObject.GetObjectMeta().GetName()).
-		Msg("ArangoDeploymentReplication added")
-	o.syncArangoDeploymentReplication(apiObject)
+			return nil
+		}))
 }
 
-// onUpdateArangoDeploymentReplication deployment replication update callback
-func (o *Operator) onUpdateArangoDeploymentReplication(oldObj, newObj interface{}) {
-	o.Dependencies.LivenessProbe.Lock()
-	defer o.Dependencies.LivenessProbe.Unlock()
+func (c *deploymentReplicationController) AddNamespace(operator backupOper.Operator, namespace string, informers arangoInformer.SharedInformerFactory) error {
+	replications := informers.Replication().V1().ArangoDeploymentReplications()
 
-	apiObject := newObj.(*api.ArangoDeploymentReplication)
-	o.log.Debug().
-		Str("name", apiObject.GetObjectMeta().GetName()).
-		Msg("ArangoDeploymentReplication updated")
-	o.syncArangoDeploymentReplication(apiObject)
+	if err := operator.RegisterInformer(replications.Informer(),
+		api.SchemeGroupVersion.Group,
+		api.SchemeGroupVersion.Version,
+		replication2.ArangoDeploymentReplicationResourceKind); err != nil {
+		return err
+	}
+
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	c.listers[namespace] = replications.Lister().ArangoDeploymentReplications(namespace)
+	return nil
 }
 
-// onDeleteArangoDeploymentReplication deployment replication delete callback
-func (o *Operator) onDeleteArangoDeploymentReplication(obj interface{}) {
-	o.Dependencies.LivenessProbe.Lock()
-	defer o.Dependencies.LivenessProbe.Unlock()
+// RemoveNamespace releases the deployment replications of the namespace, the namespace can be watched by another operator.
+func (c *deploymentReplicationController) RemoveNamespace(namespace string) {
+	c.lock.Lock()
+	delete(c.listers, namespace)
+	c.lock.Unlock()
 
-	log := o.log
-	apiObject, ok := obj.(*api.ArangoDeploymentReplication)
-	if !ok {
-		tombstone, ok := obj.(cache.DeletedFinalStateUnknown)
-		if !ok {
-			log.Error().Interface("event-object", obj).Msg("unknown object from ArangoDeploymentReplication delete event")
-			return
-		}
-		apiObject, ok = tombstone.Obj.(*api.ArangoDeploymentReplication)
-		if !ok {
-			log.Error().Interface("event-object", obj).Msg("Tombstone contained object that is not an ArangoDeploymentReplication")
-			return
+	c.operator.releaseArangoDeploymentReplications(namespace)
+}
+
+func (c *deploymentReplicationController) lister(namespace string) (replicationLister.ArangoDeploymentReplicationNamespaceLister, bool) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	lister, ok := c.listers[namespace]
+	return lister, ok
+}
+
+// syncArangoDeploymentReplication creates or updates the given deployment replication.
+// Returns the delay after which the deployment replication has to be synced again.
+func (o *Operator) syncArangoDeploymentReplication(apiObject *api.ArangoDeploymentReplication) (time.Duration, error) {
+	log := o.log.With().Str("namespace", apiObject.GetNamespace()).Str("name", apiObject.GetName()).Logger()
+	key := objectKey(apiObject.GetNamespace(), apiObject.GetName())
+
+	if apiObject.Status.Phase.IsFailed() {
+		deploymentReplicationsFailed.Inc()
+		log.Warn().Msg("Ignore failed deployment replication. Please delete its CR")
+		return 0, nil
+	}
+
+	if repl, ok := o.getArangoDeploymentReplication(key); ok {
+		if apiObject.GetResourceVersion() != repl.GetResourceVersion() {
+			log.Debug().Msg("ArangoDeploymentReplication updated")
+			deploymentReplicationsModified.Inc()
 		}
+		return repl.Sync(apiObject)
 	}
-	log.Debug().
-		Str("name", apiObject.GetObjectMeta().GetName()).
-		Msg("ArangoDeploymentReplication deleted")
-	ev := &Event{
-		Type:                  kwatch.Deleted,
-		DeploymentReplication: apiObject,
+
+	log.Debug().Msg("ArangoDeploymentReplication added")
+
+	// Fill in defaults
+	apiObject.Spec.SetDefaults()
+	// Validate deployment spec
+	if err := apiObject.Spec.Validate(); err != nil {
+		log.Warn().Err(err).Msg("Invalid deployment replication spec. Please fix the following problem with the deployment replication spec")
+		return 0, nil
 	}
 
-	//	pt.start()
-	err := o.handleDeploymentReplicationEvent(ev)
+	cfg, deps := o.makeDeploymentReplicationConfigAndDeps(apiObject)
+	nc, err := replication.New(cfg, deps, apiObject)
 	if err != nil {
-		log.Warn().Err(err).Msg("Failed to handle event")
+		return 0, maskAny(fmt.Errorf("failed to create deployment: %s", err))
 	}
-	//pt.stop()
+	o.addArangoDeploymentReplication(key, nc)
+
+	deploymentReplicationsCreated.Inc()
+
+	return nc.Sync(apiObject)
 }
 
-// syncArangoDeploymentReplication synchronized the given deployment replication.
-func (o *Operator) syncArangoDeploymentReplication(apiObject *api.ArangoDeploymentReplication) {
-	ev := &Event{
-		Type:                  kwatch.Added,
-		DeploymentReplication: apiObject,
-	}
-	// re-watch or restart could give ADD event.
-	// If for an ADD event the cluster spec is invalid then it is not added to the local cache
-	// so modifying that deployment will result in another ADD event
-	if _, ok := o.deploymentReplications[apiObject.Name]; ok {
-		ev.Type = kwatch.Modified
-	}
+// getArangoDeploymentReplication returns the deployment replication with given key.
+func (o *Operator) getArangoDeploymentReplication(key string) (*replication.DeploymentReplication, bool) {
+	o.Dependencies.LivenessProbe.Lock()
+	defer o.Dependencies.LivenessProbe.Unlock()
+	o.deploymentReplicationsLock.Lock()
+	defer o.deploymentReplicationsLock.Unlock()
 
-	//pt.start()
-	err := o.handleDeploymentReplicationEvent(ev)
-	if err != nil {
-		o.log.Warn().Err(err).Msg("Failed to handle event")
-	}
-	//pt.stop()
+	repl, ok := o.deploymentReplications[key]
+	return repl, ok
 }
 
-// handleDeploymentReplicationEvent processed the given event.
-func (o *Operator) handleDeploymentReplicationEvent(event *Event) error {
-	apiObject := event.DeploymentReplication
+// addArangoDeploymentReplication adds the deployment replication with given key.
+func (o *Operator) addArangoDeploymentReplication(key string, repl *replication.DeploymentReplication) {
+	o.Dependencies.LivenessProbe.Lock()
+	defer o.Dependencies.LivenessProbe.Unlock()
+	o.deploymentReplicationsLock.Lock()
+	defer o.deploymentReplicationsLock.Unlock()
 
-	if apiObject.Status.Phase.IsFailed() {
-		deploymentReplicationsFailed.Inc()
-		if event.Type == kwatch.Deleted {
-			delete(o.deploymentReplications, apiObject.Name)
-			return nil
-		}
-		return maskAny(fmt.Errorf("ignore failed deployment replication (%s). Please delete its CR", apiObject.Name))
-	}
+	o.deploymentReplications[key] = repl
+	deploymentReplicationsCurrent.Set(float64(len(o.deploymentReplications)))
+}
 
-	switch event.Type {
-	case kwatch.Added:
-		if _, ok := o.deploymentReplications[apiObject.Name]; ok {
-			return maskAny(fmt.Errorf("unsafe state. deployment replication (%s) was created before but we received event (%s)", apiObject.Name, event.Type))
-		}
+// deleteArangoDeploymentReplication stops the deployment replication with given namespace and name.
+func (o *Operator) deleteArangoDeploymentReplication(namespace, name string) {
+	o.Dependencies.LivenessProbe.Lock()
+	defer o.Dependencies.LivenessProbe.Unlock()
+	o.deploymentReplicationsLock.Lock()
+	defer o.deploymentReplicationsLock.Unlock()
 
-		// Fill in defaults
-		apiObject.Spec.SetDefaults()
-		// Validate deployment spec
-		if err := apiObject.Spec.Validate(); err != nil {
-			return maskAny(errors.Wrapf(err, "invalid deployment replication spec. please fix the following problem with the deployment replication spec: %v", err))
-		}
+	key := objectKey(namespace, name)
+	repl, ok := o.deploymentReplications[key]
+	if !ok {
+		return
+	}
 
-		cfg, deps := o.makeDeploymentReplicationConfigAndDeps(apiObject)
-		nc, err := replication.New(cfg, deps, apiObject)
-		if err != nil {
-			return maskAny(fmt.Errorf("failed to create deployment: %s", err))
-		}
-		o.deploymentReplications[apiObject.Name] = nc
+	o.log.Debug().Str("namespace", namespace).Str("name", name).Msg("ArangoDeploymentReplication deleted")
 
-		deploymentReplicationsCreated.Inc()
-		deploymentReplicationsCurrent.Set(float64(len(o.deploymentReplications)))
+	repl.Delete()
+	delete(o.deploymentReplications, key)
+	deploymentReplicationsDeleted.Inc()
+	deploymentReplicationsCurrent.Set(float64(len(o.deploymentReplications)))
+}
 
-	case kwatch.Modified:
-		repl, ok := o.deploymentReplications[apiObject.Name]
-		if !ok {
-			return maskAny(fmt.Errorf("unsafe state. deployment replication (%s) was never created but we received event (%s)", apiObject.Name, event.Type))
-		}
-		repl.Update(apiObject)
-		deploymentReplicationsModified.Inc()
+// releaseArangoDeploymentReplications removes all deployment replications of the given namespace.
+func (o *Operator) releaseArangoDeploymentReplications(namespace string) {
+	o.Dependencies.LivenessProbe.Lock()
+	defer o.Dependencies.LivenessProbe.Unlock()
+	o.deploymentReplicationsLock.Lock()
+	defer o.deploymentReplicationsLock.Unlock()
 
-	case kwatch.Deleted:
-		repl, ok := o.deploymentReplications[apiObject.Name]
-		if !ok {
-			return maskAny(fmt.Errorf("unsafe state. deployment replication (%s) was never created but we received event (%s)", apiObject.Name, event.Type))
+	for key, repl := range o.deploymentReplications {
+		if repl.Namespace() != namespace {
+			continue
 		}
-		repl.Delete()
-		delete(o.deploymentReplications, apiObject.Name)
-		deploymentReplicationsDeleted.Inc()
-		deploymentReplicationsCurrent.Set(float64(len(o.deploymentReplications)))
+
+		o.log.Debug().Str("deployment-replication", key).Msg("ArangoDeploymentReplication released")
+		delete(o.deploymentReplications, key)
 	}
-	return nil
+	deploymentReplicationsCurrent.Set(float64(len(o.deploymentReplications)))
 }
 
 // makeDeploymentReplicationConfigAndDeps creates a Config & Dependencies object for a new DeploymentReplication.
 func (o *Operator) makeDeploymentReplicationConfigAndDeps(apiObject *api.ArangoDeploymentReplication) (replication.Config, replication.Dependencies) {
 	cfg := replication.Config{
-		Namespace: o.Config.Namespace,
+		Namespace: apiObject.GetNamespace(),
 	}
 	deps := replication.Dependencies{
 		Log: o.Dependencies.LogService.MustGetLogger("deployment-replication").With().
@@ -24,17 +24,27 @@ package operator
 
 import (
 	"fmt"
+	"sync"
+	"time"
 
-	"github.com/pkg/errors"
-	kwatch "k8s.io/apimachinery/pkg/watch"
-	"k8s.io/client-go/tools/cache"
+	core "k8s.io/api/core/v1"
+	kubeInformer "k8s.io/client-go/informers"
 
 	api "github.com/arangodb/kube-arangodb/pkg/apis/storage/v1alpha"
+	backupOper "github.com/arangodb/kube-arangodb/pkg/backup/operator"
+	"github.com/arangodb/kube-arangodb/pkg/backup/operator/operation"
+	arangoInformer "github.com/arangodb/kube-arangodb/pkg/generated/informers/externalversions"
+	storageLister "github.com/arangodb/kube-arangodb/pkg/generated/listers/storage/v1alpha"
 	"github.com/arangodb/kube-arangodb/pkg/metrics"
 	"github.com/arangodb/kube-arangodb/pkg/storage"
 	"github.com/arangodb/kube-arangodb/pkg/util/k8sutil"
 )
 
+const (
+	persistentVolumeClaimKind = "PersistentVolumeClaim"
+	persistentVolumeKind      = "PersistentVolume"
+)
+
 var (
 	localStoragesCreated  = metrics.MustRegisterCounter("controller", "local_storages_created", "Number of local storages that have been created")
 	localStoragesDeleted  = metrics.MustRegisterCounter("controller", "local_storages_deleted", "Number of local storages that have been deleted")
@@ -44,159 +54,212 @@ var (
 )
 
 // run the local storages part of the operator.
-// This registers a listener and waits until the process stops.
+// This registers a controller and waits until the process stops.
 func (o *Operator) runLocalStorages(stop <-chan struct{}) {
-	rw := k8sutil.NewResourceWatcher(
-		o.log,
-		o.Dependencies.CRCli.StorageV1alpha().RESTClient(),
-		api.ArangoLocalStorageResourcePlural,
-		"", //o.Config.Namespace,
-		&api.ArangoLocalStorage{},
-		cache.ResourceEventHandlerFuncs{
-			AddFunc:    o.onAddArangoLocalStorage,
-			UpdateFunc: o.onUpdateArangoLocalStorage,
-			DeleteFunc: o.onDeleteArangoLocalStorage,
+	o.runController("arangodb-storage-operator", newNamespaceSet([]string{""}), o.Dependencies.StorageProbe, stop,
+		&localStorageController{
+			operator: o,
 		})
-
-	o.Dependencies.StorageProbe.SetReady()
-	rw.Run(stop)
 }
 
-// onAddArangoLocalStorage local storage addition callback
-func (o *Operator) onAddArangoLocalStorage(obj interface{}) {
-	o.Dependencies.LivenessProbe.Lock()
-	defer o.Dependencies.LivenessProbe.Unlock()
+// localStorageController handles the cluster scoped ArangoLocalStorages
+type localStorageController struct {
+	operator *Operator
 
-	apiObject := obj.(*api.ArangoLocalStorage)
-	o.log.Debug().
-		Str("name", apiObject.GetObjectMeta().GetName()).
-		Msg("ArangoLocalStorage added")
-	o.syncArangoLocalStorage(apiObject)
+	lock   sync.Mutex
+	lister storageLister.ArangoLocalStorageLister
 }
 
-// onUpdateArangoLocalStorage local storage update callback
-func (o *Operator) onUpdateArangoLocalStorage(oldObj, newObj interface{}) {
-	o.Dependencies.LivenessProbe.Lock()
-	defer o.Dependencies.LivenessProbe.Unlock()
+func (c *localStorageController) Register(operator backupOper.Operator) error {
+	o := c.operator
 
-	apiObject := newObj.(*api.ArangoLocalStorage)
-	o.log.Debug().
-		Str("name", apiObject.GetObjectMeta().GetName()).
-		Msg("ArangoLocalStorage updated")
-	o.syncArangoLocalStorage(apiObject)
-}
+	// Changes of PVC's and PV's trigger an inspection of all local storages
+	kubeInformers := kubeInformer.NewSharedInformerFactory(o.Dependencies.KubeCli, 0)
 
-// onDeleteArangoLocalStorage local storage delete callback
-func (o *Operator) onDeleteArangoLocalStorage(obj interface{}) {
-	o.Dependencies.LivenessProbe.Lock()
-	defer o.Dependencies.LivenessProbe.Unlock()
+	if err := operator.RegisterInformer(kubeInformers.Core().V1().PersistentVolumeClaims().Informer(),
+		core.SchemeGroupVersion.Group,
+		core.SchemeGroupVersion.Version,
+		persistentVolumeClaimKind); err != nil {
+		return err
+	}
 
-	log := o.log
-	apiObject, ok := obj.(*api.ArangoLocalStorage)
-	if !ok {
-		tombstone, ok := obj.(cache.DeletedFinalStateUnknown)
-		if !ok {
-			log.Error().Interface("event-object", obj).Msg("unknown object from ArangoLocalStorage delete event")
-			return
-		}
-		apiObject, ok = tombstone.Obj.(*api.ArangoLocalStorage)
-		if !ok {
-			log.Error().Interface("event-object", obj).Msg("Tombstone contained object that is not an ArangoLocalStorage")
-			return
-		}
+	if err := operator.RegisterInformer(kubeInformers.Core().V1().PersistentVolumes().Informer(),
+		core.SchemeGroupVersion.Group,
+		core.SchemeGroupVersion.Version,
+		persistentVolumeKind); err != nil {
+		return err
 	}
-	log.Debug().
-		Str("name", apiObject.GetObjectMeta().GetName()).
-		Msg("ArangoLocalStorage deleted")
-	ev := &Event{
-		Type:         kwatch.Deleted,
-		LocalStorage: apiObject,
+
+	if err := operator.RegisterStarter(kubeInformers); err != nil {
+		return err
 	}
 
-	//	pt.start()
-	err := o.handleLocalStorageEvent(ev)
-	if err != nil {
-		log.Warn().Err(err).Msg("Failed to handle event")
+	for _, kind := range []string{persistentVolumeClaimKind, persistentVolumeKind} {
+		if err := operator.RegisterHandler(o.newControllerHandler(core.SchemeGroupVersion, kind,
+			func(item operation.Item) error {
+				o.triggerLocalStorages(operator)
+				return nil
+			})); err != nil {
+			return err
+		}
 	}
-	//pt.stop()
+
+	return operator.RegisterHandler(o.newControllerHandler(api.SchemeGroupVersion, api.ArangoLocalStorageResourceKind,
+		func(item operation.Item) error {
+			c.lock.Lock()
+			lister := c.lister
+			c.lock.Unlock()
+
+			if lister == nil {
+				return nil
+			}
+
+			apiObject, err := lister.Get(item.Name)
+			if err != nil {
+				if k8sutil.IsNotFound(err) {
+					o.deleteArangoLocalStorage(item.Name)
+					return nil
+				}
+				return maskAny(err)
+			}
+
+			delay, err := o.syncArangoLocalStorage(apiObject.DeepCopy())
+			if err != nil {
+				return maskAny(err)
+			}
+
+			if delay > 0 {
+				// Inspect the local storage again after the delay
+				operator.EnqueueItemAfter(item, delay)
+			}
+
+			return nil
+		}))
 }
 
-// syncArangoLocalStorage synchronizes the given local storage.
-func (o *Operator) syncArangoLocalStorage(apiObject *api.ArangoLocalStorage) {
-	ev := &Event{
-		Type:         kwatch.Added,
-		LocalStorage: apiObject,
-	}
-	// re-watch or restart could give ADD event.
-	// If for an ADD event the cluster spec is invalid then it is not added to the local cache
-	// so modifying that local storage will result in another ADD event
-	if _, ok := o.localStorages[apiObject.Name]; ok {
-		ev.Type = kwatch.Modified
-	}
+func (c *localStorageController) AddNamespace(operator backupOper.Operator, _ string, informers arangoInformer.SharedInformerFactory) error {
+	storages := informers.Storage().V1alpha().ArangoLocalStorages()
 
-	//pt.start()
-	err := o.handleLocalStorageEvent(ev)
-	if err != nil {
-		o.log.Warn().Err(err).Msg("Failed to handle event")
+	if err := operator.RegisterInformer(storages.Informer(),
+		api.SchemeGroupVersion.Group,
+		api.SchemeGroupVersion.Version,
+		api.ArangoLocalStorageResourceKind); err != nil {
+		return err
 	}
-	//pt.stop()
+
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	c.lister = storages.Lister()
+	return nil
 }
 
-// handleLocalStorageEvent processed the given event.
-func (o *Operator) handleLocalStorageEvent(event *Event) error {
-	apiObject := event.LocalStorage
+// RemoveNamespace is never called, local storages are cluster scoped.
+func (c *localStorageController) RemoveNamespace(string) {}
+
+// syncArangoLocalStorage creates or updates the given local storage.
+// Returns the delay after which the local storage has to be synced again.
+func (o *Operator) syncArangoLocalStorage(apiObject *api.ArangoLocalStorage) (time.Duration, error) {
+	log := o.log.With().Str("name", apiObject.GetName()).Logger()
 
 	if apiObject.Status.State.IsFailed() {
 		localStoragesFailed.Inc()
-		if event.Type == kwatch.Deleted {
-			delete(o.localStorages, apiObject.Name)
-			return nil
-		}
-		return maskAny(fmt.Errorf("ignore failed local storage (%s). Please delete its CR", apiObject.Name))
+		log.Warn().Msg("Ignore failed local storage. Please delete its CR")
+		return 0, nil
 	}
 
 	// Fill in defaults
 	apiObject.Spec.SetDefaults(apiObject.GetName())
 	// Validate local storage spec
 	if err := apiObject.Spec.Validate(); err != nil {
-		return maskAny(errors.Wrapf(err, "invalid local storage spec. please fix the following problem with the local storage spec: %v", err))
+		log.Warn().Err(err).Msg("Invalid local storage spec. Please fix the following problem with the local storage spec")
+		return 0, nil
 	}
 
-	switch event.Type {
-	case kwatch.Added:
-		if _, ok := o.localStorages[apiObject.Name]; ok {
-			return maskAny(fmt.Errorf("unsafe state. local storage (%s) was created before but we received event (%s)", apiObject.Name, event.Type))
+	if stg, ok := o.getArangoLocalStorage(apiObject.Name); ok {
+		if apiObject.GetResourceVersion() != stg.GetResourceVersion() {
+			log.Debug().Msg("ArangoLocalStorage updated")
+			localStoragesModified.Inc()
 		}
+		return stg.Sync(apiObject)
+	}
+
+	log.Debug().Msg("ArangoLocalStorage added")
+
+	cfg, deps := o.makeLocalStorageConfigAndDeps(apiObject)
+	stg, err := storage.New(cfg, deps, apiObject)
+	if err != nil {
+		return 0, maskAny(fmt.Errorf("failed to create local storage: %s", err))
+	}
+	o.addArangoLocalStorage(apiObject.Name, stg)
+
+	localStoragesCreated.Inc()
+
+	return stg.Sync(apiObject)
+}
+
+// getArangoLocalStorage returns the local storage with given name.
+func (o *Operator) getArangoLocalStorage(name string) (*storage.LocalStorage, bool) {
+	o.Dependencies.LivenessProbe.Lock()
+	defer o.Dependencies.LivenessProbe.Unlock()
+	o.localStoragesLock.Lock()
+	defer o.localStoragesLock.Unlock()
+
+	stg, ok := o.localStorages[name]
+	return stg, ok
+}
+
+// addArangoLocalStorage adds the local storage with given name.
+func (o *Operator) addArangoLocalStorage(name string, stg *storage.LocalStorage) {
+	o.Dependencies.LivenessProbe.Lock()
+	defer o.Dependencies.LivenessProbe.Unlock()
+	o.localStoragesLock.Lock()
+	defer o.localStoragesLock.Unlock()
+
+	o.localStorages[name] = stg
+	localStoragesCurrent.Set(float64(len(o.localStorages)))
+}
+
+// triggerLocalStorages enqueues all local storages for an immediate inspection.
+func (o *Operator) triggerLocalStorages(operator backupOper.Operator) {
+	o.Dependencies.LivenessProbe.Lock()
+	defer o.Dependencies.LivenessProbe.Unlock()
+	o.localStoragesLock.Lock()
+	defer o.localStoragesLock.Unlock()
 
-		cfg, deps := o.makeLocalStorageConfigAndDeps(apiObject)
-		stg, err := storage.New(cfg, deps, apiObject)
+	for name, stg := range o.localStorages {
+		item, err := operation.NewItem(operation.Update,
+			api.SchemeGroupVersion.Group,
+			api.SchemeGroupVersion.Version,
+			api.ArangoLocalStorageResourceKind,
+			"", name)
 		if err != nil {
-			return maskAny(fmt.Errorf("failed to create local storage: %s", err))
+			continue
 		}
-		o.localStorages[apiObject.Name] = stg
 
-		localStoragesCreated.Inc()
-		localStoragesCurrent.Set(float64(len(o.localStorages)))
+		stg.TriggerInspection()
+		operator.EnqueueItem(item)
+	}
+}
 
-	case kwatch.Modified:
-		stg, ok := o.localStorages[apiObject.Name]
-		if !ok {
-			return maskAny(fmt.Errorf("unsafe state. local storage (%s) was never created but we received event (%s)", apiObject.Name, event.Type))
-		}
-		stg.Update(apiObject)
-		localStoragesModified.Inc()
+// deleteArangoLocalStorage stops the local storage with given name.
+func (o *Operator) deleteArangoLocalStorage(name string) {
+	o.Dependencies.LivenessProbe.Lock()
+	defer o.Dependencies.LivenessProbe.Unlock()
+	o.localStoragesLock.Lock()
+	defer o.localStoragesLock.Unlock()
 
-	case kwatch.Deleted:
-		stg, ok := o.localStorages[apiObject.Name]
-		if !ok {
-			return maskAny(fmt.Errorf("unsafe state. local storage (%s) was never created but we received event (%s)", apiObject.Name, event.Type))
-		}
-		stg.Delete()
-		delete(o.localStorages, apiObject.Name)
-		localStoragesDeleted.Inc()
-		localStoragesCurrent.Set(float64(len(o.localStorages)))
+	stg, ok := o.localStorages[name]
+	if !ok {
+		return
 	}
-	return nil
+
+	o.log.Debug().Str("name", name).Msg("ArangoLocalStorage deleted")
+
+	stg.Delete()
+	delete(o.localStorages, name)
+	localStoragesDeleted.Inc()
+	localStoragesCurrent.Set(float64(len(o.localStorages)))
 }
 
 // makeLocalStorageConfigAndDeps creates a Config & Dependencies object for a new LocalStorage.
@@ -0,0 +1,217 @@
+//
+// DISCLAIMER
+//
+// Copyright 2020 ArangoDB GmbH, Cologne, Germany
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// Copyright holder is ArangoDB GmbH, Cologne, Germany
+//
+
+package operator
+
+import (
+	"fmt"
+	"reflect"
+	"sort"
+	"sync"
+
+	core "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	meta "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/tools/cache"
+)
+
+// resolveNamespaces returns the sorted list of namespaces watched by the operator.
+// In multi-namespaced scope it is the union of the configured namespaces and the namespaces
+// matching the configured label selector. In all other scopes it is the namespace of the operator.
+func (o *Operator) resolveNamespaces() ([]string, error) {
+	if !o.Scope.IsMultiNamespaced() {
+		return []string{o.Config.Namespace}, nil
+	}
+
+	return resolveNamespaces(o.Dependencies.KubeCli, o.Config.Namespaces, o.Config.NamespaceSelector)
+}
+
+func resolveNamespaces(kubecli kubernetes.Interface, namespaces []string, selector string) ([]string, error) {
+	var selected []string
+
+	if selector != "" {
+		list, err := kubecli.CoreV1().Namespaces().List(meta.ListOptions{LabelSelector: selector})
+		if err != nil {
+			if apierrors.IsForbidden(err) {
+				return nil, maskAny(fmt.Errorf("operator is not allowed to list namespaces matching selector %s, "+
+					"--scope.namespace-selector requires cluster-wide permission to get, list and watch namespaces: %s", selector, err))
+			}
+			return nil, maskAny(fmt.Errorf("failed to list namespaces matching selector %s: %s", selector, err))
+		}
+
+		for _, namespace := range list.Items {
+			selected = append(selected, namespace.GetName())
+		}
+	}
+
+	return mergeNamespaces(namespaces, selected), nil
+}
+
+// mergeNamespaces returns the sorted union of the configured and the selected namespaces.
+// The result is empty when no namespace is configured or selected.
+func mergeNamespaces(namespaces, selected []string) []string {
+	unique := map[string]struct{}{}
+
+	for _, namespace := range append(append([]string{}, namespaces...), selected...) {
+		if namespace == "" {
+			continue
+		}
+		unique[namespace] = struct{}{}
+	}
+
+	result := make([]string, 0, len(unique))
+	for namespace := range unique {
+		result = append(result, namespace)
+	}
+	sort.Strings(result)
+
+	return result
+}
+
+// watchNamespaces watches the namespaces matching the configured label selector and updates the set of watched
+// namespaces, so the controllers start and stop informers of the changed namespaces.
+func (o *Operator) watchNamespaces(stop <-chan struct{}) {
+	if !o.Scope.IsMultiNamespaced() || o.Config.NamespaceSelector == "" {
+		return
+	}
+
+	source := cache.NewFilteredListWatchFromClient(o.Dependencies.KubeCli.CoreV1().RESTClient(), "namespaces", "",
+		func(options *meta.ListOptions) {
+			options.LabelSelector = o.Config.NamespaceSelector
+		})
+
+	var informer cache.Controller
+	var store cache.Store
+
+	check := func() {
+		if informer == nil || !informer.HasSynced() {
+			return
+		}
+
+		var selected []string
+		for _, obj := range store.List() {
+			if namespace, ok := obj.(*core.Namespace); ok {
+				selected = append(selected, namespace.GetName())
+			}
+		}
+
+		namespaces := mergeNamespaces(o.Config.Namespaces, selected)
+		if o.namespaces.Set(namespaces) {
+			if len(namespaces) == 0 {
+				o.log.Warn().Msg("No namespaces are watched, controllers are idle")
+			} else {
+				o.log.Info().Strs("namespaces", namespaces).Msg("Watched namespaces changed")
+			}
+		}
+	}
+
+	store, informer = cache.NewInformer(source, &core.Namespace{}, 0, cache.ResourceEventHandlerFuncs{
+		AddFunc:    func(obj interface{}) { check() },
+		UpdateFunc: func(oldObj, newObj interface{}) { check() },
+		DeleteFunc: func(obj interface{}) { check() },
+	})
+
+	go informer.Run(stop)
+
+	if cache.WaitForCacheSync(stop, informer.HasSynced) {
+		check()
+	}
+}
+
+// forEachNamespace calls given function for all watched namespaces and stops on first error.
+func (o *Operator) forEachNamespace(f func(namespace string) error) error {
+	for _, namespace := range o.namespaces.Get() {
+		if err := f(namespace); err != nil {
+			return maskAny(err)
+		}
+	}
+
+	return nil
+}
+
+// namespaceSet contains the namespaces watched by the operator and notifies subscribers about changes.
+type namespaceSet struct {
+	lock        sync.Mutex
+	namespaces  []string
+	subscribers map[chan struct{}]struct{}
+}
+
+// newNamespaceSet creates a set of given namespaces.
+func newNamespaceSet(namespaces []string) *namespaceSet {
+	return &namespaceSet{
+		namespaces:  namespaces,
+		subscribers: map[chan struct{}]struct{}{},
+	}
+}
+
+// Get returns the watched namespaces.
+func (s *namespaceSet) Get() []string {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	return append([]string{}, s.namespaces...)
+}
+
+// Set replaces the watched namespaces and notifies the subscribers. Returns true when the set was changed.
+func (s *namespaceSet) Set(namespaces []string) bool {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	if reflect.DeepEqual(namespaces, s.namespaces) {
+		return false
+	}
+
+	s.namespaces = append([]string{}, namespaces...)
+
+	for ch := range s.subscribers {
+		select {
+		case ch <- struct{}{}:
+		default:
+			// Subscriber is notified already
+		}
+	}
+
+	return true
+}
+
+// Subscribe returns a channel which receives a value when the set was changed and a function to cancel the subscription.
+func (s *namespaceSet) Subscribe() (<-chan struct{}, func()) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	ch := make(chan struct{}, 1)
+	s.subscribers[ch] = struct{}{}
+
+	return ch, func() {
+		s.lock.Lock()
+		defer s.lock.Unlock()
+
+		delete(s.subscribers, ch)
+	}
+}
+
+// objectKey returns the key of a namespaced object in the operator maps.
+func objectKey(namespace, name string) string {
+	if namespace == "" {
+		return name
+	}
+	return namespace + "/" + name
+}
@@ -0,0 +1,165 @@
+//
+// DISCLAIMER
+//
+// Copyright 2020 ArangoDB GmbH, Cologne, Germany
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// Copyright holder is ArangoDB GmbH, Cologne, Germany
+//
+
+package operator
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	backupOper "github.com/arangodb/kube-arangodb/pkg/backup/operator"
+	arangoFake "github.com/arangodb/kube-arangodb/pkg/generated/clientset/versioned/fake"
+	arangoInformer "github.com/arangodb/kube-arangodb/pkg/generated/informers/externalversions"
+	"github.com/arangodb/kube-arangodb/pkg/util/probe"
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/require"
+	core "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	meta "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/uuid"
+	"k8s.io/client-go/kubernetes/fake"
+	k8stesting "k8s.io/client-go/testing"
+)
+
+func newNamespace(name string, labels map[string]string) *core.Namespace {
+	return &core.Namespace{
+		ObjectMeta: meta.ObjectMeta{
+			Name:   name,
+			Labels: labels,
+		},
+	}
+}
+
+func Test_ResolveNamespaces_List(t *testing.T) {
+	kubecli := fake.NewSimpleClientset()
+
+	namespaces, err := resolveNamespaces(kubecli, []string{"b", "a", "b", ""}, "")
+	require.NoError(t, err)
+	require.Equal(t, []string{"a", "b"}, namespaces)
+}
+
+func Test_ResolveNamespaces_Selector(t *testing.T) {
+	kubecli := fake.NewSimpleClientset(
+		newNamespace("tenant-a", map[string]string{"arangodb": "enabled"}),
+		newNamespace("tenant-b", map[string]string{"arangodb": "enabled"}),
+		newNamespace("other", nil),
+	)
+
+	namespaces, err := resolveNamespaces(kubecli, []string{"static"}, "arangodb=enabled")
+	require.NoError(t, err)
+	require.Equal(t, []string{"static", "tenant-a", "tenant-b"}, namespaces)
+}
+
+func Test_ResolveNamespaces_Empty(t *testing.T) {
+	kubecli := fake.NewSimpleClientset(newNamespace("other", nil))
+
+	namespaces, err := resolveNamespaces(kubecli, nil, "arangodb=enabled")
+	require.NoError(t, err)
+	require.Empty(t, namespaces)
+}
+
+func Test_ResolveNamespaces_Forbidden(t *testing.T) {
+	kubecli := fake.NewSimpleClientset()
+	kubecli.PrependReactor("list", "namespaces", func(action k8stesting.Action) (bool, runtime.Object, error) {
+		return true, nil, apierrors.NewForbidden(core.Resource("namespaces"), "", fmt.Errorf("forbidden"))
+	})
+
+	_, err := resolveNamespaces(kubecli, []string{"static"}, "arangodb=enabled")
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "cluster-wide permission")
+}
+
+func Test_MergeNamespaces(t *testing.T) {
+	require.Equal(t, []string{"a", "b", "static"}, mergeNamespaces([]string{"static", "b"}, []string{"b", "a", ""}))
+	require.Empty(t, mergeNamespaces(nil, nil))
+}
+
+func Test_NamespaceSet(t *testing.T) {
+	namespaces := newNamespaceSet([]string{"a"})
+
+	changed, unsubscribe := namespaces.Subscribe()
+	defer unsubscribe()
+
+	require.False(t, namespaces.Set([]string{"a"}))
+	require.Len(t, changed, 0)
+
+	require.True(t, namespaces.Set([]string{"a", "b"}))
+	require.True(t, namespaces.Set([]string{"b"}))
+	require.Len(t, changed, 1)
+	require.Equal(t, []string{"b"}, namespaces.Get())
+}
+
+// testController records the namespaces added to and removed from a controller
+type testController struct {
+	added, removed chan string
+}
+
+func (c *testController) Register(backupOper.Operator) error {
+	return nil
+}
+
+func (c *testController) AddNamespace(_ backupOper.Operator, namespace string, _ arangoInformer.SharedInformerFactory) error {
+	c.added <- namespace
+	return nil
+}
+
+func (c *testController) RemoveNamespace(namespace string) {
+	c.removed <- namespace
+}
+
+func waitForNamespace(t *testing.T, ch <-chan string) string {
+	select {
+	case namespace := <-ch:
+		return namespace
+	case <-time.After(time.Second):
+		require.Fail(t, "namespace not received")
+		return ""
+	}
+}
+
+func Test_RunController_NamespacesChanged(t *testing.T) {
+	o := &Operator{log: zerolog.Nop()}
+	namespaces := newNamespaceSet([]string{"a"})
+	c := &testController{added: make(chan string, 8), removed: make(chan string, 8)}
+	readyProbe := &probe.ReadyProbe{}
+
+	stop := make(chan struct{})
+	defer close(stop)
+
+	go o.runControllerWith("test-"+string(uuid.NewUUID()), arangoFake.NewSimpleClientset(), 0, 1, namespaces, readyProbe, stop, c)
+
+	require.Equal(t, "a", waitForNamespace(t, c.added))
+
+	// Namespace is added and removed at runtime
+	namespaces.Set([]string{"b"})
+	require.Equal(t, "b", waitForNamespace(t, c.added))
+	require.Equal(t, "a", waitForNamespace(t, c.removed))
+	require.True(t, readyProbe.IsReady())
+
+	// Controller is idle without namespaces
+	namespaces.Set(nil)
+	require.Equal(t, "b", waitForNamespace(t, c.removed))
+
+	namespaces.Set([]string{"a"})
+	require.Equal(t, "a", waitForNamespace(t, c.added))
+	require.Len(t, c.removed, 0)
+}
@@ -0,0 +1,126 @@
+//
+// DISCLAIMER
+//
+// Copyright 2020 ArangoDB GmbH, Cologne, Germany
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// Copyright holder is ArangoDB GmbH, Cologne, Germany
+//
+
+package operator
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"time"
+
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/types"
+
+	"github.com/arangodb/kube-arangodb/pkg/operator/sharding"
+	"github.com/arangodb/kube-arangodb/pkg/util/constants"
+	"github.com/arangodb/kube-arangodb/pkg/util/probe"
+	"github.com/arangodb/kube-arangodb/pkg/util/trigger"
+)
+
+const (
+	shardingLeaseDuration = 15 * time.Second
+	shardingRenewDeadline = 10 * time.Second
+	shardingRetryPeriod   = 2 * time.Second
+)
+
+// runWithSharding runs the operator on all replicas at the same time.
+// Objects are divided into shards, and every shard is owned by exactly one replica.
+// Shards are moved to other replicas when a replica joins, leaves or stops renewing its leases.
+func (o *Operator) runWithSharding(lockName, label string, onStart func(stop <-chan struct{}), readyProbe *probe.ReadyProbe) {
+	log := o.log.With().Str("lock-name", lockName).Logger()
+	eventTarget := o.getLeaderElectionEventTarget(log)
+	recordEvent := func(reason, message string) {
+		if eventTarget != nil {
+			o.Dependencies.EventRecorder.Event(eventTarget, v1.EventTypeNormal, reason, message)
+		}
+	}
+	ctx := context.Background()
+
+	o.sharding = sharding.New(log, o.Dependencies.KubeCli, sharding.Config{
+		Namespace:     o.Config.Namespace,
+		Name:          lockName,
+		Identity:      o.Config.ID,
+		Shards:        o.Config.Shards,
+		LeaseDuration: shardingLeaseDuration,
+		RenewDeadline: shardingRenewDeadline,
+		RetryPeriod:   shardingRetryPeriod,
+	}, sharding.Callbacks{
+		OnAcquired: func(shard int) {
+			o.shardingResync.Trigger()
+		},
+		OnReleased: o.releaseShard,
+	})
+
+	recordEvent("Sharding Enabled", fmt.Sprintf("Pod %s is running as one of the sharded replicas", o.Config.PodName))
+	readyProbe.SetReady()
+	if err := o.setRoleLabel(log, label, constants.LabelRoleLeader); err != nil {
+		log.Error().Msg("Cannot set leader role on Pod. Terminating process")
+		os.Exit(2)
+	}
+
+	go o.sharding.Run(ctx.Done())
+
+	onStart(ctx.Done())
+}
+
+// ownsObject returns true if the object with given UID is handled by this replica.
+func (o *Operator) ownsObject(uid types.UID) bool {
+	if o.sharding == nil {
+		return true
+	}
+
+	return o.sharding.Owns(uid)
+}
+
+// releaseShard stops all deployments of the given shard, so the shard can be handed over to another replica.
+// Called by the coordinator, so it only signals the deployments to stop and does not wait for them.
+func (o *Operator) releaseShard(shard int) {
+	o.deploymentsLock.Lock()
+	defer o.deploymentsLock.Unlock()
+
+	for key, depl := range o.deployments {
+		if o.sharding.ShardOf(depl.GetAPIObject().GetUID()) != shard {
+			continue
+		}
+
+		o.log.Info().Str("deployment", key).Int("shard", shard).Msg("Handing over ArangoDeployment")
+		o.handOverArangoDeployment(key)
+	}
+}
+
+// shardingResyncStarter enqueues all objects when a new shard was acquired
+type shardingResyncStarter struct {
+	trigger *trigger.Trigger
+	resync  func()
+}
+
+func (s *shardingResyncStarter) Start(stop <-chan struct{}) {
+	go func() {
+		for {
+			select {
+			case <-stop:
+				return
+			case <-s.trigger.Done():
+				s.resync()
+			}
+		}
+	}()
+}
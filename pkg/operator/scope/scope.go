@@ -28,6 +28,8 @@ func AsScope(s string) (Scope, bool) {
 		return LegacyScope, true
 	case NamespacedScope.String():
 		return NamespacedScope, true
+	case MultiNamespacedScope.String():
+		return MultiNamespacedScope, true
 	}
 
 	return "", false
@@ -39,13 +41,21 @@ func (s Scope) String() string {
 	return string(s)
 }
 
+// IsNamespaced returns true if Operator has no cluster-wide access
 func (s Scope) IsNamespaced() bool {
-	return s == NamespacedScope
+	return s == NamespacedScope || s == MultiNamespacedScope
+}
+
+// IsMultiNamespaced returns true if Operator watches a set of namespaces instead of its own one
+func (s Scope) IsMultiNamespaced() bool {
+	return s == MultiNamespacedScope
 }
 
 const (
 	LegacyScope     Scope = "legacy"
 	NamespacedScope Scope = "namespaced"
+	// MultiNamespacedScope - watch list of namespaces (or namespaces matching label selector) without cluster-wide access
+	MultiNamespacedScope Scope = "multi-namespaced"
 
 	DefaultScope = LegacyScope
 )
@@ -37,6 +37,8 @@ func (o *Operator) DeploymentOperator() server.DeploymentOperator {
 func (o *Operator) GetDeployments() ([]server.Deployment, error) {
 	o.Dependencies.LivenessProbe.Lock()
 	defer o.Dependencies.LivenessProbe.Unlock()
+	o.deploymentsLock.Lock()
+	defer o.deploymentsLock.Unlock()
 
 	result := make([]server.Deployment, 0, len(o.deployments))
 	for _, d := range o.deployments {
@@ -52,6 +54,8 @@ func (o *Operator) GetDeployments() ([]server.Deployment, error) {
 func (o *Operator) GetDeployment(name string) (server.Deployment, error) {
 	o.Dependencies.LivenessProbe.Lock()
 	defer o.Dependencies.LivenessProbe.Unlock()
+	o.deploymentsLock.Lock()
+	defer o.deploymentsLock.Unlock()
 
 	for _, d := range o.deployments {
 		if d.Name() == name {
@@ -70,6 +74,8 @@ func (o *Operator) DeploymentReplicationOperator() server.DeploymentReplicationO
 func (o *Operator) GetDeploymentReplications() ([]server.DeploymentReplication, error) {
 	o.Dependencies.LivenessProbe.Lock()
 	defer o.Dependencies.LivenessProbe.Unlock()
+	o.deploymentReplicationsLock.Lock()
+	defer o.deploymentReplicationsLock.Unlock()
 
 	result := make([]server.DeploymentReplication, 0, len(o.deploymentReplications))
 	for _, d := range o.deploymentReplications {
@@ -85,6 +91,8 @@ func (o *Operator) GetDeploymentReplications() ([]server.DeploymentReplication,
 func (o *Operator) GetDeploymentReplication(name string) (server.DeploymentReplication, error) {
 	o.Dependencies.LivenessProbe.Lock()
 	defer o.Dependencies.LivenessProbe.Unlock()
+	o.deploymentReplicationsLock.Lock()
+	defer o.deploymentReplicationsLock.Unlock()
 
 	for _, d := range o.deploymentReplications {
 		if d.Name() == name {
@@ -103,6 +111,8 @@ func (o *Operator) StorageOperator() server.StorageOperator {
 func (o *Operator) GetLocalStorages() ([]server.LocalStorage, error) {
 	o.Dependencies.LivenessProbe.Lock()
 	defer o.Dependencies.LivenessProbe.Unlock()
+	o.localStoragesLock.Lock()
+	defer o.localStoragesLock.Unlock()
 
 	result := make([]server.LocalStorage, 0, len(o.localStorages))
 	for _, ls := range o.localStorages {
@@ -118,6 +128,8 @@ func (o *Operator) GetLocalStorages() ([]server.LocalStorage, error) {
 func (o *Operator) GetLocalStorage(name string) (server.LocalStorage, error) {
 	o.Dependencies.LivenessProbe.Lock()
 	defer o.Dependencies.LivenessProbe.Unlock()
+	o.localStoragesLock.Lock()
+	defer o.localStoragesLock.Unlock()
 
 	for _, ls := range o.localStorages {
 		if ls.Name() == name {
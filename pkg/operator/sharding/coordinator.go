@@ -0,0 +1,398 @@
+//
+// DISCLAIMER
+//
+// Copyright 2020 ArangoDB GmbH, Cologne, Germany
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// Copyright holder is ArangoDB GmbH, Cologne, Germany
+//
+
+package sharding
+
+import (
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/rs/zerolog"
+	coordination "k8s.io/api/coordination/v1"
+	meta "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/kubernetes"
+
+	"github.com/arangodb/kube-arangodb/pkg/util/k8sutil"
+)
+
+const (
+	// LabelName is the label with the name of the sharded operator set on all its leases
+	LabelName = "arangodb.com/sharding"
+	// LabelType is the label with the type of the lease
+	LabelType = "arangodb.com/sharding-type"
+
+	leaseTypeMember = "member"
+	leaseTypeShard  = "shard"
+
+	// DefaultShards is the default number of shards
+	DefaultShards = 32
+)
+
+// Config of the Coordinator
+type Config struct {
+	// Namespace in which leases are stored
+	Namespace string
+	// Name of the sharded operator, used as prefix of the lease names
+	Name string
+	// Identity of this replica
+	Identity string
+	// Shards is the number of shards objects are divided into
+	Shards int
+
+	// LeaseDuration is the time after which lease which is not renewed is considered as expired
+	LeaseDuration time.Duration
+	// RenewDeadline is the time after which owned shard is released when its lease can not be renewed
+	RenewDeadline time.Duration
+	// RetryPeriod is the interval of lease renewals
+	RetryPeriod time.Duration
+}
+
+// Callbacks are called when ownership of a shard changes.
+// OnReleased is called before the lease is given up, so work on the shard has to be stopped when it returns.
+type Callbacks struct {
+	OnAcquired func(shard int)
+	OnReleased func(shard int)
+}
+
+// Coordinator distributes shards between active replicas.
+// Every replica keeps its own member lease alive. Shards are assigned to the live members
+// with rendezvous hashing and ownership of every shard is guarded by a shard lease,
+// so at any time at most one replica works on a shard.
+type Coordinator struct {
+	log       zerolog.Logger
+	kubecli   kubernetes.Interface
+	config    Config
+	callbacks Callbacks
+
+	now func() time.Time
+
+	lock    sync.RWMutex
+	owned   map[int]time.Time
+	members []string
+}
+
+// New creates a new Coordinator
+func New(log zerolog.Logger, kubecli kubernetes.Interface, config Config, callbacks Callbacks) *Coordinator {
+	if config.Shards <= 0 {
+		config.Shards = DefaultShards
+	}
+
+	return &Coordinator{
+		log:       log.With().Str("sharding", config.Name).Logger(),
+		kubecli:   kubecli,
+		config:    config,
+		callbacks: callbacks,
+		now:       time.Now,
+		owned:     map[int]time.Time{},
+	}
+}
+
+// Owns returns true if this replica owns the object with given UID.
+func (c *Coordinator) Owns(uid types.UID) bool {
+	return c.OwnsShard(ShardOf(uid, c.config.Shards))
+}
+
+// OwnsShard returns true if this replica owns given shard.
+func (c *Coordinator) OwnsShard(shard int) bool {
+	c.lock.RLock()
+	defer c.lock.RUnlock()
+
+	_, ok := c.owned[shard]
+	return ok
+}
+
+// ShardOf returns the shard of the object with given UID.
+func (c *Coordinator) ShardOf(uid types.UID) int {
+	return ShardOf(uid, c.config.Shards)
+}
+
+// Members returns live members seen during the last synchronization.
+func (c *Coordinator) Members() []string {
+	c.lock.RLock()
+	defer c.lock.RUnlock()
+
+	return append([]string(nil), c.members...)
+}
+
+// Run synchronizes leases until given channel is closed. All owned shards are released on stop.
+func (c *Coordinator) Run(stop <-chan struct{}) {
+	t := time.NewTicker(c.config.RetryPeriod)
+	defer t.Stop()
+
+	for {
+		if err := c.Sync(); err != nil {
+			c.log.Warn().Err(err).Msg("Failed to synchronize shard leases")
+		}
+
+		select {
+		case <-stop:
+			c.shutdown()
+			return
+		case <-t.C:
+		}
+	}
+}
+
+// Sync renews the member lease and acquires, renews or releases shard leases.
+func (c *Coordinator) Sync() error {
+	now := c.now()
+	leases := c.kubecli.CoordinationV1().Leases(c.config.Namespace)
+
+	if err := c.renewMember(now); err != nil {
+		c.expire(now)
+		return err
+	}
+
+	list, err := leases.List(meta.ListOptions{
+		LabelSelector: labels.SelectorFromSet(labels.Set{LabelName: c.config.Name}).String(),
+	})
+	if err != nil {
+		c.expire(now)
+		return maskAny(err)
+	}
+
+	members := []string{c.config.Identity}
+	shards := map[string]*coordination.Lease{}
+
+	for i := range list.Items {
+		lease := &list.Items[i]
+
+		switch lease.GetLabels()[LabelType] {
+		case leaseTypeMember:
+			holder := holderOf(lease)
+			if holder == c.config.Identity {
+				continue
+			}
+			if c.isExpired(lease, now) {
+				// Member is gone, remove its lease so the list stays short
+				if err := leases.Delete(lease.GetName(), &meta.DeleteOptions{}); err != nil && !k8sutil.IsNotFound(err) {
+					c.log.Debug().Err(err).Str("lease", lease.GetName()).Msg("Failed to remove expired member lease")
+				}
+				continue
+			}
+			members = append(members, holder)
+		case leaseTypeShard:
+			shards[lease.GetName()] = lease
+		}
+	}
+
+	sort.Strings(members)
+
+	c.lock.Lock()
+	c.members = members
+	c.lock.Unlock()
+
+	for shard := 0; shard < c.config.Shards; shard++ {
+		lease := shards[c.shardLeaseName(shard)]
+
+		if Owner(shard, members) != c.config.Identity {
+			// Lease is not renewed anymore and expires, so the new owner takes the shard over
+			// when the work started by this replica is surely finished
+			c.release(shard)
+			continue
+		}
+
+		if err := c.acquire(shard, lease, now); err != nil {
+			c.log.Debug().Err(err).Int("shard", shard).Msg("Unable to acquire shard lease")
+		}
+	}
+
+	c.expire(now)
+
+	return nil
+}
+
+// renewMember creates or renews member lease of this replica
+func (c *Coordinator) renewMember(now time.Time) error {
+	leases := c.kubecli.CoordinationV1().Leases(c.config.Namespace)
+	name := c.memberLeaseName()
+
+	lease, err := leases.Get(name, meta.GetOptions{})
+	if err != nil {
+		if !k8sutil.IsNotFound(err) {
+			return maskAny(err)
+		}
+
+		if _, err := leases.Create(c.newLease(name, leaseTypeMember, now)); err != nil {
+			return maskAny(err)
+		}
+
+		return nil
+	}
+
+	c.hold(lease, now)
+
+	if _, err := leases.Update(lease); err != nil {
+		return maskAny(err)
+	}
+
+	return nil
+}
+
+// acquire acquires or renews given shard lease
+func (c *Coordinator) acquire(shard int, lease *coordination.Lease, now time.Time) error {
+	leases := c.kubecli.CoordinationV1().Leases(c.config.Namespace)
+
+	if lease == nil {
+		if _, err := leases.Create(c.newLease(c.shardLeaseName(shard), leaseTypeShard, now)); err != nil {
+			return maskAny(err)
+		}
+	} else {
+		if holder := holderOf(lease); holder != "" && holder != c.config.Identity && !c.isExpired(lease, now) {
+			return maskAny(fmt.Errorf("shard is still owned by %s", holder))
+		}
+
+		lease = lease.DeepCopy()
+		c.hold(lease, now)
+
+		if _, err := leases.Update(lease); err != nil {
+			return maskAny(err)
+		}
+	}
+
+	c.lock.Lock()
+	_, owned := c.owned[shard]
+	c.owned[shard] = now
+	c.lock.Unlock()
+
+	if !owned {
+		c.log.Info().Int("shard", shard).Msg("Shard acquired")
+		if c.callbacks.OnAcquired != nil {
+			c.callbacks.OnAcquired(shard)
+		}
+	}
+
+	return nil
+}
+
+// release stops work on given shard. Its lease is not renewed anymore.
+func (c *Coordinator) release(shard int) {
+	c.lock.Lock()
+	_, owned := c.owned[shard]
+	delete(c.owned, shard)
+	c.lock.Unlock()
+
+	if !owned {
+		return
+	}
+
+	c.log.Info().Int("shard", shard).Msg("Shard released")
+	if c.callbacks.OnReleased != nil {
+		c.callbacks.OnReleased(shard)
+	}
+}
+
+// expire releases shards which were not renewed within the renew deadline.
+// Other replicas can take them over after the lease duration, so work has to be stopped before.
+func (c *Coordinator) expire(now time.Time) {
+	var expired []int
+
+	c.lock.RLock()
+	for shard, renewed := range c.owned {
+		if now.Sub(renewed) > c.config.RenewDeadline {
+			expired = append(expired, shard)
+		}
+	}
+	c.lock.RUnlock()
+
+	for _, shard := range expired {
+		c.log.Warn().Int("shard", shard).Msg("Shard lease not renewed in time")
+		c.release(shard)
+	}
+}
+
+// shutdown releases all owned shards and removes the member lease
+func (c *Coordinator) shutdown() {
+	for shard := 0; shard < c.config.Shards; shard++ {
+		c.release(shard)
+	}
+
+	if err := c.kubecli.CoordinationV1().Leases(c.config.Namespace).Delete(c.memberLeaseName(), &meta.DeleteOptions{}); err != nil && !k8sutil.IsNotFound(err) {
+		c.log.Debug().Err(err).Msg("Unable to remove member lease")
+	}
+}
+
+func (c *Coordinator) newLease(name, leaseType string, now time.Time) *coordination.Lease {
+	lease := &coordination.Lease{
+		ObjectMeta: meta.ObjectMeta{
+			Name: name,
+			Labels: map[string]string{
+				LabelName: c.config.Name,
+				LabelType: leaseType,
+			},
+		},
+	}
+
+	c.hold(lease, now)
+
+	return lease
+}
+
+// hold sets this replica as holder of the lease
+func (c *Coordinator) hold(lease *coordination.Lease, now time.Time) {
+	identity := c.config.Identity
+	duration := int32(c.config.LeaseDuration / time.Second)
+	renew := meta.NewMicroTime(now)
+
+	if holderOf(lease) != identity {
+		lease.Spec.AcquireTime = &renew
+		transitions := int32(0)
+		if lease.Spec.LeaseTransitions != nil {
+			transitions = *lease.Spec.LeaseTransitions + 1
+		}
+		lease.Spec.LeaseTransitions = &transitions
+	}
+
+	lease.Spec.HolderIdentity = &identity
+	lease.Spec.LeaseDurationSeconds = &duration
+	lease.Spec.RenewTime = &renew
+}
+
+func (c *Coordinator) isExpired(lease *coordination.Lease, now time.Time) bool {
+	if lease.Spec.RenewTime == nil {
+		return true
+	}
+
+	duration := c.config.LeaseDuration
+	if lease.Spec.LeaseDurationSeconds != nil {
+		duration = time.Duration(*lease.Spec.LeaseDurationSeconds) * time.Second
+	}
+
+	return lease.Spec.RenewTime.Add(duration).Before(now)
+}
+
+func (c *Coordinator) memberLeaseName() string {
+	return fmt.Sprintf("%s-member-%s", c.config.Name, c.config.Identity)
+}
+
+func (c *Coordinator) shardLeaseName(shard int) string {
+	return fmt.Sprintf("%s-shard-%d", c.config.Name, shard)
+}
+
+func holderOf(lease *coordination.Lease) string {
+	if lease.Spec.HolderIdentity == nil {
+		return ""
+	}
+	return *lease.Spec.HolderIdentity
+}
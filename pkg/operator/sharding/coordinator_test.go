@@ -0,0 +1,198 @@
+//
+// DISCLAIMER
+//
+// Copyright 2020 ArangoDB GmbH, Cologne, Germany
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// Copyright holder is ArangoDB GmbH, Cologne, Germany
+//
+
+package sharding
+
+import (
+	"testing"
+	"time"
+
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/require"
+	meta "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/kubernetes/fake"
+)
+
+const testShards = 16
+
+type testCoordinator struct {
+	*Coordinator
+
+	acquired, released []int
+}
+
+func newTestCoordinator(kubecli kubernetes.Interface, identity string, now *time.Time) *testCoordinator {
+	t := &testCoordinator{}
+
+	t.Coordinator = New(zerolog.Nop(), kubecli, Config{
+		Namespace:     "test",
+		Name:          "arango-deployment-operator",
+		Identity:      identity,
+		Shards:        testShards,
+		LeaseDuration: 15 * time.Second,
+		RenewDeadline: 10 * time.Second,
+		RetryPeriod:   2 * time.Second,
+	}, Callbacks{
+		OnAcquired: func(shard int) {
+			t.acquired = append(t.acquired, shard)
+		},
+		OnReleased: func(shard int) {
+			t.released = append(t.released, shard)
+		},
+	})
+	t.Coordinator.now = func() time.Time {
+		return *now
+	}
+
+	return t
+}
+
+func (t *testCoordinator) ownedShards() int {
+	count := 0
+	for shard := 0; shard < testShards; shard++ {
+		if t.OwnsShard(shard) {
+			count++
+		}
+	}
+	return count
+}
+
+func requireDisjoint(t *testing.T, coordinators ...*testCoordinator) {
+	for shard := 0; shard < testShards; shard++ {
+		owners := 0
+		for _, c := range coordinators {
+			if c.OwnsShard(shard) {
+				owners++
+			}
+		}
+		require.True(t, owners <= 1, "shard %d is owned by %d replicas", shard, owners)
+	}
+}
+
+func Test_Coordinator_Single(t *testing.T) {
+	now := time.Now()
+	kubecli := fake.NewSimpleClientset()
+
+	a := newTestCoordinator(kubecli, "a", &now)
+
+	require.NoError(t, a.Sync())
+	require.Equal(t, testShards, a.ownedShards())
+	require.Len(t, a.acquired, testShards)
+
+	leases, err := kubecli.CoordinationV1().Leases("test").List(meta.ListOptions{})
+	require.NoError(t, err)
+	require.Len(t, leases.Items, testShards+1)
+}
+
+func Test_Coordinator_Rebalance(t *testing.T) {
+	now := time.Now()
+	kubecli := fake.NewSimpleClientset()
+
+	a := newTestCoordinator(kubecli, "a", &now)
+	b := newTestCoordinator(kubecli, "b", &now)
+
+	require.NoError(t, a.Sync())
+	require.Equal(t, testShards, a.ownedShards())
+
+	// b joins, but can not take shards until a gives them up
+	require.NoError(t, b.Sync())
+	requireDisjoint(t, a, b)
+	require.Equal(t, 0, b.ownedShards())
+
+	// a sees b and releases its shards
+	require.NoError(t, a.Sync())
+	requireDisjoint(t, a, b)
+	require.NotEmpty(t, a.released)
+
+	// b waits until the released leases expire
+	now = now.Add(10 * time.Second)
+	require.NoError(t, a.Sync())
+	require.NoError(t, b.Sync())
+	require.Equal(t, 0, b.ownedShards())
+
+	// b takes released shards over
+	now = now.Add(10 * time.Second)
+	require.NoError(t, a.Sync())
+	require.NoError(t, b.Sync())
+	requireDisjoint(t, a, b)
+	require.Equal(t, testShards, a.ownedShards()+b.ownedShards())
+	require.True(t, b.ownedShards() > 0)
+}
+
+func Test_Coordinator_Handover(t *testing.T) {
+	now := time.Now()
+	kubecli := fake.NewSimpleClientset()
+
+	a := newTestCoordinator(kubecli, "a", &now)
+	b := newTestCoordinator(kubecli, "b", &now)
+
+	require.NoError(t, a.Sync())
+	require.NoError(t, b.Sync())
+	require.NoError(t, a.Sync())
+	now = now.Add(20 * time.Second)
+	require.NoError(t, a.Sync())
+	require.NoError(t, b.Sync())
+	require.Equal(t, testShards, a.ownedShards()+b.ownedShards())
+	require.True(t, a.ownedShards() > 0)
+
+	// a stops renewing its leases
+	now = now.Add(20 * time.Second)
+
+	require.NoError(t, b.Sync())
+	require.Equal(t, testShards, b.ownedShards())
+	require.Equal(t, []string{"b"}, b.Members())
+}
+
+func Test_Coordinator_Expire(t *testing.T) {
+	now := time.Now()
+	kubecli := fake.NewSimpleClientset()
+
+	a := newTestCoordinator(kubecli, "a", &now)
+
+	require.NoError(t, a.Sync())
+	require.Equal(t, testShards, a.ownedShards())
+
+	// a is not able to renew its leases before the deadline
+	now = now.Add(11 * time.Second)
+	a.expire(now)
+
+	require.Equal(t, 0, a.ownedShards())
+	require.Len(t, a.released, testShards)
+}
+
+func Test_Coordinator_Shutdown(t *testing.T) {
+	now := time.Now()
+	kubecli := fake.NewSimpleClientset()
+
+	a := newTestCoordinator(kubecli, "a", &now)
+	b := newTestCoordinator(kubecli, "b", &now)
+
+	require.NoError(t, a.Sync())
+	require.NoError(t, b.Sync())
+
+	a.shutdown()
+	require.Equal(t, 0, a.ownedShards())
+
+	// b takes over all shards when leases of a expire
+	now = now.Add(20 * time.Second)
+	require.NoError(t, b.Sync())
+	require.Equal(t, testShards, b.ownedShards())
+}
@@ -0,0 +1,76 @@
+//
+// DISCLAIMER
+//
+// Copyright 2020 ArangoDB GmbH, Cologne, Germany
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// Copyright holder is ArangoDB GmbH, Cologne, Germany
+//
+
+package sharding
+
+import (
+	"hash/fnv"
+	"strconv"
+
+	"k8s.io/apimachinery/pkg/types"
+)
+
+// ShardOf returns the shard to which the object with given UID belongs.
+func ShardOf(uid types.UID, shards int) int {
+	if shards <= 1 {
+		return 0
+	}
+
+	h := fnv.New32a()
+	h.Write([]byte(uid))
+
+	return int(h.Sum32() % uint32(shards))
+}
+
+// Owner returns the member which owns given shard.
+// Rendezvous hashing is used, so only shards of the added or removed member are moved.
+func Owner(shard int, members []string) string {
+	var owner string
+	var ownerWeight uint64
+
+	for _, member := range members {
+		weight := memberWeight(shard, member)
+		if owner == "" || weight > ownerWeight || (weight == ownerWeight && member < owner) {
+			owner = member
+			ownerWeight = weight
+		}
+	}
+
+	return owner
+}
+
+func memberWeight(shard int, member string) uint64 {
+	h := fnv.New64a()
+	h.Write([]byte(member))
+	h.Write([]byte{'/'})
+	h.Write([]byte(strconv.Itoa(shard)))
+
+	return mix(h.Sum64())
+}
+
+// mix spreads bits of the fnv hash (murmur3 finalizer), fnv alone is biased for keys with common prefix.
+func mix(x uint64) uint64 {
+	x ^= x >> 33
+	x *= 0xff51afd7ed558ccd
+	x ^= x >> 33
+	x *= 0xc4ceb9fe1a85ec53
+	x ^= x >> 33
+	return x
+}
@@ -0,0 +1,81 @@
+//
+// DISCLAIMER
+//
+// Copyright 2020 ArangoDB GmbH, Cologne, Germany
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// Copyright holder is ArangoDB GmbH, Cologne, Germany
+//
+
+package sharding
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/uuid"
+)
+
+func Test_ShardOf_Stable(t *testing.T) {
+	uid := types.UID("7f1c8a5e-2b6d-4e0a-9d1f-3c4b5a6d7e8f")
+
+	require.Equal(t, ShardOf(uid, 32), ShardOf(uid, 32))
+	require.Equal(t, 0, ShardOf(uid, 1))
+	require.Equal(t, 0, ShardOf(uid, 0))
+
+	for i := 0; i < 100; i++ {
+		shard := ShardOf(uuid.NewUUID(), 32)
+		require.True(t, shard >= 0 && shard < 32)
+	}
+}
+
+func Test_Owner_Empty(t *testing.T) {
+	require.Equal(t, "", Owner(0, nil))
+}
+
+func Test_Owner_OrderIndependent(t *testing.T) {
+	for shard := 0; shard < 32; shard++ {
+		require.Equal(t, Owner(shard, []string{"a", "b", "c"}), Owner(shard, []string{"c", "a", "b"}))
+	}
+}
+
+func Test_Owner_Distribution(t *testing.T) {
+	members := []string{"operator-0", "operator-1", "operator-2"}
+	owned := map[string]int{}
+
+	for shard := 0; shard < 300; shard++ {
+		owned[Owner(shard, members)]++
+	}
+
+	for _, member := range members {
+		require.True(t, owned[member] > 50, fmt.Sprintf("member %s owns only %d shards", member, owned[member]))
+	}
+}
+
+func Test_Owner_MinimalMovement(t *testing.T) {
+	members := []string{"operator-0", "operator-1", "operator-2"}
+
+	for shard := 0; shard < 64; shard++ {
+		before := Owner(shard, members)
+		after := Owner(shard, members[:2])
+
+		if before != "operator-2" {
+			require.Equal(t, before, after, "shard %d moved between remaining members", shard)
+		} else {
+			require.NotEqual(t, "operator-2", after)
+		}
+	}
+}
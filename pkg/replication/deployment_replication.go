@@ -25,7 +25,6 @@ package replication
 import (
 	"fmt"
 	"reflect"
-	"sync/atomic"
 	"time"
 
 	"github.com/rs/zerolog"
@@ -37,8 +36,6 @@ import (
 	api "github.com/arangodb/kube-arangodb/pkg/apis/replication/v1"
 	"github.com/arangodb/kube-arangodb/pkg/generated/clientset/versioned"
 	"github.com/arangodb/kube-arangodb/pkg/util/k8sutil"
-	"github.com/arangodb/kube-arangodb/pkg/util/retry"
-	"github.com/arangodb/kube-arangodb/pkg/util/trigger"
 )
 
 // Config holds configuration settings for a DeploymentReplication
@@ -54,23 +51,9 @@ type Dependencies struct {
 	EventRecorder record.EventRecorder
 }
 
-// deploymentReplicationEvent strongly typed type of event
-type deploymentReplicationEventType string
-
-const (
-	eventArangoDeploymentReplicationUpdated deploymentReplicationEventType = "DeploymentReplicationUpdated"
-)
-
-// seploymentReplicationEvent holds an event passed from the controller to the deployment replication.
-type deploymentReplicationEvent struct {
-	Type                  deploymentReplicationEventType
-	DeploymentReplication *api.ArangoDeploymentReplication
-}
-
 const (
-	deploymentReplicationEventQueueSize = 100
-	minInspectionInterval               = time.Second // Ensure we inspect the generated resources no less than with this interval
-	maxInspectionInterval               = time.Minute // Ensure we inspect the generated resources no less than with this interval
+	minInspectionInterval = time.Second // Ensure we inspect the generated resources no less than with this interval
+	maxInspectionInterval = time.Minute // Ensure we inspect the generated resources no less than with this interval
 )
 
 // DeploymentReplication is the in process state of an ArangoDeploymentReplication.
@@ -80,11 +63,9 @@ type DeploymentReplication struct {
 	config    Config
 	deps      Dependencies
 
-	eventCh chan *deploymentReplicationEvent
-	stopCh  chan struct{}
-	stopped int32
-
-	inspectTrigger         trigger.Trigger
+	handledVersion         string // Resource version of the last API object change handled
+	inspectionInterval     time.Duration
+	nextInspection         time.Time
 	recentInspectionErrors int
 	clientCache            client.ClientCache
 }
@@ -95,101 +76,61 @@ func New(config Config, deps Dependencies, apiObject *api.ArangoDeploymentReplic
 		return nil, maskAny(err)
 	}
 	dr := &DeploymentReplication{
-		apiObject: apiObject,
-		status:    *(apiObject.Status.DeepCopy()),
-		config:    config,
-		deps:      deps,
-		eventCh:   make(chan *deploymentReplicationEvent, deploymentReplicationEventQueueSize),
-		stopCh:    make(chan struct{}),
+		apiObject:          apiObject,
+		status:             *(apiObject.Status.DeepCopy()),
+		config:             config,
+		deps:               deps,
+		inspectionInterval: maxInspectionInterval,
 	}
 
-	go dr.run()
-
 	return dr, nil
 }
 
-// Update the deployment replication.
-// This sends an update event in the event queue.
-func (dr *DeploymentReplication) Update(apiObject *api.ArangoDeploymentReplication) {
-	dr.send(&deploymentReplicationEvent{
-		Type:                  eventArangoDeploymentReplicationUpdated,
-		DeploymentReplication: apiObject,
-	})
-}
-
-// Delete the deployment replication.
-// Called when the local storage was deleted by the user.
-func (dr *DeploymentReplication) Delete() {
-	dr.deps.Log.Info().Msg("deployment replication is deleted by user")
-	if atomic.CompareAndSwapInt32(&dr.stopped, 0, 1) {
-		close(dr.stopCh)
+// Sync handles changes of the given API object and inspects the deployment replication when it is due.
+// It is called by the controller for every change of the API object and after the returned delay.
+// Returns the delay until the next inspection, 0 when no new inspection has to be scheduled.
+func (dr *DeploymentReplication) Sync(apiObject *api.ArangoDeploymentReplication) (time.Duration, error) {
+	if dr.status.Phase == api.DeploymentReplicationPhaseFailed {
+		// Failed status was not stored yet
+		return 0, dr.reportFailedStatus()
 	}
-}
 
-// send given event into the deployment replication event queue.
-func (dr *DeploymentReplication) send(ev *deploymentReplicationEvent) {
-	select {
-	case dr.eventCh <- ev:
-		l, ecap := len(dr.eventCh), cap(dr.eventCh)
-		if l > int(float64(ecap)*0.8) {
-			dr.deps.Log.Warn().
-				Int("used", l).
-				Int("capacity", ecap).
-				Msg("event queue buffer is almost full")
+	if version := apiObject.GetResourceVersion(); version != dr.apiObject.GetResourceVersion() && version != dr.handledVersion {
+		dr.handledVersion = version
+		if err := dr.handleArangoDeploymentReplicationUpdatedEvent(apiObject); err != nil {
+			return 0, dr.failOnError(err, "Failed to handle deployment replication update")
+		}
+	} else if time.Now().Before(dr.nextInspection) {
+		// Inspection is already scheduled
+		return 0, nil
+	} else if !dr.nextInspection.IsZero() {
+		// Backoff with next interval
+		dr.inspectionInterval = time.Duration(float64(dr.inspectionInterval) * 1.5)
+		if dr.inspectionInterval > maxInspectionInterval {
+			dr.inspectionInterval = maxInspectionInterval
 		}
-	case <-dr.stopCh:
-	}
-}
-
-// run is the core the core worker.
-// It processes the event queue and polls the state of generated
-// resource on a regular basis.
-func (dr *DeploymentReplication) run() {
-	log := dr.deps.Log
-
-	// Add finalizers
-	if err := dr.addFinalizers(); err != nil {
-		log.Warn().Err(err).Msg("Failed to add finalizers")
 	}
 
-	inspectionInterval := maxInspectionInterval
-	dr.inspectTrigger.Trigger()
-	for {
-		select {
-		case <-dr.stopCh:
-			// We're being stopped.
-			return
+	dr.inspectionInterval = dr.inspectDeploymentReplication(dr.inspectionInterval)
+	dr.nextInspection = time.Now().Add(dr.inspectionInterval)
 
-		case event := <-dr.eventCh:
-			// Got event from event queue
-			switch event.Type {
-			case eventArangoDeploymentReplicationUpdated:
-				if err := dr.handleArangoDeploymentReplicationUpdatedEvent(event); err != nil {
-					dr.failOnError(err, "Failed to handle deployment replication update")
-					return
-				}
-			default:
-				panic("unknown event type" + event.Type)
-			}
+	return dr.inspectionInterval, nil
+}
 
-		case <-dr.inspectTrigger.Done():
-			inspectionInterval = dr.inspectDeploymentReplication(inspectionInterval)
+// GetResourceVersion returns the resource version of the last known API object.
+func (dr *DeploymentReplication) GetResourceVersion() string {
+	return dr.apiObject.GetResourceVersion()
+}
 
-		case <-time.After(inspectionInterval):
-			// Trigger inspection
-			dr.inspectTrigger.Trigger()
-			// Backoff with next interval
-			inspectionInterval = time.Duration(float64(inspectionInterval) * 1.5)
-			if inspectionInterval > maxInspectionInterval {
-				inspectionInterval = maxInspectionInterval
-			}
-		}
-	}
+// Delete the deployment replication.
+// Called when the deployment replication was deleted by the user.
+func (dr *DeploymentReplication) Delete() {
+	dr.deps.Log.Info().Msg("deployment replication is deleted by user")
 }
 
 // handleArangoDeploymentReplicationUpdatedEvent is called when the deployment replication is updated by the user.
-func (dr *DeploymentReplication) handleArangoDeploymentReplicationUpdatedEvent(event *deploymentReplicationEvent) error {
-	log := dr.deps.Log.With().Str("deployoment-replication", event.DeploymentReplication.GetName()).Logger()
+func (dr *DeploymentReplication) handleArangoDeploymentReplicationUpdatedEvent(apiObject *api.ArangoDeploymentReplication) error {
+	log := dr.deps.Log.With().Str("deployoment-replication", apiObject.GetName()).Logger()
 	repls := dr.deps.CRCli.ReplicationV1().ArangoDeploymentReplications(dr.apiObject.GetNamespace())
 
 	// Get the most recent version of the deployment replication from the API server
@@ -230,9 +171,6 @@ func (dr *DeploymentReplication) handleArangoDeploymentReplicationUpdatedEvent(e
 		return maskAny(fmt.Errorf("failed to update ArangoDeploymentReplication spec: %v", err))
 	}
 
-	// Trigger inspect
-	dr.inspectTrigger.Trigger()
-
 	return nil
 }
 
@@ -318,47 +256,24 @@ func (dr *DeploymentReplication) updateCRSpec(newSpec api.DeploymentReplicationS
 }
 
 // failOnError reports the given error and sets the deployment replication status to failed.
-func (dr *DeploymentReplication) failOnError(err error, msg string) {
+func (dr *DeploymentReplication) failOnError(err error, msg string) error {
 	log := dr.deps.Log
 	log.Error().Err(err).Msg(msg)
 	dr.status.Reason = err.Error()
-	dr.reportFailedStatus()
+	return dr.reportFailedStatus()
 }
 
-// reportFailedStatus sets the status of the deployment replication to Failed and keeps trying to forward
-// that to the API server.
-func (dr *DeploymentReplication) reportFailedStatus() {
+// reportFailedStatus sets the status of the deployment replication to Failed and forwards that to the API server.
+// On error the controller retries with backoff.
+func (dr *DeploymentReplication) reportFailedStatus() error {
 	log := dr.deps.Log
-	log.Info().Msg("local storage failed. Reporting failed reason...")
-	repls := dr.deps.CRCli.ReplicationV1().ArangoDeploymentReplications(dr.apiObject.GetNamespace())
+	log.Info().Msg("deployment replication failed. Reporting failed reason...")
 
-	op := func() error {
-		dr.status.Phase = api.DeploymentReplicationPhaseFailed
-		err := dr.updateCRStatus()
-		if err == nil || k8sutil.IsNotFound(err) {
-			// Status has been updated
-			return nil
-		}
-
-		if !k8sutil.IsConflict(err) {
-			log.Warn().Err(err).Msg("retry report status: fail to update")
-			return maskAny(err)
-		}
-
-		depl, err := repls.Get(dr.apiObject.Name, metav1.GetOptions{})
-		if err != nil {
-			// Update (PUT) will return conflict even if object is deleted since we have UID set in object.
-			// Because it will check UID first and return something like:
-			// "Precondition failed: UID in precondition: 0xc42712c0f0, UID in object meta: ".
-			if k8sutil.IsNotFound(err) {
-				return nil
-			}
-			log.Warn().Err(err).Msg("retry report status: fail to get latest version")
-			return maskAny(err)
-		}
-		dr.apiObject = depl
-		return maskAny(fmt.Errorf("retry needed"))
+	dr.status.Phase = api.DeploymentReplicationPhaseFailed
+	if err := dr.updateCRStatus(); err != nil && !k8sutil.IsNotFound(err) {
+		log.Warn().Err(err).Msg("report status: fail to update")
+		return maskAny(err)
 	}
 
-	retry.Retry(op, time.Hour*24*365)
+	return nil
 }
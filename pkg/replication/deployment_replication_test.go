@@ -0,0 +1,120 @@
+//
+// DISCLAIMER
+//
+// Copyright 2020 ArangoDB GmbH, Cologne, Germany
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// Copyright holder is ArangoDB GmbH, Cologne, Germany
+//
+
+package replication
+
+import (
+	"testing"
+	"time"
+
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	kubefake "k8s.io/client-go/kubernetes/fake"
+	"k8s.io/client-go/tools/record"
+
+	api "github.com/arangodb/kube-arangodb/pkg/apis/replication/v1"
+	arangofake "github.com/arangodb/kube-arangodb/pkg/generated/clientset/versioned/fake"
+	"github.com/arangodb/kube-arangodb/pkg/util"
+)
+
+func newTestDeploymentReplication(t *testing.T) (*DeploymentReplication, *api.ArangoDeploymentReplication) {
+	apiObject := &api.ArangoDeploymentReplication{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "replication",
+			Namespace: "ns",
+		},
+		Spec: api.DeploymentReplicationSpec{
+			Source: api.EndpointSpec{
+				MasterEndpoint: []string{"https://source:8629"},
+				Authentication: api.EndpointAuthenticationSpec{
+					KeyfileSecretName: util.NewString("source-keyfile"),
+				},
+				TLS: api.EndpointTLSSpec{
+					CASecretName: util.NewString("source-ca"),
+				},
+			},
+			Destination: api.EndpointSpec{
+				DeploymentName: util.NewString("destination"),
+			},
+		},
+	}
+	apiObject.Spec.SetDefaults()
+
+	crCli := arangofake.NewSimpleClientset()
+	apiObject, err := crCli.ReplicationV1().ArangoDeploymentReplications(apiObject.GetNamespace()).Create(apiObject)
+	require.NoError(t, err)
+
+	dr, err := New(Config{Namespace: apiObject.GetNamespace()}, Dependencies{
+		Log:           zerolog.Nop(),
+		KubeCli:       kubefake.NewSimpleClientset(),
+		CRCli:         crCli,
+		EventRecorder: record.NewFakeRecorder(100),
+	}, apiObject)
+	require.NoError(t, err)
+
+	return dr, apiObject
+}
+
+func TestDeploymentReplication_Sync(t *testing.T) {
+	t.Run("Inspection is scheduled once", func(t *testing.T) {
+		dr, apiObject := newTestDeploymentReplication(t)
+
+		delay, err := dr.Sync(apiObject)
+		require.NoError(t, err)
+		require.NotZero(t, delay)
+
+		delay, err = dr.Sync(dr.apiObject)
+		require.NoError(t, err)
+		require.Zero(t, delay)
+	})
+
+	t.Run("Inspection is due", func(t *testing.T) {
+		dr, apiObject := newTestDeploymentReplication(t)
+
+		_, err := dr.Sync(apiObject)
+		require.NoError(t, err)
+
+		dr.nextInspection = time.Now()
+
+		delay, err := dr.Sync(dr.apiObject)
+		require.NoError(t, err)
+		require.NotZero(t, delay)
+	})
+
+	t.Run("Update is handled immediately", func(t *testing.T) {
+		dr, apiObject := newTestDeploymentReplication(t)
+
+		_, err := dr.Sync(apiObject)
+		require.NoError(t, err)
+
+		updated := dr.apiObject.DeepCopy()
+		updated.SetResourceVersion("updated")
+
+		delay, err := dr.Sync(updated)
+		require.NoError(t, err)
+		require.NotZero(t, delay)
+
+		// Same change is not handled twice
+		delay, err = dr.Sync(updated)
+		require.NoError(t, err)
+		require.Zero(t, delay)
+	})
+}
@@ -40,6 +40,7 @@ import (
 
 	"github.com/arangodb/kube-arangodb/dashboard"
 	"github.com/arangodb/kube-arangodb/pkg/util/probe"
+	"github.com/arangodb/kube-arangodb/pkg/webhook"
 )
 
 // Config settings for the Server
@@ -69,6 +70,7 @@ type Dependencies struct {
 	Backup                OperatorDependency
 	Operators             Operators
 	Secrets               corev1.SecretInterface
+	Webhook               *webhook.Handler // Admission and conversion webhook handler, webhooks are not served when nil
 }
 
 // Operators is the API provided to the server for accessing the various operators.
@@ -171,6 +173,11 @@ func NewServer(cli corev1.CoreV1Interface, cfg Config, deps Dependencies) (*Serv
 	r.GET("/ready", gin.WrapF(ready(readyProbes...)))
 	r.GET("/metrics", gin.WrapH(prometheus.Handler()))
 	r.POST("/login", s.auth.handleLogin)
+	if deps.Webhook != nil {
+		r.POST(webhook.ValidatePath, gin.WrapF(deps.Webhook.Validate))
+		r.POST(webhook.MutatePath, gin.WrapF(deps.Webhook.Mutate))
+		r.POST(webhook.ConvertPath, gin.WrapF(deps.Webhook.Convert))
+	}
 	api := r.Group("/api", s.auth.checkAuthentication)
 	{
 		api.GET("/operators", s.handleGetOperators)
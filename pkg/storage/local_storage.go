@@ -26,9 +26,9 @@ import (
 	"context"
 	"fmt"
 	"reflect"
-	"sync/atomic"
 	"time"
 
+	"github.com/pkg/errors"
 	"github.com/rs/zerolog"
 	"github.com/rs/zerolog/log"
 	v1 "k8s.io/api/core/v1"
@@ -40,8 +40,6 @@ import (
 	api "github.com/arangodb/kube-arangodb/pkg/apis/storage/v1alpha"
 	"github.com/arangodb/kube-arangodb/pkg/generated/clientset/versioned"
 	"github.com/arangodb/kube-arangodb/pkg/util/k8sutil"
-	"github.com/arangodb/kube-arangodb/pkg/util/retry"
-	"github.com/arangodb/kube-arangodb/pkg/util/trigger"
 )
 
 // Config holds configuration settings for a LocalStorage
@@ -59,28 +57,9 @@ type Dependencies struct {
 	EventRecorder record.EventRecorder
 }
 
-// localStorageEvent strongly typed type of event
-type localStorageEventType string
-
-const (
-	eventArangoLocalStorageUpdated localStorageEventType = "ArangoLocalStorageUpdated"
-	eventPVCAdded                  localStorageEventType = "pvcAdded"
-	eventPVCUpdated                localStorageEventType = "pvcUpdated"
-	eventPVUpdated                 localStorageEventType = "pvUpdated"
-)
-
-// localStorageEvent holds an event passed from the controller to the local storage.
-type localStorageEvent struct {
-	Type                  localStorageEventType
-	LocalStorage          *api.ArangoLocalStorage
-	PersistentVolume      *v1.PersistentVolume
-	PersistentVolumeClaim *v1.PersistentVolumeClaim
-}
-
 const (
-	localStorageEventQueueSize = 100
-	minInspectionInterval      = time.Second // Ensure we inspect the generated resources no less than with this interval
-	maxInspectionInterval      = time.Minute // Ensure we inspect the generated resources no less than with this interval
+	minInspectionInterval = time.Second // Ensure we inspect the generated resources no less than with this interval
+	maxInspectionInterval = time.Minute // Ensure we inspect the generated resources no less than with this interval
 )
 
 // LocalStorage is the in process state of an ArangoLocalStorage.
@@ -90,16 +69,19 @@ type LocalStorage struct {
 	config    Config
 	deps      Dependencies
 
-	eventCh chan *localStorageEvent
-	stopCh  chan struct{}
-	stopped int32
-
 	eventsCli corev1.EventInterface
 
 	image           string
 	imagePullPolicy v1.PullPolicy
-	inspectTrigger  trigger.Trigger
 	pvCleaner       *pvCleaner
+
+	created                bool   // StorageClass, DaemonSet and Service are created
+	handledVersion         string // Resource version of the last API object change handled
+	inspectionTriggered    bool
+	inspectionInterval     time.Duration
+	nextInspection         time.Time
+	recentInspectionErrors int
+	pvsNeededSince         *time.Time
 }
 
 // New creates a new LocalStorage from the given API object.
@@ -108,68 +90,85 @@ func New(config Config, deps Dependencies, apiObject *api.ArangoLocalStorage) (*
 		return nil, maskAny(err)
 	}
 	ls := &LocalStorage{
-		apiObject: apiObject,
-		status:    *(apiObject.Status.DeepCopy()),
-		config:    config,
-		deps:      deps,
-		eventCh:   make(chan *localStorageEvent, localStorageEventQueueSize),
-		stopCh:    make(chan struct{}),
+		apiObject:          apiObject,
+		status:             *(apiObject.Status.DeepCopy()),
+		config:             config,
+		deps:               deps,
+		inspectionInterval: maxInspectionInterval,
 	}
 
 	ls.pvCleaner = newPVCleaner(deps.Log, deps.KubeCli, ls.GetClientByNodeName)
 
-	go ls.run()
-	go ls.listenForPvcEvents()
-	go ls.listenForPvEvents()
-	go ls.pvCleaner.Run(ls.stopCh)
-
 	return ls, nil
 }
 
-// Update the local storage.
-// This sends an update event in the event queue.
-func (ls *LocalStorage) Update(apiObject *api.ArangoLocalStorage) {
-	ls.send(&localStorageEvent{
-		Type:         eventArangoLocalStorageUpdated,
-		LocalStorage: apiObject,
-	})
+// GetResourceVersion returns the resource version of the last known API object.
+func (ls *LocalStorage) GetResourceVersion() string {
+	return ls.apiObject.GetResourceVersion()
 }
 
-// Delete the local storage.
-// Called when the local storage was deleted by the user.
-func (ls *LocalStorage) Delete() {
-	ls.deps.Log.Info().Msg("local storage is deleted by user")
-	if atomic.CompareAndSwapInt32(&ls.stopped, 0, 1) {
-		close(ls.stopCh)
-	}
+// TriggerInspection makes the next Sync inspect the PVC's and PV's immediately.
+// Called when a PVC or PV has changed.
+func (ls *LocalStorage) TriggerInspection() {
+	ls.inspectionTriggered = true
 }
 
-// send given event into the local storage event queue.
-func (ls *LocalStorage) send(ev *localStorageEvent) {
-	select {
-	case ls.eventCh <- ev:
-		l, ecap := len(ls.eventCh), cap(ls.eventCh)
-		if l > int(float64(ecap)*0.8) {
-			ls.deps.Log.Warn().
-				Int("used", l).
-				Int("capacity", ecap).
-				Msg("event queue buffer is almost full")
+// Sync handles changes of the given API object and inspects the local storage when it is due.
+// It is called by the controller for every change of the API object, of PVC's and PV's and after the returned delay.
+// Returns the delay until the next inspection, 0 when no new inspection has to be scheduled.
+func (ls *LocalStorage) Sync(apiObject *api.ArangoLocalStorage) (time.Duration, error) {
+	if ls.status.State == api.LocalStorageStateFailed {
+		// Failed status was not stored yet
+		return 0, ls.reportFailedStatus()
+	}
+
+	if !ls.created {
+		if err := ls.create(); err != nil {
+			return 0, ls.failOnError(err, "Failed to create local storage")
 		}
-	case <-ls.stopCh:
+		ls.created = true
 	}
+
+	if version := apiObject.GetResourceVersion(); version != ls.apiObject.GetResourceVersion() && version != ls.handledVersion {
+		ls.handledVersion = version
+		if err := ls.handleArangoLocalStorageUpdatedEvent(apiObject); err != nil {
+			return 0, ls.failOnError(err, "Failed to handle local storage update")
+		}
+	} else if !ls.inspectionTriggered {
+		if time.Now().Before(ls.nextInspection) {
+			// Inspection is already scheduled
+			return 0, nil
+		}
+
+		if !ls.nextInspection.IsZero() {
+			// Backoff with next interval
+			ls.inspectionInterval = time.Duration(float64(ls.inspectionInterval) * 1.5)
+			if ls.inspectionInterval > maxInspectionInterval {
+				ls.inspectionInterval = maxInspectionInterval
+			}
+		}
+	}
+
+	ls.inspectionTriggered = false
+	ls.inspect()
+	ls.nextInspection = time.Now().Add(ls.inspectionInterval)
+
+	return ls.inspectionInterval, nil
 }
 
-// run is the core the core worker.
-// It processes the event queue and polls the state of generated
-// resource on a regular basis.
-func (ls *LocalStorage) run() {
-	//log := ls.deps.Log
+// Delete the local storage.
+// Called when the local storage was deleted by the user.
+func (ls *LocalStorage) Delete() {
+	ls.deps.Log.Info().Msg("local storage is deleted by user")
+}
 
+// create finds the image of the operator and creates the StorageClass,
+// the DaemonSet of provisioners and the Service to access them.
+func (ls *LocalStorage) create() error {
 	// Find out my image
 	image, pullPolicy, err := ls.getMyImage()
 	if err != nil {
-		ls.failOnError(err, "Failed to get my own image")
-		return
+		return errors.Wrap(err, "failed to get my own image")
 	}
 	ls.image = image
 	ls.imagePullPolicy = pullPolicy
@@ -184,115 +183,83 @@ func (ls *LocalStorage) run() {
 
 	// Create StorageClass
 	if err := ls.ensureStorageClass(ls.apiObject); err != nil {
-		ls.failOnError(err, "Failed to create storage class")
-		return
+		return errors.Wrap(err, "failed to create storage class")
 	}
 
 	// Create DaemonSet
 	if err := ls.ensureDaemonSet(ls.apiObject); err != nil {
-		ls.failOnError(err, "Failed to create daemon set")
-		return
+		return errors.Wrap(err, "failed to create daemon set")
 	}
 
 	// Create Service to access provisioners
 	if err := ls.ensureProvisionerService(ls.apiObject); err != nil {
-		ls.failOnError(err, "Failed to create service")
-		return
+		return errors.Wrap(err, "failed to create service")
 	}
 
-	inspectionInterval := maxInspectionInterval
-	recentInspectionErrors := 0
-	var pvsNeededSince *time.Time
-	for {
-		select {
-		case <-ls.stopCh:
-			// We're being stopped.
-			return
-
-		case event := <-ls.eventCh:
-			// Got event from event queue
-			switch event.Type {
-			case eventArangoLocalStorageUpdated:
-				if err := ls.handleArangoLocalStorageUpdatedEvent(event); err != nil {
-					ls.failOnError(err, "Failed to handle local storage update")
-					return
-				}
-			case eventPVCAdded, eventPVCUpdated, eventPVUpdated:
-				// Do an inspection of PVC's
-				ls.inspectTrigger.Trigger()
-			default:
-				panic("unknown event type" + event.Type)
-			}
+	return nil
+}
 
-		case <-ls.inspectTrigger.Done():
-			hasError := false
-			unboundPVCs, err := ls.inspectPVCs()
-			if err != nil {
-				hasError = true
-				ls.createEvent(k8sutil.NewErrorEvent("PVC inspection failed", err, ls.apiObject))
+// inspect creates PV's for unbound PVC's and cleans released PV's.
+func (ls *LocalStorage) inspect() {
+	hasError := false
+	unboundPVCs, err := ls.inspectPVCs()
+	if err != nil {
+		hasError = true
+		ls.createEvent(k8sutil.NewErrorEvent("PVC inspection failed", err, ls.apiObject))
+	}
+	pvsAvailable, err := ls.inspectPVs()
+	if err != nil {
+		hasError = true
+		ls.createEvent(k8sutil.NewErrorEvent("PV inspection failed", err, ls.apiObject))
+	}
+	if ls.pvCleaner.Clean() {
+		hasError = true
+	}
+	if len(unboundPVCs) == 0 {
+		ls.pvsNeededSince = nil
+	} else if len(unboundPVCs) > 0 {
+		createNow := false
+		if ls.pvsNeededSince != nil && time.Since(*ls.pvsNeededSince) > time.Second*30 {
+			// Create now
+			createNow = true
+		} else if pvsAvailable < len(unboundPVCs) {
+			// Create now
+			createNow = true
+		} else {
+			// Volumes are there, just may no be a match.
+			// Wait for that
+			if ls.pvsNeededSince == nil {
+				now := time.Now()
+				ls.pvsNeededSince = &now
 			}
-			pvsAvailable, err := ls.inspectPVs()
-			if err != nil {
+		}
+		if createNow {
+			ctx := context.Background()
+			if err := ls.createPVs(ctx, ls.apiObject, unboundPVCs); err != nil {
 				hasError = true
-				ls.createEvent(k8sutil.NewErrorEvent("PV inspection failed", err, ls.apiObject))
+				ls.createEvent(k8sutil.NewErrorEvent("PV creation failed", err, ls.apiObject))
 			}
-			if len(unboundPVCs) == 0 {
-				pvsNeededSince = nil
-			} else if len(unboundPVCs) > 0 {
-				createNow := false
-				if pvsNeededSince != nil && time.Since(*pvsNeededSince) > time.Second*30 {
-					// Create now
-					createNow = true
-				} else if pvsAvailable < len(unboundPVCs) {
-					// Create now
-					createNow = true
-				} else {
-					// Volumes are there, just may no be a match.
-					// Wait for that
-					if pvsNeededSince == nil {
-						now := time.Now()
-						pvsNeededSince = &now
-					}
-				}
-				if createNow {
-					ctx := context.Background()
-					if err := ls.createPVs(ctx, ls.apiObject, unboundPVCs); err != nil {
-						hasError = true
-						ls.createEvent(k8sutil.NewErrorEvent("PV creation failed", err, ls.apiObject))
-					}
-				}
-			}
-			if hasError {
-				if recentInspectionErrors == 0 {
-					inspectionInterval = minInspectionInterval
-					recentInspectionErrors++
-				}
-			} else {
-				if ls.status.State == api.LocalStorageStateCreating || ls.status.State == api.LocalStorageStateNone {
-					ls.status.State = api.LocalStorageStateRunning
-					if err := ls.updateCRStatus(); err != nil {
-						hasError = true
-						ls.createEvent(k8sutil.NewErrorEvent("Failed to update LocalStorage state", err, ls.apiObject))
-					}
-				}
-				recentInspectionErrors = 0
-			}
-
-		case <-time.After(inspectionInterval):
-			// Trigger inspection
-			ls.inspectTrigger.Trigger()
-			// Backoff with next interval
-			inspectionInterval = time.Duration(float64(inspectionInterval) * 1.5)
-			if inspectionInterval > maxInspectionInterval {
-				inspectionInterval = maxInspectionInterval
+		}
+	}
+	if hasError {
+		if ls.recentInspectionErrors == 0 {
+			ls.inspectionInterval = minInspectionInterval
+			ls.recentInspectionErrors++
+		}
+	} else {
+		if ls.status.State == api.LocalStorageStateCreating || ls.status.State == api.LocalStorageStateNone {
+			ls.status.State = api.LocalStorageStateRunning
+			if err := ls.updateCRStatus(); err != nil {
+				ls.createEvent(k8sutil.NewErrorEvent("Failed to update LocalStorage state", err, ls.apiObject))
 			}
 		}
+		ls.recentInspectionErrors = 0
 	}
 }
 
 // handleArangoLocalStorageUpdatedEvent is called when the local storage is updated by the user.
-func (ls *LocalStorage) handleArangoLocalStorageUpdatedEvent(event *localStorageEvent) error {
-	log := ls.deps.Log.With().Str("localStorage", event.LocalStorage.GetName()).Logger()
+func (ls *LocalStorage) handleArangoLocalStorageUpdatedEvent(apiObject *api.ArangoLocalStorage) error {
+	log := ls.deps.Log.With().Str("localStorage", apiObject.GetName()).Logger()
 
 	// Get the most recent version of the local storage from the API server
 	current, err := ls.deps.StorageCRCli.StorageV1alpha().ArangoLocalStorages().Get(ls.apiObject.GetName(), metav1.GetOptions{})
@@ -332,9 +299,6 @@ func (ls *LocalStorage) handleArangoLocalStorageUpdatedEvent(event *localStorage
 		return maskAny(fmt.Errorf("failed to update ArangoLocalStorage spec: %v", err))
 	}
 
-	// Trigger inspect
-	ls.inspectTrigger.Trigger()
-
 	return nil
 }
 
@@ -415,47 +379,25 @@ func (ls *LocalStorage) updateCRSpec(newSpec api.LocalStorageSpec) error {
 }
 
 // failOnError reports the given error and sets the local storage status to failed.
-func (ls *LocalStorage) failOnError(err error, msg string) {
+func (ls *LocalStorage) failOnError(err error, msg string) error {
 	log.Error().Err(err).Msg(msg)
 	ls.status.Reason = err.Error()
-	ls.reportFailedStatus()
+	return ls.reportFailedStatus()
 }
 
-// reportFailedStatus sets the status of the local storage to Failed and keeps trying to forward
-// that to the API server.
-func (ls *LocalStorage) reportFailedStatus() {
+// reportFailedStatus sets the status of the local storage to Failed and forwards that to the API server.
+// On error the controller retries with backoff.
+func (ls *LocalStorage) reportFailedStatus() error {
 	log := ls.deps.Log
 	log.Info().Msg("local storage failed. Reporting failed reason...")
 
-	op := func() error {
-		ls.status.State = api.LocalStorageStateFailed
-		err := ls.updateCRStatus()
-		if err == nil || k8sutil.IsNotFound(err) {
-			// Status has been updated
-			return nil
-		}
-
-		if !k8sutil.IsConflict(err) {
-			log.Warn().Err(err).Msg("retry report status: fail to update")
-			return maskAny(err)
-		}
-
-		depl, err := ls.deps.StorageCRCli.StorageV1alpha().ArangoLocalStorages().Get(ls.apiObject.Name, metav1.GetOptions{})
-		if err != nil {
-			// Update (PUT) will return conflict even if object is deleted since we have UID set in object.
-			// Because it will check UID first and return something like:
-			// "Precondition failed: UID in precondition: 0xc42712c0f0, UID in object meta: ".
-			if k8sutil.IsNotFound(err) {
-				return nil
-			}
-			log.Warn().Err(err).Msg("retry report status: fail to get latest version")
-			return maskAny(err)
-		}
-		ls.apiObject = depl
-		return maskAny(fmt.Errorf("retry needed"))
+	ls.status.State = api.LocalStorageStateFailed
+	if err := ls.updateCRStatus(); err != nil && !k8sutil.IsNotFound(err) {
+		log.Warn().Err(err).Msg("report status: fail to update")
+		return maskAny(err)
 	}
 
-	retry.Retry(op, time.Hour*24*365)
+	return nil
 }
 
 // isOwnerOf returns true if the given object belong to this local storage.
@@ -26,7 +26,6 @@ import (
 	"context"
 	"fmt"
 	"sync"
-	"time"
 
 	"github.com/rs/zerolog"
 	v1 "k8s.io/api/core/v1"
@@ -35,7 +34,6 @@ import (
 
 	"github.com/arangodb/kube-arangodb/pkg/storage/provisioner"
 	"github.com/arangodb/kube-arangodb/pkg/util/k8sutil"
-	"github.com/arangodb/kube-arangodb/pkg/util/trigger"
 )
 
 type pvCleaner struct {
@@ -43,7 +41,6 @@ type pvCleaner struct {
 	log          zerolog.Logger
 	cli          kubernetes.Interface
 	items        []v1.PersistentVolume
-	trigger      trigger.Trigger
 	clientGetter func(nodeName string) (provisioner.API, error)
 }
 
@@ -56,26 +53,17 @@ func newPVCleaner(log zerolog.Logger, cli kubernetes.Interface, clientGetter fun
 	}
 }
 
-// Run continues cleaning PV's until the given channel is closed.
-func (c *pvCleaner) Run(stopCh <-chan struct{}) {
+// Clean cleans the queued PV's until all are cleaned or cleaning fails.
+// Returns true when there are PV's left to clean.
+func (c *pvCleaner) Clean() bool {
 	for {
-		delay := time.Hour
 		hasMore, err := c.cleanFirst()
 		if err != nil {
 			c.log.Error().Err(err).Msg("Failed to clean PersistentVolume")
+			return hasMore
 		}
-		if hasMore {
-			delay = time.Millisecond * 5
-		}
-
-		select {
-		case <-stopCh:
-			// We're done
-			return
-		case <-c.trigger.Done():
-			// Continue
-		case <-time.After(delay):
-			// Continue
+		if !hasMore {
+			return false
 		}
 	}
 }
@@ -94,7 +82,6 @@ func (c *pvCleaner) Add(pv v1.PersistentVolume) {
 
 	// Is new, add it
 	c.items = append(c.items, pv)
-	c.trigger.Trigger()
 }
 
 // cleanFirst tries to clean the first PV in the list.
@@ -38,6 +38,7 @@ import (
 	corev1 "k8s.io/client-go/kubernetes/typed/core/v1"
 
 	api "github.com/arangodb/kube-arangodb/pkg/apis/deployment/v1"
+	"github.com/arangodb/kube-arangodb/pkg/deployment/pod"
 	"github.com/arangodb/kube-arangodb/pkg/util/k8sutil"
 )
 
@@ -155,13 +156,19 @@ func CreateArangodAgencyConnection(ctx context.Context, apiObject *api.ArangoDep
 
 // CreateArangodAgencyClient creates a go-driver client for accessing the agents of the given deployment.
 func CreateArangodAgencyClient(ctx context.Context, cli corev1.CoreV1Interface, apiObject *api.ArangoDeployment) (agency.Agency, error) {
-	var dnsNames []string
+	var hosts []string
 	for _, m := range apiObject.Status.Members.Agents {
 		dnsName := k8sutil.CreatePodDNSName(apiObject, api.ServerGroupAgents.AsRole(), m.ID)
-		dnsNames = append(dnsNames, dnsName)
+		hosts = append(hosts, net.JoinHostPort(dnsName, strconv.Itoa(k8sutil.ArangoPort)))
 	}
+	return CreateArangodAgencyClientForHosts(ctx, cli, apiObject, hosts)
+}
+
+// CreateArangodAgencyClientForHosts creates a go-driver client for accessing the agents of the given deployment
+// through the given host:port addresses instead of the agent DNS names (e.g. local port forwards).
+func CreateArangodAgencyClientForHosts(ctx context.Context, cli corev1.CoreV1Interface, apiObject *api.ArangoDeployment, hosts []string) (agency.Agency, error) {
 	shortTimeout := false
-	connConfig, err := createArangodHTTPConfigForDNSNames(ctx, apiObject, dnsNames, shortTimeout)
+	connConfig, err := createArangodHTTPConfigForHosts(ctx, apiObject, hosts, shortTimeout)
 	if err != nil {
 		return nil, maskAny(err)
 	}
@@ -186,6 +193,31 @@ func CreateArangodAgencyClient(ctx context.Context, cli corev1.CoreV1Interface,
 	return a, nil
 }
 
+// CreateArangodClientForHost creates a go-driver client for a server of the given deployment
+// reachable through the given host:port address (e.g. a local port forward).
+func CreateArangodClientForHost(ctx context.Context, cli corev1.CoreV1Interface, apiObject *api.ArangoDeployment, host string) (driver.Client, error) {
+	connConfig, err := createArangodHTTPConfigForHosts(ctx, apiObject, []string{host}, false)
+	if err != nil {
+		return nil, maskAny(err)
+	}
+	conn, err := http.NewConnection(connConfig)
+	if err != nil {
+		return nil, maskAny(err)
+	}
+	auth, err := createArangodClientAuthentication(ctx, cli, apiObject)
+	if err != nil {
+		return nil, maskAny(err)
+	}
+	c, err := driver.NewClient(driver.ClientConfig{
+		Connection:     conn,
+		Authentication: auth,
+	})
+	if err != nil {
+		return nil, maskAny(err)
+	}
+	return c, nil
+}
+
 // CreateArangodImageIDClient creates a go-driver client for an ArangoDB instance
 // running in an Image-ID pod.
 func CreateArangodImageIDClient(ctx context.Context, deployment k8sutil.APIObject, role, id string) (driver.Client, error) {
@@ -228,6 +260,15 @@ func createArangodClientForDNSName(ctx context.Context, cli corev1.CoreV1Interfa
 
 // createArangodHTTPConfigForDNSNames creates a go-driver HTTP connection config for a given DNS names.
 func createArangodHTTPConfigForDNSNames(ctx context.Context, apiObject *api.ArangoDeployment, dnsNames []string, shortTimeout bool) (http.ConnectionConfig, error) {
+	hosts := make([]string, 0, len(dnsNames))
+	for _, dnsName := range dnsNames {
+		hosts = append(hosts, net.JoinHostPort(dnsName, strconv.Itoa(k8sutil.ArangoPort)))
+	}
+	return createArangodHTTPConfigForHosts(ctx, apiObject, hosts, shortTimeout)
+}
+
+// createArangodHTTPConfigForHosts creates a go-driver HTTP connection config for a given host:port addresses.
+func createArangodHTTPConfigForHosts(ctx context.Context, apiObject *api.ArangoDeployment, hosts []string, shortTimeout bool) (http.ConnectionConfig, error) {
 	scheme := "http"
 	transport := sharedHTTPTransport
 	if shortTimeout {
@@ -244,8 +285,8 @@ func createArangodHTTPConfigForDNSNames(ctx context.Context, apiObject *api.Aran
 		Transport:          transport,
 		DontFollowRedirect: true,
 	}
-	for _, dnsName := range dnsNames {
-		connConfig.Endpoints = append(connConfig.Endpoints, scheme+"://"+net.JoinHostPort(dnsName, strconv.Itoa(k8sutil.ArangoPort)))
+	for _, host := range hosts {
+		connConfig.Endpoints = append(connConfig.Endpoints, scheme+"://"+host)
 	}
 	return connConfig, nil
 }
@@ -257,7 +298,11 @@ func createArangodClientAuthentication(ctx context.Context, cli corev1.CoreV1Int
 		// Should we skip using it?
 		if ctx.Value(skipAuthenticationKey{}) == nil {
 			secrets := cli.Secrets(apiObject.GetNamespace())
-			s, err := k8sutil.GetTokenSecret(secrets, apiObject.Spec.Authentication.GetJWTSecretName())
+			// Folder keeps the active token, user provided token can be replaced by the rotation
+			s, err := k8sutil.GetTokenSecret(secrets, pod.JWTSecretFolder(apiObject.GetName()))
+			if err != nil {
+				s, err = k8sutil.GetTokenSecret(secrets, apiObject.Spec.Authentication.GetJWTSecretName())
+			}
 			if err != nil {
 				return nil, maskAny(err)
 			}
@@ -36,6 +36,8 @@ const (
 	SecretEncryptionKey = "key"   // Key in a Secret.Data used to store an 32-byte encryption key
 	SecretKeyToken      = "token" // Key inside a Secret used to hold a JWT or monitoring token
 
+	SecretKeyCosignPublicKey = "cosign.pub" // Key in Secret.data used to store a PEM encoded cosign public key
+
 	SecretCACertificate = "ca.crt" // Key in Secret.data used to store a PEM encoded CA certificate (public key)
 	SecretCAKey         = "ca.key" // Key in Secret.data used to store a PEM encoded CA private key
 
@@ -47,6 +49,9 @@ const (
 	SecretAccessPackageYaml = "accessPackage.yaml" // Key in Secret.data used to store a YAML encoded access package
 
 	FinalizerDeplRemoveChildFinalizers = "database.arangodb.com/remove-child-finalizers" // Finalizer added to ArangoDeployment, indicating the need to remove finalizers from all children
+	FinalizerDatabaseDrop              = "arangodatabase.database.arangodb.com/drop"     // Finalizer added to ArangoDatabase, indicating the need to drop the database
+	FinalizerUserRemove                = "arangouser.database.arangodb.com/remove"       // Finalizer added to ArangoUser, indicating the need to remove the user
+	FinalizerCollectionDrop            = "arangocollection.database.arangodb.com/drop"   // Finalizer added to ArangoCollection, indicating the need to drop the collection
 	FinalizerDeplReplStopSync          = "replication.database.arangodb.com/stop-sync"   // Finalizer added to ArangoDeploymentReplication, indicating the need to stop synchronization
 	FinalizerPodAgencyServing          = "agent.database.arangodb.com/agency-serving"    // Finalizer added to Agents, indicating the need for keeping enough agents alive
 	FinalizerPodDrainDBServer          = "dbserver.database.arangodb.com/drain"          // Finalizer added to DBServers, indicating the need for draining that dbserver
@@ -0,0 +1,140 @@
+//
+// DISCLAIMER
+//
+// Copyright 2020 ArangoDB GmbH, Cologne, Germany
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// Copyright holder is ArangoDB GmbH, Cologne, Germany
+//
+
+package conversion
+
+import (
+	"fmt"
+	"reflect"
+)
+
+// Convert copies in into out, matching struct fields by their Go name.
+// It is meant for converting between versions of an API which share the Go layout of their types but differ
+// in serialization, so types are allowed to differ as long as their structure matches.
+// Fields of in without a counterpart in out must have a zero value, otherwise the conversion would be lossy
+// and an error is returned.
+// Values of identical types are shared between in and out, so in should be a deep copy owned by the caller.
+func Convert(in, out interface{}) error {
+	src := reflect.ValueOf(in)
+	dst := reflect.ValueOf(out)
+
+	if dst.Kind() != reflect.Ptr || dst.IsNil() {
+		return maskAny(fmt.Errorf("expected non nil pointer as output, got %T", out))
+	}
+
+	if src.Kind() == reflect.Ptr {
+		if src.IsNil() {
+			return maskAny(fmt.Errorf("expected non nil input"))
+		}
+		src = src.Elem()
+	}
+
+	if err := convert(src, dst.Elem(), src.Type().String()); err != nil {
+		return maskAny(err)
+	}
+
+	return nil
+}
+
+func convert(src, dst reflect.Value, path string) error {
+	if src.Type() == dst.Type() {
+		dst.Set(src)
+		return nil
+	}
+
+	if src.Kind() != dst.Kind() {
+		return fmt.Errorf("%s: unable to convert %s into %s", path, src.Type(), dst.Type())
+	}
+
+	switch src.Kind() {
+	case reflect.Ptr:
+		if src.IsNil() {
+			dst.Set(reflect.Zero(dst.Type()))
+			return nil
+		}
+		dst.Set(reflect.New(dst.Type().Elem()))
+		return convert(src.Elem(), dst.Elem(), path)
+	case reflect.Struct:
+		for i := 0; i < src.NumField(); i++ {
+			field := src.Type().Field(i)
+			fieldPath := path + "." + field.Name
+
+			target := dst.FieldByName(field.Name)
+			if !target.IsValid() {
+				if !src.Field(i).IsZero() {
+					return fmt.Errorf("%s: field does not exist in %s", fieldPath, dst.Type())
+				}
+				continue
+			}
+
+			if field.PkgPath != "" {
+				return fmt.Errorf("%s: unable to convert unexported field", fieldPath)
+			}
+
+			if err := convert(src.Field(i), target, fieldPath); err != nil {
+				return err
+			}
+		}
+		return nil
+	case reflect.Slice:
+		if src.IsNil() {
+			dst.Set(reflect.Zero(dst.Type()))
+			return nil
+		}
+		dst.Set(reflect.MakeSlice(dst.Type(), src.Len(), src.Len()))
+		for i := 0; i < src.Len(); i++ {
+			if err := convert(src.Index(i), dst.Index(i), fmt.Sprintf("%s[%d]", path, i)); err != nil {
+				return err
+			}
+		}
+		return nil
+	case reflect.Array:
+		for i := 0; i < src.Len(); i++ {
+			if err := convert(src.Index(i), dst.Index(i), fmt.Sprintf("%s[%d]", path, i)); err != nil {
+				return err
+			}
+		}
+		return nil
+	case reflect.Map:
+		if src.IsNil() {
+			dst.Set(reflect.Zero(dst.Type()))
+			return nil
+		}
+		dst.Set(reflect.MakeMapWithSize(dst.Type(), src.Len()))
+		iter := src.MapRange()
+		for iter.Next() {
+			key := reflect.New(dst.Type().Key()).Elem()
+			if err := convert(iter.Key(), key, fmt.Sprintf("%s[%v]", path, iter.Key())); err != nil {
+				return err
+			}
+			value := reflect.New(dst.Type().Elem()).Elem()
+			if err := convert(iter.Value(), value, fmt.Sprintf("%s[%v]", path, iter.Key())); err != nil {
+				return err
+			}
+			dst.SetMapIndex(key, value)
+		}
+		return nil
+	case reflect.Interface, reflect.Func, reflect.Chan, reflect.UnsafePointer:
+		return fmt.Errorf("%s: unable to convert %s", path, src.Kind())
+	default:
+		dst.Set(src.Convert(dst.Type()))
+		return nil
+	}
+}
@@ -0,0 +1,95 @@
+//
+// DISCLAIMER
+//
+// Copyright 2020 ArangoDB GmbH, Cologne, Germany
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// Copyright holder is ArangoDB GmbH, Cologne, Germany
+//
+
+package conversion
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type nameA string
+type nameB string
+
+type innerA struct {
+	Name  nameA
+	Value *int
+}
+
+type innerB struct {
+	Value *int
+	Name  nameB
+}
+
+type objectA struct {
+	Inner   *innerA
+	List    []innerA
+	Map     map[string]innerA
+	Legacy  *bool
+	Counter int
+}
+
+type objectB struct {
+	Inner   *innerB
+	List    []innerB
+	Map     map[string]innerB
+	Counter int
+}
+
+func TestConvert(t *testing.T) {
+	value := 5
+	in := objectA{
+		Inner:   &innerA{Name: "a", Value: &value},
+		List:    []innerA{{Name: "b"}},
+		Map:     map[string]innerA{"c": {Name: "c"}},
+		Counter: 3,
+	}
+
+	var out objectB
+	require.NoError(t, Convert(&in, &out))
+	assert.Equal(t, objectB{
+		Inner:   &innerB{Name: "a", Value: &value},
+		List:    []innerB{{Name: "b"}},
+		Map:     map[string]innerB{"c": {Name: "c"}},
+		Counter: 3,
+	}, out)
+
+	var back objectA
+	require.NoError(t, Convert(out, &back))
+	assert.Equal(t, in, back)
+}
+
+func TestConvertLossy(t *testing.T) {
+	legacy := true
+	in := objectA{Legacy: &legacy}
+
+	var out objectB
+	err := Convert(&in, &out)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "Legacy")
+}
+
+func TestConvertMismatch(t *testing.T) {
+	var out struct{ Counter string }
+	assert.Error(t, Convert(objectA{Counter: 1}, &out))
+	assert.Error(t, Convert(objectA{}, out))
+}
@@ -0,0 +1,100 @@
+//
+// DISCLAIMER
+//
+// Copyright 2020 ArangoDB GmbH, Cologne, Germany
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// Copyright holder is ArangoDB GmbH, Cologne, Germany
+//
+
+package crd
+
+import (
+	"github.com/rs/zerolog"
+	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	apiextensionsclient "k8s.io/apiextensions-apiserver/pkg/client/clientset/clientset"
+	"k8s.io/apimachinery/pkg/api/equality"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/fields"
+	"k8s.io/client-go/tools/cache"
+)
+
+// EnsureConversionWebhook configures the custom resource definition with given name
+// to convert between its versions with the webhook served by the given service.
+// The custom resource definition is updated only when its conversion configuration differs.
+func EnsureConversionWebhook(clientset apiextensionsclient.Interface, crdName string, service apiextensionsv1.ServiceReference, caBundle []byte) error {
+	crds := clientset.ApiextensionsV1().CustomResourceDefinitions()
+
+	crd, err := crds.Get(crdName, metav1.GetOptions{})
+	if err != nil {
+		return maskAny(err)
+	}
+
+	conversion := newConversionWebhook(service, caBundle)
+	if equality.Semantic.DeepEqual(crd.Spec.Conversion, conversion) {
+		return nil
+	}
+
+	crd.Spec.Conversion = conversion
+	if _, err := crds.Update(crd); err != nil {
+		return maskAny(err)
+	}
+
+	return nil
+}
+
+// WatchConversionWebhook watches the custom resource definition with given name and restores
+// its conversion webhook configuration when it drifts, e.g. after an upgrade of the CRD chart.
+// It blocks until the given channel is closed.
+func WatchConversionWebhook(log zerolog.Logger, clientset apiextensionsclient.Interface, crdName string,
+	service apiextensionsv1.ServiceReference, caBundle []byte, stop <-chan struct{}) {
+	source := cache.NewListWatchFromClient(clientset.ApiextensionsV1().RESTClient(), "customresourcedefinitions", "",
+		fields.OneTermEqualSelector("metadata.name", crdName))
+
+	conversion := newConversionWebhook(service, caBundle)
+
+	check := func(obj interface{}) {
+		crd, ok := obj.(*apiextensionsv1.CustomResourceDefinition)
+		if !ok || equality.Semantic.DeepEqual(crd.Spec.Conversion, conversion) {
+			return
+		}
+
+		if err := EnsureConversionWebhook(clientset, crdName, service, caBundle); err != nil {
+			log.Warn().Err(err).Str("crd", crdName).Msg("Failed to restore conversion webhook")
+			return
+		}
+
+		log.Info().Str("crd", crdName).Msg("Restored conversion webhook")
+	}
+
+	_, informer := cache.NewInformer(source, &apiextensionsv1.CustomResourceDefinition{}, 0, cache.ResourceEventHandlerFuncs{
+		AddFunc:    check,
+		UpdateFunc: func(oldObj, newObj interface{}) { check(newObj) },
+	})
+
+	informer.Run(stop)
+}
+
+func newConversionWebhook(service apiextensionsv1.ServiceReference, caBundle []byte) *apiextensionsv1.CustomResourceConversion {
+	return &apiextensionsv1.CustomResourceConversion{
+		Strategy: apiextensionsv1.WebhookConverter,
+		Webhook: &apiextensionsv1.WebhookConversion{
+			ClientConfig: &apiextensionsv1.WebhookClientConfig{
+				Service:  &service,
+				CABundle: caBundle,
+			},
+			ConversionReviewVersions: []string{"v1", "v1beta1"},
+		},
+	}
+}
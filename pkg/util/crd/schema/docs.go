@@ -0,0 +1,154 @@
+//
+// DISCLAIMER
+//
+// Copyright 2020 ArangoDB GmbH, Cologne, Germany
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// Copyright holder is ArangoDB GmbH, Cologne, Germany
+//
+
+package schema
+
+import (
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+const markerPrefix = "+kubebuilder:validation:"
+
+// Comment is the documentation of a type or field, split into the description and validation markers
+type Comment struct {
+	Description string
+	Markers     map[string]string
+}
+
+// TypeDoc is the documentation of a type and its fields
+type TypeDoc struct {
+	Comment
+	Fields map[string]Comment
+}
+
+// Docs contains documentation of types, keyed by the package path and the name of the type
+type Docs map[string]TypeDoc
+
+// LoadTree loads documentation of all packages in the directory of the module and its subdirectories
+func (d Docs) LoadTree(root, dir string) error {
+	return filepath.Walk(filepath.Join(root, dir), func(path string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+
+		if !info.IsDir() {
+			return nil
+		}
+
+		rel, err := filepath.Rel(root, path)
+		if err != nil {
+			return err
+		}
+
+		if err := d.Load(ModulePath+filepath.ToSlash(rel), path); err != nil {
+			return maskAny(err)
+		}
+
+		return nil
+	})
+}
+
+// Load parses Go sources of the package with given path from the directory and adds documentation of its types
+func (d Docs) Load(pkgPath, dir string) error {
+	fset := token.NewFileSet()
+	pkgs, err := parser.ParseDir(fset, dir, func(info os.FileInfo) bool {
+		return !strings.HasSuffix(info.Name(), "_test.go")
+	}, parser.ParseComments)
+	if err != nil {
+		return maskAny(err)
+	}
+
+	for _, pkg := range pkgs {
+		for _, file := range pkg.Files {
+			for _, decl := range file.Decls {
+				gen, ok := decl.(*ast.GenDecl)
+				if !ok || gen.Tok != token.TYPE {
+					continue
+				}
+
+				for _, spec := range gen.Specs {
+					typeSpec := spec.(*ast.TypeSpec)
+
+					doc := typeSpec.Doc
+					if doc == nil && len(gen.Specs) == 1 {
+						doc = gen.Doc
+					}
+
+					t := TypeDoc{
+						Comment: parseComment(doc),
+						Fields:  map[string]Comment{},
+					}
+
+					if s, ok := typeSpec.Type.(*ast.StructType); ok {
+						for _, field := range s.Fields.List {
+							doc := field.Doc
+							if doc == nil {
+								doc = field.Comment
+							}
+							for _, name := range field.Names {
+								t.Fields[name.Name] = parseComment(doc)
+							}
+						}
+					}
+
+					d[pkgPath+"."+typeSpec.Name.Name] = t
+				}
+			}
+		}
+	}
+
+	return nil
+}
+
+func parseComment(doc *ast.CommentGroup) Comment {
+	c := Comment{
+		Markers: map[string]string{},
+	}
+
+	if doc == nil {
+		return c
+	}
+
+	var lines []string
+	for _, line := range strings.Split(doc.Text(), "\n") {
+		// Indented lines are examples, not markers
+		if strings.HasPrefix(line, "+") {
+			if strings.HasPrefix(line, markerPrefix) {
+				marker := strings.SplitN(strings.TrimPrefix(line, markerPrefix), "=", 2)
+				if len(marker) == 2 {
+					c.Markers[marker[0]] = marker[1]
+				} else {
+					c.Markers[marker[0]] = ""
+				}
+			}
+			continue
+		}
+
+		lines = append(lines, strings.TrimSpace(line))
+	}
+
+	c.Description = strings.TrimSpace(strings.Join(lines, "\n"))
+	return c
+}
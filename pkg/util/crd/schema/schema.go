@@ -0,0 +1,318 @@
+//
+// DISCLAIMER
+//
+// Copyright 2020 ArangoDB GmbH, Cologne, Germany
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// Copyright holder is ArangoDB GmbH, Cologne, Germany
+//
+
+package schema
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+
+	"github.com/ghodss/yaml"
+	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+)
+
+const (
+	// ModulePath is the path of the module, types of other modules are not expanded in schemas
+	ModulePath = "github.com/arangodb/kube-arangodb/"
+
+	header = "# Code generated by crdgen. DO NOT EDIT.\n"
+
+	markerEnum       = "Enum"
+	markerMinimum    = "Minimum"
+	markerMaximum    = "Maximum"
+	markerSchemaless = "Schemaless"
+)
+
+var (
+	jsonMarshaler = reflect.TypeOf((*json.Marshaler)(nil)).Elem()
+
+	// knownTypes contains schemas of types with custom JSON serialization
+	knownTypes = map[reflect.Type]apiextensionsv1.JSONSchemaProps{
+		reflect.TypeOf(metav1.Time{}):           {Type: "string", Format: "date-time"},
+		reflect.TypeOf(metav1.MicroTime{}):      {Type: "string", Format: "date-time"},
+		reflect.TypeOf(metav1.Duration{}):       {Type: "string"},
+		reflect.TypeOf(resource.Quantity{}):     {XIntOrString: true},
+		reflect.TypeOf(intstr.IntOrString{}):    {XIntOrString: true},
+		reflect.TypeOf(metav1.LabelSelector{}):  preserveUnknownFields(),
+		reflect.TypeOf(metav1.OwnerReference{}): preserveUnknownFields(),
+	}
+)
+
+// Generator renders structural OpenAPI v3 schemas of custom resources from their Go types.
+// Types of this module are expanded with descriptions and validations taken from their documentation.
+// Struct types of other modules, like the Kubernetes core types, are kept as objects with unknown fields preserved.
+//
+// Supported markers, set in documentation of a type or field:
+//
+//	+kubebuilder:validation:Enum=A;B
+//	+kubebuilder:validation:Minimum=0
+//	+kubebuilder:validation:Maximum=100
+//	+kubebuilder:validation:Schemaless
+type Generator struct {
+	docs Docs
+}
+
+// NewGenerator creates a generator using given documentation of types
+func NewGenerator(docs Docs) *Generator {
+	return &Generator{docs: docs}
+}
+
+// Generate returns the schema of the custom resource with given type
+func (g *Generator) Generate(t reflect.Type) (*apiextensionsv1.JSONSchemaProps, error) {
+	for t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+
+	if t.Kind() != reflect.Struct {
+		return nil, maskAny(fmt.Errorf("custom resource type %s is not a struct", t))
+	}
+
+	s, err := g.schema(t, nil)
+	if err != nil {
+		return nil, maskAny(err)
+	}
+
+	if _, ok := s.Properties["metadata"]; ok {
+		// Metadata is validated by the API server
+		s.Properties["metadata"] = apiextensionsv1.JSONSchemaProps{Type: "object"}
+	}
+
+	return &s, nil
+}
+
+// Render returns the schema of the custom resource with given type in YAML format
+func (g *Generator) Render(t reflect.Type) ([]byte, error) {
+	s, err := g.Generate(t)
+	if err != nil {
+		return nil, maskAny(err)
+	}
+
+	data, err := yaml.Marshal(s)
+	if err != nil {
+		return nil, maskAny(err)
+	}
+
+	return append([]byte(header), data...), nil
+}
+
+func (g *Generator) schema(t reflect.Type, stack []reflect.Type) (apiextensionsv1.JSONSchemaProps, error) {
+	if s, ok := knownTypes[t]; ok {
+		return s, nil
+	}
+
+	if !strings.HasPrefix(t.PkgPath(), ModulePath) && t.Kind() == reflect.Struct {
+		if t.Implements(jsonMarshaler) || reflect.PtrTo(t).Implements(jsonMarshaler) {
+			return apiextensionsv1.JSONSchemaProps{}, fmt.Errorf("type %s with custom JSON serialization is not supported", t)
+		}
+		return preserveUnknownFields(), nil
+	}
+
+	for _, p := range stack {
+		if p == t {
+			return apiextensionsv1.JSONSchemaProps{}, fmt.Errorf("recursive type %s is not supported", t)
+		}
+	}
+
+	var s apiextensionsv1.JSONSchemaProps
+
+	switch t.Kind() {
+	case reflect.Bool:
+		s.Type = "boolean"
+	case reflect.String:
+		s.Type = "string"
+	case reflect.Int, reflect.Int64, reflect.Uint, reflect.Uint64:
+		s.Type = "integer"
+		s.Format = "int64"
+	case reflect.Int8, reflect.Int16, reflect.Int32, reflect.Uint8, reflect.Uint16, reflect.Uint32:
+		s.Type = "integer"
+		s.Format = "int32"
+	case reflect.Float32, reflect.Float64:
+		s.Type = "number"
+	case reflect.Ptr:
+		return g.schema(t.Elem(), stack)
+	case reflect.Slice, reflect.Array:
+		if t.Elem().Kind() == reflect.Uint8 {
+			s.Type = "string"
+			s.Format = "byte"
+			break
+		}
+		items, err := g.schema(t.Elem(), append(stack, t))
+		if err != nil {
+			return s, err
+		}
+		s.Type = "array"
+		s.Items = &apiextensionsv1.JSONSchemaPropsOrArray{Schema: &items}
+	case reflect.Map:
+		if t.Key().Kind() != reflect.String {
+			return s, fmt.Errorf("map %s with non string keys is not supported", t)
+		}
+		values, err := g.schema(t.Elem(), append(stack, t))
+		if err != nil {
+			return s, err
+		}
+		s.Type = "object"
+		s.AdditionalProperties = &apiextensionsv1.JSONSchemaPropsOrBool{Allows: true, Schema: &values}
+	case reflect.Struct:
+		s.Type = "object"
+		s.Properties = map[string]apiextensionsv1.JSONSchemaProps{}
+		if err := g.properties(t, &s, append(stack, t)); err != nil {
+			return s, err
+		}
+	case reflect.Interface:
+		return preserveUnknownFields(), nil
+	default:
+		return s, fmt.Errorf("kind %s of type %s is not supported", t.Kind(), t)
+	}
+
+	if doc, ok := g.docs[t.PkgPath()+"."+t.Name()]; ok && t.Name() != "" {
+		if err := applyMarkers(&s, t, doc.Markers); err != nil {
+			return s, fmt.Errorf("%s: %s", t, err.Error())
+		}
+		if s.Description == "" {
+			s.Description = doc.Description
+		}
+	}
+
+	return s, nil
+}
+
+// properties adds fields of the struct type into the schema, following JSON serialization rules
+func (g *Generator) properties(t reflect.Type, s *apiextensionsv1.JSONSchemaProps, stack []reflect.Type) error {
+	doc := g.docs[t.PkgPath()+"."+t.Name()]
+
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+
+		name, opts := parseTag(field.Tag.Get("json"))
+		if name == "-" {
+			continue
+		}
+
+		fieldType := field.Type
+		for fieldType.Kind() == reflect.Ptr {
+			fieldType = fieldType.Elem()
+		}
+
+		embedded := field.Anonymous && name == "" && fieldType.Kind() == reflect.Struct
+		if field.PkgPath != "" && !embedded {
+			// Unexported fields are not serialized, unless they are embedded structs
+			continue
+		}
+
+		if embedded {
+			if err := g.properties(fieldType, s, stack); err != nil {
+				return err
+			}
+			continue
+		}
+
+		if name == "" {
+			name = field.Name
+		}
+
+		p, err := g.schema(field.Type, stack)
+		if err != nil {
+			return fmt.Errorf("%s.%s: %s", t.Name(), field.Name, err.Error())
+		}
+
+		if c, ok := doc.Fields[field.Name]; ok {
+			if err := applyMarkers(&p, fieldType, c.Markers); err != nil {
+				return fmt.Errorf("%s.%s: %s", t.Name(), field.Name, err.Error())
+			}
+			if c.Description != "" {
+				p.Description = c.Description
+			}
+		}
+
+		if !opts["omitempty"] {
+			switch field.Type.Kind() {
+			case reflect.Ptr, reflect.Slice, reflect.Map, reflect.Interface:
+				// Nil values are serialized as null
+				p.Nullable = true
+			}
+		}
+
+		s.Properties[name] = p
+	}
+
+	return nil
+}
+
+// applyMarkers sets validations of the markers on the schema
+func applyMarkers(s *apiextensionsv1.JSONSchemaProps, t reflect.Type, markers map[string]string) error {
+	for marker, value := range markers {
+		switch marker {
+		case markerEnum:
+			s.Enum = nil
+			for _, v := range strings.Split(value, ";") {
+				var raw []byte
+				var err error
+				if t.Kind() == reflect.String {
+					raw, err = json.Marshal(v)
+				} else {
+					raw, err = json.Marshal(json.Number(v))
+				}
+				if err != nil {
+					return err
+				}
+				s.Enum = append(s.Enum, apiextensionsv1.JSON{Raw: raw})
+			}
+		case markerMinimum, markerMaximum:
+			v, err := strconv.ParseFloat(value, 64)
+			if err != nil {
+				return fmt.Errorf("invalid %s marker: %s", marker, err.Error())
+			}
+			if marker == markerMinimum {
+				s.Minimum = &v
+			} else {
+				s.Maximum = &v
+			}
+		case markerSchemaless:
+			*s = preserveUnknownFields()
+		default:
+			return fmt.Errorf("unknown marker %s", marker)
+		}
+	}
+
+	return nil
+}
+
+func parseTag(tag string) (string, map[string]bool) {
+	parts := strings.Split(tag, ",")
+	opts := map[string]bool{}
+	for _, o := range parts[1:] {
+		opts[o] = true
+	}
+	return parts[0], opts
+}
+
+func preserveUnknownFields() apiextensionsv1.JSONSchemaProps {
+	preserve := true
+	return apiextensionsv1.JSONSchemaProps{
+		Type:                   "object",
+		XPreserveUnknownFields: &preserve,
+	}
+}
@@ -0,0 +1,120 @@
+//
+// DISCLAIMER
+//
+// Copyright 2020 ArangoDB GmbH, Cologne, Germany
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// Copyright holder is ArangoDB GmbH, Cologne, Germany
+//
+
+package schema
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	core "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+type testMode string
+
+type testInner struct {
+	Mode  *testMode `json:"mode,omitempty"`
+	Count *int      `json:"count,omitempty"`
+}
+
+type testSpec struct {
+	testInline `json:",inline"`
+
+	Inner     testInner         `json:"inner"`
+	List      []string          `json:"list"`
+	Labels    map[string]string `json:"labels,omitempty"`
+	Container *core.Container   `json:"container,omitempty"`
+	Time      metav1.Time       `json:"time,omitempty"`
+	Data      []byte            `json:"data,omitempty"`
+	Ignored   string            `json:"-"`
+}
+
+type testInline struct {
+	Inlined bool `json:"inlined,omitempty"`
+}
+
+type testResource struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+	Spec              testSpec `json:"spec"`
+}
+
+type testRecursive struct {
+	Next *testRecursive `json:"next,omitempty"`
+}
+
+const testPkg = ModulePath + "pkg/util/crd/schema"
+
+func TestGenerate(t *testing.T) {
+	docs := Docs{}
+	require.NoError(t, docs.Load(testPkg, "."))
+
+	// Documentation of test types is not parsed from test files
+	docs[testPkg+".testMode"] = TypeDoc{Comment: Comment{
+		Description: "Mode of the test",
+		Markers:     map[string]string{"Enum": "A;B"},
+	}}
+	docs[testPkg+".testInner"] = TypeDoc{Fields: map[string]Comment{
+		"Count": {Description: "Count of items", Markers: map[string]string{"Minimum": "0", "Maximum": "10"}},
+	}}
+
+	s, err := NewGenerator(docs).Generate(reflect.TypeOf(testResource{}))
+	require.NoError(t, err)
+
+	assert.Equal(t, "object", s.Type)
+	assert.Equal(t, "string", s.Properties["apiVersion"].Type)
+	assert.Equal(t, "object", s.Properties["metadata"].Type)
+	assert.Empty(t, s.Properties["metadata"].Properties)
+
+	spec := s.Properties["spec"]
+	assert.Contains(t, spec.Properties, "inlined")
+	assert.NotContains(t, spec.Properties, "Ignored")
+	assert.True(t, spec.Properties["list"].Nullable)
+	assert.False(t, spec.Properties["labels"].Nullable)
+	assert.Equal(t, "string", spec.Properties["labels"].AdditionalProperties.Schema.Type)
+	assert.True(t, *spec.Properties["container"].XPreserveUnknownFields)
+	assert.Equal(t, "date-time", spec.Properties["time"].Format)
+	assert.Equal(t, "byte", spec.Properties["data"].Format)
+
+	mode := spec.Properties["inner"].Properties["mode"]
+	assert.Equal(t, "Mode of the test", mode.Description)
+	require.Len(t, mode.Enum, 2)
+	assert.Equal(t, `"A"`, string(mode.Enum[0].Raw))
+
+	count := spec.Properties["inner"].Properties["count"]
+	assert.Equal(t, "Count of items", count.Description)
+	assert.Equal(t, float64(0), *count.Minimum)
+	assert.Equal(t, float64(10), *count.Maximum)
+
+	_, err = NewGenerator(docs).Generate(reflect.TypeOf(testRecursive{}))
+	assert.Error(t, err)
+}
+
+func TestParseComment(t *testing.T) {
+	docs := Docs{}
+	require.NoError(t, docs.Load(testPkg, "."))
+
+	c := docs[testPkg+".Generator"]
+	assert.Contains(t, c.Description, "Generator renders structural OpenAPI v3 schemas")
+	assert.Empty(t, c.Markers)
+}
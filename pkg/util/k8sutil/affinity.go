@@ -38,9 +38,9 @@ func CreateAffinity(deploymentName, role string, required bool, affinityWithRole
 					{
 						MatchExpressions: []v1.NodeSelectorRequirement{
 							{
-								Key:      "beta.kubernetes.io/arch",
+								Key:      NodeArchitectureKey,
 								Operator: "In",
-								Values:   []string{"amd64"},
+								Values:   []string{NodeArchitecture},
 							},
 						},
 					},
@@ -32,6 +32,7 @@ import (
 	"k8s.io/client-go/tools/clientcmd"
 
 	apiextensionsclient "k8s.io/apiextensions-apiserver/pkg/client/clientset/clientset"
+	"k8s.io/client-go/dynamic"
 	"k8s.io/client-go/kubernetes"
 	"k8s.io/client-go/rest"
 )
@@ -96,6 +97,19 @@ func NewKubeExtClient() (apiextensionsclient.Interface, error) {
 	return c, nil
 }
 
+// NewKubeDynamicClient creates a new dynamic client, used for custom resources of other projects
+func NewKubeDynamicClient() (dynamic.Interface, error) {
+	cfg, err := NewKubeConfig()
+	if err != nil {
+		return nil, maskAny(err)
+	}
+	c, err := dynamic.NewForConfig(cfg)
+	if err != nil {
+		return nil, maskAny(err)
+	}
+	return c, nil
+}
+
 func NewKubeMonitoringV1Client() (monitoringClient.MonitoringV1Interface, error) {
 	cfg, err := NewKubeConfig()
 	if err != nil {
@@ -35,6 +35,8 @@ const (
 	// K8s constants
 	ClusterIPNone       = "None"
 	TopologyKeyHostname = "kubernetes.io/hostname"
+	NodeArchitectureKey = "beta.kubernetes.io/arch"
+	NodeArchitecture    = "amd64" // Architecture of the nodes ArangoDB pods are scheduled on
 
 	// Internal constants
 	ImageIDAndVersionRole = "id" // Role use by identification pods
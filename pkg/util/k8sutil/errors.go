@@ -54,3 +54,9 @@ func IsNotFound(err error) bool {
 func IsInvalid(err error) bool {
 	return apierrors.IsInvalid(errors.Cause(err))
 }
+
+// IsForbidden returns true if the given error is or is caused by a
+// kubernetes ForbiddenError,
+func IsForbidden(err error) bool {
+	return apierrors.IsForbidden(errors.Cause(err))
+}
@@ -25,6 +25,7 @@ package k8sutil
 import (
 	"fmt"
 	"strings"
+	"time"
 
 	driver "github.com/arangodb/go-driver"
 	upgraderules "github.com/arangodb/go-upgrade-rules"
@@ -228,6 +229,15 @@ func NewCannotShrinkVolumeEvent(apiObject APIObject, pvcname string) *Event {
 	return event
 }
 
+// NewCertificateExpiringEvent creates an event indicating that a certificate, which is not renewed by the operator, expires soon.
+func NewCertificateExpiringEvent(apiObject APIObject, secretName string, notAfter time.Time) *Event {
+	event := newDeploymentEvent(apiObject)
+	event.Type = v1.EventTypeWarning
+	event.Reason = "Certificate Expiring"
+	event.Message = fmt.Sprintf("The CA certificate from secret %s expires at %s and it will not be renewed by the operator", secretName, notAfter.UTC().Format(time.RFC3339))
+	return event
+}
+
 // NewUpgradeNotAllowedEvent creates an event indicating that an upgrade (or downgrade) is not allowed.
 func NewUpgradeNotAllowedEvent(apiObject APIObject,
 	fromVersion, toVersion driver.Version,
@@ -258,6 +268,15 @@ func NewUpgradeNotAllowedEvent(apiObject APIObject,
 	return event
 }
 
+// NewUpgradeRollbackEvent creates an event indicating that a failed upgrade is rolled back.
+func NewUpgradeRollbackEvent(apiObject APIObject, memberID, fromImage, toImage string) *Event {
+	event := newDeploymentEvent(apiObject)
+	event.Type = v1.EventTypeWarning
+	event.Reason = "Upgrade Rolled Back"
+	event.Message = fmt.Sprintf("Member %s did not come up on image %s, rolling back to image %s", memberID, toImage, fromImage)
+	return event
+}
+
 // NewErrorEvent creates an even of type error.
 func NewErrorEvent(reason string, err error, apiObject APIObject) *Event {
 	event := newDeploymentEvent(apiObject)
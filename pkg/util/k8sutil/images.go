@@ -25,11 +25,13 @@ package k8sutil
 import (
 	"strings"
 
+	"github.com/arangodb/kube-arangodb/pkg/util/registry"
 	corev1 "k8s.io/api/core/v1"
 )
 
 const (
 	dockerPullableImageIDPrefix = "docker-pullable://"
+	imageIDDigestSeparator      = "@sha256:"
 )
 
 // ConvertImageID2Image converts a ImageID from a ContainerStatus to an Image that can be used
@@ -41,6 +43,33 @@ func ConvertImageID2Image(imageID string) string {
 	return imageID
 }
 
+// GetImageDigest returns the digest from an ImageID of a ContainerStatus.
+// Returns false when the ImageID does not contain a repository digest.
+func GetImageDigest(imageID string) (string, bool) {
+	i := strings.Index(imageID, imageIDDigestSeparator)
+	if i < 0 {
+		return "", false
+	}
+
+	return imageID[i+1:], true
+}
+
+// GetPinnedImage returns the given image referenced by the digest from the ImageID.
+// Returns false when the ImageID does not contain a repository digest.
+func GetPinnedImage(image, imageID string) (string, bool) {
+	digest, ok := GetImageDigest(imageID)
+	if !ok {
+		return "", false
+	}
+
+	ref, err := registry.ParseReference(image)
+	if err != nil {
+		return "", false
+	}
+
+	return ref.Pinned(digest), true
+}
+
 // GetArangoDBImageIDFromPod returns the ArangoDB specific image from a pod
 func GetArangoDBImageIDFromPod(pod *corev1.Pod) string {
 	rawImageID := pod.Status.ContainerStatuses[0].ImageID
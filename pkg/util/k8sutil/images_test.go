@@ -0,0 +1,42 @@
+//
+// DISCLAIMER
+//
+// Copyright 2020 ArangoDB GmbH, Cologne, Germany
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// Copyright holder is ArangoDB GmbH, Cologne, Germany
+//
+
+package k8sutil
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGetPinnedImage(t *testing.T) {
+	digest := "sha256:0123456789abcdef0123456789abcdef0123456789abcdef0123456789abcdef"
+
+	image, ok := GetPinnedImage("arangodb/arangodb:3.7.10", "docker.io/arangodb/arangodb@"+digest)
+	assert.True(t, ok)
+	assert.Equal(t, "arangodb/arangodb@"+digest, image)
+
+	image, ok = GetPinnedImage("localhost:5000/arangodb:3.7.10", ConvertImageID2Image("docker-pullable://localhost:5000/arangodb@"+digest))
+	assert.True(t, ok)
+	assert.Equal(t, "localhost:5000/arangodb@"+digest, image)
+
+	_, ok = GetPinnedImage("arangodb/arangodb:3.7.10", digest)
+	assert.False(t, ok)
+}
@@ -33,6 +33,7 @@ import (
 // of resource. The handler functions are protected from panics.
 type ResourceWatcher struct {
 	informer cache.Controller
+	indexer  cache.Indexer
 }
 
 // NewResourceWatcher creates a helper that watches for changes in a resource of a specific type.
@@ -45,7 +46,7 @@ func NewResourceWatcher(log zerolog.Logger, getter cache.Getter, resource, names
 		namespace,
 		fields.Everything())
 
-	_, informer := cache.NewIndexerInformer(source, objType, 0, cache.ResourceEventHandlerFuncs{
+	indexer, informer := cache.NewIndexerInformer(source, objType, 0, cache.ResourceEventHandlerFuncs{
 		AddFunc: func(obj interface{}) {
 			defer func() {
 				if err := recover(); err != nil {
@@ -80,9 +81,20 @@ func NewResourceWatcher(log zerolog.Logger, getter cache.Getter, resource, names
 
 	return &ResourceWatcher{
 		informer: informer,
+		indexer:  indexer,
 	}
 }
 
+// Indexer returns the local cache of the watched resources
+func (rw *ResourceWatcher) Indexer() cache.Indexer {
+	return rw.indexer
+}
+
+// HasSynced returns true when the initial list of resources was loaded into the cache
+func (rw *ResourceWatcher) HasSynced() bool {
+	return rw.informer.HasSynced()
+}
+
 // Run continues to watch for events on the selected type of resource
 // until the given channel is closed.
 func (rw *ResourceWatcher) Run(stopCh <-chan struct{}) {
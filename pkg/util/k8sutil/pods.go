@@ -41,23 +41,29 @@ import (
 )
 
 const (
-	ServerContainerName             = "server"
-	ExporterContainerName           = "exporter"
-	ArangodVolumeName               = "arangod-data"
-	TlsKeyfileVolumeName            = "tls-keyfile"
-	ClientAuthCAVolumeName          = "client-auth-ca"
-	ClusterJWTSecretVolumeName      = "cluster-jwt"
-	MasterJWTSecretVolumeName       = "master-jwt"
-	RocksdbEncryptionVolumeName     = "rocksdb-encryption"
-	ExporterJWTVolumeName           = "exporter-jwt"
-	ArangodVolumeMountDir           = "/data"
-	RocksDBEncryptionVolumeMountDir = "/secrets/rocksdb/encryption"
-	TLSKeyfileVolumeMountDir        = "/secrets/tls"
-	TLSSNIKeyfileVolumeMountDir     = "/secrets/sni"
-	ClientAuthCAVolumeMountDir      = "/secrets/client-auth/ca"
-	ClusterJWTSecretVolumeMountDir  = "/secrets/cluster/jwt"
-	ExporterJWTVolumeMountDir       = "/secrets/exporter/jwt"
-	MasterJWTSecretVolumeMountDir   = "/secrets/master/jwt"
+	ServerContainerName                       = "server"
+	ExporterContainerName                     = "exporter"
+	ArangodVolumeName                         = "arangod-data"
+	TlsKeyfileVolumeName                      = "tls-keyfile"
+	ClientAuthCAVolumeName                    = "client-auth-ca"
+	ClusterJWTSecretVolumeName                = "cluster-jwt"
+	MasterJWTSecretVolumeName                 = "master-jwt"
+	RocksdbEncryptionVolumeName               = "rocksdb-encryption"
+	RocksdbEncryptionWrappedVolumeName        = "rocksdb-encryption-wrapped"
+	RocksdbEncryptionProviderVolumeName       = "rocksdb-encryption-provider"
+	RocksdbEncryptionProviderCAVolumeName     = "rocksdb-encryption-provider-ca"
+	ExporterJWTVolumeName                     = "exporter-jwt"
+	ArangodVolumeMountDir                     = "/data"
+	RocksDBEncryptionVolumeMountDir           = "/secrets/rocksdb/encryption"
+	RocksDBEncryptionWrappedVolumeMountDir    = "/secrets/rocksdb/wrapped"
+	RocksDBEncryptionProviderVolumeMountDir   = "/secrets/rocksdb/provider"
+	RocksDBEncryptionProviderCAVolumeMountDir = "/secrets/rocksdb/provider-ca"
+	TLSKeyfileVolumeMountDir                  = "/secrets/tls"
+	TLSSNIKeyfileVolumeMountDir               = "/secrets/sni"
+	ClientAuthCAVolumeMountDir                = "/secrets/client-auth/ca"
+	ClusterJWTSecretVolumeMountDir            = "/secrets/cluster/jwt"
+	ExporterJWTVolumeMountDir                 = "/secrets/exporter/jwt"
+	MasterJWTSecretVolumeMountDir             = "/secrets/master/jwt"
 )
 
 // IsPodReady returns true if the PodReady condition on
@@ -183,6 +189,12 @@ func CreateTLSKeyfileSecretName(deploymentName, role, id string) string {
 	return CreatePodName(deploymentName, role, id, "-tls-keyfile")
 }
 
+// CreateTLSCertificateName returns the name of the cert-manager Certificate (and of the Secret it populates)
+// for a member with a given id in a deployment with a given name.
+func CreateTLSCertificateName(deploymentName, role, id string) string {
+	return CreatePodName(deploymentName, role, id, "-tls-certificate")
+}
+
 // ArangodVolumeMount creates a volume mount structure for arangod.
 func ArangodVolumeMount() core.VolumeMount {
 	return core.VolumeMount{
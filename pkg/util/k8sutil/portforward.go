@@ -0,0 +1,89 @@
+//
+// DISCLAIMER
+//
+// Copyright 2020 ArangoDB GmbH, Cologne, Germany
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// Copyright holder is ArangoDB GmbH, Cologne, Germany
+//
+
+package k8sutil
+
+import (
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"strconv"
+
+	"github.com/pkg/errors"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/rest"
+	"k8s.io/client-go/tools/portforward"
+	"k8s.io/client-go/transport/spdy"
+)
+
+// PortForward forwards a random local port to the given port of a pod.
+// It returns the local host:port address and a function which stops the forwarding.
+func PortForward(cfg *rest.Config, kubecli kubernetes.Interface, namespace, podName string, port int) (string, func(), error) {
+	roundTripper, upgrader, err := spdy.RoundTripperFor(cfg)
+	if err != nil {
+		return "", nil, maskAny(err)
+	}
+
+	url := kubecli.CoreV1().RESTClient().Post().
+		Resource("pods").
+		Namespace(namespace).
+		Name(podName).
+		SubResource("portforward").
+		URL()
+
+	dialer := spdy.NewDialer(upgrader, &http.Client{Transport: roundTripper}, http.MethodPost, url)
+
+	stopCh := make(chan struct{})
+	readyCh := make(chan struct{})
+	errCh := make(chan error, 1)
+
+	pf, err := portforward.NewOnAddresses(dialer, []string{"localhost"}, []string{fmt.Sprintf("0:%d", port)}, stopCh, readyCh, ioutil.Discard, ioutil.Discard)
+	if err != nil {
+		return "", nil, maskAny(err)
+	}
+
+	go func() {
+		errCh <- pf.ForwardPorts()
+	}()
+
+	select {
+	case <-readyCh:
+	case err := <-errCh:
+		if err == nil {
+			err = errors.Errorf("port forwarding to pod %s stopped unexpectedly", podName)
+		}
+		return "", nil, maskAny(err)
+	}
+
+	ports, err := pf.GetPorts()
+	if err != nil {
+		close(stopCh)
+		return "", nil, maskAny(err)
+	}
+
+	if len(ports) != 1 {
+		close(stopCh)
+		return "", nil, maskAny(errors.Errorf("expected 1 forwarded port, got %d", len(ports)))
+	}
+
+	return "localhost:" + strconv.Itoa(int(ports[0].Local)), func() {
+		close(stopCh)
+	}, nil
+}
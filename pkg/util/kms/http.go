@@ -0,0 +1,70 @@
+//
+// DISCLAIMER
+//
+// Copyright 2020 ArangoDB GmbH, Cologne, Germany
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// Copyright holder is ArangoDB GmbH, Cologne, Germany
+//
+
+package kms
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+
+	"github.com/pkg/errors"
+)
+
+// doJSON sends the request as JSON and decodes the JSON response
+func doJSON(ctx context.Context, client *http.Client, url string, headers map[string]string, request, response interface{}) error {
+	body, err := json.Marshal(request)
+	if err != nil {
+		return maskAny(err)
+	}
+
+	req, err := http.NewRequest(http.MethodPost, url, bytes.NewReader(body))
+	if err != nil {
+		return maskAny(err)
+	}
+	req = req.WithContext(ctx)
+	req.Header.Set("Content-Type", "application/json")
+	for k, v := range headers {
+		req.Header.Set(k, v)
+	}
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return maskAny(err)
+	}
+	defer resp.Body.Close()
+
+	data, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return maskAny(err)
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return errors.Errorf("Provider responded with %d: %s", resp.StatusCode, string(bytes.TrimSpace(data)))
+	}
+
+	if err := json.Unmarshal(data, response); err != nil {
+		return errors.Wrapf(err, "Unable to parse provider response")
+	}
+
+	return nil
+}
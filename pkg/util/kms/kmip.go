@@ -0,0 +1,143 @@
+//
+// DISCLAIMER
+//
+// Copyright 2020 ArangoDB GmbH, Cologne, Germany
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// Copyright holder is ArangoDB GmbH, Cologne, Germany
+//
+
+package kms
+
+import (
+	"context"
+	"crypto/tls"
+	"net"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+func newKMIPProvider(config Config) (Provider, error) {
+	if config.KeyName == "" {
+		return nil, errors.Errorf("Key identifier is required")
+	}
+
+	tlsConfig, err := config.tlsConfig()
+	if err != nil {
+		return nil, maskAny(err)
+	}
+
+	if host, _, err := net.SplitHostPort(config.Endpoint); err == nil {
+		tlsConfig.ServerName = host
+	} else {
+		return nil, errors.Wrapf(err, "Invalid KMIP endpoint")
+	}
+
+	return &kmipProvider{
+		endpoint:  config.Endpoint,
+		keyID:     config.KeyName,
+		tlsConfig: tlsConfig,
+		dialer:    &net.Dialer{Timeout: config.Timeout},
+	}, nil
+}
+
+// kmipProvider unwraps keys with the Decrypt operation of a KMIP 1.2 server,
+// using the key with the configured unique identifier and its default cryptographic parameters.
+type kmipProvider struct {
+	endpoint  string
+	keyID     string
+	tlsConfig *tls.Config
+	dialer    *net.Dialer
+}
+
+func (k *kmipProvider) Unwrap(ctx context.Context, wrapped []byte) ([]byte, error) {
+	rawConn, err := k.dialer.DialContext(ctx, "tcp", k.endpoint)
+	if err != nil {
+		return nil, maskAny(err)
+	}
+
+	conn := tls.Client(rawConn, k.tlsConfig)
+	defer conn.Close()
+
+	if deadline, ok := ctx.Deadline(); ok {
+		conn.SetDeadline(deadline)
+	} else if k.dialer.Timeout > 0 {
+		conn.SetDeadline(time.Now().Add(k.dialer.Timeout))
+	}
+
+	if _, err := conn.Write(kmipDecryptRequest(k.keyID, wrapped).Marshal()); err != nil {
+		return nil, maskAny(err)
+	}
+
+	resp, err := readTTLV(conn)
+	if err != nil {
+		return nil, maskAny(err)
+	}
+
+	return kmipDecryptResponse(resp)
+}
+
+func kmipDecryptRequest(keyID string, data []byte) ttlv {
+	return ttlvStructure(kmipTagRequestMessage,
+		ttlvStructure(kmipTagRequestHeader,
+			ttlvStructure(kmipTagProtocolVersion,
+				ttlvInteger(kmipTagProtocolVersionMajor, 1),
+				ttlvInteger(kmipTagProtocolVersionMinor, 2),
+			),
+			ttlvInteger(kmipTagBatchCount, 1),
+		),
+		ttlvStructure(kmipTagBatchItem,
+			ttlvEnumeration(kmipTagOperation, kmipOperationDecrypt),
+			ttlvStructure(kmipTagRequestPayload,
+				ttlvTextString(kmipTagUniqueIdentifier, keyID),
+				ttlvByteString(kmipTagData, data),
+			),
+		),
+	)
+}
+
+func kmipDecryptResponse(resp ttlv) ([]byte, error) {
+	if resp.Tag != kmipTagResponseMessage {
+		return nil, errors.Errorf("Unexpected KMIP message %x", resp.Tag)
+	}
+
+	item, ok := resp.Child(kmipTagBatchItem)
+	if !ok {
+		return nil, errors.Errorf("KMIP response does not contain batch item")
+	}
+
+	status, ok := item.Child(kmipTagResultStatus)
+	if !ok {
+		return nil, errors.Errorf("KMIP response does not contain result status")
+	}
+
+	if status.Int != kmipResultSuccess {
+		reason, _ := item.Child(kmipTagResultReason)
+		message, _ := item.Child(kmipTagResultMessage)
+		return nil, errors.Errorf("KMIP Decrypt failed with status %d, reason %d: %s", status.Int, reason.Int, string(message.Bytes))
+	}
+
+	payload, ok := item.Child(kmipTagResponsePayload)
+	if !ok {
+		return nil, errors.Errorf("KMIP response does not contain payload")
+	}
+
+	data, ok := payload.Child(kmipTagData)
+	if !ok {
+		return nil, errors.Errorf("KMIP response does not contain data")
+	}
+
+	return data.Bytes, nil
+}
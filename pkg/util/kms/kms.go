@@ -0,0 +1,124 @@
+//
+// DISCLAIMER
+//
+// Copyright 2020 ArangoDB GmbH, Cologne, Germany
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// Copyright holder is ArangoDB GmbH, Cologne, Germany
+//
+
+package kms
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"net/http"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+// Type is the type of the key provider
+type Type string
+
+const (
+	// TypeVault unwraps keys with the HashiCorp Vault transit secrets engine
+	TypeVault Type = "Vault"
+	// TypeKMIP unwraps keys with the Decrypt operation of a KMIP server
+	TypeKMIP Type = "KMIP"
+	// TypeWebhook unwraps keys with a generic envelope encryption webhook
+	TypeWebhook Type = "Webhook"
+
+	// TokenKey is the key of the token in the credentials secret
+	TokenKey = "token"
+
+	defaultTimeout = 15 * time.Second
+)
+
+// Config holds the configuration of the key provider
+type Config struct {
+	Type     Type
+	Endpoint string
+	KeyName  string
+	// MountPath of the Vault transit secrets engine
+	MountPath string
+
+	// Token used to authenticate against Vault or the webhook
+	Token string
+	// ClientCert and ClientKey are PEM encoded TLS client credentials (KMIP)
+	ClientCert, ClientKey []byte
+	// CA is the PEM encoded CA used to verify the provider endpoint
+	CA []byte
+
+	Timeout time.Duration
+}
+
+// Provider unwraps key encryption wrapped keys
+type Provider interface {
+	// Unwrap returns the plain key for the wrapped one
+	Unwrap(ctx context.Context, wrapped []byte) ([]byte, error)
+}
+
+// NewProvider creates a provider for the given config
+func NewProvider(config Config) (Provider, error) {
+	if config.Timeout == 0 {
+		config.Timeout = defaultTimeout
+	}
+
+	switch config.Type {
+	case TypeVault:
+		return newVaultProvider(config)
+	case TypeKMIP:
+		return newKMIPProvider(config)
+	case TypeWebhook:
+		return newWebhookProvider(config)
+	default:
+		return nil, errors.Errorf("Provider type %s is not supported", config.Type)
+	}
+}
+
+func (c Config) tlsConfig() (*tls.Config, error) {
+	tlsConfig := &tls.Config{}
+
+	if len(c.CA) > 0 {
+		pool := x509.NewCertPool()
+		if !pool.AppendCertsFromPEM(c.CA) {
+			return nil, errors.Errorf("Unable to parse provider CA")
+		}
+		tlsConfig.RootCAs = pool
+	}
+
+	if len(c.ClientCert) > 0 || len(c.ClientKey) > 0 {
+		cert, err := tls.X509KeyPair(c.ClientCert, c.ClientKey)
+		if err != nil {
+			return nil, errors.Wrapf(err, "Unable to parse provider client certificate")
+		}
+		tlsConfig.Certificates = []tls.Certificate{cert}
+	}
+
+	return tlsConfig, nil
+}
+
+func (c Config) httpClient() (*http.Client, error) {
+	tlsConfig, err := c.tlsConfig()
+	if err != nil {
+		return nil, maskAny(err)
+	}
+
+	return &http.Client{
+		Transport: &http.Transport{TLSClientConfig: tlsConfig},
+		Timeout:   c.Timeout,
+	}, nil
+}
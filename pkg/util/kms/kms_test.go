@@ -0,0 +1,190 @@
+//
+// DISCLAIMER
+//
+// Copyright 2020 ArangoDB GmbH, Cologne, Germany
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// Copyright holder is ArangoDB GmbH, Cologne, Germany
+//
+
+package kms
+
+import (
+	"context"
+	"crypto/tls"
+	"encoding/base64"
+	"encoding/json"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	certificates "github.com/arangodb-helper/go-certificates"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var testKey = []byte("01234567890123456789012345678901")
+
+func TestVaultProvider(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/v1/transit/decrypt/arangodb", r.URL.Path)
+
+		if r.Header.Get(vaultTokenHeader) != "token" {
+			w.WriteHeader(http.StatusForbidden)
+			return
+		}
+
+		var req vaultDecryptRequest
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&req))
+		assert.Equal(t, "vault:v1:wrapped", req.Ciphertext)
+
+		var resp vaultDecryptResponse
+		resp.Data.Plaintext = base64.StdEncoding.EncodeToString(testKey)
+		json.NewEncoder(w).Encode(resp)
+	}))
+	defer server.Close()
+
+	p, err := NewProvider(Config{Type: TypeVault, Endpoint: server.URL, KeyName: "arangodb", Token: "token"})
+	require.NoError(t, err)
+
+	key, err := p.Unwrap(context.Background(), []byte("vault:v1:wrapped\n"))
+	require.NoError(t, err)
+	assert.Equal(t, testKey, key)
+
+	p, err = NewProvider(Config{Type: TypeVault, Endpoint: server.URL, KeyName: "arangodb", Token: "invalid"})
+	require.NoError(t, err)
+
+	_, err = p.Unwrap(context.Background(), []byte("vault:v1:wrapped"))
+	assert.Error(t, err)
+}
+
+func TestWebhookProvider(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var req WebhookRequest
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&req))
+		assert.Equal(t, "kek", req.KeyName)
+		assert.Equal(t, "Bearer token", r.Header.Get("Authorization"))
+
+		wrapped, err := base64.StdEncoding.DecodeString(req.Ciphertext)
+		require.NoError(t, err)
+
+		// Stand-in "unwraps" by reversing the bytes
+		key := make([]byte, len(wrapped))
+		for i := range wrapped {
+			key[len(wrapped)-1-i] = wrapped[i]
+		}
+
+		json.NewEncoder(w).Encode(WebhookResponse{Plaintext: base64.StdEncoding.EncodeToString(key)})
+	}))
+	defer server.Close()
+
+	p, err := NewProvider(Config{Type: TypeWebhook, Endpoint: server.URL, KeyName: "kek", Token: "token"})
+	require.NoError(t, err)
+
+	key, err := p.Unwrap(context.Background(), []byte("abc"))
+	require.NoError(t, err)
+	assert.Equal(t, []byte("cba"), key)
+}
+
+func TestKMIPProvider(t *testing.T) {
+	cert, key, err := certificates.CreateCertificate(certificates.CreateCertificateOptions{
+		CommonName: "localhost",
+		Hosts:      []string{"127.0.0.1"},
+		ValidFrom:  time.Now(),
+		ValidFor:   time.Hour,
+		IsCA:       true,
+		ECDSACurve: "P256",
+	}, nil)
+	require.NoError(t, err)
+
+	serverCert, err := tls.X509KeyPair([]byte(cert), []byte(key))
+	require.NoError(t, err)
+
+	listener, err := tls.Listen("tcp", "127.0.0.1:0", &tls.Config{Certificates: []tls.Certificate{serverCert}})
+	require.NoError(t, err)
+	defer listener.Close()
+
+	go func() {
+		conn, err := listener.Accept()
+		if err != nil {
+			return
+		}
+		defer conn.Close()
+
+		req, err := readTTLV(conn)
+		if !assert.NoError(t, err) {
+			return
+		}
+
+		item, _ := req.Child(kmipTagBatchItem)
+		operation, _ := item.Child(kmipTagOperation)
+		assert.Equal(t, kmipOperationDecrypt, operation.Int)
+		payload, _ := item.Child(kmipTagRequestPayload)
+		id, _ := payload.Child(kmipTagUniqueIdentifier)
+		assert.Equal(t, "key-1", string(id.Bytes))
+		data, _ := payload.Child(kmipTagData)
+		assert.Equal(t, []byte("wrapped"), data.Bytes)
+
+		resp := ttlvStructure(kmipTagResponseMessage,
+			ttlvStructure(kmipTagBatchItem,
+				ttlvEnumeration(kmipTagOperation, kmipOperationDecrypt),
+				ttlvEnumeration(kmipTagResultStatus, kmipResultSuccess),
+				ttlvStructure(kmipTagResponsePayload,
+					ttlvTextString(kmipTagUniqueIdentifier, "key-1"),
+					ttlvByteString(kmipTagData, testKey),
+				),
+			),
+		)
+		conn.Write(resp.Marshal())
+	}()
+
+	_, port, err := net.SplitHostPort(listener.Addr().String())
+	require.NoError(t, err)
+
+	p, err := NewProvider(Config{
+		Type:       TypeKMIP,
+		Endpoint:   net.JoinHostPort("127.0.0.1", port),
+		KeyName:    "key-1",
+		CA:         []byte(cert),
+		ClientCert: []byte(cert),
+		ClientKey:  []byte(key),
+	})
+	require.NoError(t, err)
+
+	unwrapped, err := p.Unwrap(context.Background(), []byte("wrapped"))
+	require.NoError(t, err)
+	assert.Equal(t, testKey, unwrapped)
+}
+
+func TestTTLVRoundTrip(t *testing.T) {
+	msg := kmipDecryptRequest("id", []byte("0123456789"))
+
+	items, err := unmarshalTTLV(msg.Marshal())
+	require.NoError(t, err)
+	require.Len(t, items, 1)
+	assert.Equal(t, msg, items[0])
+
+	// Values are padded to 8 bytes
+	assert.Len(t, ttlvByteString(kmipTagData, []byte("0123456789")).Marshal(), 8+16)
+
+	_, err = unmarshalTTLV(msg.Marshal()[:12])
+	assert.Error(t, err)
+}
+
+func TestNewProviderUnknownType(t *testing.T) {
+	_, err := NewProvider(Config{Type: "Unknown"})
+	assert.Error(t, err)
+}
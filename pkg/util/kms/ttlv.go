@@ -0,0 +1,213 @@
+//
+// DISCLAIMER
+//
+// Copyright 2020 ArangoDB GmbH, Cologne, Germany
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// Copyright holder is ArangoDB GmbH, Cologne, Germany
+//
+
+package kms
+
+import (
+	"encoding/binary"
+	"io"
+
+	"github.com/pkg/errors"
+)
+
+// Minimal KMIP TTLV (Tag, Type, Length, Value) encoding, enough to send a Decrypt operation
+
+const (
+	ttlvTypeStructure   byte = 0x01
+	ttlvTypeInteger     byte = 0x02
+	ttlvTypeEnumeration byte = 0x05
+	ttlvTypeTextString  byte = 0x07
+	ttlvTypeByteString  byte = 0x08
+
+	ttlvHeaderLength = 8
+	// ttlvMaxLength limits the size of messages read from the server
+	ttlvMaxLength = 1 << 20
+
+	kmipTagBatchCount           uint32 = 0x42000D
+	kmipTagBatchItem            uint32 = 0x42000F
+	kmipTagData                 uint32 = 0x4200C2
+	kmipTagOperation            uint32 = 0x42005C
+	kmipTagProtocolVersion      uint32 = 0x420069
+	kmipTagProtocolVersionMajor uint32 = 0x42006A
+	kmipTagProtocolVersionMinor uint32 = 0x42006B
+	kmipTagRequestHeader        uint32 = 0x420077
+	kmipTagRequestMessage       uint32 = 0x420078
+	kmipTagRequestPayload       uint32 = 0x420079
+	kmipTagResponseMessage      uint32 = 0x42007B
+	kmipTagResponsePayload      uint32 = 0x42007C
+	kmipTagResultMessage        uint32 = 0x42007D
+	kmipTagResultReason         uint32 = 0x42007E
+	kmipTagResultStatus         uint32 = 0x42007F
+	kmipTagUniqueIdentifier     uint32 = 0x420094
+
+	kmipOperationDecrypt int32 = 0x1F
+	kmipResultSuccess    int32 = 0x00
+)
+
+type ttlv struct {
+	Tag  uint32
+	Type byte
+
+	Children []ttlv
+	Int      int32
+	Bytes    []byte
+}
+
+func ttlvStructure(tag uint32, children ...ttlv) ttlv {
+	return ttlv{Tag: tag, Type: ttlvTypeStructure, Children: children}
+}
+
+func ttlvInteger(tag uint32, v int32) ttlv {
+	return ttlv{Tag: tag, Type: ttlvTypeInteger, Int: v}
+}
+
+func ttlvEnumeration(tag uint32, v int32) ttlv {
+	return ttlv{Tag: tag, Type: ttlvTypeEnumeration, Int: v}
+}
+
+func ttlvTextString(tag uint32, v string) ttlv {
+	return ttlv{Tag: tag, Type: ttlvTypeTextString, Bytes: []byte(v)}
+}
+
+func ttlvByteString(tag uint32, v []byte) ttlv {
+	return ttlv{Tag: tag, Type: ttlvTypeByteString, Bytes: v}
+}
+
+// Child returns the first child with the given tag
+func (t ttlv) Child(tag uint32) (ttlv, bool) {
+	for _, c := range t.Children {
+		if c.Tag == tag {
+			return c, true
+		}
+	}
+	return ttlv{}, false
+}
+
+// Marshal encodes the item
+func (t ttlv) Marshal() []byte {
+	var value []byte
+
+	switch t.Type {
+	case ttlvTypeStructure:
+		for _, c := range t.Children {
+			value = append(value, c.Marshal()...)
+		}
+	case ttlvTypeInteger, ttlvTypeEnumeration:
+		value = make([]byte, 4)
+		binary.BigEndian.PutUint32(value, uint32(t.Int))
+	default:
+		value = t.Bytes
+	}
+
+	out := make([]byte, ttlvHeaderLength, ttlvHeaderLength+len(value)+7)
+	out[0] = byte(t.Tag >> 16)
+	out[1] = byte(t.Tag >> 8)
+	out[2] = byte(t.Tag)
+	out[3] = t.Type
+	binary.BigEndian.PutUint32(out[4:], uint32(len(value)))
+	out = append(out, value...)
+
+	if pad := len(value) % 8; pad != 0 {
+		out = append(out, make([]byte, 8-pad)...)
+	}
+
+	return out
+}
+
+// unmarshalTTLV decodes all items from data
+func unmarshalTTLV(data []byte) ([]ttlv, error) {
+	var items []ttlv
+
+	for len(data) > 0 {
+		if len(data) < ttlvHeaderLength {
+			return nil, errors.Errorf("Truncated TTLV header")
+		}
+
+		t := ttlv{
+			Tag:  uint32(data[0])<<16 | uint32(data[1])<<8 | uint32(data[2]),
+			Type: data[3],
+		}
+		length := int(binary.BigEndian.Uint32(data[4:8]))
+		padded := length
+		if pad := length % 8; pad != 0 {
+			padded += 8 - pad
+		}
+
+		data = data[ttlvHeaderLength:]
+		if len(data) < length {
+			return nil, errors.Errorf("Truncated TTLV value")
+		}
+		value := data[:length]
+
+		switch t.Type {
+		case ttlvTypeStructure:
+			children, err := unmarshalTTLV(value)
+			if err != nil {
+				return nil, err
+			}
+			t.Children = children
+		case ttlvTypeInteger, ttlvTypeEnumeration:
+			if length != 4 {
+				return nil, errors.Errorf("Invalid length %d of integer", length)
+			}
+			t.Int = int32(binary.BigEndian.Uint32(value))
+		default:
+			t.Bytes = append([]byte{}, value...)
+		}
+
+		items = append(items, t)
+
+		if len(data) < padded {
+			padded = len(data)
+		}
+		data = data[padded:]
+	}
+
+	return items, nil
+}
+
+// readTTLV reads a single item from the reader
+func readTTLV(r io.Reader) (ttlv, error) {
+	header := make([]byte, ttlvHeaderLength)
+	if _, err := io.ReadFull(r, header); err != nil {
+		return ttlv{}, maskAny(err)
+	}
+
+	length := binary.BigEndian.Uint32(header[4:])
+	if length > ttlvMaxLength {
+		return ttlv{}, errors.Errorf("TTLV message too large: %d", length)
+	}
+
+	value := make([]byte, length)
+	if _, err := io.ReadFull(r, value); err != nil {
+		return ttlv{}, maskAny(err)
+	}
+
+	items, err := unmarshalTTLV(append(header, value...))
+	if err != nil {
+		return ttlv{}, maskAny(err)
+	}
+
+	if len(items) != 1 {
+		return ttlv{}, errors.Errorf("Expected single TTLV item, got %d", len(items))
+	}
+
+	return items[0], nil
+}
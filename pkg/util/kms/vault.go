@@ -0,0 +1,93 @@
+//
+// DISCLAIMER
+//
+// Copyright 2020 ArangoDB GmbH, Cologne, Germany
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// Copyright holder is ArangoDB GmbH, Cologne, Germany
+//
+
+package kms
+
+import (
+	"context"
+	"encoding/base64"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/pkg/errors"
+)
+
+const vaultTokenHeader = "X-Vault-Token"
+
+func newVaultProvider(config Config) (Provider, error) {
+	if config.KeyName == "" {
+		return nil, errors.Errorf("Key name is required")
+	}
+
+	client, err := config.httpClient()
+	if err != nil {
+		return nil, maskAny(err)
+	}
+
+	mountPath := strings.Trim(config.MountPath, "/")
+	if mountPath == "" {
+		mountPath = "transit"
+	}
+
+	return &vaultProvider{
+		client: client,
+		url:    fmt.Sprintf("%s/v1/%s/decrypt/%s", strings.TrimRight(config.Endpoint, "/"), mountPath, config.KeyName),
+		token:  config.Token,
+	}, nil
+}
+
+// vaultProvider unwraps keys with the decrypt endpoint of the Vault transit secrets engine.
+// Wrapped keys are Vault ciphertexts (vault:v1:...).
+type vaultProvider struct {
+	client *http.Client
+	url    string
+	token  string
+}
+
+type vaultDecryptRequest struct {
+	Ciphertext string `json:"ciphertext"`
+}
+
+type vaultDecryptResponse struct {
+	Data struct {
+		Plaintext string `json:"plaintext"`
+	} `json:"data"`
+}
+
+func (v *vaultProvider) Unwrap(ctx context.Context, wrapped []byte) ([]byte, error) {
+	var resp vaultDecryptResponse
+
+	headers := map[string]string{}
+	if v.token != "" {
+		headers[vaultTokenHeader] = v.token
+	}
+
+	if err := doJSON(ctx, v.client, v.url, headers, vaultDecryptRequest{Ciphertext: strings.TrimSpace(string(wrapped))}, &resp); err != nil {
+		return nil, maskAny(err)
+	}
+
+	key, err := base64.StdEncoding.DecodeString(resp.Data.Plaintext)
+	if err != nil {
+		return nil, errors.Wrapf(err, "Unable to decode plaintext")
+	}
+
+	return key, nil
+}
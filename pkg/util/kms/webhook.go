@@ -0,0 +1,91 @@
+//
+// DISCLAIMER
+//
+// Copyright 2020 ArangoDB GmbH, Cologne, Germany
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// Copyright holder is ArangoDB GmbH, Cologne, Germany
+//
+
+package kms
+
+import (
+	"context"
+	"encoding/base64"
+	"net/http"
+
+	"github.com/pkg/errors"
+)
+
+func newWebhookProvider(config Config) (Provider, error) {
+	client, err := config.httpClient()
+	if err != nil {
+		return nil, maskAny(err)
+	}
+
+	return &webhookProvider{
+		client:  client,
+		url:     config.Endpoint,
+		keyName: config.KeyName,
+		token:   config.Token,
+	}, nil
+}
+
+// webhookProvider unwraps keys with a generic envelope encryption webhook.
+// The webhook receives a POST request with WebhookRequest and responds with WebhookResponse.
+type webhookProvider struct {
+	client  *http.Client
+	url     string
+	keyName string
+	token   string
+}
+
+// WebhookRequest is sent to the envelope encryption webhook
+type WebhookRequest struct {
+	// KeyName is the name of the key encryption key, optional
+	KeyName string `json:"keyName,omitempty"`
+	// Ciphertext is the base64 encoded wrapped key
+	Ciphertext string `json:"ciphertext"`
+}
+
+// WebhookResponse is returned by the envelope encryption webhook
+type WebhookResponse struct {
+	// Plaintext is the base64 encoded key
+	Plaintext string `json:"plaintext"`
+}
+
+func (w *webhookProvider) Unwrap(ctx context.Context, wrapped []byte) ([]byte, error) {
+	var resp WebhookResponse
+
+	headers := map[string]string{}
+	if w.token != "" {
+		headers["Authorization"] = "Bearer " + w.token
+	}
+
+	req := WebhookRequest{
+		KeyName:    w.keyName,
+		Ciphertext: base64.StdEncoding.EncodeToString(wrapped),
+	}
+
+	if err := doJSON(ctx, w.client, w.url, headers, req, &resp); err != nil {
+		return nil, maskAny(err)
+	}
+
+	key, err := base64.StdEncoding.DecodeString(resp.Plaintext)
+	if err != nil {
+		return nil, errors.Wrapf(err, "Unable to decode plaintext")
+	}
+
+	return key, nil
+}
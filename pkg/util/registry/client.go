@@ -0,0 +1,346 @@
+//
+// DISCLAIMER
+//
+// Copyright 2020 ArangoDB GmbH, Cologne, Germany
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// Copyright holder is ArangoDB GmbH, Cologne, Germany
+//
+
+package registry
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/pkg/errors"
+)
+
+const (
+	headerAccept          = "Accept"
+	headerAuthorization   = "Authorization"
+	headerAuthenticate    = "WWW-Authenticate"
+	headerContentDigest   = "Docker-Content-Digest"
+	mediaTypeManifestV2   = "application/vnd.docker.distribution.manifest.v2+json"
+	mediaTypeManifestList = "application/vnd.docker.distribution.manifest.list.v2+json"
+	mediaTypeOCIManifest  = "application/vnd.oci.image.manifest.v1+json"
+	mediaTypeOCIIndex     = "application/vnd.oci.image.index.v1+json"
+
+	maxResponseSize = 16 * 1024 * 1024
+)
+
+var manifestMediaTypes = strings.Join([]string{
+	mediaTypeManifestV2,
+	mediaTypeManifestList,
+	mediaTypeOCIManifest,
+	mediaTypeOCIIndex,
+}, ", ")
+
+// Descriptor describes content stored in a registry
+type Descriptor struct {
+	MediaType   string            `json:"mediaType,omitempty"`
+	Digest      string            `json:"digest"`
+	Size        int64             `json:"size,omitempty"`
+	Annotations map[string]string `json:"annotations,omitempty"`
+	Platform    *Platform         `json:"platform,omitempty"`
+}
+
+// Platform of an image in a manifest list
+type Platform struct {
+	Architecture string `json:"architecture"`
+	OS           string `json:"os"`
+}
+
+// Manifest of an image or a manifest list
+type Manifest struct {
+	MediaType string       `json:"mediaType,omitempty"`
+	Config    Descriptor   `json:"config"`
+	Layers    []Descriptor `json:"layers,omitempty"`
+	Manifests []Descriptor `json:"manifests,omitempty"`
+}
+
+// IsList returns true when the manifest references manifests of multiple platforms
+func (m Manifest) IsList() bool {
+	return len(m.Manifests) > 0
+}
+
+// Client talks to the registry HTTP API V2
+type Client struct {
+	// HTTP client used for requests, http.DefaultClient when nil
+	HTTP *http.Client
+	// Credentials used to authenticate to registries
+	Credentials Credentials
+	// Insecure uses plain http instead of https
+	Insecure bool
+}
+
+// Resolve returns the digest of the manifest referenced by the given reference
+func (c *Client) Resolve(ctx context.Context, ref Reference) (string, error) {
+	resp, err := c.do(ctx, http.MethodHead, ref, "manifests/"+ref.Reference(), manifestMediaTypes)
+	if err != nil {
+		return "", maskAny(err)
+	}
+	defer resp.Body.Close()
+
+	digest := resp.Header.Get(headerContentDigest)
+	if digest == "" {
+		if ref.Digest != "" {
+			return ref.Digest, nil
+		}
+		return "", errors.Errorf("registry did not return digest of %s:%s", ref.Name, ref.Tag)
+	}
+
+	return digest, nil
+}
+
+// Manifest returns the manifest referenced by the given reference and its digest
+func (c *Client) Manifest(ctx context.Context, ref Reference) (Manifest, string, error) {
+	resp, err := c.do(ctx, http.MethodGet, ref, "manifests/"+ref.Reference(), manifestMediaTypes)
+	if err != nil {
+		return Manifest{}, "", maskAny(err)
+	}
+	defer resp.Body.Close()
+
+	data, err := readAll(resp.Body)
+	if err != nil {
+		return Manifest{}, "", maskAny(err)
+	}
+
+	var manifest Manifest
+	if err := json.Unmarshal(data, &manifest); err != nil {
+		return Manifest{}, "", maskAny(err)
+	}
+
+	digest := resp.Header.Get(headerContentDigest)
+	if digest == "" {
+		digest = Digest(data)
+	}
+
+	return manifest, digest, nil
+}
+
+// Blob returns the content of the blob with the given digest.
+// The content is verified against the digest.
+func (c *Client) Blob(ctx context.Context, ref Reference, digest string) ([]byte, error) {
+	resp, err := c.do(ctx, http.MethodGet, ref, "blobs/"+digest, "")
+	if err != nil {
+		return nil, maskAny(err)
+	}
+	defer resp.Body.Close()
+
+	data, err := readAll(resp.Body)
+	if err != nil {
+		return nil, maskAny(err)
+	}
+
+	if d := Digest(data); d != digest {
+		return nil, errors.Errorf("digest mismatch of blob %s, got %s", digest, d)
+	}
+
+	return data, nil
+}
+
+func (c *Client) httpClient() *http.Client {
+	if c.HTTP == nil {
+		return http.DefaultClient
+	}
+	return c.HTTP
+}
+
+func (c *Client) url(ref Reference, path string) string {
+	scheme := "https"
+	if c.Insecure {
+		scheme = "http"
+	}
+	return fmt.Sprintf("%s://%s/v2/%s/%s", scheme, ref.Domain, ref.Repository, path)
+}
+
+func (c *Client) do(ctx context.Context, method string, ref Reference, path, accept string) (*http.Response, error) {
+	u := c.url(ref, path)
+
+	resp, err := c.request(ctx, method, u, accept, "")
+	if err != nil {
+		return nil, maskAny(err)
+	}
+
+	if resp.StatusCode == http.StatusUnauthorized {
+		challenge := resp.Header.Get(headerAuthenticate)
+		resp.Body.Close()
+
+		auth, err := c.authorize(ctx, ref, challenge)
+		if err != nil {
+			return nil, maskAny(err)
+		}
+
+		if resp, err = c.request(ctx, method, u, accept, auth); err != nil {
+			return nil, maskAny(err)
+		}
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		resp.Body.Close()
+		return nil, errors.Errorf("unexpected status %d from %s", resp.StatusCode, u)
+	}
+
+	return resp, nil
+}
+
+func (c *Client) request(ctx context.Context, method, u, accept, auth string) (*http.Response, error) {
+	req, err := http.NewRequest(method, u, nil)
+	if err != nil {
+		return nil, maskAny(err)
+	}
+	req = req.WithContext(ctx)
+
+	if accept != "" {
+		req.Header.Set(headerAccept, accept)
+	}
+	if auth != "" {
+		req.Header.Set(headerAuthorization, auth)
+	}
+
+	resp, err := c.httpClient().Do(req)
+	if err != nil {
+		return nil, maskAny(err)
+	}
+
+	return resp, nil
+}
+
+// authorize returns the Authorization header value answering the given challenge
+func (c *Client) authorize(ctx context.Context, ref Reference, challenge string) (string, error) {
+	credential, hasCredential := c.Credentials.Get(ref.Domain)
+
+	scheme, params := parseChallenge(challenge)
+	switch strings.ToLower(scheme) {
+	case "basic":
+		if !hasCredential {
+			return "", errors.Errorf("registry %s requires credentials", ref.Domain)
+		}
+		req := &http.Request{Header: http.Header{}}
+		req.SetBasicAuth(credential.Username, credential.Password)
+		return req.Header.Get(headerAuthorization), nil
+	case "bearer":
+		realm, ok := params["realm"]
+		if !ok {
+			return "", errors.Errorf("registry %s returned bearer challenge without realm", ref.Domain)
+		}
+
+		query := url.Values{}
+		if service, ok := params["service"]; ok {
+			query.Set("service", service)
+		}
+		scope, ok := params["scope"]
+		if !ok {
+			scope = fmt.Sprintf("repository:%s:pull", ref.Repository)
+		}
+		query.Set("scope", scope)
+
+		req, err := http.NewRequest(http.MethodGet, realm+"?"+query.Encode(), nil)
+		if err != nil {
+			return "", maskAny(err)
+		}
+		req = req.WithContext(ctx)
+		if hasCredential {
+			req.SetBasicAuth(credential.Username, credential.Password)
+		}
+
+		resp, err := c.httpClient().Do(req)
+		if err != nil {
+			return "", maskAny(err)
+		}
+		defer resp.Body.Close()
+
+		if resp.StatusCode != http.StatusOK {
+			return "", errors.Errorf("unexpected status %d from token endpoint of %s", resp.StatusCode, ref.Domain)
+		}
+
+		data, err := readAll(resp.Body)
+		if err != nil {
+			return "", maskAny(err)
+		}
+
+		var token struct {
+			Token       string `json:"token"`
+			AccessToken string `json:"access_token"`
+		}
+		if err := json.Unmarshal(data, &token); err != nil {
+			return "", maskAny(err)
+		}
+
+		if token.Token == "" {
+			token.Token = token.AccessToken
+		}
+		if token.Token == "" {
+			return "", errors.Errorf("token endpoint of %s returned empty token", ref.Domain)
+		}
+
+		return "Bearer " + token.Token, nil
+	default:
+		return "", errors.Errorf("unsupported authentication challenge %q from %s", challenge, ref.Domain)
+	}
+}
+
+// parseChallenge parses a WWW-Authenticate header value
+func parseChallenge(challenge string) (string, map[string]string) {
+	params := map[string]string{}
+
+	parts := strings.SplitN(strings.TrimSpace(challenge), " ", 2)
+	if len(parts) < 2 {
+		return parts[0], params
+	}
+
+	rest := parts[1]
+	for rest != "" {
+		eq := strings.Index(rest, "=")
+		if eq < 0 {
+			break
+		}
+		key := strings.ToLower(strings.TrimSpace(rest[:eq]))
+		rest = rest[eq+1:]
+
+		var value string
+		if strings.HasPrefix(rest, "\"") {
+			end := strings.Index(rest[1:], "\"")
+			if end < 0 {
+				value, rest = rest[1:], ""
+			} else {
+				value, rest = rest[1:end+1], rest[end+2:]
+			}
+		} else if comma := strings.Index(rest, ","); comma >= 0 {
+			value, rest = rest[:comma], rest[comma:]
+		} else {
+			value, rest = rest, ""
+		}
+
+		params[key] = value
+		rest = strings.TrimLeft(rest, ", ")
+	}
+
+	return parts[0], params
+}
+
+func readAll(r io.Reader) ([]byte, error) {
+	data, err := ioutil.ReadAll(io.LimitReader(r, maxResponseSize))
+	if err != nil {
+		return nil, maskAny(err)
+	}
+	return data, nil
+}
@@ -0,0 +1,145 @@
+//
+// DISCLAIMER
+//
+// Copyright 2020 ArangoDB GmbH, Cologne, Germany
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// Copyright holder is ArangoDB GmbH, Cologne, Germany
+//
+
+package registry_test
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"encoding/pem"
+	"testing"
+
+	"github.com/arangodb/kube-arangodb/pkg/util/registry"
+	"github.com/arangodb/kube-arangodb/pkg/util/registry/fake"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestClient_Resolve(t *testing.T) {
+	r := fake.NewRegistry()
+	defer r.Close()
+
+	digest := r.AddImage("arangodb/arangodb", "3.7.10", registry.ImageConfig{})
+
+	ref, err := registry.ParseReference(r.Domain() + "/arangodb/arangodb:3.7.10")
+	require.NoError(t, err)
+
+	resolved, err := r.Client().Resolve(context.Background(), ref)
+	require.NoError(t, err)
+	assert.Equal(t, digest, resolved)
+
+	manifest, manifestDigest, err := r.Client().Manifest(context.Background(), ref)
+	require.NoError(t, err)
+	assert.Equal(t, digest, manifestDigest)
+
+	_, err = r.Client().Blob(context.Background(), ref, manifest.Config.Digest)
+	require.NoError(t, err)
+
+	t.Run("Missing tag", func(t *testing.T) {
+		ref.Tag = "3.7.11"
+		_, err := r.Client().Resolve(context.Background(), ref)
+		assert.Error(t, err)
+	})
+
+	t.Run("Without credentials", func(t *testing.T) {
+		c := r.Client()
+		c.Credentials = nil
+		_, err := c.Resolve(context.Background(), ref)
+		assert.Error(t, err)
+	})
+}
+
+func TestClient_Config(t *testing.T) {
+	r := fake.NewRegistry()
+	defer r.Close()
+
+	config := registry.ImageConfig{
+		Labels: map[string]string{"org.opencontainers.image.version": "3.7.10"},
+		Env:    []string{"PATH=/usr/bin", "ARANGO_VERSION=3.7.10"},
+	}
+	digest := r.AddImage("arangodb/arangodb", "3.7.10", config)
+
+	amd64 := registry.Platform{OS: "linux", Architecture: "amd64"}
+
+	ref, err := registry.ParseReference(r.Domain() + "/arangodb/arangodb:3.7.10")
+	require.NoError(t, err)
+
+	c, d, err := r.Client().Config(context.Background(), ref, amd64)
+	require.NoError(t, err)
+	assert.Equal(t, digest, d)
+	assert.Equal(t, config, c)
+
+	v, ok := c.GetEnv("ARANGO_VERSION")
+	assert.True(t, ok)
+	assert.Equal(t, "3.7.10", v)
+	_, ok = c.GetEnv("ARANGO_LICENSE")
+	assert.False(t, ok)
+
+	t.Run("Manifest list", func(t *testing.T) {
+		list := r.AddManifest("arangodb/arangodb", "3.7", registry.Manifest{
+			Manifests: []registry.Descriptor{
+				{Digest: r.AddImage("arangodb/arangodb", "3.7-arm64", registry.ImageConfig{}), Platform: &registry.Platform{OS: "linux", Architecture: "arm64"}},
+				{Digest: digest, Platform: &amd64},
+			},
+		})
+
+		ref.Tag = "3.7"
+		c, d, err := r.Client().Config(context.Background(), ref, amd64)
+		require.NoError(t, err)
+		assert.Equal(t, list, d)
+		assert.Equal(t, config, c)
+
+		_, _, err = r.Client().Config(context.Background(), ref, registry.Platform{OS: "windows", Architecture: "amd64"})
+		assert.Error(t, err)
+	})
+}
+
+func TestVerifySignature(t *testing.T) {
+	r := fake.NewRegistry()
+	defer r.Close()
+
+	digest := r.AddImage("arangodb/arangodb", "3.7.10", registry.ImageConfig{})
+	unsigned := r.AddImage("arangodb/arangodb", "3.7.11", registry.ImageConfig{Env: []string{"A=1"}})
+
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	other, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+
+	require.NoError(t, r.Sign("arangodb/arangodb", digest, key))
+
+	der, err := x509.MarshalPKIXPublicKey(&key.PublicKey)
+	require.NoError(t, err)
+	publicKey, err := registry.ParsePublicKey(pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Bytes: der}))
+	require.NoError(t, err)
+
+	ref, err := registry.ParseReference(r.Domain() + "/arangodb/arangodb:3.7.10")
+	require.NoError(t, err)
+
+	assert.NoError(t, registry.VerifySignature(context.Background(), r.Client(), ref, digest, publicKey))
+	assert.Error(t, registry.VerifySignature(context.Background(), r.Client(), ref, digest, &other.PublicKey))
+	assert.Error(t, registry.VerifySignature(context.Background(), r.Client(), ref, unsigned, publicKey))
+
+	_, err = registry.ParsePublicKey([]byte("not a key"))
+	assert.Error(t, err)
+}
@@ -0,0 +1,93 @@
+//
+// DISCLAIMER
+//
+// Copyright 2020 ArangoDB GmbH, Cologne, Germany
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// Copyright holder is ArangoDB GmbH, Cologne, Germany
+//
+
+package registry
+
+import (
+	"context"
+	"encoding/json"
+	"strings"
+
+	"github.com/pkg/errors"
+)
+
+// ImageConfig is the runtime configuration of an image
+type ImageConfig struct {
+	Labels map[string]string `json:"Labels,omitempty"`
+	Env    []string          `json:"Env,omitempty"`
+}
+
+// GetEnv returns the value of the given environment variable of the image
+func (c ImageConfig) GetEnv(name string) (string, bool) {
+	for _, env := range c.Env {
+		if parts := strings.SplitN(env, "=", 2); len(parts) == 2 && parts[0] == name {
+			return parts[1], true
+		}
+	}
+
+	return "", false
+}
+
+// ImageConfigFile is the config blob of an image
+type ImageConfigFile struct {
+	Architecture string      `json:"architecture,omitempty"`
+	OS           string      `json:"os,omitempty"`
+	Config       ImageConfig `json:"config"`
+}
+
+// Config returns the configuration of the image for the given platform and the digest of the manifest.
+// For manifest lists the digest of the list is returned, as it is the digest reported by the container runtime.
+func (c *Client) Config(ctx context.Context, ref Reference, platform Platform) (ImageConfig, string, error) {
+	manifest, digest, err := c.Manifest(ctx, ref)
+	if err != nil {
+		return ImageConfig{}, "", maskAny(err)
+	}
+
+	if manifest.IsList() {
+		var found bool
+		for _, m := range manifest.Manifests {
+			if m.Platform != nil && m.Platform.OS == platform.OS && m.Platform.Architecture == platform.Architecture {
+				platformRef := ref
+				platformRef.Digest = m.Digest
+				if manifest, _, err = c.Manifest(ctx, platformRef); err != nil {
+					return ImageConfig{}, "", maskAny(err)
+				}
+				found = true
+				break
+			}
+		}
+
+		if !found {
+			return ImageConfig{}, "", errors.Errorf("image %s is not available for %s/%s", ref.Name, platform.OS, platform.Architecture)
+		}
+	}
+
+	data, err := c.Blob(ctx, ref, manifest.Config.Digest)
+	if err != nil {
+		return ImageConfig{}, "", maskAny(err)
+	}
+
+	var config ImageConfigFile
+	if err := json.Unmarshal(data, &config); err != nil {
+		return ImageConfig{}, "", maskAny(err)
+	}
+
+	return config.Config, digest, nil
+}
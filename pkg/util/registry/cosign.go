@@ -0,0 +1,145 @@
+//
+// DISCLAIMER
+//
+// Copyright 2020 ArangoDB GmbH, Cologne, Germany
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// Copyright holder is ArangoDB GmbH, Cologne, Germany
+//
+
+package registry
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/asn1"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"encoding/pem"
+	"math/big"
+	"strings"
+
+	"github.com/pkg/errors"
+)
+
+const (
+	// CosignSignatureAnnotation is the annotation of signature layers holding the base64 encoded signature
+	CosignSignatureAnnotation = "dev.cosignproject.cosign/signature"
+	cosignSignatureSuffix     = ".sig"
+)
+
+// Digest returns the sha256 digest of the given content
+func Digest(data []byte) string {
+	sum := sha256.Sum256(data)
+	return digestAlgorithmSHA2 + hex.EncodeToString(sum[:])
+}
+
+// ParsePublicKey parses a PEM encoded ECDSA public key as generated by cosign
+func ParsePublicKey(data []byte) (*ecdsa.PublicKey, error) {
+	block, _ := pem.Decode(data)
+	if block == nil {
+		return nil, errors.Errorf("public key is not PEM encoded")
+	}
+
+	key, err := x509.ParsePKIXPublicKey(block.Bytes)
+	if err != nil {
+		return nil, maskAny(err)
+	}
+
+	ecKey, ok := key.(*ecdsa.PublicKey)
+	if !ok {
+		return nil, errors.Errorf("public key is not an ECDSA key")
+	}
+
+	return ecKey, nil
+}
+
+type ecdsaSignature struct {
+	R, S *big.Int
+}
+
+type cosignPayload struct {
+	Critical struct {
+		Image struct {
+			DockerManifestDigest string `json:"docker-manifest-digest"`
+		} `json:"image"`
+	} `json:"critical"`
+}
+
+// SignatureReference returns the reference of the cosign signature of the image with the given digest
+func SignatureReference(ref Reference, digest string) Reference {
+	sig := ref
+	sig.Digest = ""
+	sig.Tag = strings.Replace(digest, ":", "-", 1) + cosignSignatureSuffix
+	return sig
+}
+
+// VerifySignature verifies that the image with the given digest is signed by the given key.
+// Signatures are looked up the way cosign stores them, as a manifest tagged with the image digest.
+func VerifySignature(ctx context.Context, client *Client, ref Reference, digest string, key *ecdsa.PublicKey) error {
+	manifest, _, err := client.Manifest(ctx, SignatureReference(ref, digest))
+	if err != nil {
+		return errors.Wrapf(err, "unable to fetch signature of %s", ref.Pinned(digest))
+	}
+
+	for _, layer := range manifest.Layers {
+		signature, ok := layer.Annotations[CosignSignatureAnnotation]
+		if !ok {
+			continue
+		}
+
+		payload, err := client.Blob(ctx, ref, layer.Digest)
+		if err != nil {
+			return maskAny(err)
+		}
+
+		if err := verifyPayload(payload, signature, digest, key); err == nil {
+			return nil
+		}
+	}
+
+	return errors.Errorf("no valid signature found for %s", ref.Pinned(digest))
+}
+
+// verifyPayload verifies the signature of a cosign payload and that the payload references the digest
+func verifyPayload(payload []byte, signature, digest string, key *ecdsa.PublicKey) error {
+	raw, err := base64.StdEncoding.DecodeString(signature)
+	if err != nil {
+		return maskAny(err)
+	}
+
+	var sig ecdsaSignature
+	if _, err := asn1.Unmarshal(raw, &sig); err != nil {
+		return maskAny(err)
+	}
+
+	hash := sha256.Sum256(payload)
+	if !ecdsa.Verify(key, hash[:], sig.R, sig.S) {
+		return errors.Errorf("invalid signature")
+	}
+
+	var p cosignPayload
+	if err := json.Unmarshal(payload, &p); err != nil {
+		return maskAny(err)
+	}
+
+	if p.Critical.Image.DockerManifestDigest != digest {
+		return errors.Errorf("signature is for %s", p.Critical.Image.DockerManifestDigest)
+	}
+
+	return nil
+}
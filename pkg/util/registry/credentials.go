@@ -0,0 +1,113 @@
+//
+// DISCLAIMER
+//
+// Copyright 2020 ArangoDB GmbH, Cologne, Germany
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// Copyright holder is ArangoDB GmbH, Cologne, Germany
+//
+
+package registry
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"strings"
+)
+
+// Credential is a username and password used to authenticate to a registry
+type Credential struct {
+	Username string
+	Password string
+}
+
+// Credentials maps registry domains to credentials
+type Credentials map[string]Credential
+
+type dockerConfigEntry struct {
+	Username string `json:"username,omitempty"`
+	Password string `json:"password,omitempty"`
+	Auth     string `json:"auth,omitempty"`
+}
+
+type dockerConfigJSON struct {
+	Auths map[string]dockerConfigEntry `json:"auths"`
+}
+
+// AddDockerConfigJSON adds credentials from the content of a kubernetes.io/dockerconfigjson secret
+func (c Credentials) AddDockerConfigJSON(data []byte) error {
+	var config dockerConfigJSON
+	if err := json.Unmarshal(data, &config); err != nil {
+		return maskAny(err)
+	}
+
+	return c.add(config.Auths)
+}
+
+// AddDockerConfig adds credentials from the content of a legacy kubernetes.io/dockercfg secret
+func (c Credentials) AddDockerConfig(data []byte) error {
+	var config map[string]dockerConfigEntry
+	if err := json.Unmarshal(data, &config); err != nil {
+		return maskAny(err)
+	}
+
+	return c.add(config)
+}
+
+func (c Credentials) add(entries map[string]dockerConfigEntry) error {
+	for server, entry := range entries {
+		credential := Credential{Username: entry.Username, Password: entry.Password}
+		if entry.Auth != "" {
+			auth, err := base64.StdEncoding.DecodeString(entry.Auth)
+			if err != nil {
+				return maskAny(err)
+			}
+			parts := strings.SplitN(string(auth), ":", 2)
+			if len(parts) != 2 {
+				continue
+			}
+			credential = Credential{Username: parts[0], Password: parts[1]}
+		}
+
+		c[registryDomain(server)] = credential
+	}
+
+	return nil
+}
+
+// Get returns credentials of the given registry domain
+func (c Credentials) Get(domain string) (Credential, bool) {
+	if c == nil {
+		return Credential{}, false
+	}
+
+	credential, ok := c[domain]
+	return credential, ok
+}
+
+// registryDomain returns the domain of a server entry in a docker config
+func registryDomain(server string) string {
+	if server == dockerHubLegacyKey {
+		return dockerHubAPIDomain
+	}
+
+	server = strings.TrimPrefix(strings.TrimPrefix(server, "https://"), "http://")
+	server = strings.SplitN(server, "/", 2)[0]
+
+	if server == dockerHubDomain || server == "index.docker.io" {
+		return dockerHubAPIDomain
+	}
+
+	return server
+}
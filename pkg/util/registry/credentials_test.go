@@ -0,0 +1,66 @@
+//
+// DISCLAIMER
+//
+// Copyright 2020 ArangoDB GmbH, Cologne, Germany
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// Copyright holder is ArangoDB GmbH, Cologne, Germany
+//
+
+package registry
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCredentials_AddDockerConfigJSON(t *testing.T) {
+	data := []byte(`{"auths":{
+		"https://index.docker.io/v1/":{"auth":"dXNlcjpwYXNz"},
+		"quay.io":{"username":"robot","password":"secret"},
+		"https://registry.example.com/v2/":{"auth":"YWRtaW46YTpi"}
+	}}`)
+
+	c := Credentials{}
+	require.NoError(t, c.AddDockerConfigJSON(data))
+
+	credential, ok := c.Get("registry-1.docker.io")
+	require.True(t, ok)
+	assert.Equal(t, Credential{Username: "user", Password: "pass"}, credential)
+
+	credential, ok = c.Get("quay.io")
+	require.True(t, ok)
+	assert.Equal(t, Credential{Username: "robot", Password: "secret"}, credential)
+
+	credential, ok = c.Get("registry.example.com")
+	require.True(t, ok)
+	assert.Equal(t, Credential{Username: "admin", Password: "a:b"}, credential)
+
+	_, ok = c.Get("gcr.io")
+	assert.False(t, ok)
+}
+
+func TestCredentials_AddDockerConfig(t *testing.T) {
+	c := Credentials{}
+	require.NoError(t, c.AddDockerConfig([]byte(`{"docker.io":{"auth":"dXNlcjpwYXNz"}}`)))
+
+	credential, ok := c.Get("registry-1.docker.io")
+	require.True(t, ok)
+	assert.Equal(t, "user", credential.Username)
+
+	assert.Error(t, c.AddDockerConfigJSON([]byte(`{`)))
+	assert.Error(t, c.AddDockerConfig([]byte(`{"quay.io":{"auth":"%%%"}}`)))
+}
@@ -0,0 +1,226 @@
+//
+// DISCLAIMER
+//
+// Copyright 2020 ArangoDB GmbH, Cologne, Germany
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// Copyright holder is ArangoDB GmbH, Cologne, Germany
+//
+
+package fake
+
+import (
+	"crypto/ecdsa"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/asn1"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+
+	"github.com/arangodb/kube-arangodb/pkg/util/registry"
+)
+
+const (
+	token             = "fake-token"
+	mediaTypeManifest = "application/vnd.docker.distribution.manifest.v2+json"
+)
+
+// Registry is an in-memory registry stand-in serving manifests and blobs over TLS behind token authentication
+type Registry struct {
+	Username string
+	Password string
+
+	server    *httptest.Server
+	lock      sync.Mutex
+	manifests map[string][]byte
+	blobs     map[string][]byte
+	requests  int
+}
+
+// NewRegistry starts a new registry. It has to be closed with Close.
+func NewRegistry() *Registry {
+	r := &Registry{
+		Username:  "user",
+		Password:  "pass",
+		manifests: map[string][]byte{},
+		blobs:     map[string][]byte{},
+	}
+
+	r.server = httptest.NewTLSServer(http.HandlerFunc(r.serve))
+
+	return r
+}
+
+// Close stops the registry
+func (r *Registry) Close() {
+	r.server.Close()
+}
+
+// Domain returns the domain of the registry used in image names
+func (r *Registry) Domain() string {
+	return strings.TrimPrefix(r.server.URL, "https://")
+}
+
+// HTTPClient returns a HTTP client trusting the registry certificate
+func (r *Registry) HTTPClient() *http.Client {
+	return r.server.Client()
+}
+
+// Credentials returns credentials of the registry
+func (r *Registry) Credentials() registry.Credentials {
+	return registry.Credentials{
+		r.Domain(): {Username: r.Username, Password: r.Password},
+	}
+}
+
+// Client returns a client authenticated to the registry
+func (r *Registry) Client() *registry.Client {
+	return &registry.Client{
+		HTTP:        r.HTTPClient(),
+		Credentials: r.Credentials(),
+	}
+}
+
+// DockerConfigJSON returns the content of a kubernetes.io/dockerconfigjson secret for the registry
+func (r *Registry) DockerConfigJSON() []byte {
+	auth := base64.StdEncoding.EncodeToString([]byte(r.Username + ":" + r.Password))
+	return []byte(fmt.Sprintf(`{"auths":{"%s":{"auth":"%s"}}}`, r.Domain(), auth))
+}
+
+// Requests returns the number of manifest and blob requests served
+func (r *Registry) Requests() int {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	return r.requests
+}
+
+// AddBlob stores the given blob
+func (r *Registry) AddBlob(data []byte) registry.Descriptor {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	digest := registry.Digest(data)
+	r.blobs[digest] = data
+	return registry.Descriptor{Digest: digest, Size: int64(len(data))}
+}
+
+// AddManifest stores the given manifest under the tag and returns its digest
+func (r *Registry) AddManifest(repository, tag string, manifest registry.Manifest) string {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	data, _ := json.Marshal(manifest)
+	digest := registry.Digest(data)
+	r.manifests[repository+":"+tag] = data
+	r.manifests[repository+":"+digest] = data
+	return digest
+}
+
+// AddImage stores an image with the given config under the tag and returns its digest
+func (r *Registry) AddImage(repository, tag string, config registry.ImageConfig) string {
+	data, _ := json.Marshal(registry.ImageConfigFile{
+		Architecture: "amd64",
+		OS:           "linux",
+		Config:       config,
+	})
+
+	return r.AddManifest(repository, tag, registry.Manifest{
+		MediaType: mediaTypeManifest,
+		Config:    r.AddBlob(data),
+	})
+}
+
+// Sign stores a cosign signature of the image with the given digest
+func (r *Registry) Sign(repository, digest string, key *ecdsa.PrivateKey) error {
+	payload := []byte(fmt.Sprintf(`{"critical":{"identity":{"docker-reference":"%s"},"image":{"docker-manifest-digest":"%s"},"type":"cosign container image signature"},"optional":null}`, repository, digest))
+	hash := sha256.Sum256(payload)
+
+	sigR, sigS, err := ecdsa.Sign(rand.Reader, key, hash[:])
+	if err != nil {
+		return err
+	}
+
+	signature, err := asn1.Marshal(struct{ R, S *big.Int }{R: sigR, S: sigS})
+	if err != nil {
+		return err
+	}
+
+	layer := r.AddBlob(payload)
+	layer.Annotations = map[string]string{registry.CosignSignatureAnnotation: base64.StdEncoding.EncodeToString(signature)}
+
+	ref := registry.SignatureReference(registry.Reference{}, digest)
+	r.AddManifest(repository, ref.Tag, registry.Manifest{
+		MediaType: mediaTypeManifest,
+		Config:    r.AddBlob([]byte(`{}`)),
+		Layers:    []registry.Descriptor{layer},
+	})
+
+	return nil
+}
+
+func (r *Registry) serve(w http.ResponseWriter, req *http.Request) {
+	if req.URL.Path == "/token" {
+		if u, p, ok := req.BasicAuth(); !ok || u != r.Username || p != r.Password {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		fmt.Fprintf(w, `{"token":"%s"}`, token)
+		return
+	}
+
+	if req.Header.Get("Authorization") != "Bearer "+token {
+		w.Header().Set("WWW-Authenticate", fmt.Sprintf(`Bearer realm="%s/token",service="fake"`, r.server.URL))
+		w.WriteHeader(http.StatusUnauthorized)
+		return
+	}
+
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	r.requests++
+
+	path := strings.TrimPrefix(req.URL.Path, "/v2/")
+	if i := strings.LastIndex(path, "/manifests/"); i >= 0 {
+		data, ok := r.manifests[path[:i]+":"+path[i+len("/manifests/"):]]
+		if !ok {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		w.Header().Set("Docker-Content-Digest", registry.Digest(data))
+		w.Header().Set("Content-Type", mediaTypeManifest)
+		if req.Method != http.MethodHead {
+			w.Write(data)
+		}
+		return
+	}
+
+	if i := strings.LastIndex(path, "/blobs/"); i >= 0 {
+		data, ok := r.blobs[path[i+len("/blobs/"):]]
+		if !ok {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		w.Write(data)
+		return
+	}
+
+	w.WriteHeader(http.StatusNotFound)
+}
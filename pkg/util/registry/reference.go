@@ -0,0 +1,116 @@
+//
+// DISCLAIMER
+//
+// Copyright 2020 ArangoDB GmbH, Cologne, Germany
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// Copyright holder is ArangoDB GmbH, Cologne, Germany
+//
+
+package registry
+
+import (
+	"strings"
+
+	"github.com/pkg/errors"
+)
+
+const (
+	dockerHubDomain     = "docker.io"
+	dockerHubAPIDomain  = "registry-1.docker.io"
+	dockerHubLegacyKey  = "https://index.docker.io/v1/"
+	dockerHubLibrary    = "library/"
+	defaultTag          = "latest"
+	digestAlgorithmSHA2 = "sha256:"
+)
+
+// Reference is a parsed image reference
+type Reference struct {
+	// Name of the image as given, without tag and digest
+	Name string
+	// Domain of the registry API
+	Domain string
+	// Repository path inside the registry
+	Repository string
+	// Tag of the image, empty when the image is referenced by digest only
+	Tag string
+	// Digest of the image, empty when the image is referenced by tag
+	Digest string
+}
+
+// ParseReference parses the given image reference.
+// Images without registry domain are resolved to Docker Hub.
+func ParseReference(image string) (Reference, error) {
+	var r Reference
+
+	name := image
+	if i := strings.Index(name, "@"); i >= 0 {
+		r.Digest = name[i+1:]
+		name = name[:i]
+		if !strings.HasPrefix(r.Digest, digestAlgorithmSHA2) {
+			return Reference{}, errors.Errorf("unsupported digest %s", r.Digest)
+		}
+	}
+
+	if i := strings.LastIndex(name, ":"); i > strings.LastIndex(name, "/") {
+		r.Tag = name[i+1:]
+		name = name[:i]
+	}
+
+	if name == "" {
+		return Reference{}, errors.Errorf("invalid image reference %s", image)
+	}
+
+	if r.Tag == "" && r.Digest == "" {
+		r.Tag = defaultTag
+	}
+
+	r.Name = name
+	r.Domain = dockerHubAPIDomain
+	r.Repository = name
+
+	if parts := strings.SplitN(name, "/", 2); len(parts) == 2 && isDomain(parts[0]) {
+		r.Domain = parts[0]
+		r.Repository = parts[1]
+	}
+
+	if r.Domain == dockerHubDomain {
+		r.Domain = dockerHubAPIDomain
+	}
+
+	if r.Domain == dockerHubAPIDomain && !strings.Contains(r.Repository, "/") {
+		r.Repository = dockerHubLibrary + r.Repository
+	}
+
+	return r, nil
+}
+
+// Reference returns the tag or digest used to fetch the manifest of the image
+func (r Reference) Reference() string {
+	if r.Digest != "" {
+		return r.Digest
+	}
+
+	return r.Tag
+}
+
+// Pinned returns the image referenced by the given digest
+func (r Reference) Pinned(digest string) string {
+	return r.Name + "@" + digest
+}
+
+// isDomain returns true when the first component of an image name is a registry domain
+func isDomain(s string) bool {
+	return s == "localhost" || strings.ContainsAny(s, ".:")
+}
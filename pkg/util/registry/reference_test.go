@@ -0,0 +1,74 @@
+//
+// DISCLAIMER
+//
+// Copyright 2020 ArangoDB GmbH, Cologne, Germany
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// Copyright holder is ArangoDB GmbH, Cologne, Germany
+//
+
+package registry
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseReference(t *testing.T) {
+	digest := "sha256:" + "0123456789abcdef0123456789abcdef0123456789abcdef0123456789abcdef"
+
+	testCases := map[string]Reference{
+		"arangodb": {
+			Name: "arangodb", Domain: "registry-1.docker.io", Repository: "library/arangodb", Tag: "latest",
+		},
+		"arangodb/arangodb:3.7.10": {
+			Name: "arangodb/arangodb", Domain: "registry-1.docker.io", Repository: "arangodb/arangodb", Tag: "3.7.10",
+		},
+		"docker.io/arangodb:3.7": {
+			Name: "docker.io/arangodb", Domain: "registry-1.docker.io", Repository: "library/arangodb", Tag: "3.7",
+		},
+		"localhost:5000/arangodb/arangodb:3.7": {
+			Name: "localhost:5000/arangodb/arangodb", Domain: "localhost:5000", Repository: "arangodb/arangodb", Tag: "3.7",
+		},
+		"quay.io/arangodb/arangodb@" + digest: {
+			Name: "quay.io/arangodb/arangodb", Domain: "quay.io", Repository: "arangodb/arangodb", Digest: digest,
+		},
+		"quay.io/arangodb/arangodb:3.7@" + digest: {
+			Name: "quay.io/arangodb/arangodb", Domain: "quay.io", Repository: "arangodb/arangodb", Tag: "3.7", Digest: digest,
+		},
+	}
+
+	for image, expected := range testCases {
+		t.Run(image, func(t *testing.T) {
+			ref, err := ParseReference(image)
+			require.NoError(t, err)
+			assert.Equal(t, expected, ref)
+		})
+	}
+
+	_, err := ParseReference("arangodb@md5:1234")
+	assert.Error(t, err)
+	_, err = ParseReference(":3.7")
+	assert.Error(t, err)
+}
+
+func TestReference_Pinned(t *testing.T) {
+	ref, err := ParseReference("arangodb/arangodb:3.7.10")
+	require.NoError(t, err)
+
+	assert.Equal(t, "3.7.10", ref.Reference())
+	assert.Equal(t, "arangodb/arangodb@sha256:abc", ref.Pinned("sha256:abc"))
+}
@@ -43,3 +43,13 @@ func TimeCompareEqualPointer(a, b *metav1.Time) bool {
 
 	return TimeCompareEqual(*a, *b)
 }
+
+// TimeCompareEqualOptional compares two optional times, allowing an error of 1s.
+// Two nil times are considered equal.
+func TimeCompareEqualOptional(a, b *metav1.Time) bool {
+	if a == nil && b == nil {
+		return true
+	}
+
+	return TimeCompareEqualPointer(a, b)
+}
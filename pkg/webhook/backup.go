@@ -0,0 +1,70 @@
+//
+// DISCLAIMER
+//
+// Copyright 2020 ArangoDB GmbH, Cologne, Germany
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// Copyright holder is ArangoDB GmbH, Cologne, Germany
+//
+
+package webhook
+
+import (
+	"github.com/arangodb/kube-arangodb/pkg/apis/backup"
+	backupApi "github.com/arangodb/kube-arangodb/pkg/apis/backup/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+)
+
+func init() {
+	registerResource(backup.ArangoBackupGroupName, backupApi.ArangoBackupVersion, backup.ArangoBackupResourcePlural, backupResource{})
+	registerResource(backup.ArangoBackupGroupName, backupApi.ArangoBackupVersion, backup.ArangoBackupPolicyResourcePlural, backupPolicyResource{})
+}
+
+// backupResource admits ArangoBackups
+type backupResource struct{}
+
+func (backupResource) New() runtime.Object {
+	return &backupApi.ArangoBackup{}
+}
+
+func (backupResource) Default(old, obj runtime.Object) {}
+
+func (backupResource) Validate(old, obj runtime.Object) error {
+	b := obj.(*backupApi.ArangoBackup)
+
+	if err := b.Spec.Validate(); err != nil {
+		return maskAny(err)
+	}
+
+	return nil
+}
+
+// backupPolicyResource admits ArangoBackupPolicies
+type backupPolicyResource struct{}
+
+func (backupPolicyResource) New() runtime.Object {
+	return &backupApi.ArangoBackupPolicy{}
+}
+
+func (backupPolicyResource) Default(old, obj runtime.Object) {}
+
+func (backupPolicyResource) Validate(old, obj runtime.Object) error {
+	p := obj.(*backupApi.ArangoBackupPolicy)
+
+	if err := p.Spec.Validate(); err != nil {
+		return maskAny(err)
+	}
+
+	return nil
+}
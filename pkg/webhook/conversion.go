@@ -0,0 +1,189 @@
+//
+// DISCLAIMER
+//
+// Copyright 2020 ArangoDB GmbH, Cologne, Germany
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// Copyright holder is ArangoDB GmbH, Cologne, Germany
+//
+
+package webhook
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"sync"
+
+	apiextensions "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+)
+
+const (
+	// ConvertPath is the path of the custom resource conversion webhook
+	ConvertPath = "/webhook/convert"
+
+	// legacyVersion is served by the CRDs of the operator with the schema of v1
+	legacyVersion = "v1alpha"
+)
+
+// Convertible converts objects of a single version of a custom resource from and into the hub version of the resource
+type Convertible interface {
+	// New returns an empty object of the version
+	New() runtime.Object
+	// ToHub converts the object into the hub version
+	ToHub(obj runtime.Object) (runtime.Object, error)
+	// FromHub converts the object of the hub version into this version
+	FromHub(hub runtime.Object) (runtime.Object, error)
+}
+
+var conversions = map[schema.GroupVersionKind]Convertible{}
+var conversionsLock sync.Mutex
+
+func registerConversion(gv schema.GroupVersion, kind string, c Convertible) {
+	conversionsLock.Lock()
+	defer conversionsLock.Unlock()
+
+	gvk := gv.WithKind(kind)
+	if _, ok := conversions[gvk]; ok {
+		panic("Conversion already registered")
+	}
+
+	conversions[gvk] = c
+}
+
+// hubConversion converts the hub version and versions sharing its schema
+type hubConversion struct {
+	new func() runtime.Object
+}
+
+func (h hubConversion) New() runtime.Object {
+	return h.new()
+}
+
+func (hubConversion) ToHub(obj runtime.Object) (runtime.Object, error) {
+	return obj, nil
+}
+
+func (hubConversion) FromHub(hub runtime.Object) (runtime.Object, error) {
+	return hub, nil
+}
+
+// Convert serves the custom resource conversion webhook
+func (h *Handler) Convert(w http.ResponseWriter, r *http.Request) {
+	data, err := ioutil.ReadAll(http.MaxBytesReader(w, r.Body, maxReviewSize))
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	// ConversionReview has the same format in v1 and v1beta1, response is returned in the version of the request
+	var review apiextensions.ConversionReview
+	if err := json.Unmarshal(data, &review); err != nil || review.Request == nil {
+		http.Error(w, "invalid conversion review", http.StatusBadRequest)
+		return
+	}
+
+	resp := &apiextensions.ConversionResponse{
+		UID: review.Request.UID,
+	}
+
+	if objects, err := h.convert(review.Request); err != nil {
+		h.log.Warn().Err(err).Str("version", review.Request.DesiredAPIVersion).Msg("Conversion failed")
+		resp.Result = metav1.Status{
+			Status:  metav1.StatusFailure,
+			Message: err.Error(),
+		}
+	} else {
+		resp.ConvertedObjects = objects
+		resp.Result = metav1.Status{
+			Status: metav1.StatusSuccess,
+		}
+	}
+
+	review.Response = resp
+	review.Request = nil
+
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(review); err != nil {
+		h.log.Warn().Err(err).Msg("Failed to write conversion review")
+	}
+}
+
+func (h *Handler) convert(req *apiextensions.ConversionRequest) ([]runtime.RawExtension, error) {
+	desired, err := schema.ParseGroupVersion(req.DesiredAPIVersion)
+	if err != nil {
+		return nil, maskAny(err)
+	}
+
+	objects := make([]runtime.RawExtension, len(req.Objects))
+	for i, o := range req.Objects {
+		data, err := h.convertObject(o.Raw, desired)
+		if err != nil {
+			return nil, maskAny(err)
+		}
+		objects[i] = runtime.RawExtension{Raw: data}
+	}
+
+	return objects, nil
+}
+
+func (h *Handler) convertObject(data []byte, desired schema.GroupVersion) ([]byte, error) {
+	var meta metav1.TypeMeta
+	if err := json.Unmarshal(data, &meta); err != nil {
+		return nil, maskAny(err)
+	}
+
+	gvk := meta.GroupVersionKind()
+	if gvk.GroupVersion() == desired {
+		return data, nil
+	}
+
+	from, ok := h.conversions[gvk]
+	if !ok {
+		return nil, maskAny(fmt.Errorf("conversion from %s %s is not supported", gvk.GroupVersion(), gvk.Kind))
+	}
+
+	to, ok := h.conversions[desired.WithKind(gvk.Kind)]
+	if !ok {
+		return nil, maskAny(fmt.Errorf("conversion into %s %s is not supported", desired, gvk.Kind))
+	}
+
+	obj := from.New()
+	if err := json.Unmarshal(data, obj); err != nil {
+		return nil, maskAny(fmt.Errorf("unable to decode object: %s", err.Error()))
+	}
+
+	hub, err := from.ToHub(obj)
+	if err != nil {
+		return nil, maskAny(err)
+	}
+
+	out, err := to.FromHub(hub)
+	if err != nil {
+		return nil, maskAny(err)
+	}
+
+	out.GetObjectKind().SetGroupVersionKind(desired.WithKind(gvk.Kind))
+
+	result, err := json.Marshal(out)
+	if err != nil {
+		return nil, maskAny(err)
+	}
+
+	return result, nil
+}
@@ -0,0 +1,142 @@
+//
+// DISCLAIMER
+//
+// Copyright 2020 ArangoDB GmbH, Cologne, Germany
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// Copyright holder is ArangoDB GmbH, Cologne, Germany
+//
+
+package webhook
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	apiextensions "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+
+	api "github.com/arangodb/kube-arangodb/pkg/apis/deployment/v1"
+	"github.com/arangodb/kube-arangodb/pkg/apis/deployment/v2alpha1"
+	replicationApi "github.com/arangodb/kube-arangodb/pkg/apis/replication/v1"
+	"github.com/arangodb/kube-arangodb/pkg/util"
+)
+
+func serveConversion(t *testing.T, desiredAPIVersion string, objs ...interface{}) *apiextensions.ConversionResponse {
+	req := &apiextensions.ConversionRequest{
+		UID:               "test-uid",
+		DesiredAPIVersion: desiredAPIVersion,
+	}
+
+	for _, obj := range objs {
+		data, err := json.Marshal(obj)
+		require.NoError(t, err)
+		req.Objects = append(req.Objects, runtime.RawExtension{Raw: data})
+	}
+
+	review, err := json.Marshal(apiextensions.ConversionReview{
+		TypeMeta: metav1.TypeMeta{APIVersion: "apiextensions.k8s.io/v1beta1", Kind: "ConversionReview"},
+		Request:  req,
+	})
+	require.NoError(t, err)
+
+	w := httptest.NewRecorder()
+	NewHandler(zerolog.Nop()).Convert(w, httptest.NewRequest(http.MethodPost, ConvertPath, bytes.NewReader(review)))
+	require.Equal(t, http.StatusOK, w.Code)
+
+	var response apiextensions.ConversionReview
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+	require.NotNil(t, response.Response)
+	assert.Equal(t, "apiextensions.k8s.io/v1beta1", response.APIVersion)
+	assert.EqualValues(t, "test-uid", response.Response.UID)
+
+	return response.Response
+}
+
+func TestHandler_ConvertDeployment(t *testing.T) {
+	d := newDeployment(api.DeploymentSpec{
+		Agents: api.ServerGroupSpec{
+			Probes: &api.ServerGroupProbesSpec{OldReadinessProbeDisabled: util.NewBool(true)},
+		},
+	})
+	d.TypeMeta = metav1.TypeMeta{APIVersion: "database.arangodb.com/v1", Kind: "ArangoDeployment"}
+	d.Status.CurrentImage = &api.ImageInfo{Image: "arangodb/arangodb:3.7", ImageID: "sha256:1"}
+
+	resp := serveConversion(t, "database.arangodb.com/v2alpha1", d)
+	require.Equal(t, metav1.StatusSuccess, resp.Result.Status, resp.Result.Message)
+	require.Len(t, resp.ConvertedObjects, 1)
+
+	var converted map[string]interface{}
+	require.NoError(t, json.Unmarshal(resp.ConvertedObjects[0].Raw, &converted))
+	assert.Equal(t, "database.arangodb.com/v2alpha1", converted["apiVersion"])
+	assert.Equal(t, "ArangoDeployment", converted["kind"])
+	assert.Contains(t, converted["status"], "currentImage")
+
+	var v2 v2alpha1.ArangoDeployment
+	require.NoError(t, json.Unmarshal(resp.ConvertedObjects[0].Raw, &v2))
+	assert.Equal(t, util.NewBool(true), v2.Spec.Agents.Probes.GetReadinessProbeDisabled())
+
+	t.Run("Back to v1", func(t *testing.T) {
+		resp := serveConversion(t, "database.arangodb.com/v1", &v2)
+		require.Equal(t, metav1.StatusSuccess, resp.Result.Status, resp.Result.Message)
+		require.Len(t, resp.ConvertedObjects, 1)
+
+		var back api.ArangoDeployment
+		require.NoError(t, json.Unmarshal(resp.ConvertedObjects[0].Raw, &back))
+		assert.Equal(t, d.Spec, back.Spec)
+		assert.Equal(t, d.Status, back.Status)
+		assert.Equal(t, d.ObjectMeta, back.ObjectMeta)
+	})
+
+	t.Run("Legacy version", func(t *testing.T) {
+		resp := serveConversion(t, "database.arangodb.com/v1alpha", d)
+		require.Equal(t, metav1.StatusSuccess, resp.Result.Status, resp.Result.Message)
+
+		var legacy api.ArangoDeployment
+		require.NoError(t, json.Unmarshal(resp.ConvertedObjects[0].Raw, &legacy))
+		assert.Equal(t, "database.arangodb.com/v1alpha", legacy.APIVersion)
+		assert.Equal(t, d.Spec, legacy.Spec)
+	})
+}
+
+func TestHandler_ConvertReplication(t *testing.T) {
+	r := &replicationApi.ArangoDeploymentReplication{
+		TypeMeta:   metav1.TypeMeta{APIVersion: "replication.database.arangodb.com/v1", Kind: "ArangoDeploymentReplication"},
+		ObjectMeta: metav1.ObjectMeta{Name: "test", Namespace: "default"},
+		Status:     replicationApi.DeploymentReplicationStatus{CancelFailures: 2},
+	}
+
+	resp := serveConversion(t, "replication.database.arangodb.com/v2alpha1", r)
+	require.Equal(t, metav1.StatusSuccess, resp.Result.Status, resp.Result.Message)
+
+	var converted map[string]interface{}
+	require.NoError(t, json.Unmarshal(resp.ConvertedObjects[0].Raw, &converted))
+	assert.Equal(t, "replication.database.arangodb.com/v2alpha1", converted["apiVersion"])
+	assert.Contains(t, converted["status"], "cancelFailures")
+}
+
+func TestHandler_ConvertUnknown(t *testing.T) {
+	obj := map[string]interface{}{"apiVersion": "storage.arangodb.com/v1alpha", "kind": "ArangoLocalStorage"}
+
+	resp := serveConversion(t, "storage.arangodb.com/v1", obj)
+	assert.Equal(t, metav1.StatusFailure, resp.Result.Status)
+	assert.Empty(t, resp.ConvertedObjects)
+}
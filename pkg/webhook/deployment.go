@@ -0,0 +1,189 @@
+//
+// DISCLAIMER
+//
+// Copyright 2020 ArangoDB GmbH, Cologne, Germany
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// Copyright holder is ArangoDB GmbH, Cologne, Germany
+//
+
+package webhook
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/arangodb/kube-arangodb/pkg/apis/deployment"
+	api "github.com/arangodb/kube-arangodb/pkg/apis/deployment/v1"
+	"github.com/arangodb/kube-arangodb/pkg/apis/deployment/v2alpha1"
+	"github.com/arangodb/kube-arangodb/pkg/deployment/features"
+	"github.com/pkg/errors"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+)
+
+func init() {
+	registerResource(deployment.ArangoDeploymentGroupName, api.ArangoDeploymentVersion, deployment.ArangoDeploymentResourcePlural, deploymentResource{})
+
+	newDeployment := func() runtime.Object { return &api.ArangoDeployment{} }
+	registerConversion(api.SchemeGroupVersion, deployment.ArangoDeploymentResourceKind, hubConversion{new: newDeployment})
+	registerConversion(schema.GroupVersion{Group: deployment.ArangoDeploymentGroupName, Version: legacyVersion},
+		deployment.ArangoDeploymentResourceKind, hubConversion{new: newDeployment})
+	registerConversion(v2alpha1.SchemeGroupVersion, deployment.ArangoDeploymentResourceKind, deploymentV2Alpha1Conversion{})
+}
+
+// deploymentV2Alpha1Conversion converts ArangoDeployments served as v2alpha1
+type deploymentV2Alpha1Conversion struct{}
+
+func (deploymentV2Alpha1Conversion) New() runtime.Object {
+	return &v2alpha1.ArangoDeployment{}
+}
+
+func (deploymentV2Alpha1Conversion) ToHub(obj runtime.Object) (runtime.Object, error) {
+	var hub api.ArangoDeployment
+	if err := obj.(*v2alpha1.ArangoDeployment).ConvertTo(&hub); err != nil {
+		return nil, maskAny(err)
+	}
+	return &hub, nil
+}
+
+func (deploymentV2Alpha1Conversion) FromHub(hub runtime.Object) (runtime.Object, error) {
+	var d v2alpha1.ArangoDeployment
+	if err := d.ConvertFrom(hub.(*api.ArangoDeployment)); err != nil {
+		return nil, maskAny(err)
+	}
+	return &d, nil
+}
+
+// deploymentResource admits ArangoDeployments
+type deploymentResource struct{}
+
+func (deploymentResource) New() runtime.Object {
+	return &api.ArangoDeployment{}
+}
+
+// Default persists only the defaults of the immutable fields selecting the layout of a new deployment,
+// so a change of the operator defaults can not change it later. All other defaults are kept in the spec
+// accepted by the operator, the spec stays equal to the applied manifest.
+func (deploymentResource) Default(old, obj runtime.Object) {
+	if old != nil {
+		return
+	}
+
+	d := obj.(*api.ArangoDeployment)
+
+	if d.Spec.GetMode() == "" {
+		d.Spec.Mode = api.NewMode(api.DeploymentModeCluster)
+	}
+	if d.Spec.GetEnvironment() == "" {
+		d.Spec.Environment = api.NewEnvironment(api.EnvironmentDevelopment)
+	}
+	if d.Spec.GetStorageEngine() == "" {
+		d.Spec.StorageEngine = api.NewStorageEngine(api.StorageEngineRocksDB)
+	}
+}
+
+func (deploymentResource) Validate(old, obj runtime.Object) error {
+	d := obj.(*api.ArangoDeployment)
+
+	spec := d.Spec.DeepCopy()
+	var status api.DeploymentStatus
+
+	if o, ok := old.(*api.ArangoDeployment); ok {
+		before := acceptedSpec(o)
+		before.SetDefaults(o.GetName())
+		spec.SetDefaultsFrom(before)
+		spec.SetDefaults(d.GetName())
+
+		if fields := before.ResetImmutableFields(spec); len(fields) > 0 {
+			return errors.Errorf("immutable fields can not be changed: spec.%s", strings.Join(fields, ", spec."))
+		}
+
+		status = o.Status
+	} else {
+		spec.SetDefaults(d.GetName())
+	}
+
+	if err := spec.Validate(); err != nil {
+		return maskAny(err)
+	}
+
+	if err := validateDeploymentFeatures(*spec, status); err != nil {
+		return maskAny(err)
+	}
+
+	return nil
+}
+
+// acceptedSpec returns the spec last accepted by the operator
+func acceptedSpec(d *api.ArangoDeployment) api.DeploymentSpec {
+	if s := d.Status.AcceptedSpec; s != nil {
+		return *s.DeepCopy()
+	}
+
+	return *d.Spec.DeepCopy()
+}
+
+// deploymentImage returns the info of the image the deployment is going to run.
+// Returns false when the image is not discovered yet.
+func deploymentImage(spec api.DeploymentSpec, status api.DeploymentStatus) (api.ImageInfo, bool) {
+	if info, ok := status.Images.GetByImage(spec.GetImage()); ok {
+		return info, true
+	}
+
+	if status.CurrentImage != nil {
+		return *status.CurrentImage, true
+	}
+
+	return api.ImageInfo{}, false
+}
+
+// validateDeploymentFeatures verifies that features used in the spec are supported by the version of the deployment
+func validateDeploymentFeatures(spec api.DeploymentSpec, status api.DeploymentStatus) error {
+	image, ok := deploymentImage(spec, status)
+	if !ok {
+		// Version is not known before image discovery
+		return nil
+	}
+
+	if spec.TLS.IsSecure() && spec.TLS.SNI != nil && len(spec.TLS.SNI.Mapping) > 0 {
+		if !features.TLSSNI().Supported(image.ArangoDBVersion, image.Enterprise) {
+			return featureError("spec.tls.sni", features.TLSSNI(), image)
+		}
+	}
+
+	if spec.RocksDB.IsEncrypted() && !image.Enterprise {
+		return errors.Errorf("spec.rocksdb.encryption requires Enterprise Edition, image %s is Community Edition", image.Image)
+	}
+
+	return nil
+}
+
+func featureError(field string, f features.Feature, image api.ImageInfo) error {
+	if !f.Enabled() {
+		return errors.Errorf("%s requires feature %s, which is disabled in the operator", field, f.Name())
+	}
+
+	edition := "Community"
+	if image.Enterprise {
+		edition = "Enterprise"
+	}
+
+	requirement := fmt.Sprintf("ArangoDB %s", f.Version())
+	if f.EnterpriseRequired() {
+		requirement += " Enterprise Edition"
+	}
+
+	return errors.Errorf("%s requires %s or newer, image %s is ArangoDB %s %s Edition", field, requirement, image.Image, image.ArangoDBVersion, edition)
+}
@@ -0,0 +1,104 @@
+//
+// DISCLAIMER
+//
+// Copyright 2020 ArangoDB GmbH, Cologne, Germany
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// Copyright holder is ArangoDB GmbH, Cologne, Germany
+//
+
+package webhook
+
+import (
+	"strings"
+
+	"github.com/arangodb/kube-arangodb/pkg/apis/replication"
+	replicationApi "github.com/arangodb/kube-arangodb/pkg/apis/replication/v1"
+	replicationV2Alpha1 "github.com/arangodb/kube-arangodb/pkg/apis/replication/v2alpha1"
+	"github.com/pkg/errors"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+)
+
+func init() {
+	registerResource(replication.ArangoDeploymentReplicationGroupName, replicationApi.ArangoDeploymentReplicationVersion,
+		replication.ArangoDeploymentReplicationResourcePlural, replicationResource{})
+
+	newReplication := func() runtime.Object { return &replicationApi.ArangoDeploymentReplication{} }
+	registerConversion(replicationApi.SchemeGroupVersion, replication.ArangoDeploymentReplicationResourceKind, hubConversion{new: newReplication})
+	registerConversion(schema.GroupVersion{Group: replication.ArangoDeploymentReplicationGroupName, Version: legacyVersion},
+		replication.ArangoDeploymentReplicationResourceKind, hubConversion{new: newReplication})
+	registerConversion(replicationV2Alpha1.SchemeGroupVersion, replication.ArangoDeploymentReplicationResourceKind, replicationV2Alpha1Conversion{})
+}
+
+// replicationV2Alpha1Conversion converts ArangoDeploymentReplications served as v2alpha1
+type replicationV2Alpha1Conversion struct{}
+
+func (replicationV2Alpha1Conversion) New() runtime.Object {
+	return &replicationV2Alpha1.ArangoDeploymentReplication{}
+}
+
+func (replicationV2Alpha1Conversion) ToHub(obj runtime.Object) (runtime.Object, error) {
+	var hub replicationApi.ArangoDeploymentReplication
+	if err := obj.(*replicationV2Alpha1.ArangoDeploymentReplication).ConvertTo(&hub); err != nil {
+		return nil, maskAny(err)
+	}
+	return &hub, nil
+}
+
+func (replicationV2Alpha1Conversion) FromHub(hub runtime.Object) (runtime.Object, error) {
+	var r replicationV2Alpha1.ArangoDeploymentReplication
+	if err := r.ConvertFrom(hub.(*replicationApi.ArangoDeploymentReplication)); err != nil {
+		return nil, maskAny(err)
+	}
+	return &r, nil
+}
+
+// replicationResource admits ArangoDeploymentReplications
+type replicationResource struct{}
+
+func (replicationResource) New() runtime.Object {
+	return &replicationApi.ArangoDeploymentReplication{}
+}
+
+func (replicationResource) Default(old, obj runtime.Object) {
+	r := obj.(*replicationApi.ArangoDeploymentReplication)
+
+	if o, ok := old.(*replicationApi.ArangoDeploymentReplication); ok {
+		r.Spec.SetDefaultsFrom(o.Spec)
+	}
+	r.Spec.SetDefaults()
+}
+
+func (replicationResource) Validate(old, obj runtime.Object) error {
+	r := obj.(*replicationApi.ArangoDeploymentReplication)
+
+	spec := r.Spec.DeepCopy()
+	if o, ok := old.(*replicationApi.ArangoDeploymentReplication); ok {
+		spec.SetDefaultsFrom(o.Spec)
+		spec.SetDefaults()
+
+		if fields := o.Spec.ResetImmutableFields(spec); len(fields) > 0 {
+			return errors.Errorf("immutable fields can not be changed: spec.%s", strings.Join(fields, ", spec."))
+		}
+	} else {
+		spec.SetDefaults()
+	}
+
+	if err := spec.Validate(); err != nil {
+		return maskAny(err)
+	}
+
+	return nil
+}
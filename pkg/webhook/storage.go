@@ -0,0 +1,66 @@
+//
+// DISCLAIMER
+//
+// Copyright 2020 ArangoDB GmbH, Cologne, Germany
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// Copyright holder is ArangoDB GmbH, Cologne, Germany
+//
+
+package webhook
+
+import (
+	"strings"
+
+	storageApi "github.com/arangodb/kube-arangodb/pkg/apis/storage/v1alpha"
+	"github.com/pkg/errors"
+	"k8s.io/apimachinery/pkg/runtime"
+)
+
+func init() {
+	registerResource(storageApi.SchemeGroupVersion.Group, storageApi.SchemeGroupVersion.Version,
+		storageApi.ArangoLocalStorageResourcePlural, localStorageResource{})
+}
+
+// localStorageResource admits ArangoLocalStorages
+type localStorageResource struct{}
+
+func (localStorageResource) New() runtime.Object {
+	return &storageApi.ArangoLocalStorage{}
+}
+
+func (localStorageResource) Default(old, obj runtime.Object) {
+	s := obj.(*storageApi.ArangoLocalStorage)
+
+	s.Spec.SetDefaults(s.GetName())
+}
+
+func (localStorageResource) Validate(old, obj runtime.Object) error {
+	s := obj.(*storageApi.ArangoLocalStorage)
+
+	spec := s.Spec.DeepCopy()
+	spec.SetDefaults(s.GetName())
+
+	if o, ok := old.(*storageApi.ArangoLocalStorage); ok {
+		if fields := o.Spec.ResetImmutableFields(spec); len(fields) > 0 {
+			return errors.Errorf("immutable fields can not be changed: spec.%s", strings.Join(fields, ", spec."))
+		}
+	}
+
+	if err := spec.Validate(); err != nil {
+		return maskAny(err)
+	}
+
+	return nil
+}
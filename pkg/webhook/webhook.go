@@ -0,0 +1,306 @@
+//
+// DISCLAIMER
+//
+// Copyright 2020 ArangoDB GmbH, Cologne, Germany
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// Copyright holder is ArangoDB GmbH, Cologne, Germany
+//
+
+package webhook
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"sync"
+
+	"github.com/rs/zerolog"
+	admission "k8s.io/api/admission/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+)
+
+const (
+	// ValidatePath is the path of the validating admission webhook
+	ValidatePath = "/webhook/validate"
+	// MutatePath is the path of the mutating admission webhook
+	MutatePath = "/webhook/mutate"
+
+	maxReviewSize = 3 * 1024 * 1024
+)
+
+// Resource admits objects of a single custom resource
+type Resource interface {
+	// New returns an empty object of the resource
+	New() runtime.Object
+	// Default sets defaults of the object. Old is nil when the object is created.
+	Default(old, obj runtime.Object)
+	// Validate validates the object. Old is nil when the object is created.
+	Validate(old, obj runtime.Object) error
+}
+
+var resources = map[metav1.GroupVersionResource]Resource{}
+var resourcesLock sync.Mutex
+
+func registerResource(group, version, resource string, r Resource) {
+	resourcesLock.Lock()
+	defer resourcesLock.Unlock()
+
+	gvr := metav1.GroupVersionResource{Group: group, Version: version, Resource: resource}
+	if _, ok := resources[gvr]; ok {
+		panic("Resource already registered")
+	}
+
+	resources[gvr] = r
+}
+
+// Handler serves validating and mutating admission reviews of registered resources
+type Handler struct {
+	log         zerolog.Logger
+	resources   map[metav1.GroupVersionResource]Resource
+	conversions map[schema.GroupVersionKind]Convertible
+}
+
+// NewHandler creates a handler admitting and converting all custom resources of the operator
+func NewHandler(log zerolog.Logger) *Handler {
+	resourcesLock.Lock()
+	defer resourcesLock.Unlock()
+	conversionsLock.Lock()
+	defer conversionsLock.Unlock()
+
+	h := &Handler{
+		log:         log,
+		resources:   make(map[metav1.GroupVersionResource]Resource, len(resources)),
+		conversions: make(map[schema.GroupVersionKind]Convertible, len(conversions)),
+	}
+
+	for gvr, r := range resources {
+		h.resources[gvr] = r
+	}
+
+	for gvk, c := range conversions {
+		h.conversions[gvk] = c
+	}
+
+	return h
+}
+
+// Validate serves the validating admission webhook
+func (h *Handler) Validate(w http.ResponseWriter, r *http.Request) {
+	h.serve(w, r, h.validate)
+}
+
+// Mutate serves the mutating admission webhook
+func (h *Handler) Mutate(w http.ResponseWriter, r *http.Request) {
+	h.serve(w, r, h.mutate)
+}
+
+type admitFunc func(req *admission.AdmissionRequest, resource Resource, old, obj runtime.Object) (*admission.AdmissionResponse, error)
+
+func (h *Handler) serve(w http.ResponseWriter, r *http.Request, admit admitFunc) {
+	data, err := ioutil.ReadAll(http.MaxBytesReader(w, r.Body, maxReviewSize))
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	// AdmissionReview has the same format in v1 and v1beta1, response is returned in the version of the request
+	var review admission.AdmissionReview
+	if err := json.Unmarshal(data, &review); err != nil || review.Request == nil {
+		http.Error(w, "invalid admission review", http.StatusBadRequest)
+		return
+	}
+
+	review.Response = h.review(review.Request, admit)
+	review.Response.UID = review.Request.UID
+	review.Request = nil
+
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(review); err != nil {
+		h.log.Warn().Err(err).Msg("Failed to write admission review")
+	}
+}
+
+func (h *Handler) review(req *admission.AdmissionRequest, admit admitFunc) *admission.AdmissionResponse {
+	log := h.log.With().
+		Str("resource", req.Resource.Resource).
+		Str("namespace", req.Namespace).
+		Str("name", req.Name).
+		Str("operation", string(req.Operation)).
+		Logger()
+
+	resource, ok := h.resources[req.Resource]
+	if !ok || req.SubResource != "" {
+		// Not handled resources and subresources, like status, are always allowed
+		return allowed()
+	}
+
+	if req.Operation != admission.Create && req.Operation != admission.Update {
+		return allowed()
+	}
+
+	obj, err := decode(resource, req.Object.Raw)
+	if err != nil {
+		return denied(http.StatusBadRequest, err)
+	}
+
+	var old runtime.Object
+	if req.Operation == admission.Update {
+		if old, err = decode(resource, req.OldObject.Raw); err != nil {
+			return denied(http.StatusBadRequest, err)
+		}
+	}
+
+	resp, err := admit(req, resource, old, obj)
+	if err != nil {
+		log.Debug().Err(err).Msg("Admission denied")
+		return denied(http.StatusUnprocessableEntity, err)
+	}
+
+	return resp
+}
+
+func (h *Handler) validate(req *admission.AdmissionRequest, resource Resource, old, obj runtime.Object) (*admission.AdmissionResponse, error) {
+	if old != nil {
+		// Updates which do not change the spec, like status updates of the operator, are always allowed,
+		// so objects accepted before are not blocked by stricter validation
+		before, err := rawSpec(req.OldObject.Raw)
+		if err != nil {
+			return nil, maskAny(err)
+		}
+		after, err := rawSpec(req.Object.Raw)
+		if err != nil {
+			return nil, maskAny(err)
+		}
+		if equalJSON(before, after) {
+			return allowed(), nil
+		}
+	}
+
+	if err := resource.Validate(old, obj); err != nil {
+		return nil, maskAny(err)
+	}
+
+	return allowed(), nil
+}
+
+func (h *Handler) mutate(req *admission.AdmissionRequest, resource Resource, old, obj runtime.Object) (*admission.AdmissionResponse, error) {
+	resource.Default(old, obj)
+
+	patch, err := specPatch(req.Object.Raw, obj)
+	if err != nil {
+		return nil, maskAny(err)
+	}
+
+	resp := allowed()
+	if patch != nil {
+		patchType := admission.PatchTypeJSONPatch
+		resp.Patch = patch
+		resp.PatchType = &patchType
+	}
+
+	return resp, nil
+}
+
+// specPatch returns a JSON patch replacing the spec of the original object with the spec of the defaulted object.
+// Returns nil when the spec is not changed.
+func specPatch(original []byte, obj runtime.Object) ([]byte, error) {
+	before, err := rawSpec(original)
+	if err != nil {
+		return nil, maskAny(err)
+	}
+
+	data, err := json.Marshal(obj)
+	if err != nil {
+		return nil, maskAny(err)
+	}
+
+	after, err := rawSpec(data)
+	if err != nil {
+		return nil, maskAny(err)
+	}
+
+	if equalJSON(before, after) {
+		return nil, nil
+	}
+
+	op := "replace"
+	if len(before) == 0 {
+		op = "add"
+	}
+
+	patch, err := json.Marshal([]map[string]interface{}{
+		{"op": op, "path": "/spec", "value": after},
+	})
+	if err != nil {
+		return nil, maskAny(err)
+	}
+
+	return patch, nil
+}
+
+// rawSpec returns the spec of the given object document
+func rawSpec(data []byte) (json.RawMessage, error) {
+	var obj struct {
+		Spec json.RawMessage `json:"spec,omitempty"`
+	}
+	if err := json.Unmarshal(data, &obj); err != nil {
+		return nil, maskAny(err)
+	}
+
+	return obj.Spec, nil
+}
+
+// equalJSON returns true when both documents are semantically equal
+func equalJSON(a, b []byte) bool {
+	if bytes.Equal(a, b) {
+		return true
+	}
+
+	var x, y interface{}
+	if json.Unmarshal(a, &x) != nil || json.Unmarshal(b, &y) != nil {
+		return false
+	}
+
+	xd, _ := json.Marshal(x)
+	yd, _ := json.Marshal(y)
+	return bytes.Equal(xd, yd)
+}
+
+func decode(resource Resource, data []byte) (runtime.Object, error) {
+	obj := resource.New()
+	if err := json.Unmarshal(data, obj); err != nil {
+		return nil, fmt.Errorf("unable to decode object: %s", err.Error())
+	}
+	return obj, nil
+}
+
+func allowed() *admission.AdmissionResponse {
+	return &admission.AdmissionResponse{Allowed: true}
+}
+
+func denied(code int32, err error) *admission.AdmissionResponse {
+	return &admission.AdmissionResponse{
+		Allowed: false,
+		Result: &metav1.Status{
+			Status:  metav1.StatusFailure,
+			Code:    code,
+			Message: err.Error(),
+		},
+	}
+}
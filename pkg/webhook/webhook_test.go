@@ -0,0 +1,243 @@
+//
+// DISCLAIMER
+//
+// Copyright 2020 ArangoDB GmbH, Cologne, Germany
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// Copyright holder is ArangoDB GmbH, Cologne, Germany
+//
+
+package webhook
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	backupApi "github.com/arangodb/kube-arangodb/pkg/apis/backup/v1"
+	api "github.com/arangodb/kube-arangodb/pkg/apis/deployment/v1"
+	storageApi "github.com/arangodb/kube-arangodb/pkg/apis/storage/v1alpha"
+	"github.com/arangodb/kube-arangodb/pkg/deployment/features"
+	"github.com/arangodb/kube-arangodb/pkg/util"
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	admission "k8s.io/api/admission/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+)
+
+var (
+	deploymentGVR  = metav1.GroupVersionResource{Group: "database.arangodb.com", Version: "v1", Resource: "arangodeployments"}
+	policyGVR      = metav1.GroupVersionResource{Group: "backup.arangodb.com", Version: "v1", Resource: "arangobackuppolicies"}
+	localStorageGV = metav1.GroupVersionResource{Group: "storage.arangodb.com", Version: "v1alpha", Resource: "arangolocalstorages"}
+)
+
+func newReview(t *testing.T, gvr metav1.GroupVersionResource, old, obj runtime.Object) []byte {
+	req := &admission.AdmissionRequest{
+		UID:       "test-uid",
+		Resource:  gvr,
+		Name:      "test",
+		Namespace: "default",
+		Operation: admission.Create,
+	}
+
+	var err error
+	req.Object.Raw, err = json.Marshal(obj)
+	require.NoError(t, err)
+
+	if old != nil {
+		req.Operation = admission.Update
+		req.OldObject.Raw, err = json.Marshal(old)
+		require.NoError(t, err)
+	}
+
+	data, err := json.Marshal(admission.AdmissionReview{
+		TypeMeta: metav1.TypeMeta{APIVersion: "admission.k8s.io/v1", Kind: "AdmissionReview"},
+		Request:  req,
+	})
+	require.NoError(t, err)
+
+	return data
+}
+
+func serveReview(t *testing.T, handler http.HandlerFunc, review []byte) *admission.AdmissionResponse {
+	w := httptest.NewRecorder()
+	handler(w, httptest.NewRequest(http.MethodPost, ValidatePath, bytes.NewReader(review)))
+	require.Equal(t, http.StatusOK, w.Code)
+
+	var response admission.AdmissionReview
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+	require.NotNil(t, response.Response)
+	assert.Equal(t, "admission.k8s.io/v1", response.APIVersion)
+	assert.EqualValues(t, "test-uid", response.Response.UID)
+
+	return response.Response
+}
+
+func newDeployment(spec api.DeploymentSpec) *api.ArangoDeployment {
+	return &api.ArangoDeployment{
+		ObjectMeta: metav1.ObjectMeta{Name: "test", Namespace: "default"},
+		Spec:       spec,
+	}
+}
+
+func TestHandler_ValidateDeployment(t *testing.T) {
+	h := NewHandler(zerolog.Nop())
+
+	cluster := api.DeploymentSpec{Mode: api.NewMode(api.DeploymentModeCluster)}
+
+	t.Run("Valid", func(t *testing.T) {
+		resp := serveReview(t, h.Validate, newReview(t, deploymentGVR, nil, newDeployment(cluster)))
+		assert.True(t, resp.Allowed)
+	})
+
+	t.Run("Invalid group count", func(t *testing.T) {
+		spec := cluster.DeepCopy()
+		spec.DBServers.Count = util.NewInt(1)
+		resp := serveReview(t, h.Validate, newReview(t, deploymentGVR, nil, newDeployment(*spec)))
+		assert.False(t, resp.Allowed)
+		assert.Contains(t, resp.Result.Message, "Invalid count value")
+	})
+
+	t.Run("Immutable field", func(t *testing.T) {
+		spec := cluster.DeepCopy()
+		spec.Mode = api.NewMode(api.DeploymentModeSingle)
+		resp := serveReview(t, h.Validate, newReview(t, deploymentGVR, newDeployment(cluster), newDeployment(*spec)))
+		assert.False(t, resp.Allowed)
+		assert.Contains(t, resp.Result.Message, "spec.mode")
+	})
+
+	t.Run("Unsupported feature", func(t *testing.T) {
+		*features.TLSSNI().EnabledPointer() = true
+		defer func() {
+			*features.TLSSNI().EnabledPointer() = false
+		}()
+
+		old := newDeployment(cluster)
+		old.Status.CurrentImage = &api.ImageInfo{Image: "arangodb/arangodb:3.6.5", ArangoDBVersion: "3.6.5"}
+
+		spec := cluster.DeepCopy()
+		spec.TLS.SNI = &api.TLSSNISpec{Mapping: map[string][]string{"sni-secret": {"example.com"}}}
+		resp := serveReview(t, h.Validate, newReview(t, deploymentGVR, old, newDeployment(*spec)))
+		assert.False(t, resp.Allowed)
+		assert.Contains(t, resp.Result.Message, "spec.tls.sni")
+
+		old.Status.CurrentImage = &api.ImageInfo{Image: "arangodb/enterprise:3.7.3", ArangoDBVersion: "3.7.3", Enterprise: true}
+		resp = serveReview(t, h.Validate, newReview(t, deploymentGVR, old, newDeployment(*spec)))
+		assert.True(t, resp.Allowed)
+
+		spec = cluster.DeepCopy()
+		spec.RocksDB.Encryption.KeySecretName = util.NewString("encryption-key")
+		old = newDeployment(cluster)
+		old.Status.CurrentImage = &api.ImageInfo{Image: "arangodb/arangodb:3.7.3", ArangoDBVersion: "3.7.3"}
+		resp = serveReview(t, h.Validate, newReview(t, deploymentGVR, old, newDeployment(*spec)))
+		assert.False(t, resp.Allowed)
+		assert.Contains(t, resp.Result.Message, "spec.rocksdb.encryption")
+	})
+
+	t.Run("Status update", func(t *testing.T) {
+		spec := cluster.DeepCopy()
+		spec.RocksDB.Encryption.KeySecretName = util.NewString("encryption-key")
+		old := newDeployment(*spec)
+
+		// Image discovery found a Community image for a deployment with encryption enabled
+		old.Status.Images = api.ImageInfoList{{Image: "arangodb/arangodb:3.7.3", ArangoDBVersion: "3.7.3"}}
+		old.Status.CurrentImage = &api.ImageInfo{Image: "arangodb/arangodb:3.7.3", ArangoDBVersion: "3.7.3"}
+		obj := old.DeepCopy()
+		obj.Status.Phase = api.DeploymentPhaseFailed
+		resp := serveReview(t, h.Validate, newReview(t, deploymentGVR, old, obj))
+		assert.True(t, resp.Allowed)
+
+		// Invalid spec accepted before is not blocked either
+		spec = cluster.DeepCopy()
+		spec.DBServers.Count = util.NewInt(1)
+		old = newDeployment(*spec)
+		obj = old.DeepCopy()
+		obj.Status.Phase = api.DeploymentPhaseFailed
+		resp = serveReview(t, h.Validate, newReview(t, deploymentGVR, old, obj))
+		assert.True(t, resp.Allowed)
+	})
+}
+
+func TestHandler_MutateDeployment(t *testing.T) {
+	h := NewHandler(zerolog.Nop())
+
+	d := newDeployment(api.DeploymentSpec{Mode: api.NewMode(api.DeploymentModeCluster)})
+	resp := serveReview(t, h.Mutate, newReview(t, deploymentGVR, nil, d))
+	require.True(t, resp.Allowed)
+	require.NotNil(t, resp.PatchType)
+	assert.Equal(t, admission.PatchTypeJSONPatch, *resp.PatchType)
+
+	var patch []struct {
+		Op    string             `json:"op"`
+		Path  string             `json:"path"`
+		Value api.DeploymentSpec `json:"value"`
+	}
+	require.NoError(t, json.Unmarshal(resp.Patch, &patch))
+	require.Len(t, patch, 1)
+	assert.Equal(t, "replace", patch[0].Op)
+	assert.Equal(t, "/spec", patch[0].Path)
+	assert.Equal(t, api.EnvironmentDevelopment, patch[0].Value.GetEnvironment())
+	assert.Equal(t, api.StorageEngineRocksDB, patch[0].Value.GetStorageEngine())
+	// Other defaults are not persisted
+	assert.Nil(t, patch[0].Value.DBServers.Count)
+	assert.Nil(t, patch[0].Value.ImagePullPolicy)
+
+	// Defaulted object is not patched again
+	d.Spec = patch[0].Value
+	resp = serveReview(t, h.Mutate, newReview(t, deploymentGVR, nil, d))
+	require.True(t, resp.Allowed)
+	assert.Nil(t, resp.Patch)
+
+	// Updates are not defaulted
+	updated := newDeployment(api.DeploymentSpec{})
+	resp = serveReview(t, h.Mutate, newReview(t, deploymentGVR, d, updated))
+	require.True(t, resp.Allowed)
+	assert.Nil(t, resp.Patch)
+}
+
+func TestHandler_ValidateOtherResources(t *testing.T) {
+	h := NewHandler(zerolog.Nop())
+
+	policy := &backupApi.ArangoBackupPolicy{
+		ObjectMeta: metav1.ObjectMeta{Name: "test"},
+		Spec:       backupApi.ArangoBackupPolicySpec{Schedule: "invalid"},
+	}
+	resp := serveReview(t, h.Validate, newReview(t, policyGVR, nil, policy))
+	assert.False(t, resp.Allowed)
+
+	policy.Spec.Schedule = "*/15 * * * *"
+	resp = serveReview(t, h.Validate, newReview(t, policyGVR, nil, policy))
+	assert.True(t, resp.Allowed)
+
+	storage := &storageApi.ArangoLocalStorage{
+		ObjectMeta: metav1.ObjectMeta{Name: "test"},
+	}
+	resp = serveReview(t, h.Validate, newReview(t, localStorageGV, nil, storage))
+	assert.False(t, resp.Allowed)
+
+	t.Run("Unknown resource", func(t *testing.T) {
+		resp := serveReview(t, h.Validate, newReview(t, metav1.GroupVersionResource{Group: "apps", Version: "v1", Resource: "deployments"}, nil, storage))
+		assert.True(t, resp.Allowed)
+	})
+
+	t.Run("Invalid review", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		h.Validate(w, httptest.NewRequest(http.MethodPost, ValidatePath, bytes.NewReader([]byte("{"))))
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+	})
+}
@@ -35,6 +35,7 @@ import (
 
 	deplv1 "github.com/arangodb/kube-arangodb/pkg/apis/deployment/v1"
 	extclient "github.com/arangodb/kube-arangodb/pkg/client"
+	"github.com/arangodb/kube-arangodb/pkg/deployment/pod"
 	acli "github.com/arangodb/kube-arangodb/pkg/generated/clientset/versioned"
 	"github.com/arangodb/kube-arangodb/pkg/util"
 	"github.com/arangodb/kube-arangodb/pkg/util/constants"
@@ -60,6 +61,7 @@ var (
 		ImageName         string
 		LicenseSecretName string
 		Coordinators      int
+		SecurityProfile   string
 	}
 
 	cmdRebootInspect = &cobra.Command{
@@ -80,6 +82,7 @@ func init() {
 	cmdReboot.Flags().StringVar(&rebootOptions.ImageName, "image-name", "arangodb/arangodb:latest", "Image used for the deployment")
 	cmdReboot.Flags().StringVar(&rebootOptions.LicenseSecretName, "license-secret-name", "", "Name of secret for license key")
 	cmdReboot.Flags().IntVar(&rebootOptions.Coordinators, "coordinators", 1, "Initial number of coordinators")
+	cmdReboot.Flags().StringVar(&rebootOptions.SecurityProfile, "security-profile", string(deplv1.SecurityProfileNone), "Security profile of the volume inspector pods and the deployment (none|restricted)")
 
 	cmdRebootInspect.Flags().StringVar(&rebootInspectOptions.TargetDir, "target-dir", "/data", "Path to mounted database directory")
 }
@@ -99,7 +102,7 @@ type VolumeInspectResult struct {
 	Error error
 }
 
-func runVolumeInspector(ctx context.Context, kube kubernetes.Interface, ns, name, image, storageClassName string) (string, string, error) {
+func runVolumeInspector(ctx context.Context, kube kubernetes.Interface, ns, name, image, storageClassName string, profile *deplv1.SecurityProfile) (string, string, error) {
 
 	deletePVC := true
 	claimname := "arangodb-reboot-pvc-" + name
@@ -137,7 +140,8 @@ func runVolumeInspector(ctx context.Context, kube kubernetes.Interface, ns, name
 	podname := "arangodb-reboot-pod-" + name
 	podspec := corev1.Pod{
 		ObjectMeta: metav1.ObjectMeta{
-			Name: podname,
+			Name:        podname,
+			Annotations: pod.SecurityProfileAnnotations(profile),
 		},
 		Spec: corev1.PodSpec{
 			RestartPolicy: corev1.RestartPolicyNever,
@@ -181,6 +185,8 @@ func runVolumeInspector(ctx context.Context, kube kubernetes.Interface, ns, name
 		},
 	}
 
+	pod.ApplySecurityProfile(&podspec.Spec, profile)
+
 	_, err = kube.CoreV1().Pods(ns).Create(&podspec)
 	if err != nil {
 		return "", "", errors.Wrap(err, "failed to create pod")
@@ -250,13 +256,13 @@ func runVolumeInspector(ctx context.Context, kube kubernetes.Interface, ns, name
 	}
 }
 
-func doVolumeInspection(ctx context.Context, kube kubernetes.Interface, ns, name, storageClassName string, resultChan chan<- VolumeInspectResult, image string) {
+func doVolumeInspection(ctx context.Context, kube kubernetes.Interface, ns, name, storageClassName string, resultChan chan<- VolumeInspectResult, image string, profile *deplv1.SecurityProfile) {
 	// Create Volume Claim
 	// Create Pod mounting this volume
 	// Wait for pod to be completed
 	// Read logs - parse json
 	// Delete pod
-	uuid, claim, err := runVolumeInspector(ctx, kube, ns, name, image, storageClassName)
+	uuid, claim, err := runVolumeInspector(ctx, kube, ns, name, image, storageClassName, profile)
 	if err != nil {
 		resultChan <- VolumeInspectResult{Error: err}
 	}
@@ -314,7 +320,7 @@ func getMyImage(kube kubernetes.Interface, ns, name string) (string, error) {
 	return pod.Spec.Containers[0].Image, nil
 }
 
-func createArangoDeployment(cli acli.Interface, ns, deplname, arangoimage string, results map[string]VolumeInspectResult) error {
+func createArangoDeployment(cli acli.Interface, ns, deplname, arangoimage string, profile *deplv1.SecurityProfile, results map[string]VolumeInspectResult) error {
 
 	prmr := make(map[string]VolumeInspectResult)
 	agnt := make(map[string]VolumeInspectResult)
@@ -334,7 +340,8 @@ func createArangoDeployment(cli acli.Interface, ns, deplname, arangoimage string
 			Name: deplname,
 		},
 		Spec: deplv1.DeploymentSpec{
-			Image: util.NewString(arangoimage),
+			Image:           util.NewString(arangoimage),
+			SecurityProfile: profile,
 			Coordinators: deplv1.ServerGroupSpec{
 				Count: util.NewInt(rebootOptions.Coordinators),
 			},
@@ -396,6 +403,11 @@ func cmdRebootRun(cmd *cobra.Command, args []string) {
 		cliLog.Fatal().Err(err).Msg("failed to get my image")
 	}
 
+	profile := deplv1.SecurityProfile(rebootOptions.SecurityProfile).New()
+	if err := profile.Validate(); err != nil {
+		cliLog.Fatal().Err(err).Msg("invalid security profile")
+	}
+
 	vinfo, err := preflightChecks(kubecli, volumes)
 	if err != nil {
 		cliLog.Fatal().Err(err).Msg("preflight checks failed")
@@ -411,7 +423,7 @@ func cmdRebootRun(cmd *cobra.Command, args []string) {
 		wg.Add(1)
 		go func(vn string) {
 			defer wg.Done()
-			doVolumeInspection(ctx, kubecli, namespace, vn, vinfo[vn].StorageClassName, resultChan, image)
+			doVolumeInspection(ctx, kubecli, namespace, vn, vinfo[vn].StorageClassName, resultChan, image, profile)
 		}(volumeName)
 	}
 
@@ -438,7 +450,7 @@ func cmdRebootRun(cmd *cobra.Command, args []string) {
 
 	cliLog.Debug().Msg("results complete - generating ArangoDeployment resource")
 
-	if err := createArangoDeployment(extcli, namespace, rebootOptions.DeploymentName, rebootOptions.ImageName, members); err != nil {
+	if err := createArangoDeployment(extcli, namespace, rebootOptions.DeploymentName, rebootOptions.ImageName, profile, members); err != nil {
 		cliLog.Error().Err(err).Msg("failed to create deployment")
 	}
 
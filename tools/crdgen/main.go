@@ -0,0 +1,124 @@
+//
+// DISCLAIMER
+//
+// Copyright 2020 ArangoDB GmbH, Cologne, Germany
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// Copyright holder is ArangoDB GmbH, Cologne, Germany
+//
+
+package main
+
+import (
+	"bytes"
+	"flag"
+	"fmt"
+	"io/ioutil"
+	"log"
+	"os"
+	"path/filepath"
+	"reflect"
+
+	backupApi "github.com/arangodb/kube-arangodb/pkg/apis/backup/v1"
+	deploymentApi "github.com/arangodb/kube-arangodb/pkg/apis/deployment/v1"
+	deploymentV2Alpha1 "github.com/arangodb/kube-arangodb/pkg/apis/deployment/v2alpha1"
+	replicationApi "github.com/arangodb/kube-arangodb/pkg/apis/replication/v1"
+	replicationV2Alpha1 "github.com/arangodb/kube-arangodb/pkg/apis/replication/v2alpha1"
+	storageApi "github.com/arangodb/kube-arangodb/pkg/apis/storage/v1alpha"
+	"github.com/arangodb/kube-arangodb/pkg/util/crd/schema"
+)
+
+// crdSchema is a schema file rendered from the Go type of a custom resource
+type crdSchema struct {
+	File string
+	Type reflect.Type
+}
+
+var (
+	crdSchemas = []crdSchema{
+		{File: "chart/kube-arangodb-crd/schemas/arangodeployments.v1.yaml", Type: reflect.TypeOf(deploymentApi.ArangoDeployment{})},
+		{File: "chart/kube-arangodb-crd/schemas/arangodeployments.v2alpha1.yaml", Type: reflect.TypeOf(deploymentV2Alpha1.ArangoDeployment{})},
+		{File: "chart/kube-arangodb-crd/schemas/arangodeploymentreplications.v1.yaml", Type: reflect.TypeOf(replicationApi.ArangoDeploymentReplication{})},
+		{File: "chart/kube-arangodb-crd/schemas/arangodeploymentreplications.v2alpha1.yaml", Type: reflect.TypeOf(replicationV2Alpha1.ArangoDeploymentReplication{})},
+		{File: "chart/kube-arangodb-crd/schemas/arangobackups.v1.yaml", Type: reflect.TypeOf(backupApi.ArangoBackup{})},
+		{File: "chart/kube-arangodb-crd/schemas/arangobackuppolicies.v1.yaml", Type: reflect.TypeOf(backupApi.ArangoBackupPolicy{})},
+		{File: "chart/kube-arangodb-crd/schemas/arangodatabases.v1.yaml", Type: reflect.TypeOf(deploymentApi.ArangoDatabase{})},
+		{File: "chart/kube-arangodb-crd/schemas/arangousers.v1.yaml", Type: reflect.TypeOf(deploymentApi.ArangoUser{})},
+		{File: "chart/kube-arangodb-crd/schemas/arangocollections.v1.yaml", Type: reflect.TypeOf(deploymentApi.ArangoCollection{})},
+		{File: "chart/kube-arangodb/schemas/arangolocalstorages.v1alpha.yaml", Type: reflect.TypeOf(storageApi.ArangoLocalStorage{})},
+	}
+)
+
+var (
+	root   string
+	verify bool
+)
+
+func init() {
+	flag.StringVar(&root, "root", ".", "Root directory of the repository")
+	flag.BoolVar(&verify, "verify-only", false, "Verify that schema files are up to date, without writing them")
+}
+
+func main() {
+	flag.Parse()
+
+	changed, err := generate(root, !verify)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	if verify && len(changed) > 0 {
+		for _, file := range changed {
+			fmt.Printf("%s is out of date\n", file)
+		}
+		os.Exit(1)
+	}
+}
+
+// generate renders all schema files and returns the ones which differ from the files on disk.
+// If write is set, changed files are written.
+func generate(root string, write bool) ([]string, error) {
+	docs := schema.Docs{}
+	if err := docs.LoadTree(root, "pkg/apis"); err != nil {
+		return nil, err
+	}
+
+	g := schema.NewGenerator(docs)
+
+	var changed []string
+	for _, s := range crdSchemas {
+		data, err := g.Render(s.Type)
+		if err != nil {
+			return nil, fmt.Errorf("unable to render schema of %s: %s", s.Type, err.Error())
+		}
+
+		file := filepath.Join(root, s.File)
+		if current, err := ioutil.ReadFile(file); err == nil && bytes.Equal(current, data) {
+			continue
+		}
+
+		changed = append(changed, s.File)
+
+		if write {
+			if err := os.MkdirAll(filepath.Dir(file), 0755); err != nil {
+				return nil, err
+			}
+			if err := ioutil.WriteFile(file, data, 0644); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	return changed, nil
+}
@@ -0,0 +1,166 @@
+//
+// DISCLAIMER
+//
+// Copyright 2020 ArangoDB GmbH, Cologne, Germany
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// Copyright holder is ArangoDB GmbH, Cologne, Germany
+//
+
+package main
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"math/rand"
+	"path/filepath"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/ghodss/yaml"
+	fuzz "github.com/google/gofuzz"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"k8s.io/apiextensions-apiserver/pkg/apis/apiextensions"
+	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	structuralschema "k8s.io/apiextensions-apiserver/pkg/apiserver/schema"
+	"k8s.io/apiextensions-apiserver/pkg/apiserver/schema/pruning"
+	apiservervalidation "k8s.io/apiextensions-apiserver/pkg/apiserver/validation"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+
+	deploymentApi "github.com/arangodb/kube-arangodb/pkg/apis/deployment/v1"
+	"github.com/arangodb/kube-arangodb/pkg/util"
+)
+
+const repositoryRoot = "../.."
+
+func loadSchema(t *testing.T, file string) *apiextensions.JSONSchemaProps {
+	data, err := ioutil.ReadFile(filepath.Join(repositoryRoot, file))
+	require.NoError(t, err)
+
+	var v1 apiextensionsv1.JSONSchemaProps
+	require.NoError(t, yaml.Unmarshal(data, &v1))
+
+	var s apiextensions.JSONSchemaProps
+	require.NoError(t, apiextensionsv1.Convert_v1_JSONSchemaProps_To_apiextensions_JSONSchemaProps(&v1, &s, nil))
+
+	return &s
+}
+
+func loadStructural(t *testing.T, file string) *structuralschema.Structural {
+	s, err := structuralschema.NewStructural(loadSchema(t, file))
+	require.NoError(t, err)
+	return s
+}
+
+func TestSchemasUpToDate(t *testing.T) {
+	changed, err := generate(repositoryRoot, false)
+	require.NoError(t, err)
+	assert.Empty(t, changed, "Schemas are out of date, run make update-generated")
+}
+
+func TestSchemasStructural(t *testing.T) {
+	for _, s := range crdSchemas {
+		t.Run(s.File, func(t *testing.T) {
+			errs := structuralschema.ValidateStructural(nil, loadStructural(t, s.File))
+			assert.Empty(t, errs)
+		})
+	}
+}
+
+func TestSchemasPruning(t *testing.T) {
+	seed := time.Now().UnixNano()
+	t.Logf("Fuzz seed: %d", seed)
+
+	f := fuzz.New().
+		RandSource(rand.NewSource(seed)).
+		NilChance(0.3).
+		NumElements(0, 2).
+		MaxDepth(12).
+		Funcs(
+			func(q *resource.Quantity, c fuzz.Continue) {
+				*q = *resource.NewQuantity(c.Int63n(1000), resource.DecimalSI)
+			},
+			func(i *intstr.IntOrString, c fuzz.Continue) {
+				*i = intstr.FromInt(c.Intn(100))
+			},
+			func(m *metav1.ObjectMeta, c fuzz.Continue) {
+				// Metadata is not covered by the schemas
+				*m = metav1.ObjectMeta{Name: c.RandString()}
+			},
+		)
+
+	for _, s := range crdSchemas {
+		t.Run(s.File, func(t *testing.T) {
+			structural := loadStructural(t, s.File)
+
+			for i := 0; i < 50; i++ {
+				obj := reflect.New(s.Type).Interface()
+				f.Fuzz(obj)
+
+				data, err := json.Marshal(obj)
+				require.NoError(t, err)
+
+				var before, after map[string]interface{}
+				require.NoError(t, json.Unmarshal(data, &before))
+				require.NoError(t, json.Unmarshal(data, &after))
+
+				pruning.Prune(after, structural, true)
+				require.Equal(t, before, after, "Fields of %s are pruned by the schema", s.Type)
+			}
+		})
+	}
+}
+
+func TestSchemasValidation(t *testing.T) {
+	validator, _, err := apiservervalidation.NewSchemaValidator(&apiextensions.CustomResourceValidation{
+		OpenAPIV3Schema: loadSchema(t, "chart/kube-arangodb-crd/schemas/arangodeployments.v1.yaml"),
+	})
+	require.NoError(t, err)
+
+	validate := func(spec deploymentApi.DeploymentSpec) []string {
+		data, err := json.Marshal(deploymentApi.ArangoDeployment{Spec: spec})
+		require.NoError(t, err)
+
+		var obj map[string]interface{}
+		require.NoError(t, json.Unmarshal(data, &obj))
+
+		var errs []string
+		for _, e := range apiservervalidation.ValidateCustomResource(nil, obj, validator) {
+			errs = append(errs, e.Error())
+		}
+		return errs
+	}
+
+	assert.Empty(t, validate(deploymentApi.DeploymentSpec{
+		Mode:          deploymentApi.NewMode(deploymentApi.DeploymentModeCluster),
+		StorageEngine: deploymentApi.NewStorageEngine(deploymentApi.StorageEngineRocksDB),
+		DBServers:     deploymentApi.ServerGroupSpec{Count: util.NewInt(3)},
+	}))
+
+	assert.NotEmpty(t, validate(deploymentApi.DeploymentSpec{
+		Mode: deploymentApi.NewMode("Clustr"),
+	}))
+
+	assert.NotEmpty(t, validate(deploymentApi.DeploymentSpec{
+		ExternalAccess: deploymentApi.ExternalAccessSpec{Type: deploymentApi.NewExternalAccessType("Public")},
+	}))
+
+	assert.NotEmpty(t, validate(deploymentApi.DeploymentSpec{
+		DBServers: deploymentApi.ServerGroupSpec{Count: util.NewInt(-1)},
+	}))
+}